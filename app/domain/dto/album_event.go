@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// Types of album change events
+const (
+	AlbumCreated  = "album.created"
+	AlbumUpdated  = "album.updated"
+	AlbumDeleted  = "album.deleted"
+	AlbumRestored = "album.restored"
+	// EventsLost tells a resuming client that events after the one it last received were dropped
+	// from the event log before it came back, so it should reload what it shows
+	EventsLost = "events.lost"
+)
+
+// AlbumEvent is a change to an album, as streamed to clients
+type AlbumEvent struct {
+	// ID orders the events and is what clients resume from; it is assigned by the event log
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	AlbumID    string    `json:"album_id,omitempty"`
+	Album      *Album    `json:"album,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// BuildAlbumEventDTO describes a change to an album; album is nil for deletions
+func BuildAlbumEventDTO(eventType string, albumID string, album *Album) AlbumEvent {
+	return AlbumEvent{Type: eventType, AlbumID: albumID, Album: album, OccurredAt: time.Now().UTC()}
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "boilerplate/app/domain/errors"
+
+// Statuses of a row in an AlbumImportReport
+const (
+	AlbumImportCreated = "created"
+	AlbumImportSkipped = "skipped"
+	AlbumImportFailed  = "failed"
+)
+
+// AlbumImportReport is the outcome of a bulk album import
+type AlbumImportReport struct {
+	DryRun  bool                 `json:"dry_run"`
+	Created int                  `json:"created"`
+	Skipped int                  `json:"skipped"`
+	Failed  int                  `json:"failed"`
+	Rows    []AlbumImportRowInfo `json:"rows"`
+}
+
+// AlbumImportRowInfo is the outcome of one row of an import
+type AlbumImportRowInfo struct {
+	Line    int                 `json:"line"`
+	ID      string              `json:"id,omitempty"`
+	Status  string              `json:"status"`
+	Reason  string              `json:"reason,omitempty"`
+	Details []errors.FieldError `json:"details,omitempty"`
+}
+
+// Add records the outcome of a row and updates the totals
+func (r *AlbumImportReport) Add(row AlbumImportRowInfo) {
+	switch row.Status {
+	case AlbumImportCreated:
+		r.Created++
+	case AlbumImportSkipped:
+		r.Skipped++
+	case AlbumImportFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
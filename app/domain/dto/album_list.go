@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"boilerplate/app/domain/entity"
+)
+
+// AlbumList is a page of albums with opaque cursors to its neighbours
+type AlbumList struct {
+	Albums     []Album `json:"albums"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	PrevCursor string  `json:"prev_cursor,omitempty"`
+}
+
+// albumCursor is the serialised form of entity.AlbumCursor
+type albumCursor struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d,omitempty"`
+	SortValue  string `json:"v"`
+	ID         string `json:"i"`
+	Before     bool   `json:"b,omitempty"`
+}
+
+func BuildAlbumListDTO(page entity.AlbumPage) AlbumList {
+	albums := make([]Album, len(page.Albums))
+	for i, albumEntity := range page.Albums {
+		albums[i] = BuildAlbumDTO(albumEntity)
+	}
+
+	return AlbumList{
+		Albums:     albums,
+		NextCursor: EncodeAlbumCursor(page.Next),
+		PrevCursor: EncodeAlbumCursor(page.Prev),
+	}
+}
+
+// EncodeAlbumCursor turns a cursor into an opaque token, or "" for a nil cursor
+func EncodeAlbumCursor(cursor *entity.AlbumCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	data, _ := json.Marshal(albumCursor{
+		SortBy:     string(cursor.SortBy),
+		Descending: cursor.Descending,
+		SortValue:  cursor.SortValue,
+		ID:         cursor.ID,
+		Before:     cursor.Before,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeAlbumCursor parses a token produced by EncodeAlbumCursor
+func DecodeAlbumCursor(token string) (*entity.AlbumCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %v", err)
+	}
+
+	var c albumCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("malformed cursor: %v", err)
+	}
+
+	cursor := &entity.AlbumCursor{
+		SortBy:     entity.AlbumSortField(c.SortBy),
+		Descending: c.Descending,
+		SortValue:  c.SortValue,
+		ID:         c.ID,
+		Before:     c.Before,
+	}
+	if !cursor.SortBy.IsValid() || cursor.ID == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	return cursor, nil
+}
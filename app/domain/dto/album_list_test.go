@@ -0,0 +1,32 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+)
+
+func TestAlbumCursorRoundTrip(t *testing.T) {
+	cursor := &entity.AlbumCursor{
+		SortBy:     entity.AlbumSortByCreatedAt,
+		Descending: true,
+		SortValue:  "2024-01-02 03:04:05",
+		ID:         "A0001",
+		Before:     true,
+	}
+
+	decoded, err := dto.DecodeAlbumCursor(dto.EncodeAlbumCursor(cursor))
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestDecodeAlbumCursor_Invalid(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "eyJzIjoicmF0aW5nIiwidiI6IjEiLCJpIjoiMSJ9"} {
+		_, err := dto.DecodeAlbumCursor(token)
+		assert.Error(t, err, token)
+	}
+}
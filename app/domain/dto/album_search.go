@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"boilerplate/app/domain/entity"
+)
+
+// Tags wrapped around the matched parts of highlighted fields
+const (
+	HighlightOpenTag  = "<em>"
+	HighlightCloseTag = "</em>"
+)
+
+// AlbumSearchResults is one page of albums matching a search, best matches first
+type AlbumSearchResults struct {
+	Results    []AlbumSearchHit `json:"results"`
+	NextOffset int              `json:"next_offset,omitempty"`
+}
+
+// AlbumSearchHit is an album matching a search. Highlights holds the matched fields
+// HTML-escaped, with each occurrence of a query term wrapped in <em> tags.
+type AlbumSearchHit struct {
+	Album      Album             `json:"album"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+func BuildAlbumSearchResultsDTO(result entity.AlbumSearchResult, opts entity.AlbumSearchOptions) AlbumSearchResults {
+	terms := searchTerms(opts.Query)
+	hits := make([]AlbumSearchHit, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = AlbumSearchHit{
+			Album: BuildAlbumDTO(hit.Album),
+			Score: hit.Score,
+		}
+		for field, value := range map[string]string{"title": hit.Album.Title, "artist": hit.Album.Artist} {
+			if highlighted, ok := Highlight(value, terms); ok {
+				if hits[i].Highlights == nil {
+					hits[i].Highlights = make(map[string]string)
+				}
+				hits[i].Highlights[field] = highlighted
+			}
+		}
+	}
+
+	results := AlbumSearchResults{Results: hits}
+	if result.HasMore {
+		results.NextOffset = opts.Offset + len(hits)
+	}
+	return results
+}
+
+// searchTerms splits a query into the words to highlight, dropping full-text operators
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\'' && r != '-'
+	})
+}
+
+// Highlight HTML-escapes text and wraps every case-insensitive occurrence of a term in <em> tags.
+// It reports whether any term occurred.
+func Highlight(text string, terms []string) (string, bool) {
+	lower := []rune(strings.ToLower(text))
+	runes := []rune(text)
+	if len(lower) != len(runes) {
+		// Lower-casing changed the length, so offsets cannot be mapped back; match case-sensitively
+		lower = runes
+	}
+
+	// Mark the runes covered by any term, so overlapping matches merge into one
+	marked := make([]bool, len(runes))
+	found := false
+	for _, term := range terms {
+		needle := []rune(strings.ToLower(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if string(lower[i:i+len(needle)]) == string(needle) {
+				for j := i; j < i+len(needle); j++ {
+					marked[j] = true
+				}
+				found = true
+			}
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			b.WriteString(HighlightOpenTag + segment + HighlightCloseTag)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	return b.String(), true
+}
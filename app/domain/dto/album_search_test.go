@@ -0,0 +1,62 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected string
+		found    bool
+	}{
+		{name: "Case-insensitive match", text: "Kind of Blue", terms: []string{"blue"}, expected: "Kind of <em>Blue</em>", found: true},
+		{name: "Several terms", text: "Blue Train", terms: []string{"train", "blue"}, expected: "<em>Blue</em> <em>Train</em>", found: true},
+		{name: "Overlapping terms merge", text: "Bluebird", terms: []string{"blue", "ebird"}, expected: "<em>Bluebird</em>", found: true},
+		{name: "Text is escaped", text: "Rock & <Roll>", terms: []string{"roll"}, expected: "Rock &amp; &lt;<em>Roll</em>&gt;", found: true},
+		{name: "No match", text: "Giant Steps", terms: []string{"blue"}, found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, found := dto.Highlight(tt.text, tt.terms)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBuildAlbumSearchResultsDTO(t *testing.T) {
+	result := entity.AlbumSearchResult{
+		Hits: []entity.AlbumSearchHit{
+			{Album: entity.Album{ID: "1", Title: "Kind of Blue", Artist: "Miles Davis"}, Score: 2.5},
+			{Album: entity.Album{ID: "2", Title: "Giant Steps", Artist: "Blue Mitchell"}, Score: 1},
+		},
+		HasMore: true,
+	}
+
+	results := dto.BuildAlbumSearchResultsDTO(result, entity.AlbumSearchOptions{Query: "+blue", Limit: 2, Offset: 4})
+
+	assert.Equal(t, dto.AlbumSearchResults{
+		Results: []dto.AlbumSearchHit{
+			{
+				Album:      dto.Album{ID: "1", Title: "Kind of Blue", Artist: "Miles Davis"},
+				Score:      2.5,
+				Highlights: map[string]string{"title": "Kind of <em>Blue</em>"},
+			},
+			{
+				Album:      dto.Album{ID: "2", Title: "Giant Steps", Artist: "Blue Mitchell"},
+				Score:      1,
+				Highlights: map[string]string{"artist": "<em>Blue</em> Mitchell"},
+			},
+		},
+		NextOffset: 6,
+	}, results)
+}
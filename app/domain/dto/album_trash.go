@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// AlbumPurgeReport tells which albums a purge removed from the trash for good
+type AlbumPurgeReport struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Purged        int       `json:"purged"`
+	IDs           []string  `json:"ids"`
+}
+
+func BuildAlbumPurgeReportDTO(deletedBefore time.Time, ids []string) AlbumPurgeReport {
+	if ids == nil {
+		ids = []string{}
+	}
+	return AlbumPurgeReport{
+		DeletedBefore: deletedBefore,
+		Purged:        len(ids),
+		IDs:           ids,
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+// AlbumVersion identifies the state of an album without its content, for conditional requests
+type AlbumVersion struct {
+	Version   int64      `json:"version"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+func BuildAlbumVersionDTO(albumEntity entity.Album) AlbumVersion {
+	version := AlbumVersion{Version: albumEntity.Version}
+	if !albumEntity.UpdatedAt.IsZero() {
+		updatedAt := albumEntity.UpdatedAt
+		version.UpdatedAt = &updatedAt
+	}
+	return version
+}
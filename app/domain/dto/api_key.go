@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+// APIKey is an API key, as issued to a calling service
+type APIKey struct {
+	ID     string `json:"id"`
+	Name   string `json:"name" binding:"required,max=255"`
+	Prefix string `json:"prefix"`
+	// Key is only answered when the key is issued or rotated
+	Key        string     `json:"key,omitempty"`
+	Scopes     []string   `json:"scopes" binding:"dive,scope"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// APIKeyRotation is the request to replace an API key with a new one
+type APIKeyRotation struct {
+	// GracePeriodSeconds keeps the replaced key valid for that long, so that its holders can switch
+	// over; 0 revokes it at once
+	GracePeriodSeconds int `json:"grace_period_seconds" binding:"min=0,max=604800"`
+}
+
+// BuildAPIKeyDTO describes an API key, leaving the key and its hash out
+func BuildAPIKeyDTO(apiKeyEntity entity.APIKey) APIKey {
+	apiKey := APIKey{
+		ID:         apiKeyEntity.ID,
+		Name:       apiKeyEntity.Name,
+		Prefix:     apiKeyEntity.Prefix,
+		Scopes:     apiKeyEntity.Scopes,
+		ExpiresAt:  apiKeyEntity.ExpiresAt,
+		LastUsedAt: apiKeyEntity.LastUsedAt,
+		RevokedAt:  apiKeyEntity.RevokedAt,
+	}
+	if apiKey.Scopes == nil {
+		apiKey.Scopes = []string{}
+	}
+	if !apiKeyEntity.CreatedAt.IsZero() {
+		createdAt := apiKeyEntity.CreatedAt
+		apiKey.CreatedAt = &createdAt
+	}
+	if !apiKeyEntity.UpdatedAt.IsZero() {
+		updatedAt := apiKeyEntity.UpdatedAt
+		apiKey.UpdatedAt = &updatedAt
+	}
+	return apiKey
+}
+
+func BuildAPIKeyDTOs(apiKeyEntities []entity.APIKey) []APIKey {
+	apiKeys := make([]APIKey, len(apiKeyEntities))
+	for i, apiKeyEntity := range apiKeyEntities {
+		apiKeys[i] = BuildAPIKeyDTO(apiKeyEntity)
+	}
+	return apiKeys
+}
+
+// BuildAPIKeyEntity converts a request to issue a key into its entity form
+func BuildAPIKeyEntity(apiKey APIKey) entity.APIKey {
+	return entity.APIKey{
+		Name:      apiKey.Name,
+		Scopes:    apiKey.Scopes,
+		ExpiresAt: apiKey.ExpiresAt,
+	}
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+// AuditRecord tells who changed what through a mutating API call, and how it ended
+type AuditRecord struct {
+	ID         string          `json:"id"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Method     string          `json:"method"`
+	Route      string          `json:"route"`
+	TargetID   string          `json:"target_id,omitempty"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Outcome    string          `json:"outcome"`
+	StatusCode int             `json:"status_code"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// BuildAlbumSnapshot returns the JSON form of an album and its tracks, as kept in the audit log
+func BuildAlbumSnapshot(album entity.Album, tracks []entity.Track) json.RawMessage {
+	albumDTO := BuildAlbumDTO(album)
+	albumDTO.Tracks = BuildTrackDTOs(tracks)
+	data, err := json.Marshal(albumDTO)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// AuditList is a page of audit records, newest first, with an opaque cursor to the next one
+type AuditList struct {
+	Records    []AuditRecord `json:"records"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// auditCursor is the serialised form of entity.AuditCursor
+type auditCursor struct {
+	OccurredAt time.Time `json:"t"`
+	ID         string    `json:"i"`
+}
+
+// BuildAuditRecordDTO describes a record; missing snapshots are answered as null
+func BuildAuditRecordDTO(recordEntity entity.AuditRecord) AuditRecord {
+	record := AuditRecord{
+		ID:         recordEntity.ID,
+		Actor:      recordEntity.Actor,
+		RequestID:  recordEntity.RequestID,
+		Method:     recordEntity.Method,
+		Route:      recordEntity.Route,
+		TargetID:   recordEntity.TargetID,
+		Before:     recordEntity.Before,
+		After:      recordEntity.After,
+		Outcome:    recordEntity.Outcome,
+		StatusCode: recordEntity.StatusCode,
+		OccurredAt: recordEntity.OccurredAt,
+	}
+	if record.Before == nil {
+		record.Before = json.RawMessage("null")
+	}
+	if record.After == nil {
+		record.After = json.RawMessage("null")
+	}
+	return record
+}
+
+func BuildAuditListDTO(page entity.AuditPage) AuditList {
+	records := make([]AuditRecord, len(page.Records))
+	for i, recordEntity := range page.Records {
+		records[i] = BuildAuditRecordDTO(recordEntity)
+	}
+	return AuditList{Records: records, NextCursor: EncodeAuditCursor(page.Next)}
+}
+
+// EncodeAuditCursor turns a cursor into an opaque token, or "" for a nil cursor
+func EncodeAuditCursor(cursor *entity.AuditCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	data, _ := json.Marshal(auditCursor{OccurredAt: cursor.OccurredAt, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeAuditCursor parses a token produced by EncodeAuditCursor
+func DecodeAuditCursor(token string) (*entity.AuditCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %v", err)
+	}
+
+	var c auditCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("malformed cursor: %v", err)
+	}
+	if c.OccurredAt.IsZero() || c.ID == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	return &entity.AuditCursor{OccurredAt: c.OccurredAt, ID: c.ID}, nil
+}
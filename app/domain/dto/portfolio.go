@@ -1,15 +1,107 @@
 package dto
 
-import "boilerplate/app/domain/entity"
+import (
+	"time"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+)
+
+// ReleaseDateLayout is the format of release dates in requests and responses
+const ReleaseDateLayout = "2006-01-02"
 
 type Album struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
+	ID          string     `json:"id" xml:"id" binding:"omitempty,resource_id"`
+	Title       string     `json:"title" xml:"title" binding:"required,max=255"`
+	Artist      string     `json:"artist,omitempty" xml:"artist" binding:"max=255"`
+	ReleaseDate string     `json:"release_date,omitempty" xml:"release_date" binding:"omitempty,datetime=2006-01-02"`
+	Genre       string     `json:"genre,omitempty" xml:"genre" binding:"omitempty,genre"`
+	TrackCount  int        `json:"track_count,omitempty" xml:"track_count" binding:"min=0,max=500"`
+	Version     int64      `json:"version,omitempty" xml:"-"`
+	CreatedAt   *time.Time `json:"created_at,omitempty" xml:"-"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty" xml:"-"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" xml:"-"`
+	Tracks      []Track    `json:"tracks,omitempty" xml:"-"`
+}
+
+// AlbumPatch is the request body for a partial album update
+type AlbumPatch struct {
+	Title       *string `json:"title" binding:"omitnil,min=1,max=255"`
+	Artist      *string `json:"artist" binding:"omitnil,max=255"`
+	ReleaseDate *string `json:"release_date" binding:"omitnil,datetime=2006-01-02"`
+	Genre       *string `json:"genre" binding:"omitnil,genre"`
+	TrackCount  *int    `json:"track_count" binding:"omitnil,min=0,max=500"`
 }
 
 func BuildAlbumDTO(albumEntity entity.Album) Album {
-	return Album{
-		ID:    albumEntity.ID.String(),
-		Title: albumEntity.Title,
+	album := Album{
+		ID:         albumEntity.ID.String(),
+		Title:      albumEntity.Title,
+		Artist:     albumEntity.Artist,
+		Genre:      albumEntity.Genre,
+		TrackCount: albumEntity.TrackCount,
+		Version:    albumEntity.Version,
+	}
+	if albumEntity.ReleaseDate != nil {
+		album.ReleaseDate = albumEntity.ReleaseDate.Format(ReleaseDateLayout)
+	}
+	if !albumEntity.CreatedAt.IsZero() {
+		createdAt := albumEntity.CreatedAt
+		album.CreatedAt = &createdAt
+	}
+	if !albumEntity.UpdatedAt.IsZero() {
+		updatedAt := albumEntity.UpdatedAt
+		album.UpdatedAt = &updatedAt
+	}
+	album.DeletedAt = albumEntity.DeletedAt
+	return album
+}
+
+// BuildAlbumEntity converts an album request into its entity form; timestamps are left to the store
+func BuildAlbumEntity(album Album) (entity.Album, error) {
+	albumEntity := entity.Album{
+		ID:         entity.AlbumID(album.ID),
+		Title:      album.Title,
+		Artist:     album.Artist,
+		Genre:      album.Genre,
+		TrackCount: album.TrackCount,
+	}
+	if album.ReleaseDate != "" {
+		releaseDate, err := parseReleaseDate(album.ReleaseDate)
+		if err != nil {
+			return entity.Album{}, err
+		}
+		albumEntity.ReleaseDate = &releaseDate
+	}
+	return albumEntity, nil
+}
+
+// BuildAlbumPatchEntity converts a patch request into its entity form
+func BuildAlbumPatchEntity(patch AlbumPatch) (entity.AlbumPatch, error) {
+	patchEntity := entity.AlbumPatch{
+		Title:      patch.Title,
+		Artist:     patch.Artist,
+		Genre:      patch.Genre,
+		TrackCount: patch.TrackCount,
+	}
+	if patch.ReleaseDate != nil {
+		releaseDate, err := parseReleaseDate(*patch.ReleaseDate)
+		if err != nil {
+			return entity.AlbumPatch{}, err
+		}
+		patchEntity.ReleaseDate = &releaseDate
+	}
+	return patchEntity, nil
+}
+
+func parseReleaseDate(value string) (time.Time, error) {
+	releaseDate, err := time.Parse(ReleaseDateLayout, value)
+	if err != nil {
+		return time.Time{}, errors.NewValidationError(errors.FieldError{
+			Field:   "release_date",
+			Code:    "format",
+			Message: "must be a date formatted as YYYY-MM-DD",
+		})
 	}
+	return releaseDate, nil
 }
@@ -2,8 +2,10 @@ package dto_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 
 	"boilerplate/app/domain/dto"
 	"boilerplate/app/domain/entity"
@@ -67,3 +69,37 @@ func TestBuildAlbumDTO(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildAlbumEntity(t *testing.T) {
+	releaseDate := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("All fields", func(t *testing.T) {
+		albumEntity, err := dto.BuildAlbumEntity(dto.Album{
+			ID:          "A0001",
+			Title:       "Test Album",
+			Artist:      "Artist",
+			ReleaseDate: "2023-06-30",
+			Genre:       "rock",
+			TrackCount:  12,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Album{
+			ID:          entity.AlbumID("A0001"),
+			Title:       "Test Album",
+			Artist:      "Artist",
+			ReleaseDate: &releaseDate,
+			Genre:       "rock",
+			TrackCount:  12,
+		}, albumEntity)
+
+		// Converting back yields the same release date
+		assert.Equal(t, "2023-06-30", dto.BuildAlbumDTO(albumEntity).ReleaseDate)
+	})
+
+	t.Run("Invalid release date", func(t *testing.T) {
+		_, err := dto.BuildAlbumEntity(dto.Album{ID: "A0001", ReleaseDate: "30/06/2023"})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+type Track struct {
+	ID              string     `json:"id" binding:"omitempty,resource_id"`
+	AlbumID         string     `json:"album_id"`
+	Position        int        `json:"position" binding:"min=1"`
+	Title           string     `json:"title" binding:"required,max=255"`
+	DurationSeconds int        `json:"duration_seconds" binding:"min=0,max=86400"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+func BuildTrackDTO(trackEntity entity.Track) Track {
+	track := Track{
+		ID:              trackEntity.ID.String(),
+		AlbumID:         trackEntity.AlbumID.String(),
+		Position:        trackEntity.Position,
+		Title:           trackEntity.Title,
+		DurationSeconds: trackEntity.DurationSeconds,
+	}
+	if !trackEntity.CreatedAt.IsZero() {
+		createdAt := trackEntity.CreatedAt
+		track.CreatedAt = &createdAt
+	}
+	if !trackEntity.UpdatedAt.IsZero() {
+		updatedAt := trackEntity.UpdatedAt
+		track.UpdatedAt = &updatedAt
+	}
+	return track
+}
+
+func BuildTrackDTOs(trackEntities []entity.Track) []Track {
+	tracks := make([]Track, len(trackEntities))
+	for i, trackEntity := range trackEntities {
+		tracks[i] = BuildTrackDTO(trackEntity)
+	}
+	return tracks
+}
+
+// BuildTrackEntity converts a track request into its entity form
+func BuildTrackEntity(track Track) entity.Track {
+	return entity.Track{
+		ID:              entity.TrackID(track.ID),
+		AlbumID:         entity.AlbumID(track.AlbumID),
+		Position:        track.Position,
+		Title:           track.Title,
+		DurationSeconds: track.DurationSeconds,
+	}
+}
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+// Webhook is a webhook endpoint, as registered by a partner
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url" binding:"required,max=2048"`
+	// Secret is only answered when the webhook is created; it is generated when none is given
+	Secret string   `json:"secret,omitempty" binding:"omitempty,min=16,max=255"`
+	Events []string `json:"events" binding:"dive,webhook_event"`
+	// Enabled defaults to true
+	Enabled      *bool      `json:"enabled"`
+	FailureCount int        `json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// WebhookDelivery is one attempt at delivering an album event to a webhook
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	DeliveryID string    `json:"delivery_id"`
+	EventID    string    `json:"event_id,omitempty"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BuildWebhookDTO describes a webhook, leaving its secret out
+func BuildWebhookDTO(webhookEntity entity.Webhook) Webhook {
+	enabled := webhookEntity.Enabled
+	webhook := Webhook{
+		ID:           webhookEntity.ID,
+		URL:          webhookEntity.URL,
+		Events:       webhookEntity.Events,
+		Enabled:      &enabled,
+		FailureCount: webhookEntity.FailureCount,
+		DisabledAt:   webhookEntity.DisabledAt,
+	}
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+	if !webhookEntity.CreatedAt.IsZero() {
+		createdAt := webhookEntity.CreatedAt
+		webhook.CreatedAt = &createdAt
+	}
+	if !webhookEntity.UpdatedAt.IsZero() {
+		updatedAt := webhookEntity.UpdatedAt
+		webhook.UpdatedAt = &updatedAt
+	}
+	return webhook
+}
+
+func BuildWebhookDTOs(webhookEntities []entity.Webhook) []Webhook {
+	webhooks := make([]Webhook, len(webhookEntities))
+	for i, webhookEntity := range webhookEntities {
+		webhooks[i] = BuildWebhookDTO(webhookEntity)
+	}
+	return webhooks
+}
+
+// BuildWebhookEntity converts a webhook request into its entity form; a missing enabled flag enables it
+func BuildWebhookEntity(webhook Webhook) entity.Webhook {
+	enabled := true
+	if webhook.Enabled != nil {
+		enabled = *webhook.Enabled
+	}
+	return entity.Webhook{
+		ID:      webhook.ID,
+		URL:     webhook.URL,
+		Secret:  webhook.Secret,
+		Events:  webhook.Events,
+		Enabled: enabled,
+	}
+}
+
+func BuildWebhookDeliveryDTOs(deliveryEntities []entity.WebhookDelivery) []WebhookDelivery {
+	deliveries := make([]WebhookDelivery, len(deliveryEntities))
+	for i, delivery := range deliveryEntities {
+		deliveries[i] = WebhookDelivery{
+			ID:         delivery.ID,
+			WebhookID:  delivery.WebhookID,
+			DeliveryID: delivery.DeliveryID,
+			EventID:    delivery.EventID,
+			EventType:  delivery.EventType,
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Error:      delivery.Error,
+			DurationMS: delivery.Duration.Milliseconds(),
+			Succeeded:  delivery.Succeeded,
+			CreatedAt:  delivery.CreatedAt,
+		}
+	}
+	return deliveries
+}
@@ -0,0 +1,22 @@
+package entity
+
+// AlbumImportRow is one album read from an import file
+type AlbumImportRow struct {
+	// Line is the 1-based line of the row in the import file
+	Line  int
+	Album Album
+	// Err is set when the row could not be read into an album
+	Err error
+}
+
+// AlbumImportReader streams the rows of an import file.
+// Next returns io.EOF once every row has been read.
+type AlbumImportReader interface {
+	Next() (AlbumImportRow, error)
+}
+
+// AlbumImportOptions controls how an import is run
+type AlbumImportOptions struct {
+	// DryRun validates and checks every row without writing anything
+	DryRun bool
+}
@@ -0,0 +1,49 @@
+package entity
+
+// AlbumSortField is a column albums can be listed by
+type AlbumSortField string
+
+const (
+	AlbumSortByID        AlbumSortField = "id"
+	AlbumSortByTitle     AlbumSortField = "title"
+	AlbumSortByCreatedAt AlbumSortField = "created_at"
+)
+
+// IsValid reports whether the field is one albums can be sorted by
+func (f AlbumSortField) IsValid() bool {
+	switch f {
+	case AlbumSortByID, AlbumSortByTitle, AlbumSortByCreatedAt:
+		return true
+	}
+	return false
+}
+
+// AlbumListOptions controls which page of albums is listed and in what order
+type AlbumListOptions struct {
+	Limit       int
+	Cursor      *AlbumCursor
+	SortBy      AlbumSortField
+	Descending  bool
+	TitlePrefix string
+	// Trashed lists the soft-deleted albums instead of the live ones
+	Trashed bool
+}
+
+// AlbumCursor marks the boundary row of a page for keyset pagination
+type AlbumCursor struct {
+	SortBy     AlbumSortField
+	Descending bool
+	// SortValue is the boundary row's value of the SortBy column
+	SortValue string
+	// ID breaks ties between rows sharing the same SortValue
+	ID string
+	// Before selects the page preceding the boundary row instead of the one following it
+	Before bool
+}
+
+// AlbumPage is one page of a listing together with the cursors of its neighbours
+type AlbumPage struct {
+	Albums []Album
+	Next   *AlbumCursor
+	Prev   *AlbumCursor
+}
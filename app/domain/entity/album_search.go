@@ -0,0 +1,21 @@
+package entity
+
+// AlbumSearchOptions selects one page of albums matching a search query
+type AlbumSearchOptions struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// AlbumSearchHit is an album matching a search together with its relevance
+type AlbumSearchHit struct {
+	Album Album
+	// Score is higher for better matches; it is only comparable within one search
+	Score float64
+}
+
+// AlbumSearchResult is one page of search hits ordered by descending relevance
+type AlbumSearchResult struct {
+	Hits    []AlbumSearchHit
+	HasMore bool
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// APIKey is a long-lived credential of a service calling the API. Only a hash of the key is
+// stored; the key itself is known to its holder alone.
+type APIKey struct {
+	ID   string
+	Name string
+	// Prefix is the public part of the key, which it is looked up by
+	Prefix string
+	// Hash is the hex SHA-256 of the whole key
+	Hash   string
+	Scopes []string
+	// ExpiresAt is nil for a key that does not expire
+	ExpiresAt *time.Time
+	// LastUsedAt is updated at most once per interval, so it may lag behind the latest use
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Expired reports whether the key has expired at now
+func (k APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Revoked reports whether the key was revoked
+func (k APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
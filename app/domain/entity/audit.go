@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Outcomes of an audited request; a denied request was refused for lacking a scope and changed nothing
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+	AuditOutcomeDenied  = "denied"
+)
+
+// AuditRecord tells who changed what through a mutating API call, and how it ended
+type AuditRecord struct {
+	ID string
+	// Actor is the caller, "user:<token subject>" or "api-key:<key ID>"
+	Actor     string
+	RequestID string
+	Method    string
+	// Route is the route pattern the request matched, such as /api/v2/albums/:id
+	Route string
+	// TargetID is the album changed, empty for calls spanning many albums
+	TargetID string
+	// Before and After are JSON snapshots of the target around the call, nil when it did not exist
+	Before     json.RawMessage
+	After      json.RawMessage
+	Outcome    string
+	StatusCode int
+	OccurredAt time.Time
+}
+
+// AuditFilter selects the audit records listed, newest first; zero fields match every record
+type AuditFilter struct {
+	Actor    string
+	TargetID string
+	Route    string
+	Outcome  string
+	// From and To bound OccurredAt, From included and To excluded
+	From  time.Time
+	To    time.Time
+	Limit int
+	// Cursor continues a listing after the last record of the previous page
+	Cursor *AuditCursor
+}
+
+// AuditCursor marks the last record of a page for keyset pagination
+type AuditCursor struct {
+	OccurredAt time.Time
+	// ID breaks ties between records that occurred at the same time
+	ID string
+}
+
+// AuditPage is one page of audit records together with the cursor of the next one
+type AuditPage struct {
+	Records []AuditRecord
+	Next    *AuditCursor
+}
+
+// AuditChange collects the snapshots of the album an audited call changes. The transport recording
+// the call puts one in the request context with WithAuditChange, and the usecases fill it in around
+// their write, so that the snapshots show that very change whatever happens around it.
+type AuditChange struct {
+	mu      sync.Mutex
+	before  json.RawMessage
+	after   json.RawMessage
+	written bool
+}
+
+type auditChangeKey struct{}
+
+// WithAuditChange returns a context in which the usecases record the snapshots of their write into change
+func WithAuditChange(ctx context.Context, change *AuditChange) context.Context {
+	return context.WithValue(ctx, auditChangeKey{}, change)
+}
+
+// AuditChangeFromContext returns the change of the audited call ctx belongs to, or nil when the
+// call is not audited
+func AuditChangeFromContext(ctx context.Context) *AuditChange {
+	change, _ := ctx.Value(auditChangeKey{}).(*AuditChange)
+	return change
+}
+
+// SetBefore records the target as read right before the write, nil when it did not exist
+func (c *AuditChange) SetBefore(snapshot json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.before = snapshot
+}
+
+// SetAfter records the target as read right after a successful write, nil when it no longer exists
+func (c *AuditChange) SetAfter(snapshot json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.after = snapshot
+	c.written = true
+}
+
+// Snapshots returns the target before and after the call. A call that wrote nothing changed
+// nothing, so its after snapshot is its before snapshot.
+func (c *AuditChange) Snapshots() (before, after json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.written {
+		return c.before, c.before
+	}
+	return c.before, c.after
+}
@@ -1,8 +1,65 @@
 package entity
 
+import "time"
+
+// AlbumGenres lists the genres an album can be filed under
+var AlbumGenres = []string{
+	"rock", "pop", "jazz", "classical", "hip-hop", "electronic",
+	"folk", "country", "blues", "metal", "soundtrack", "other",
+}
+
+// IsValidGenre reports whether genre is one of AlbumGenres
+func IsValidGenre(genre string) bool {
+	for _, g := range AlbumGenres {
+		if g == genre {
+			return true
+		}
+	}
+	return false
+}
+
 type Album struct {
-	ID    AlbumID
-	Title string
+	ID          AlbumID
+	Title       string
+	Artist      string
+	ReleaseDate *time.Time
+	Genre       string
+	TrackCount  int
+	// Version is incremented by every write; writes carrying a non-zero Version only apply to that version
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// DeletedAt is set while the album is in the trash
+	DeletedAt *time.Time
+}
+
+// AlbumPatch holds the fields of a partial album update; nil fields are left unchanged
+type AlbumPatch struct {
+	Title       *string
+	Artist      *string
+	ReleaseDate *time.Time
+	Genre       *string
+	TrackCount  *int
+}
+
+// Apply returns a copy of the album with the non-nil patch fields applied
+func (p AlbumPatch) Apply(album Album) Album {
+	if p.Title != nil {
+		album.Title = *p.Title
+	}
+	if p.Artist != nil {
+		album.Artist = *p.Artist
+	}
+	if p.ReleaseDate != nil {
+		album.ReleaseDate = p.ReleaseDate
+	}
+	if p.Genre != nil {
+		album.Genre = *p.Genre
+	}
+	if p.TrackCount != nil {
+		album.TrackCount = *p.TrackCount
+	}
+	return album
 }
 
 type AlbumID string
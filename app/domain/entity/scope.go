@@ -0,0 +1,32 @@
+package entity
+
+// Permissions that can be granted to callers, as scopes of their token or API key
+const (
+	ScopeAlbumsRead  = "albums:read"
+	ScopeAlbumsWrite = "albums:write"
+	// ScopeAdmin grants every other scope, and the management of webhooks and API keys
+	ScopeAdmin = "admin"
+)
+
+// Scopes lists the permissions that can be granted to callers
+var Scopes = []string{ScopeAlbumsRead, ScopeAlbumsWrite, ScopeAdmin}
+
+// IsValidScope reports whether scope is one of Scopes
+func IsValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the granted scopes include required, which admin always does
+func HasScope(granted []string, required string) bool {
+	for _, s := range granted {
+		if s == required || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
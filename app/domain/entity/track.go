@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Track is a single song on an album
+type Track struct {
+	ID              TrackID
+	AlbumID         AlbumID
+	Position        int
+	Title           string
+	DurationSeconds int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type TrackID string
+
+func (tid *TrackID) String() string {
+	return string(*tid)
+}
+
+// AlbumGetOptions selects the related resources embedded when fetching a single album
+type AlbumGetOptions struct {
+	IncludeTracks bool
+}
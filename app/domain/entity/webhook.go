@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// WebhookEventTypes lists the album events a webhook can subscribe to
+var WebhookEventTypes = []string{"album.created", "album.updated", "album.deleted", "album.restored"}
+
+// IsValidWebhookEventType reports whether eventType is one of WebhookEventTypes
+func IsValidWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook is an endpoint of a partner system that album events are delivered to
+type Webhook struct {
+	ID  string
+	URL string
+	// Secret is the key deliveries are signed with
+	Secret string
+	// Events are the event types delivered; an empty list subscribes to every album event
+	Events  []string
+	Enabled bool
+	// FailureCount is the number of deliveries in a row that failed all their attempts
+	FailureCount int
+	// DisabledAt is set when the webhook was disabled for failing too often
+	DisabledAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Subscribes reports whether the webhook is sent events of the given type
+func (w Webhook) Subscribes(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, t := range w.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one attempt at delivering an album event to a webhook
+type WebhookDelivery struct {
+	ID        string
+	WebhookID string
+	// DeliveryID is shared by every attempt at delivering the same event, so receivers can tell retries apart
+	DeliveryID string
+	EventID    string
+	EventType  string
+	Attempt    int
+	// StatusCode is the status the endpoint answered with, 0 when it could not be reached
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	Succeeded  bool
+	CreatedAt  time.Time
+}
@@ -2,25 +2,149 @@ package errors
 
 import "errors"
 
+// Kind classifies errors by what went wrong, independently of the resource involved.
+// The presentation layer maps each kind to a response status.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindInvalidInput
+	KindPreconditionFailed
+	KindPreconditionRequired
+	KindUnsupportedMediaType
+	KindUnauthenticated
+	KindNotAcceptable
+	KindUnavailable
+	KindTooLarge
+	KindForbidden
+	KindTooManyRequests
+	KindUnprocessable
+	KindTimeout
+)
+
+// Code is a stable, machine-readable identifier of an error that clients may rely on
+type Code string
+
+// Error is a typed error carrying a Kind, a Code and optionally the error that caused it.
+// Errors with the same Code match each other with errors.Is, so the sentinels below still
+// match once a cause has been attached with WithCause.
+type Error struct {
+	Kind Kind
+	Code Code
+	// Title is a short summary that is safe to show to clients
+	Title string
+	// Cause is the underlying error, reachable with errors.Unwrap
+	Cause error
+
+	message string
+}
+
+// NewError defines an error of the given kind; message is what Error returns
+func NewError(kind Kind, code Code, title, message string) *Error {
+	return &Error{Kind: kind, Code: code, Title: title, message: message}
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.message + ": " + e.Cause.Error()
+	}
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is an Error with the same Code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithCause returns a copy of the error that wraps cause
+func (e *Error) WithCause(cause error) *Error {
+	wrapped := *e
+	wrapped.Cause = cause
+	return &wrapped
+}
+
+// KindOf returns the kind of the first Error in err's chain, or KindInternal when there is none
+func KindOf(err error) Kind {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed.Kind
+	}
+	return KindInternal
+}
+
 // Custom error definitions for specific scenarios
 var (
-	ErrAlbumNotFound  = errors.New("album not found")
-	ErrInvalidInput   = errors.New("invalid input")
-	ErrInternalServer = errors.New("internal server error")
+	ErrAlbumNotFound   = NewError(KindNotFound, "album_not_found", "Album not found", "album not found")
+	ErrAlbumExists     = NewError(KindConflict, "album_exists", "Album already exists", "album already exists")
+	ErrTrackNotFound   = NewError(KindNotFound, "track_not_found", "Track not found", "track not found")
+	ErrAlbumHasTracks  = NewError(KindConflict, "album_has_tracks", "Album still has tracks", "album has tracks")
+	ErrVersionMismatch = NewError(KindPreconditionFailed, "version_mismatch", "Album was modified by another request", "album version mismatch")
+	ErrInvalidInput    = NewError(KindInvalidInput, "invalid_input", "Invalid input", "invalid input")
+	ErrInternalServer  = NewError(KindInternal, "internal", "Internal Server Error", "internal server error")
+	ErrEventsDisabled  = NewError(KindUnavailable, "events_disabled", "Album change events are not enabled", "album events disabled")
+	ErrWebhookNotFound = NewError(KindNotFound, "webhook_not_found", "Webhook not found", "webhook not found")
+	ErrAPIKeyNotFound  = NewError(KindNotFound, "api_key_not_found", "API key not found", "api key not found")
+	ErrAPIKeyInactive  = NewError(KindConflict, "api_key_inactive", "API key is revoked or expired", "api key inactive")
+	ErrAPIKeyInvalid   = NewError(KindUnauthenticated, "api_key_invalid", "Invalid API key", "invalid api key")
+	ErrAPIKeyExpired   = NewError(KindUnauthenticated, "api_key_expired", "API key expired", "api key expired")
+	ErrAPIKeyRevoked   = NewError(KindUnauthenticated, "api_key_revoked", "API key revoked", "api key revoked")
+	ErrScopeRequired   = NewError(KindForbidden, "insufficient_scope", "Insufficient scope", "insufficient scope")
 	// Add more custom errors here as needed
 )
 
 // IsAlbumNotFound checks if the error is a album not found error
 func IsAlbumNotFound(err error) bool {
-	return err == ErrAlbumNotFound
+	return errors.Is(err, ErrAlbumNotFound)
+}
+
+// IsAlbumExists checks if the error is an album ID that is already taken
+func IsAlbumExists(err error) bool {
+	return errors.Is(err, ErrAlbumExists)
+}
+
+// IsTrackNotFound checks if the error is a track not found error
+func IsTrackNotFound(err error) bool {
+	return errors.Is(err, ErrTrackNotFound)
+}
+
+// IsAlbumHasTracks checks if the error is an album that cannot be deleted because it still has tracks
+func IsAlbumHasTracks(err error) bool {
+	return errors.Is(err, ErrAlbumHasTracks)
+}
+
+// IsVersionMismatch checks if the error is a write rejected because the album changed since it was read
+func IsVersionMismatch(err error) bool {
+	return errors.Is(err, ErrVersionMismatch)
+}
+
+// IsWebhookNotFound checks if the error is a webhook not found error
+func IsWebhookNotFound(err error) bool {
+	return errors.Is(err, ErrWebhookNotFound)
+}
+
+// IsAPIKeyNotFound checks if the error is an API key not found error
+func IsAPIKeyNotFound(err error) bool {
+	return errors.Is(err, ErrAPIKeyNotFound)
+}
+
+// IsAPIKeyInactive checks if the error is an API key that cannot be used because it is revoked or expired
+func IsAPIKeyInactive(err error) bool {
+	return errors.Is(err, ErrAPIKeyInactive)
 }
 
-// IsInvalidInput checks if the error is an invalid input error
+// IsInvalidInput checks if the error is an invalid input error, including a ValidationError
 func IsInvalidInput(err error) bool {
-	return err == ErrInvalidInput
+	return errors.Is(err, ErrInvalidInput)
 }
 
 // IsInternalServer checks if the error is an internal server error
 func IsInternalServer(err error) bool {
-	return err == ErrInternalServer
+	return errors.Is(err, ErrInternalServer)
 }
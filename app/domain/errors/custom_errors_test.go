@@ -0,0 +1,45 @@
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	customerr "boilerplate/app/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_MatchesThroughWrapping(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := fmt.Errorf("service error getting album: %w", customerr.ErrInternalServer.WithCause(cause))
+
+	assert.True(t, customerr.IsInternalServer(err))
+	assert.False(t, customerr.IsAlbumNotFound(err))
+	assert.ErrorIs(t, err, cause)
+	assert.EqualError(t, err, "service error getting album: internal server error: connection reset")
+	assert.Equal(t, customerr.KindInternal, customerr.KindOf(err))
+
+	var typed *customerr.Error
+	assert.True(t, errors.As(err, &typed))
+	assert.Equal(t, customerr.Code("internal"), typed.Code)
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected customerr.Kind
+	}{
+		{name: "Sentinel", err: customerr.ErrAlbumNotFound, expected: customerr.KindNotFound},
+		{name: "Wrapped sentinel", err: fmt.Errorf("deleting: %w", customerr.ErrAlbumHasTracks), expected: customerr.KindConflict},
+		{name: "Validation error", err: customerr.NewValidationError(customerr.FieldError{Field: "title"}), expected: customerr.KindInvalidInput},
+		{name: "Untyped error", err: errors.New("boom"), expected: customerr.KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, customerr.KindOf(tt.err))
+		})
+	}
+}
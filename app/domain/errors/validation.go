@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+)
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	XMLName xml.Name `json:"-" xml:"error"`
+	Field   string   `json:"field" xml:"field"`
+	Code    string   `json:"code" xml:"code"`
+	Message string   `json:"message" xml:"message"`
+}
+
+// ValidationError is an invalid input error listing every rejected field.
+// It matches ErrInvalidInput with IsInvalidInput and errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewValidationError builds a ValidationError from the given field errors
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+// Add appends a field error
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+// OrNil returns the error when it holds at least one field error, and nil otherwise
+func (e *ValidationError) OrNil() error {
+	if e == nil || len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+	return ErrInvalidInput.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// ValidationDetails returns the field errors carried by err, if any
+func ValidationDetails(err error) []FieldError {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Fields
+	}
+	return nil
+}
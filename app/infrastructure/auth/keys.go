@@ -0,0 +1,400 @@
+package auth
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Signing algorithms accepted by the Verifier, each verified with its own type of key
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+)
+
+// Smallest keys accepted, so that tokens cannot be forged by brute force
+const (
+	minHMACSecretLength = 32
+	minRSAKeyBits       = 2048
+)
+
+// Key verifies the signatures of one algorithm
+type Key struct {
+	// ID is matched against the kid header of tokens; keys without one only verify tokens without one
+	ID        string
+	Algorithm string
+	// Material is a []byte for HS256, an *rsa.PublicKey for RS256 and an *ecdsa.PublicKey for ES256
+	Material interface{}
+}
+
+// KeySources tells a KeySet where to load its keys from. At least one key is required.
+type KeySources struct {
+	// HMACSecret verifies HS256 tokens without a key ID
+	HMACSecret string
+	// JWKSFile is a JSON Web Key Set; its RSA, P-256 and symmetric signing keys are loaded
+	JWKSFile string
+	// PEMFiles are public keys or certificates, or directories whose *.pem files are loaded.
+	// The ID of each key is its file name without the extension.
+	PEMFiles []string
+}
+
+// KeySet holds the keys tokens are verified with. Keys are loaded from files, which are checked for
+// changes every reload interval so that keys can be rotated without a restart: the new key is
+// added next to the old one, then the old one is removed once the tokens it signed have expired.
+type KeySet struct {
+	sources        KeySources
+	reloadInterval time.Duration
+	now            func() time.Time
+
+	mu        sync.RWMutex
+	keys      []Key
+	modTimes  map[string]time.Time
+	checkedAt time.Time
+}
+
+// NewKeySet loads the keys of sources. A reload interval of 0 never reloads them.
+func NewKeySet(sources KeySources, reloadInterval time.Duration) (*KeySet, error) {
+	s := &KeySet{sources: sources, reloadInterval: reloadInterval, now: time.Now}
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+	modTimes, err := statFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.load(files)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no token verification keys configured")
+	}
+	s.keys, s.modTimes, s.checkedAt = keys, modTimes, s.now()
+	return s, nil
+}
+
+// Lookup returns the keys that may have signed a token with the given key ID and algorithm:
+// the key of that ID, or every key without an ID when the token has none
+func (s *KeySet) Lookup(keyID, algorithm string) []Key {
+	s.reloadIfChanged()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []Key
+	for _, key := range s.keys {
+		if key.ID == keyID && key.Algorithm == algorithm {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// reloadIfChanged reloads the keys once the reload interval has passed, if any file has changed.
+// Keys that fail to load are logged and the previous keys kept, so that a file caught halfway
+// through being written does not reject every token.
+func (s *KeySet) reloadIfChanged() {
+	if s.reloadInterval <= 0 {
+		return
+	}
+	s.mu.RLock()
+	due := s.now().Sub(s.checkedAt) >= s.reloadInterval
+	s.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.now().Sub(s.checkedAt) < s.reloadInterval {
+		return
+	}
+	s.checkedAt = s.now()
+
+	files, err := s.files()
+	if err != nil {
+		log.Printf("Failed to list token verification keys: %v", err)
+		return
+	}
+	modTimes, err := statFiles(files)
+	if err != nil {
+		log.Printf("Failed to check token verification keys: %v", err)
+		return
+	}
+	if sameModTimes(modTimes, s.modTimes) {
+		return
+	}
+	keys, err := s.load(files)
+	if err == nil && len(keys) == 0 {
+		err = fmt.Errorf("no keys left")
+	}
+	if err != nil {
+		log.Printf("Failed to reload token verification keys, keeping the previous ones: %v", err)
+		return
+	}
+	s.keys, s.modTimes = keys, modTimes
+	log.Printf("Reloaded %d token verification keys", len(keys))
+}
+
+// files lists the files keys are loaded from, the JWKS file first
+func (s *KeySet) files() ([]string, error) {
+	var files []string
+	if s.sources.JWKSFile != "" {
+		files = append(files, s.sources.JWKSFile)
+	}
+	for _, path := range s.sources.PEMFiles {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading key file: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.pem"))
+		if err != nil {
+			return nil, fmt.Errorf("error listing key directory %s: %w", path, err)
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// load reads the keys of every source
+func (s *KeySet) load(files []string) ([]Key, error) {
+	var keys []Key
+	if s.sources.HMACSecret != "" {
+		if len(s.sources.HMACSecret) < minHMACSecretLength {
+			return nil, fmt.Errorf("HMAC secret shorter than %d bytes", minHMACSecretLength)
+		}
+		keys = append(keys, Key{Algorithm: AlgorithmHS256, Material: []byte(s.sources.HMACSecret)})
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading key file: %w", err)
+		}
+		if file == s.sources.JWKSFile {
+			jwksKeys, err := ParseJWKS(data)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", file, err)
+			}
+			keys = append(keys, jwksKeys...)
+			continue
+		}
+		id := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		key, err := ParsePEM(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// statFiles returns the modification time of every file
+func statFiles(files []string) (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading key file: %w", err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func sameModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for file, modTime := range a {
+		if other, ok := b[file]; !ok || !other.Equal(modTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonWebKey is a key of a JSON Web Key Set (RFC 7517), with the members of RSA, EC and symmetric keys
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// Symmetric
+	K string `json:"k"`
+}
+
+// ParseJWKS reads the signing keys of a JSON Web Key Set. Encryption keys and keys of other
+// algorithms are skipped.
+func ParseJWKS(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	var keys []Key
+	for i, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		var parse func(jsonWebKey) (interface{}, error)
+		key := Key{ID: jwk.Kid}
+		switch {
+		case jwk.Kty == "RSA":
+			key.Algorithm, parse = AlgorithmRS256, parseRSAJWK
+		case jwk.Kty == "EC" && jwk.Crv == "P-256":
+			key.Algorithm, parse = AlgorithmES256, parseECJWK
+		case jwk.Kty == "oct":
+			key.Algorithm, parse = AlgorithmHS256, parseOctJWK
+		default:
+			continue
+		}
+		if jwk.Alg != "" && jwk.Alg != key.Algorithm {
+			continue
+		}
+		material, err := parse(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("key %d (%q): %w", i, jwk.Kid, err)
+		}
+		key.Material = material
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func parseRSAJWK(jwk jsonWebKey) (interface{}, error) {
+	n, err := decodeBigInt(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid n: %w", err)
+	}
+	e, err := decodeBigInt(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid e: %w", err)
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid e")
+	}
+	return checkRSAKey(&rsa.PublicKey{N: n, E: int(e.Int64())})
+}
+
+func parseECJWK(jwk jsonWebKey) (interface{}, error) {
+	x, err := decodeBigInt(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x: %w", err)
+	}
+	y, err := decodeBigInt(jwk.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y: %w", err)
+	}
+	if x.BitLen() > 256 || y.BitLen() > 256 {
+		return nil, fmt.Errorf("point is not on P-256")
+	}
+	// The point is checked with crypto/ecdh, which rejects points off the curve
+	point := make([]byte, 65)
+	point[0] = 4
+	x.FillBytes(point[1:33])
+	y.FillBytes(point[33:])
+	if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+		return nil, fmt.Errorf("point is not on P-256")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+
+func parseOctJWK(jwk jsonWebKey) (interface{}, error) {
+	secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+	if err != nil {
+		return nil, fmt.Errorf("invalid k: %w", err)
+	}
+	if len(secret) < minHMACSecretLength {
+		return nil, fmt.Errorf("secret shorter than %d bytes", minHMACSecretLength)
+	}
+	return secret, nil
+}
+
+// decodeBigInt decodes the unsigned base64url integers of JWKs
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// ParsePEM reads an RSA or P-256 public key, given as a PUBLIC KEY, RSA PUBLIC KEY or CERTIFICATE block
+func ParsePEM(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, fmt.Errorf("no PEM block found")
+	}
+
+	var publicKey interface{}
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			publicKey = cert.PublicKey
+		}
+	default:
+		return Key{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		rsaKey, err := checkRSAKey(publicKey)
+		if err != nil {
+			return Key{}, err
+		}
+		return Key{ID: id, Algorithm: AlgorithmRS256, Material: rsaKey}, nil
+	case *ecdsa.PublicKey:
+		if publicKey.Curve != elliptic.P256() {
+			return Key{}, fmt.Errorf("unsupported curve %s, only P-256 is", publicKey.Curve.Params().Name)
+		}
+		return Key{ID: id, Algorithm: AlgorithmES256, Material: publicKey}, nil
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+func checkRSAKey(key *rsa.PublicKey) (*rsa.PublicKey, error) {
+	if key.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA key shorter than %d bits", minRSAKeyBits)
+	}
+	return key, nil
+}
@@ -0,0 +1,104 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/infrastructure/auth"
+)
+
+func TestParseJWKS(t *testing.T) {
+	tests := []struct {
+		name          string
+		jwks          string
+		expectedKeys  []string
+		expectedError bool
+	}{
+		{
+			name: "Keeps the signing keys",
+			jwks: `{"keys":[` +
+				`{"kty":"oct","kid":"hmac-1","k":"MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"},` +
+				`{"kty":"oct","kid":"enc-1","use":"enc","k":"MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"},` +
+				`{"kty":"oct","kid":"hs512","alg":"HS512","k":"MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"},` +
+				`{"kty":"EC","kid":"p384","crv":"P-384","x":"AA","y":"AA"},` +
+				`{"kty":"OKP","kid":"ed25519","crv":"Ed25519","x":"AA"}]}`,
+			expectedKeys: []string{"hmac-1"},
+		},
+		{
+			name:          "Secret too short",
+			jwks:          `{"keys":[{"kty":"oct","kid":"hmac-1","k":"c2hvcnQ"}]}`,
+			expectedError: true,
+		},
+		{
+			name:          "Point off the curve",
+			jwks:          `{"keys":[{"kty":"EC","kid":"ec-1","crv":"P-256","x":"AQ","y":"AQ"}]}`,
+			expectedError: true,
+		},
+		{
+			name:          "Not a key set",
+			jwks:          `[]`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := auth.ParseJWKS([]byte(tt.jwks))
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var ids []string
+			for _, key := range keys {
+				ids = append(ids, key.ID)
+			}
+			assert.Equal(t, tt.expectedKeys, ids)
+		})
+	}
+}
+
+func TestKeySet_ReloadsRotatedKeys(t *testing.T) {
+	dir := t.TempDir()
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "2024-01.pem"), &oldKey.PublicKey)
+
+	keys, err := auth.NewKeySet(auth.KeySources{PEMFiles: []string{dir}}, time.Nanosecond)
+	require.NoError(t, err)
+	assert.Len(t, keys.Lookup("2024-01", auth.AlgorithmES256), 1)
+	assert.Empty(t, keys.Lookup("2024-02", auth.AlgorithmES256))
+
+	// The new key is added next to the old one
+	writePEM(t, filepath.Join(dir, "2024-02.pem"), &newKey.PublicKey)
+	assert.Len(t, keys.Lookup("2024-02", auth.AlgorithmES256), 1)
+	assert.Len(t, keys.Lookup("2024-01", auth.AlgorithmES256), 1)
+
+	// A file that does not parse keeps the previous keys
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2024-03.pem"), []byte("half written"), 0o600))
+	assert.Len(t, keys.Lookup("2024-02", auth.AlgorithmES256), 1)
+
+	// The old key is removed
+	require.NoError(t, os.Remove(filepath.Join(dir, "2024-03.pem")))
+	require.NoError(t, os.Remove(filepath.Join(dir, "2024-01.pem")))
+	assert.Empty(t, keys.Lookup("2024-01", auth.AlgorithmES256))
+	assert.Len(t, keys.Lookup("2024-02", auth.AlgorithmES256), 1)
+}
+
+func TestNewKeySet_RequiresKeys(t *testing.T) {
+	_, err := auth.NewKeySet(auth.KeySources{}, 0)
+	assert.Error(t, err)
+
+	_, err = auth.NewKeySet(auth.KeySources{HMACSecret: "too short"}, 0)
+	assert.Error(t, err)
+}
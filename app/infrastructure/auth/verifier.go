@@ -0,0 +1,219 @@
+// Package auth verifies the JWT bearer tokens requests are authenticated with. Tokens are signed
+// with HS256, RS256 or ES256, by keys loaded from a JWKS file, PEM files or a shared secret.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Reasons a token is rejected by Verify; the messages are sent to the client
+var (
+	ErrTokenMalformed     = errors.New("Malformed token")
+	ErrTokenAlgorithm     = errors.New("Unsupported token signing algorithm")
+	ErrTokenKeyUnknown    = errors.New("Unknown token signing key")
+	ErrTokenSignature     = errors.New("Invalid token signature")
+	ErrTokenExpired       = errors.New("Token expired")
+	ErrTokenNotValidYet   = errors.New("Token not valid yet")
+	ErrTokenExpiryMissing = errors.New("Token has no expiration time")
+	ErrTokenIssuer        = errors.New("Invalid token issuer")
+	ErrTokenAudience      = errors.New("Invalid token audience")
+	ErrTokenInvalid       = errors.New("Invalid token")
+
+	errTokenIssuedInFuture = fmt.Errorf("%w: issued in the future", ErrTokenNotValidYet)
+)
+
+// supportedAlgorithms are the algorithms tokens may be signed with
+var supportedAlgorithms = map[string]bool{AlgorithmHS256: true, AlgorithmRS256: true, AlgorithmES256: true}
+
+// Claims are the verified claims of a token, or of an API key standing in for one
+type Claims struct {
+	Subject  string
+	Issuer   string
+	Audience []string
+	ID       string
+	// Times are zero when the token does not carry them
+	IssuedAt  time.Time
+	NotBefore time.Time
+	ExpiresAt time.Time
+	// Scopes are the permissions granted to the caller: the scope claim of a token, or the scopes of a key
+	Scopes []string
+	// APIKeyID is set when the caller authenticated with an API key rather than a token
+	APIKeyID string
+}
+
+// tokenClaims are the claims read from a token: the registered ones and the space-separated
+// scope of RFC 8693
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope,omitempty"`
+}
+
+// KeyLookup finds the keys that may have signed a token; it is satisfied by *KeySet
+type KeyLookup interface {
+	Lookup(keyID, algorithm string) []Key
+}
+
+// Verifier checks the signature and the registered claims of tokens
+type Verifier struct {
+	keys     KeyLookup
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+
+	parser *jwt.Parser
+}
+
+// Option customises optional Verifier behaviour
+type Option func(*Verifier)
+
+// WithIssuer makes tokens valid only when their iss claim is issuer
+func WithIssuer(issuer string) Option {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience makes tokens valid only when their aud claim includes audience
+func WithAudience(audience string) Option {
+	return func(v *Verifier) {
+		v.audience = audience
+	}
+}
+
+// WithLeeway tolerates clocks that differ by up to leeway when checking exp, nbf and iat
+func WithLeeway(leeway time.Duration) Option {
+	return func(v *Verifier) {
+		v.leeway = leeway
+	}
+}
+
+// WithClock sets the clock tokens are checked against
+func WithClock(now func() time.Time) Option {
+	return func(v *Verifier) {
+		v.now = now
+	}
+}
+
+// NewVerifier creates a verifier of the tokens signed by keys. Tokens must carry an exp claim.
+func NewVerifier(keys KeyLookup, opts ...Option) *Verifier {
+	v := &Verifier{keys: keys, now: time.Now}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(v.leeway),
+		jwt.WithTimeFunc(v.now),
+	}
+	if v.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(v.audience))
+	}
+	v.parser = jwt.NewParser(parserOpts...)
+	return v
+}
+
+// Verify checks a token and returns its claims. The error tells why the token was rejected.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var parsed tokenClaims
+	if _, err := v.parser.ParseWithClaims(token, &parsed, v.keyFunc); err != nil {
+		return nil, v.reason(err, &parsed)
+	}
+
+	registered := parsed.RegisteredClaims
+	claims := &Claims{
+		Subject:  registered.Subject,
+		Issuer:   registered.Issuer,
+		Audience: registered.Audience,
+		ID:       registered.ID,
+		Scopes:   strings.Fields(parsed.Scope),
+	}
+	if registered.IssuedAt != nil {
+		claims.IssuedAt = registered.IssuedAt.Time
+	}
+	if registered.NotBefore != nil {
+		claims.NotBefore = registered.NotBefore.Time
+	}
+	if registered.ExpiresAt != nil {
+		claims.ExpiresAt = registered.ExpiresAt.Time
+	}
+	return claims, nil
+}
+
+// keyFunc returns the keys of the token's algorithm and key ID. Keys are tied to one algorithm,
+// so that a token cannot be verified with a key of another type, such as an HS256 token
+// signed with a public RSA key as its secret.
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	algorithm := token.Method.Alg()
+	if !supportedAlgorithms[algorithm] {
+		return nil, ErrTokenAlgorithm
+	}
+	keyID, _ := token.Header["kid"].(string)
+	keys := v.keys.Lookup(keyID, algorithm)
+	if len(keys) == 0 {
+		return nil, ErrTokenKeyUnknown
+	}
+
+	set := jwt.VerificationKeySet{Keys: make([]jwt.VerificationKey, len(keys))}
+	for i, key := range keys {
+		set.Keys[i] = key.Material
+	}
+	return set, nil
+}
+
+// reason maps the errors of the JWT parser to the errors of Verify. parsed holds the claims read
+// from the token, which tell which required claim is missing.
+func (v *Verifier) reason(err error, parsed *tokenClaims) error {
+	switch {
+	case errors.Is(err, ErrTokenAlgorithm):
+		return ErrTokenAlgorithm
+	case errors.Is(err, ErrTokenKeyUnknown):
+		return ErrTokenKeyUnknown
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ErrTokenMalformed
+	case errors.Is(err, jwt.ErrTokenUnverifiable):
+		// Left once keyFunc has passed: the alg header names no algorithm the parser knows
+		return ErrTokenAlgorithm
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return ErrTokenSignature
+	case errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return v.missingClaim(parsed)
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return ErrTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return errTokenIssuedInFuture
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrTokenIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrTokenAudience
+	default:
+		return ErrTokenInvalid
+	}
+}
+
+// missingClaim returns the error of the required claim parsed lacks: exp is always required, iss
+// when the verifier has an issuer and aud when it has an audience
+func (v *Verifier) missingClaim(parsed *tokenClaims) error {
+	switch {
+	case parsed.ExpiresAt == nil:
+		return ErrTokenExpiryMissing
+	case v.issuer != "" && parsed.Issuer == "":
+		return ErrTokenIssuer
+	case v.audience != "" && len(parsed.Audience) == 0:
+		return ErrTokenAudience
+	default:
+		return ErrTokenInvalid
+	}
+}
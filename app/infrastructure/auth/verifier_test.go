@@ -0,0 +1,180 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/infrastructure/auth"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+var testNow = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// sign returns a token of claims signed with key by method, carrying keyID unless it is empty
+func sign(t *testing.T, method jwt.SigningMethod, keyID string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// validClaims returns claims valid at testNow, overridden by overrides
+func validClaims(overrides jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://issuer.example",
+		"aud":   "album-api",
+		"scope": "albums:read albums:write",
+		"iat":   testNow.Add(-time.Minute).Unix(),
+		"exp":   testNow.Add(time.Hour).Unix(),
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+		claims[name] = value
+	}
+	return claims
+}
+
+func writePEM(t *testing.T, path string, publicKey interface{}) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	jwksFile := filepath.Join(dir, "jwks.json")
+	jwks := `{"keys":[{"kty":"RSA","kid":"rsa-1","use":"sig","alg":"RS256","n":"` +
+		base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()) + `","e":"` +
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()) + `"}]}`
+	require.NoError(t, os.WriteFile(jwksFile, []byte(jwks), 0o600))
+	writePEM(t, filepath.Join(dir, "ec-1.pem"), &ecKey.PublicKey)
+
+	keys, err := auth.NewKeySet(auth.KeySources{HMACSecret: testSecret, JWKSFile: jwksFile, PEMFiles: []string{dir}}, 0)
+	require.NoError(t, err)
+	verifier := auth.NewVerifier(keys,
+		auth.WithIssuer("https://issuer.example"),
+		auth.WithAudience("album-api"),
+		auth.WithLeeway(30*time.Second),
+		auth.WithClock(func() time.Time { return testNow }),
+	)
+
+	tests := []struct {
+		name        string
+		token       string
+		expectedErr error
+	}{
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(nil))},
+		{name: "RS256 key from the JWKS", token: sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims(nil))},
+		{name: "ES256 key from a PEM file", token: sign(t, jwt.SigningMethodES256, "ec-1", ecKey, validClaims(nil))},
+		{
+			name:  "Expired within the leeway",
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"exp": testNow.Add(-10 * time.Second).Unix()})),
+		},
+		{
+			name:        "Expired",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"exp": testNow.Add(-time.Minute).Unix()})),
+			expectedErr: auth.ErrTokenExpired,
+		},
+		{
+			name:        "Not valid yet",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"nbf": testNow.Add(time.Minute).Unix()})),
+			expectedErr: auth.ErrTokenNotValidYet,
+		},
+		{
+			name:        "Without expiration time",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"exp": nil})),
+			expectedErr: auth.ErrTokenExpiryMissing,
+		},
+		{
+			name:        "Other issuer",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"iss": "https://other.example"})),
+			expectedErr: auth.ErrTokenIssuer,
+		},
+		{
+			name:        "Without issuer",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"iss": nil})),
+			expectedErr: auth.ErrTokenIssuer,
+		},
+		{
+			name:        "Without audience",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"aud": nil})),
+			expectedErr: auth.ErrTokenAudience,
+		},
+		{
+			name:        "Other audience",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), validClaims(jwt.MapClaims{"aud": []string{"billing-api"}})),
+			expectedErr: auth.ErrTokenAudience,
+		},
+		{
+			name:        "Wrong secret",
+			token:       sign(t, jwt.SigningMethodHS256, "", []byte("fedcba9876543210fedcba9876543210"), validClaims(nil)),
+			expectedErr: auth.ErrTokenSignature,
+		},
+		{
+			name:        "Unknown key ID",
+			token:       sign(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, validClaims(nil)),
+			expectedErr: auth.ErrTokenKeyUnknown,
+		},
+		{
+			name:        "Key of another algorithm",
+			token:       sign(t, jwt.SigningMethodHS256, "rsa-1", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey), validClaims(nil)),
+			expectedErr: auth.ErrTokenKeyUnknown,
+		},
+		{
+			name:        "Unsigned",
+			token:       sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, validClaims(nil)),
+			expectedErr: auth.ErrTokenAlgorithm,
+		},
+		{
+			name:        "Unsupported algorithm",
+			token:       sign(t, jwt.SigningMethodHS512, "", []byte(testSecret), validClaims(nil)),
+			expectedErr: auth.ErrTokenAlgorithm,
+		},
+		{name: "Malformed", token: "not.a.token", expectedErr: auth.ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, claims)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", claims.Subject)
+			assert.Equal(t, []string{"album-api"}, claims.Audience)
+			assert.Equal(t, testNow.Add(-time.Minute).Unix(), claims.IssuedAt.Unix())
+			assert.Equal(t, []string{"albums:read", "albums:write"}, claims.Scopes)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -21,6 +23,66 @@ type AppConfig struct {
 	JSONPlaceHolderURL string        `env:"JSON_PLACEHOLDER_URL"`
 	APITimeout         time.Duration `env:"API_TIMEOUT"`
 	HandlerTimeout     time.Duration `env:"HANDLER_TIMEOUT"`
+
+	AlbumDeleteCascadeTracks bool `env:"ALBUM_DELETE_CASCADE_TRACKS"`
+
+	AlbumIDStrategy string        `env:"ALBUM_ID_STRATEGY"`
+	IdempotencyTTL  time.Duration `env:"IDEMPOTENCY_TTL"`
+
+	AlbumImportBatchSize int    `env:"ALBUM_IMPORT_BATCH_SIZE"`
+	AlbumSearchMode      string `env:"ALBUM_SEARCH_MODE"`
+
+	AlbumTrashRetention time.Duration `env:"ALBUM_TRASH_RETENTION"`
+
+	OpenAPIValidateRequests bool `env:"OPENAPI_VALIDATE_REQUESTS"`
+
+	GRPCPort string `env:"GRPC_PORT"`
+
+	GraphQLMaxDepth      int `env:"GRAPHQL_MAX_DEPTH"`
+	GraphQLMaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY"`
+
+	APIV1DeprecatedAt time.Time `env:"API_V1_DEPRECATION"`
+	APIV1SunsetAt     time.Time `env:"API_V1_SUNSET"`
+
+	AlbumCacheControl     string `env:"ALBUM_CACHE_CONTROL"`
+	AlbumListCacheControl string `env:"ALBUM_LIST_CACHE_CONTROL"`
+
+	CompressionMinSize      int   `env:"COMPRESSION_MIN_SIZE"`
+	DecompressedBodyMaxSize int64 `env:"DECOMPRESSED_BODY_MAX_SIZE"`
+
+	AlbumEventLogSize    int           `env:"ALBUM_EVENT_LOG_SIZE"`
+	AlbumEventBuffer     int           `env:"ALBUM_EVENT_BUFFER"`
+	AlbumStreamHeartbeat time.Duration `env:"ALBUM_STREAM_HEARTBEAT"`
+
+	JWTHMACSecret         string        `env:"JWT_HMAC_SECRET"`
+	JWTJWKSFile           string        `env:"JWT_JWKS_FILE"`
+	JWTPublicKeys         []string      `env:"JWT_PUBLIC_KEYS"`
+	JWTIssuer             string        `env:"JWT_ISSUER"`
+	JWTAudience           string        `env:"JWT_AUDIENCE"`
+	JWTLeeway             time.Duration `env:"JWT_LEEWAY"`
+	JWTKeysReloadInterval time.Duration `env:"JWT_KEYS_RELOAD_INTERVAL"`
+
+	APIKeyCacheTTL         time.Duration `env:"API_KEY_CACHE_TTL"`
+	APIKeyLastUsedInterval time.Duration `env:"API_KEY_LAST_USED_INTERVAL"`
+
+	RateLimitRead   RateLimit `env:"RATE_LIMIT_READ"`
+	RateLimitWrite  RateLimit `env:"RATE_LIMIT_WRITE"`
+	RateLimitAdmin  RateLimit `env:"RATE_LIMIT_ADMIN"`
+	RateLimitPublic RateLimit `env:"RATE_LIMIT_PUBLIC"`
+	RateLimitAuth   RateLimit `env:"RATE_LIMIT_AUTH"`
+	TrustedProxies  []string  `env:"TRUSTED_PROXIES"`
+
+	AuditQueueSize    int           `env:"AUDIT_QUEUE_SIZE"`
+	AuditWriteTimeout time.Duration `env:"AUDIT_WRITE_TIMEOUT"`
+
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT"`
+}
+
+// RateLimit is the number of requests a client may send per window, written "<requests>/<window>"
+// as in 100/1m. Requests may come in bursts of up to Requests; zero Requests lifts the limit.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
 }
 
 var AppCfg AppConfig
@@ -75,5 +137,373 @@ func LoadConfig() error {
 		AppCfg.HandlerTimeout = duration
 	}
 
+	// Deleting an album that still has tracks is refused unless cascading is enabled
+	cascadeTracksStr := os.Getenv("ALBUM_DELETE_CASCADE_TRACKS")
+	if cascadeTracksStr == "" {
+		AppCfg.AlbumDeleteCascadeTracks = false
+	} else {
+		cascade, err := strconv.ParseBool(cascadeTracksStr)
+		if err != nil {
+			return fmt.Errorf("invalid ALBUM_DELETE_CASCADE_TRACKS format: %v", err)
+		}
+		AppCfg.AlbumDeleteCascadeTracks = cascade
+	}
+
+	// Strategy for album IDs generated by the server, "uuidv7" (default) or "ulid"
+	AppCfg.AlbumIDStrategy = os.Getenv("ALBUM_ID_STRATEGY")
+	if AppCfg.AlbumIDStrategy == "" {
+		AppCfg.AlbumIDStrategy = "uuidv7"
+	}
+
+	// How long responses are kept for replay under an Idempotency-Key, default to 24 hours
+	idempotencyTTLStr := os.Getenv("IDEMPOTENCY_TTL")
+	if idempotencyTTLStr == "" {
+		AppCfg.IdempotencyTTL = 24 * time.Hour
+	} else {
+		duration, err := time.ParseDuration(idempotencyTTLStr)
+		if err != nil {
+			return fmt.Errorf("invalid IDEMPOTENCY_TTL format: %v", err)
+		}
+		AppCfg.IdempotencyTTL = duration
+	}
+
+	// Number of rows written per multi-row insert by the album import, default to 500
+	importBatchSizeStr := os.Getenv("ALBUM_IMPORT_BATCH_SIZE")
+	if importBatchSizeStr == "" {
+		AppCfg.AlbumImportBatchSize = 500
+	} else {
+		batchSize, err := strconv.Atoi(importBatchSizeStr)
+		if err != nil || batchSize < 1 {
+			return fmt.Errorf("invalid ALBUM_IMPORT_BATCH_SIZE format: %q", importBatchSizeStr)
+		}
+		AppCfg.AlbumImportBatchSize = batchSize
+	}
+
+	// How album search matches, "fulltext" (default, needs the FULLTEXT index) or "like"
+	AppCfg.AlbumSearchMode = os.Getenv("ALBUM_SEARCH_MODE")
+	switch AppCfg.AlbumSearchMode {
+	case "":
+		AppCfg.AlbumSearchMode = "fulltext"
+	case "fulltext", "like":
+	default:
+		return fmt.Errorf("invalid ALBUM_SEARCH_MODE: %q", AppCfg.AlbumSearchMode)
+	}
+
+	// How long deleted albums stay restorable before a purge removes them, default to 30 days
+	trashRetentionStr := os.Getenv("ALBUM_TRASH_RETENTION")
+	if trashRetentionStr == "" {
+		AppCfg.AlbumTrashRetention = 30 * 24 * time.Hour
+	} else {
+		duration, err := time.ParseDuration(trashRetentionStr)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid ALBUM_TRASH_RETENTION format: %q", trashRetentionStr)
+		}
+		AppCfg.AlbumTrashRetention = duration
+	}
+
+	// Reject requests that do not match the OpenAPI document before they reach the handlers
+	validateRequestsStr := os.Getenv("OPENAPI_VALIDATE_REQUESTS")
+	if validateRequestsStr == "" {
+		AppCfg.OpenAPIValidateRequests = false
+	} else {
+		validate, err := strconv.ParseBool(validateRequestsStr)
+		if err != nil {
+			return fmt.Errorf("invalid OPENAPI_VALIDATE_REQUESTS format: %v", err)
+		}
+		AppCfg.OpenAPIValidateRequests = validate
+	}
+
+	// Port the gRPC server listens on next to the HTTP server, default to 9090
+	AppCfg.GRPCPort = os.Getenv("GRPC_PORT")
+	if AppCfg.GRPCPort == "" {
+		AppCfg.GRPCPort = "9090"
+	}
+
+	// Deepest nesting of fields a GraphQL operation may select, default to 10
+	maxDepthStr := os.Getenv("GRAPHQL_MAX_DEPTH")
+	if maxDepthStr == "" {
+		AppCfg.GraphQLMaxDepth = 10
+	} else {
+		maxDepth, err := strconv.Atoi(maxDepthStr)
+		if err != nil || maxDepth < 1 {
+			return fmt.Errorf("invalid GRAPHQL_MAX_DEPTH format: %q", maxDepthStr)
+		}
+		AppCfg.GraphQLMaxDepth = maxDepth
+	}
+
+	// Largest number of fields a GraphQL operation may resolve, counting list items, default to 2000
+	maxComplexityStr := os.Getenv("GRAPHQL_MAX_COMPLEXITY")
+	if maxComplexityStr == "" {
+		AppCfg.GraphQLMaxComplexity = 2000
+	} else {
+		maxComplexity, err := strconv.Atoi(maxComplexityStr)
+		if err != nil || maxComplexity < 1 {
+			return fmt.Errorf("invalid GRAPHQL_MAX_COMPLEXITY format: %q", maxComplexityStr)
+		}
+		AppCfg.GraphQLMaxComplexity = maxComplexity
+	}
+
+	// When v1 was deprecated and when it goes away, as RFC 3339 times; v1 responses omit the
+	// Deprecation and Sunset headers while these are unset
+	if deprecationStr := os.Getenv("API_V1_DEPRECATION"); deprecationStr != "" {
+		deprecatedAt, err := time.Parse(time.RFC3339, deprecationStr)
+		if err != nil {
+			return fmt.Errorf("invalid API_V1_DEPRECATION format: %v", err)
+		}
+		AppCfg.APIV1DeprecatedAt = deprecatedAt
+	}
+	if sunsetStr := os.Getenv("API_V1_SUNSET"); sunsetStr != "" {
+		sunsetAt, err := time.Parse(time.RFC3339, sunsetStr)
+		if err != nil {
+			return fmt.Errorf("invalid API_V1_SUNSET format: %v", err)
+		}
+		AppCfg.APIV1SunsetAt = sunsetAt
+	}
+
+	// Cache-Control of album responses and of album list pages, default to "private, no-cache",
+	// which lets clients keep them but makes them revalidate with If-None-Match first
+	AppCfg.AlbumCacheControl = os.Getenv("ALBUM_CACHE_CONTROL")
+	if AppCfg.AlbumCacheControl == "" {
+		AppCfg.AlbumCacheControl = "private, no-cache"
+	}
+	AppCfg.AlbumListCacheControl = os.Getenv("ALBUM_LIST_CACHE_CONTROL")
+	if AppCfg.AlbumListCacheControl == "" {
+		AppCfg.AlbumListCacheControl = "private, no-cache"
+	}
+
+	// Smallest response body, in bytes, compressed for clients accepting gzip or brotli, default to 1024
+	minSizeStr := os.Getenv("COMPRESSION_MIN_SIZE")
+	if minSizeStr == "" {
+		AppCfg.CompressionMinSize = 1024
+	} else {
+		minSize, err := strconv.Atoi(minSizeStr)
+		if err != nil || minSize < 0 {
+			return fmt.Errorf("invalid COMPRESSION_MIN_SIZE format: %q", minSizeStr)
+		}
+		AppCfg.CompressionMinSize = minSize
+	}
+
+	// Largest content, in bytes, read out of a request body sent with gzip or brotli, default to 10 MiB
+	maxBodySizeStr := os.Getenv("DECOMPRESSED_BODY_MAX_SIZE")
+	if maxBodySizeStr == "" {
+		AppCfg.DecompressedBodyMaxSize = 10 << 20
+	} else {
+		maxBodySize, err := strconv.ParseInt(maxBodySizeStr, 10, 64)
+		if err != nil || maxBodySize <= 0 {
+			return fmt.Errorf("invalid DECOMPRESSED_BODY_MAX_SIZE format: %q", maxBodySizeStr)
+		}
+		AppCfg.DecompressedBodyMaxSize = maxBodySize
+	}
+
+	// About how many album change events are kept for streams resuming with Last-Event-ID, default to 1000
+	eventLogSizeStr := os.Getenv("ALBUM_EVENT_LOG_SIZE")
+	if eventLogSizeStr == "" {
+		AppCfg.AlbumEventLogSize = 1000
+	} else {
+		logSize, err := strconv.Atoi(eventLogSizeStr)
+		if err != nil || logSize < 1 {
+			return fmt.Errorf("invalid ALBUM_EVENT_LOG_SIZE format: %q", eventLogSizeStr)
+		}
+		AppCfg.AlbumEventLogSize = logSize
+	}
+
+	// Number of album change events a stream client may fall behind before it is disconnected, default to 64
+	eventBufferStr := os.Getenv("ALBUM_EVENT_BUFFER")
+	if eventBufferStr == "" {
+		AppCfg.AlbumEventBuffer = 64
+	} else {
+		buffer, err := strconv.Atoi(eventBufferStr)
+		if err != nil || buffer < 1 {
+			return fmt.Errorf("invalid ALBUM_EVENT_BUFFER format: %q", eventBufferStr)
+		}
+		AppCfg.AlbumEventBuffer = buffer
+	}
+
+	// Interval of the heartbeats sent on idle album change streams, default to 15 seconds
+	heartbeatStr := os.Getenv("ALBUM_STREAM_HEARTBEAT")
+	if heartbeatStr == "" {
+		AppCfg.AlbumStreamHeartbeat = 15 * time.Second
+	} else {
+		duration, err := time.ParseDuration(heartbeatStr)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid ALBUM_STREAM_HEARTBEAT format: %q", heartbeatStr)
+		}
+		AppCfg.AlbumStreamHeartbeat = duration
+	}
+
+	// Keys bearer tokens are verified with, at least one of them required: the HS256 secret, of at
+	// least 32 bytes, a JWKS file, and PEM public keys or certificates, as a comma-separated list of
+	// files and of directories whose *.pem files are loaded, each key named after its file
+	AppCfg.JWTHMACSecret = os.Getenv("JWT_HMAC_SECRET")
+	AppCfg.JWTJWKSFile = os.Getenv("JWT_JWKS_FILE")
+	AppCfg.JWTPublicKeys = nil
+	for _, path := range strings.Split(os.Getenv("JWT_PUBLIC_KEYS"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			AppCfg.JWTPublicKeys = append(AppCfg.JWTPublicKeys, path)
+		}
+	}
+	if AppCfg.JWTHMACSecret == "" && AppCfg.JWTJWKSFile == "" && len(AppCfg.JWTPublicKeys) == 0 {
+		return fmt.Errorf("missing JWT_HMAC_SECRET, JWT_JWKS_FILE or JWT_PUBLIC_KEYS")
+	}
+
+	// iss and aud tokens must carry; either check is skipped while unset
+	AppCfg.JWTIssuer = os.Getenv("JWT_ISSUER")
+	AppCfg.JWTAudience = os.Getenv("JWT_AUDIENCE")
+
+	// Clock skew tolerated when checking exp, nbf and iat, default to 30 seconds
+	leewayStr := os.Getenv("JWT_LEEWAY")
+	if leewayStr == "" {
+		AppCfg.JWTLeeway = 30 * time.Second
+	} else {
+		duration, err := time.ParseDuration(leewayStr)
+		if err != nil || duration < 0 {
+			return fmt.Errorf("invalid JWT_LEEWAY format: %q", leewayStr)
+		}
+		AppCfg.JWTLeeway = duration
+	}
+
+	// How often key files are checked for changes, so that keys can be rotated without a restart,
+	// default to 1 minute; 0 never reloads them
+	reloadIntervalStr := os.Getenv("JWT_KEYS_RELOAD_INTERVAL")
+	if reloadIntervalStr == "" {
+		AppCfg.JWTKeysReloadInterval = time.Minute
+	} else {
+		duration, err := time.ParseDuration(reloadIntervalStr)
+		if err != nil || duration < 0 {
+			return fmt.Errorf("invalid JWT_KEYS_RELOAD_INTERVAL format: %q", reloadIntervalStr)
+		}
+		AppCfg.JWTKeysReloadInterval = duration
+	}
+
+	// How long verified API keys are served from Redis before they are read from MySQL again,
+	// default to 1 minute. Revoked and rotated keys are removed from Redis at once.
+	apiKeyCacheTTLStr := os.Getenv("API_KEY_CACHE_TTL")
+	if apiKeyCacheTTLStr == "" {
+		AppCfg.APIKeyCacheTTL = time.Minute
+	} else {
+		duration, err := time.ParseDuration(apiKeyCacheTTLStr)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid API_KEY_CACHE_TTL format: %q", apiKeyCacheTTLStr)
+		}
+		AppCfg.APIKeyCacheTTL = duration
+	}
+
+	// How often at most the last use of an API key is written, default to 1 minute
+	apiKeyLastUsedStr := os.Getenv("API_KEY_LAST_USED_INTERVAL")
+	if apiKeyLastUsedStr == "" {
+		AppCfg.APIKeyLastUsedInterval = time.Minute
+	} else {
+		duration, err := time.ParseDuration(apiKeyLastUsedStr)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid API_KEY_LAST_USED_INTERVAL format: %q", apiKeyLastUsedStr)
+		}
+		AppCfg.APIKeyLastUsedInterval = duration
+	}
+
+	// Requests per client and window to the routes of each group: album reads, album writes, the
+	// routes requiring admin and the routes open to anyone. Clients are told apart by API key, token
+	// subject or IP address; 0 lifts the limit of a group.
+	rateLimits := []struct {
+		name   string
+		target *RateLimit
+		value  RateLimit
+	}{
+		{name: "RATE_LIMIT_READ", target: &AppCfg.RateLimitRead, value: RateLimit{Requests: 600, Window: time.Minute}},
+		{name: "RATE_LIMIT_WRITE", target: &AppCfg.RateLimitWrite, value: RateLimit{Requests: 120, Window: time.Minute}},
+		{name: "RATE_LIMIT_ADMIN", target: &AppCfg.RateLimitAdmin, value: RateLimit{Requests: 60, Window: time.Minute}},
+		{name: "RATE_LIMIT_PUBLIC", target: &AppCfg.RateLimitPublic, value: RateLimit{Requests: 300, Window: time.Minute}},
+		// Requests per IP address to the routes requiring credentials, taken before they are
+		// checked, so that callers guessing tokens or API keys are held back
+		{name: "RATE_LIMIT_AUTH", target: &AppCfg.RateLimitAuth, value: RateLimit{Requests: 1200, Window: time.Minute}},
+	}
+	for _, rateLimit := range rateLimits {
+		*rateLimit.target = rateLimit.value
+		if value := os.Getenv(rateLimit.name); value != "" {
+			parsed, err := parseRateLimit(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s format: %q", rateLimit.name, value)
+			}
+			*rateLimit.target = parsed
+		}
+	}
+
+	// Addresses and CIDR ranges of the reverse proxies whose X-Forwarded-For header tells the address of
+	// clients, as a comma-separated list; empty by default, so that the address of a client is the
+	// one it connected from and cannot be forged to get around the limits per IP address
+	AppCfg.TrustedProxies = nil
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			AppCfg.TrustedProxies = append(AppCfg.TrustedProxies, proxy)
+		}
+	}
+
+	// Number of audit records that may wait to be written to MySQL, default to 1000. While the
+	// queue is full, new records are dropped and logged rather than holding requests up.
+	auditQueueSizeStr := os.Getenv("AUDIT_QUEUE_SIZE")
+	if auditQueueSizeStr == "" {
+		AppCfg.AuditQueueSize = 1000
+	} else {
+		size, err := strconv.Atoi(auditQueueSizeStr)
+		if err != nil || size < 1 {
+			return fmt.Errorf("invalid AUDIT_QUEUE_SIZE format: %q", auditQueueSizeStr)
+		}
+		AppCfg.AuditQueueSize = size
+	}
+
+	// Time allowed for writing one audit record, default to 5 seconds
+	auditWriteTimeoutStr := os.Getenv("AUDIT_WRITE_TIMEOUT")
+	if auditWriteTimeoutStr == "" {
+		AppCfg.AuditWriteTimeout = 5 * time.Second
+	} else {
+		duration, err := time.ParseDuration(auditWriteTimeoutStr)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid AUDIT_WRITE_TIMEOUT format: %q", auditWriteTimeoutStr)
+		}
+		AppCfg.AuditWriteTimeout = duration
+	}
+
+	// How long the servers wait on SIGINT or SIGTERM for the requests in flight, default to 30 seconds
+	shutdownTimeoutStr := os.Getenv("SHUTDOWN_TIMEOUT")
+	if shutdownTimeoutStr == "" {
+		AppCfg.ShutdownTimeout = 30 * time.Second
+	} else {
+		duration, err := time.ParseDuration(shutdownTimeoutStr)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid SHUTDOWN_TIMEOUT format: %q", shutdownTimeoutStr)
+		}
+		AppCfg.ShutdownTimeout = duration
+	}
+
 	return nil
 }
+
+// parseRateLimit reads a RateLimit written "<requests>/<window>", or "0"
+func parseRateLimit(value string) (RateLimit, error) {
+	if value == "0" {
+		return RateLimit{}, nil
+	}
+	requestsStr, windowStr, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("missing window")
+	}
+	requests, err := strconv.Atoi(requestsStr)
+	if err != nil || requests < 0 {
+		return RateLimit{}, fmt.Errorf("invalid number of requests")
+	}
+	window, err := time.ParseDuration(windowStr)
+	if err != nil || window <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid window")
+	}
+	return RateLimit{Requests: requests, Window: window}, nil
+}
+
+// MySQLConnectionString returns the DSN of the configured MySQL database.
+// clientFoundRows makes UPDATE report matched rather than changed rows, so an unchanged album is not reported as missing.
+// parseTime scans DATETIME/TIMESTAMP columns into time.Time.
+func (c *AppConfig) MySQLConnectionString() string {
+	return mysqlConnectionString(c.MySQLUser, c.MySQLPassword, c.MySQLHost, c.MySQLDatabase)
+}
+
+func mysqlConnectionString(user, password, host, database string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:3306)/%s?clientFoundRows=true&parseTime=true", user, password, host, database)
+}
@@ -11,14 +11,24 @@ import (
 
 // WorkerConfig holds configuration for the worker, including AWS SQS settings
 type WorkerConfig struct {
-	QueueURL        string
+	QueueURL string
+	// Queue of the album event deliveries to webhooks, shared by the API and the worker
+	WebhookQueueURL string
 	Region          string
 	SQSHost         string
 	AccessKeyID     string
 	SecretAccessKey string
 
-	SQS         SQSConfig
-	AlbumWorker AlbumWorkerConfig
+	// MySQL database holding the webhooks
+	MySQLHost     string
+	MySQLUser     string
+	MySQLPassword string
+	MySQLDatabase string
+
+	SQS           SQSConfig
+	AlbumWorker   AlbumWorkerConfig
+	WebhookWorker AlbumWorkerConfig
+	Webhook       WebhookConfig
 }
 
 // AlbumWorkerConfig holds configuration specific to the album worker
@@ -28,6 +38,18 @@ type AlbumWorkerConfig struct {
 	WaitTime         time.Duration
 }
 
+// WebhookConfig holds how album events are delivered to webhooks
+type WebhookConfig struct {
+	// Attempts at a delivery before it counts as failed
+	MaxAttempts int
+	// Delay before the first retry of a delivery, doubled for every later one
+	RetryDelay time.Duration
+	// Failed deliveries in a row after which a webhook is disabled
+	DisableAfter int
+	// How long a webhook has to answer a delivery
+	DeliveryTimeout time.Duration
+}
+
 // SQSConfig holds SQS-specific configurations
 type SQSConfig struct {
 	HTTPTimeout     time.Duration
@@ -44,10 +66,15 @@ func LoadWorkerConfig() (WorkerConfig, error) {
 
 	config := WorkerConfig{
 		QueueURL:        os.Getenv("SQS_QUEUE_URL"),
+		WebhookQueueURL: os.Getenv("WEBHOOK_QUEUE_URL"),
 		Region:          os.Getenv("AWS_REGION"),
 		SQSHost:         os.Getenv("AWS_SQS_HOST"),
 		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
 		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		MySQLHost:       os.Getenv("MYSQL_HOST"),
+		MySQLUser:       os.Getenv("MYSQL_USER"),
+		MySQLPassword:   os.Getenv("MYSQL_PASSWORD"),
+		MySQLDatabase:   os.Getenv("MYSQL_DATABASE"),
 		SQS: SQSConfig{
 			HTTPTimeout:     parseDurationEnv("SQS_HTTP_TIMEOUT", 30*time.Second),
 			LongPollingWait: parseDurationEnv("SQS_LONG_POLLING_WAIT", 20*time.Second),
@@ -57,9 +84,21 @@ func LoadWorkerConfig() (WorkerConfig, error) {
 			RetryInterval:    parseDurationEnv("ALBUM_WORKER_RETRY_INTERVAL", 5*time.Second),
 			WaitTime:         parseDurationEnv("ALBUM_WORKER_WAIT_TIME", 10*time.Second),
 		},
+		WebhookWorker: AlbumWorkerConfig{
+			GoroutinesNumber: parseIntEnv("WEBHOOK_WORKER_GOROUTINES", 1),
+			RetryInterval:    parseDurationEnv("WEBHOOK_WORKER_RETRY_INTERVAL", 5*time.Second),
+			WaitTime:         parseDurationEnv("WEBHOOK_WORKER_WAIT_TIME", 10*time.Second),
+		},
+		Webhook: WebhookConfig{
+			MaxAttempts:     parseIntEnv("WEBHOOK_MAX_ATTEMPTS", 6),
+			RetryDelay:      parseDurationEnv("WEBHOOK_RETRY_DELAY", 30*time.Second),
+			DisableAfter:    parseIntEnv("WEBHOOK_DISABLE_AFTER", 5),
+			DeliveryTimeout: parseDurationEnv("WEBHOOK_DELIVERY_TIMEOUT", 10*time.Second),
+		},
 	}
 
 	if config.QueueURL == "" ||
+		config.WebhookQueueURL == "" ||
 		config.Region == "" ||
 		config.SQSHost == "" ||
 		config.AccessKeyID == "" ||
@@ -70,6 +109,12 @@ func LoadWorkerConfig() (WorkerConfig, error) {
 	return config, nil
 }
 
+// MySQLConnectionString returns the DSN of the MySQL database holding the webhooks, the same way
+// AppConfig.MySQLConnectionString does for the API
+func (c WorkerConfig) MySQLConnectionString() string {
+	return mysqlConnectionString(c.MySQLUser, c.MySQLPassword, c.MySQLHost, c.MySQLDatabase)
+}
+
 // Helper functions to parse environment variables
 func parseIntEnv(key string, defaultValue int) int {
 	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
@@ -28,7 +28,7 @@ func NewClient() *Client {
 func (c *Client) Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating GET request: %v", err)
+		return nil, fmt.Errorf("error creating GET request: %w", err)
 	}
 	c.setHeaders(req, headers)
 	return c.Do(req)
@@ -38,12 +38,12 @@ func (c *Client) Get(ctx context.Context, url string, headers map[string]string)
 func (c *Client) Post(url string, headers map[string]string, body interface{}) (*http.Response, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling JSON for POST: %v", err)
+		return nil, fmt.Errorf("error marshalling JSON for POST: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return nil, fmt.Errorf("error creating POST request: %v", err)
+		return nil, fmt.Errorf("error creating POST request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	c.setHeaders(req, headers)
@@ -51,6 +51,17 @@ func (c *Client) Post(url string, headers map[string]string, body interface{}) (
 	return c.Do(req)
 }
 
+// PostBody performs a POST request sending body as it is, for payloads that must reach the
+// server byte for byte, such as signed ones
+func (c *Client) PostBody(ctx context.Context, url string, headers map[string]string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating POST request: %w", err)
+	}
+	c.setHeaders(req, headers)
+	return c.Do(req)
+}
+
 // setHeaders adds the provided headers to the request
 func (c *Client) setHeaders(req *http.Request, headers map[string]string) {
 	for key, value := range headers {
@@ -63,7 +74,7 @@ func ReadBody(resp *http.Response) ([]byte, error) {
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
+		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 	return body, nil
 }
@@ -8,3 +8,8 @@ import (
 type HttpClientJsonPostInterface interface {
 	GetPosts(ctx context.Context) ([]entity.Post, error)
 }
+
+// HttpClientWebhookInterface delivers webhook payloads to partner endpoints
+type HttpClientWebhookInterface interface {
+	PostWebhook(ctx context.Context, url string, headers map[string]string, payload []byte) (int, error)
+}
@@ -40,7 +40,7 @@ func (s *HttpJsonPost) GetPosts(ctx context.Context) ([]entity.Post, error) {
 		if err == context.DeadlineExceeded {
 			return nil, errors.New("request timeout")
 		}
-		return nil, fmt.Errorf("error fetching posts: %v", err)
+		return nil, fmt.Errorf("error fetching posts: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -50,12 +50,12 @@ func (s *HttpJsonPost) GetPosts(ctx context.Context) ([]entity.Post, error) {
 
 	body, err := httpclient.ReadBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
+		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
 	var posts []JsonPost
 	if err := json.Unmarshal(body, &posts); err != nil {
-		return nil, fmt.Errorf("error unmarshaling JSON: %v", err)
+		return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
 	}
 
 	results := make([]entity.Post, len(posts))
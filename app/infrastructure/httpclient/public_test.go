@@ -0,0 +1,59 @@
+package httpclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/infrastructure/httpclient"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected bool
+	}{
+		{addr: "93.184.216.34", expected: true},
+		{addr: "2606:2800:220:1:248:1893:25c8:1946", expected: true},
+		{addr: "127.0.0.1"},
+		{addr: "::1"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.1.1"},
+		{addr: "169.254.169.254"},
+		{addr: "fe80::1"},
+		{addr: "fd00:ec2::254"},
+		{addr: "100.64.0.1"},
+		{addr: "0.0.0.0"},
+		{addr: "224.0.0.1"},
+		{addr: "::ffff:127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.expected, httpclient.IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestNewPublicClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which the client refuses to reach
+	_, err := httpclient.NewPublicClient().PostBody(context.Background(), server.URL, nil, []byte(`{}`))
+	assert.ErrorIs(t, err, httpclient.ErrNonPublicAddress)
+
+	// A name resolving to it is refused too
+	u, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	_, err = httpclient.NewPublicClient().PostBody(context.Background(), "http://localhost:"+u.Port(), nil, []byte(`{}`))
+	assert.ErrorIs(t, err, httpclient.ErrNonPublicAddress)
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"boilerplate/app/infrastructure/httpclient"
+)
+
+// maxDrainedBody is how much of a response body is read so that the connection can be reused
+const maxDrainedBody = 64 << 10
+
+type HttpWebhook struct {
+	http *httpclient.Client
+}
+
+func NewHttpWebhook(httpClient *httpclient.Client) *HttpWebhook {
+	return &HttpWebhook{
+		http: httpClient,
+	}
+}
+
+// PostWebhook posts a JSON payload to a webhook endpoint and returns the status it answered with.
+// The response body is ignored.
+func (s *HttpWebhook) PostWebhook(ctx context.Context, url string, headers map[string]string, payload []byte) (int, error) {
+	requestHeaders := map[string]string{"Content-Type": "application/json"}
+	for key, value := range headers {
+		requestHeaders[key] = value
+	}
+
+	resp, err := s.http.PostBody(ctx, url, requestHeaders, payload)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return 0, errors.New("request timeout")
+		}
+		return 0, fmt.Errorf("error posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBody))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,69 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supported ID strategies, selected with ALBUM_ID_STRATEGY
+const (
+	StrategyUUIDv7 = "uuidv7"
+	StrategyULID   = "ulid"
+)
+
+// Generator produces a new unique, time-ordered identifier
+type Generator func() (string, error)
+
+// New returns the generator for the given strategy
+func New(strategy string) (Generator, error) {
+	switch strategy {
+	case StrategyUUIDv7:
+		return NewUUIDv7, nil
+	case StrategyULID:
+		return NewULID, nil
+	default:
+		return nil, fmt.Errorf("unknown ID strategy %q", strategy)
+	}
+}
+
+// NewUUIDv7 returns a random, millisecond-ordered RFC 9562 UUID
+func NewUUIDv7() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("error generating UUIDv7: %w", err)
+	}
+	return id.String(), nil
+}
+
+// crockfordAlphabet is the Base32 alphabet used by ULIDs
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewULID returns a 26 character ULID: a 48-bit millisecond timestamp followed by 80 random bits
+func NewULID() (string, error) {
+	var data [16]byte
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(time.Now().UnixMilli()))
+	copy(data[:6], ts[2:])
+	if _, err := rand.Read(data[6:]); err != nil {
+		return "", fmt.Errorf("error generating ULID: %w", err)
+	}
+
+	// 26 characters carry 130 bits, so the 128-bit value is read as if it had two leading zero bits
+	out := make([]byte, 26)
+	for i := range out {
+		var v byte
+		for j := 0; j < 5; j++ {
+			bit := i*5 + j - 2
+			v <<= 1
+			if bit >= 0 && data[bit/8]&(0x80>>(bit%8)) != 0 {
+				v |= 1
+			}
+		}
+		out[i] = crockfordAlphabet[v]
+	}
+	return string(out), nil
+}
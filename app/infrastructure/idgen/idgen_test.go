@@ -0,0 +1,44 @@
+package idgen_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/infrastructure/idgen"
+)
+
+func TestNew(t *testing.T) {
+	ulidPattern := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+
+	t.Run("UUIDv7", func(t *testing.T) {
+		generate, err := idgen.New(idgen.StrategyUUIDv7)
+		assert.NoError(t, err)
+
+		id, err := generate()
+		assert.NoError(t, err)
+		parsed, err := uuid.Parse(id)
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Version(7), parsed.Version())
+	})
+
+	t.Run("ULID", func(t *testing.T) {
+		generate, err := idgen.New(idgen.StrategyULID)
+		assert.NoError(t, err)
+
+		first, err := generate()
+		assert.NoError(t, err)
+		second, _ := generate()
+		assert.Regexp(t, ulidPattern, first)
+		assert.NotEqual(t, first, second)
+		// The timestamp prefix keeps IDs sortable by creation time
+		assert.LessOrEqual(t, first[:10], second[:10])
+	})
+
+	t.Run("Unknown strategy", func(t *testing.T) {
+		_, err := idgen.New("snowflake")
+		assert.Error(t, err)
+	})
+}
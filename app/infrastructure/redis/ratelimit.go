@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// TokenBucket is the state of a token bucket once a request took a token from it, or found none
+type TokenBucket struct {
+	Allowed bool
+	// Tokens are left in the bucket, a fraction while it refills
+	Tokens float64
+}
+
+// takeTokenScript refills the bucket at KEYS[1] for the time elapsed on the Redis clock, at
+// ARGV[1] tokens per ARGV[2] milliseconds, up to ARGV[1], and takes a token when there is one.
+// A bucket left alone for a whole window is full again, so it expires. Redis 6 only lets a script
+// write after reading TIME once it replicates its effects rather than itself.
+var takeTokenScript = redis.NewScript(`
+redis.replicate_commands()
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * capacity / window)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], window)
+return {allowed, tostring(tokens)}
+`)
+
+// TakeToken takes a token from the bucket stored at key, holding up to capacity tokens and
+// refilled with capacity tokens per window. Every instance shares the bucket, timed by Redis.
+func (r *RedisCache) TakeToken(key string, capacity int, window time.Duration) (TokenBucket, error) {
+	result, err := takeTokenScript.Run(ctx, r.client, []string{key}, capacity, window.Milliseconds()).Slice()
+	if err != nil {
+		return TokenBucket{}, err
+	}
+
+	allowed, _ := result[0].(int64)
+	tokensStr, _ := result[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return TokenBucket{}, err
+	}
+	return TokenBucket{Allowed: allowed == 1, Tokens: tokens}, nil
+}
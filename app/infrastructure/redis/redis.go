@@ -36,6 +36,22 @@ func (r *RedisCache) GetFromCache(key string) ([]byte, error) {
 	return r.client.Get(ctx, key).Bytes()
 }
 
+// GetManyFromCache retrieves several keys in a single round trip.
+// The result has one entry per key, nil for the keys that are not cached.
+func (r *RedisCache) GetManyFromCache(keys ...string) ([][]byte, error) {
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]byte, len(values))
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			result[i] = []byte(s)
+		}
+	}
+	return result, nil
+}
+
 // SetToCache stores data in Redis with an expiration time
 func (r *RedisCache) SetToCache(key string, value interface{}, expiration time.Duration) error {
 	data, err := json.Marshal(value)
@@ -44,3 +60,17 @@ func (r *RedisCache) SetToCache(key string, value interface{}, expiration time.D
 	}
 	return r.client.Set(ctx, key, data, expiration).Err()
 }
+
+// SetToCacheIfAbsent stores data in Redis only when the key does not exist yet, reporting whether it was stored
+func (r *RedisCache) SetToCacheIfAbsent(key string, value interface{}, expiration time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(ctx, key, data, expiration).Result()
+}
+
+// DeleteFromCache removes the given keys from Redis
+func (r *RedisCache) DeleteFromCache(keys ...string) error {
+	return r.client.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// StreamEntry is an entry of a Redis stream: the ID Redis assigned it and the data it holds
+type StreamEntry struct {
+	ID   string
+	Data []byte
+}
+
+// streamDataField is the field of stream entries holding their data
+const streamDataField = "data"
+
+// AppendToStream adds data to a stream trimmed to about maxLen entries and returns the ID of the entry
+func (r *RedisCache) AppendToStream(stream string, maxLen int64, data []byte) (string, error) {
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: map[string]interface{}{streamDataField: data},
+	}).Result()
+}
+
+// ReadStreamAfter returns, oldest first, up to count entries of a stream following the entry with the
+// ID after, or from the oldest entry when after is empty
+func (r *RedisCache) ReadStreamAfter(stream string, after string, count int64) ([]StreamEntry, error) {
+	start := "-"
+	if after != "" {
+		start = "(" + after
+	}
+	messages, err := r.client.XRangeN(ctx, stream, start, "+", count).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]StreamEntry, len(messages))
+	for i, message := range messages {
+		data, _ := message.Values[streamDataField].(string)
+		entries[i] = StreamEntry{ID: message.ID, Data: []byte(data)}
+	}
+	return entries, nil
+}
+
+// PublishToChannel sends data to the current subscribers of a pub/sub channel
+func (r *RedisCache) PublishToChannel(channel string, data []byte) error {
+	return r.client.Publish(ctx, channel, data).Err()
+}
+
+// SubscribeToChannel calls handle with every message published to a pub/sub channel until ctx is done.
+// The subscription is renewed when the connection drops, but messages published in between are lost.
+func (r *RedisCache) SubscribeToChannel(ctx context.Context, channel string, handle func(data []byte)) error {
+	pubsub := r.client.Subscribe(ctx, channel)
+	defer pubsub.Close()
+
+	// Wait for the subscription to be confirmed so that errors are returned rather than retried
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handle([]byte(message.Payload))
+		}
+	}
+}
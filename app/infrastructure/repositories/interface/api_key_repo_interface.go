@@ -0,0 +1,18 @@
+package mysql
+
+import (
+	"boilerplate/app/domain/entity"
+	"context"
+	"time"
+)
+
+// APIKeyRepositoryInterface defines the interface for API key storage operations
+type APIKeyRepositoryInterface interface {
+	GetAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id string) (entity.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (entity.APIKey, error)
+	CreateAPIKey(ctx context.Context, apiKey entity.APIKey) error
+	RotateAPIKey(ctx context.Context, replaced entity.APIKey, replacement entity.APIKey) error
+	RevokeAPIKey(ctx context.Context, id string, revokedAt time.Time) error
+	TouchAPIKey(ctx context.Context, id string, usedAt time.Time) error
+}
@@ -0,0 +1,12 @@
+package mysql
+
+import (
+	"boilerplate/app/domain/entity"
+	"context"
+)
+
+// AuditRepositoryInterface defines the interface for audit log storage operations
+type AuditRepositoryInterface interface {
+	RecordAudit(ctx context.Context, record entity.AuditRecord) error
+	GetAuditRecords(ctx context.Context, filter entity.AuditFilter) (entity.AuditPage, error)
+}
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RepositoryInterface is an autogenerated mock type for the RepositoryInterface type
@@ -42,6 +44,54 @@ func (_m *RepositoryInterface) CreateAlbum(ctx context.Context, album entity.Alb
 	return r0, r1
 }
 
+// CreateAlbums provides a mock function with given fields: ctx, albums, dryRun
+func (_m *RepositoryInterface) CreateAlbums(ctx context.Context, albums []entity.Album, dryRun bool) ([]string, error) {
+	ret := _m.Called(ctx, albums, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAlbums")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Album, bool) ([]string, error)); ok {
+		return rf(ctx, albums, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Album, bool) []string); ok {
+		r0 = rf(ctx, albums, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Album, bool) error); ok {
+		r1 = rf(ctx, albums, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAlbum provides a mock function with given fields: ctx, id, version, cascadeTracks
+func (_m *RepositoryInterface) DeleteAlbum(ctx context.Context, id string, version int64, cascadeTracks bool) error {
+	ret := _m.Called(ctx, id, version, cascadeTracks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlbum")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, bool) error); ok {
+		r0 = rf(ctx, id, version, cascadeTracks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAlbumByID provides a mock function with given fields: ctx, id
 func (_m *RepositoryInterface) GetAlbumByID(ctx context.Context, id string) (entity.Album, error) {
 	ret := _m.Called(ctx, id)
@@ -70,29 +120,171 @@ func (_m *RepositoryInterface) GetAlbumByID(ctx context.Context, id string) (ent
 	return r0, r1
 }
 
-// GetAlbums provides a mock function with given fields: ctx
-func (_m *RepositoryInterface) GetAlbums(ctx context.Context) ([]entity.Album, error) {
-	ret := _m.Called(ctx)
+// GetAlbums provides a mock function with given fields: ctx, opts
+func (_m *RepositoryInterface) GetAlbums(ctx context.Context, opts entity.AlbumListOptions) (entity.AlbumPage, error) {
+	ret := _m.Called(ctx, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAlbums")
 	}
 
+	var r0 entity.AlbumPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumListOptions) (entity.AlbumPage, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumListOptions) entity.AlbumPage); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		r0 = ret.Get(0).(entity.AlbumPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlbumListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlbumsByIDs provides a mock function with given fields: ctx, ids
+func (_m *RepositoryInterface) GetAlbumsByIDs(ctx context.Context, ids []string) ([]entity.Album, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumsByIDs")
+	}
+
 	var r0 []entity.Album
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Album, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]entity.Album, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []entity.Album); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.Album); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Album)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeAlbums provides a mock function with given fields: ctx, deletedBefore
+func (_m *RepositoryInterface) PurgeAlbums(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeAlbums")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]string, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []string); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreAlbum provides a mock function with given fields: ctx, id
+func (_m *RepositoryInterface) RestoreAlbum(ctx context.Context, id string) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAlbum")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchAlbums provides a mock function with given fields: ctx, opts
+func (_m *RepositoryInterface) SearchAlbums(ctx context.Context, opts entity.AlbumSearchOptions) (entity.AlbumSearchResult, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAlbums")
+	}
+
+	var r0 entity.AlbumSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumSearchOptions) (entity.AlbumSearchResult, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumSearchOptions) entity.AlbumSearchResult); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		r0 = ret.Get(0).(entity.AlbumSearchResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlbumSearchOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAlbum provides a mock function with given fields: ctx, album
+func (_m *RepositoryInterface) UpdateAlbum(ctx context.Context, album entity.Album) (int64, error) {
+	ret := _m.Called(ctx, album)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAlbum")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Album) (int64, error)); ok {
+		return rf(ctx, album)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Album) int64); ok {
+		r0 = rf(ctx, album)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Album) error); ok {
+		r1 = rf(ctx, album)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,11 +3,19 @@ package mysql
 import (
 	"boilerplate/app/domain/entity"
 	"context"
+	"time"
 )
 
 // RepositoryInterface defines the interface for MySQL operations
 type RepositoryInterface interface {
-	GetAlbums(ctx context.Context) ([]entity.Album, error)
+	GetAlbums(ctx context.Context, opts entity.AlbumListOptions) (entity.AlbumPage, error)
 	CreateAlbum(ctx context.Context, album entity.Album) (string, error)
+	CreateAlbums(ctx context.Context, albums []entity.Album, dryRun bool) ([]string, error)
 	GetAlbumByID(ctx context.Context, id string) (entity.Album, error)
+	GetAlbumsByIDs(ctx context.Context, ids []string) ([]entity.Album, error)
+	SearchAlbums(ctx context.Context, opts entity.AlbumSearchOptions) (entity.AlbumSearchResult, error)
+	UpdateAlbum(ctx context.Context, album entity.Album) (int64, error)
+	DeleteAlbum(ctx context.Context, id string, version int64, cascadeTracks bool) error
+	RestoreAlbum(ctx context.Context, id string) (int64, error)
+	PurgeAlbums(ctx context.Context, deletedBefore time.Time) ([]string, error)
 }
@@ -0,0 +1,15 @@
+package mysql
+
+import (
+	"boilerplate/app/domain/entity"
+	"context"
+)
+
+// TrackRepositoryInterface defines the interface for track storage operations
+type TrackRepositoryInterface interface {
+	GetTracksByAlbumID(ctx context.Context, albumID string) ([]entity.Track, error)
+	GetTrackByID(ctx context.Context, albumID string, trackID string) (entity.Track, error)
+	CreateTrack(ctx context.Context, track entity.Track) (string, error)
+	UpdateTrack(ctx context.Context, track entity.Track) error
+	DeleteTrack(ctx context.Context, albumID string, trackID string) error
+}
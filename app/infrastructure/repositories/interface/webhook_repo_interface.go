@@ -0,0 +1,19 @@
+package mysql
+
+import (
+	"boilerplate/app/domain/entity"
+	"context"
+)
+
+// WebhookRepositoryInterface defines the interface for webhook and delivery log storage operations
+type WebhookRepositoryInterface interface {
+	GetWebhooks(ctx context.Context) ([]entity.Webhook, error)
+	GetWebhookByID(ctx context.Context, id string) (entity.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook entity.Webhook) error
+	UpdateWebhook(ctx context.Context, webhook entity.Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+	RecordWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]entity.WebhookDelivery, error)
+	ResetWebhookFailures(ctx context.Context, id string) error
+	RecordWebhookFailure(ctx context.Context, id string, disableAfter int) (bool, error)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"boilerplate/app/domain/entity"
 	"boilerplate/app/domain/errors"
@@ -11,48 +13,204 @@ import (
 
 // Album represents the structure of a album in our application with db tags for column mapping
 type Album struct {
-	ID    string `db:"id"`
-	Title string `db:"title"`
+	ID          string       `db:"id"`
+	Title       string       `db:"title"`
+	Artist      string       `db:"artist"`
+	ReleaseDate sql.NullTime `db:"release_date"`
+	Genre       string       `db:"genre"`
+	TrackCount  int          `db:"track_count"`
+	Version     int64        `db:"version"`
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	DeletedAt   sql.NullTime `db:"deleted_at"`
+}
+
+// albumColumns lists the album columns in the order scanAlbum reads them
+const albumColumns = "id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at"
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAlbum reads one row selected with albumColumns; extra receives any columns selected after them
+func scanAlbum(row rowScanner, extra ...interface{}) (Album, error) {
+	var album Album
+	dest := []interface{}{
+		&album.ID,
+		&album.Title,
+		&album.Artist,
+		&album.ReleaseDate,
+		&album.Genre,
+		&album.TrackCount,
+		&album.Version,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+		&album.DeletedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	return album, err
 }
 
 // AlbumRepository implements RepositoryInterface for MySQL database operations
 type AlbumRepository struct {
 	db *sql.DB
+
+	// How SearchAlbums matches albums
+	searchMode SearchMode
+}
+
+// AlbumRepositoryOption customises optional AlbumRepository behaviour
+type AlbumRepositoryOption func(*AlbumRepository)
+
+// WithSearchMode sets how SearchAlbums matches albums
+func WithSearchMode(mode SearchMode) AlbumRepositoryOption {
+	return func(r *AlbumRepository) {
+		r.searchMode = mode
+	}
 }
 
 // NewAlbumRepository initializes a new MySQL repository with the given connection string
-func NewAlbumRepository(db *sql.DB) (*AlbumRepository, error) {
-	return &AlbumRepository{db: db}, nil
+func NewAlbumRepository(db *sql.DB, opts ...AlbumRepositoryOption) (*AlbumRepository, error) {
+	r := &AlbumRepository{db: db, searchMode: SearchModeFullText}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
 }
 
-func (r *AlbumRepository) GetAlbums(ctx context.Context) ([]entity.Album, error) {
-	var albums []entity.Album
+// albumSortColumns maps the sortable fields to their column names
+var albumSortColumns = map[entity.AlbumSortField]string{
+	entity.AlbumSortByID:        "id",
+	entity.AlbumSortByTitle:     "title",
+	entity.AlbumSortByCreatedAt: "created_at",
+}
+
+// cursorTimeFormat is how created_at values are stored in cursors
+const cursorTimeFormat = "2006-01-02 15:04:05.999999"
+
+// GetAlbums returns one page of albums using keyset pagination on the sort column and id
+func (r *AlbumRepository) GetAlbums(ctx context.Context, opts entity.AlbumListOptions) (entity.AlbumPage, error) {
+	column, ok := albumSortColumns[opts.SortBy]
+	if !ok {
+		return entity.AlbumPage{}, errors.ErrInvalidInput
+	}
+
+	backward := opts.Cursor != nil && opts.Cursor.Before
+	// Walking backwards from a cursor scans in the opposite order and reverses the rows afterwards
+	scanDesc := opts.Descending != backward
+
+	// Soft-deleted albums only show up when listing the trash
+	conditions := []string{"deleted_at IS NULL"}
+	if opts.Trashed {
+		conditions[0] = "deleted_at IS NOT NULL"
+	}
+	var args []interface{}
+	if opts.TitlePrefix != "" {
+		conditions = append(conditions, "title LIKE ?")
+		args = append(args, escapeLike(opts.TitlePrefix)+"%")
+	}
+	if opts.Cursor != nil {
+		op := ">"
+		if scanDesc {
+			op = "<"
+		}
+		if column == "id" {
+			conditions = append(conditions, "id "+op+" ?")
+			args = append(args, opts.Cursor.ID)
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op))
+			args = append(args, opts.Cursor.SortValue, opts.Cursor.SortValue, opts.Cursor.ID)
+		}
+	}
+
+	direction := "ASC"
+	if scanDesc {
+		direction = "DESC"
+	}
+	order := "id " + direction
+	if column != "id" {
+		order = column + " " + direction + ", " + order
+	}
+
+	query := "SELECT " + albumColumns + " FROM album WHERE " + strings.Join(conditions, " AND ")
+	// Fetch one extra row to learn whether another page exists
+	query += " ORDER BY " + order + " LIMIT ?"
+	args = append(args, opts.Limit+1)
+
 	var dbAlbums []Album
-	rows, err := r.db.QueryContext(ctx, "SELECT id, title FROM album")
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error querying data: %v", err)
+		return entity.AlbumPage{}, fmt.Errorf("error querying data: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var album Album
-		if err := rows.Scan(&album.ID, &album.Title); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
+		album, err := scanAlbum(rows)
+		if err != nil {
+			return entity.AlbumPage{}, fmt.Errorf("error scanning row: %w", err)
 		}
 		dbAlbums = append(dbAlbums, album)
 	}
 
 	// Check for errors from iterating over rows.
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during row iteration: %v", err)
+		return entity.AlbumPage{}, fmt.Errorf("error during row iteration: %w", err)
 	}
 
-	for _, dbPordbAlbum := range dbAlbums {
-		album := BuildAlbumEntity(dbPordbAlbum)
-		albums = append(albums, album)
+	hasMore := len(dbAlbums) > opts.Limit
+	if hasMore {
+		dbAlbums = dbAlbums[:opts.Limit]
+	}
+	if backward {
+		for i, j := 0, len(dbAlbums)-1; i < j; i, j = i+1, j-1 {
+			dbAlbums[i], dbAlbums[j] = dbAlbums[j], dbAlbums[i]
+		}
 	}
 
-	return albums, nil
+	page := entity.AlbumPage{Albums: make([]entity.Album, 0, len(dbAlbums))}
+	for _, dbAlbum := range dbAlbums {
+		page.Albums = append(page.Albums, BuildAlbumEntity(dbAlbum))
+	}
+	if len(dbAlbums) == 0 {
+		return page, nil
+	}
+
+	first, last := dbAlbums[0], dbAlbums[len(dbAlbums)-1]
+	if hasMore || backward {
+		page.Next = buildAlbumCursor(opts, last, false)
+	}
+	if (hasMore && backward) || (opts.Cursor != nil && !backward) {
+		page.Prev = buildAlbumCursor(opts, first, true)
+	}
+
+	return page, nil
+}
+
+// buildAlbumCursor builds the cursor pointing just after (or before) the given row
+func buildAlbumCursor(opts entity.AlbumListOptions, album Album, before bool) *entity.AlbumCursor {
+	var value string
+	switch opts.SortBy {
+	case entity.AlbumSortByTitle:
+		value = album.Title
+	case entity.AlbumSortByCreatedAt:
+		value = album.CreatedAt.Format(cursorTimeFormat)
+	default:
+		value = album.ID
+	}
+
+	return &entity.AlbumCursor{
+		SortBy:     opts.SortBy,
+		Descending: opts.Descending,
+		SortValue:  value,
+		ID:         album.ID,
+		Before:     before,
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in a user-supplied pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 // CreateAlbum inserts a new album into the database
@@ -61,30 +219,317 @@ func (r *AlbumRepository) CreateAlbum(ctx context.Context, entity entity.Album)
 	album := BuildDBAlbum(entity)
 
 	// Insert the new album into the database
-	stmt, err := r.db.Prepare("INSERT INTO album (id, title) VALUES (?, ?)")
+	stmt, err := r.db.Prepare("INSERT INTO album (id, title, artist, release_date, genre, track_count) VALUES (?, ?, ?, ?, ?, ?)")
 	if err != nil {
-		return "", fmt.Errorf("error preparing statement: %v", err)
+		return "", fmt.Errorf("error preparing statement: %w", err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, album.ID, album.Title)
+	_, err = stmt.ExecContext(ctx, album.ID, album.Title, album.Artist, album.ReleaseDate, album.Genre, album.TrackCount)
 	if err != nil {
-		return "", fmt.Errorf("error executing insert: %v", err)
+		if isMySQLError(err, errDuplicateEntry) {
+			return "", errors.ErrAlbumExists
+		}
+		return "", fmt.Errorf("error executing insert: %w", err)
 	}
 
 	return album.ID, nil
 }
 
-// GetAlbumByID retrieves a specific album by its ID from MySQL
+// CreateAlbums inserts the albums with one multi-row statement inside a transaction.
+// Albums whose ID is already stored are left out and their IDs are returned.
+// With dryRun the transaction is rolled back, so the outcome is reported without writing anything.
+func (r *AlbumRepository) CreateAlbums(ctx context.Context, albums []entity.Album, dryRun bool) ([]string, error) {
+	if len(albums) == 0 {
+		return nil, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]interface{}, len(albums))
+	for i, album := range albums {
+		ids[i] = album.ID.String()
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM album WHERE id IN (?"+strings.Repeat(", ?", len(ids)-1)+")", ids...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying existing albums: %w", err)
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning existing album: %w", err)
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating existing albums: %w", err)
+	}
+
+	var skipped []string
+	var placeholders []string
+	var args []interface{}
+	for _, entity := range albums {
+		album := BuildDBAlbum(entity)
+		if existing[album.ID] {
+			skipped = append(skipped, album.ID)
+			continue
+		}
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+		args = append(args, album.ID, album.Title, album.Artist, album.ReleaseDate, album.Genre, album.TrackCount)
+	}
+
+	if len(placeholders) > 0 {
+		query := "INSERT INTO album (id, title, artist, release_date, genre, track_count) VALUES " + strings.Join(placeholders, ", ")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			if isMySQLError(err, errDuplicateEntry) {
+				return nil, errors.ErrAlbumExists
+			}
+			return nil, fmt.Errorf("error executing bulk insert: %w", err)
+		}
+	}
+
+	if dryRun {
+		return skipped, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing bulk insert: %w", err)
+	}
+	return skipped, nil
+}
+
+// GetAlbumByID retrieves a specific album by its ID from MySQL; albums in the trash are not found
 func (r *AlbumRepository) GetAlbumByID(ctx context.Context, id string) (entity.Album, error) {
-	var album Album
-	err := r.db.QueryRowContext(ctx, "SELECT id, title FROM album WHERE id = ?", id).Scan(&album.ID, &album.Title)
+	album, err := scanAlbum(r.db.QueryRowContext(ctx, "SELECT "+albumColumns+" FROM album WHERE id = ? AND deleted_at IS NULL", id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return entity.Album{}, errors.ErrAlbumNotFound
 		}
-		return entity.Album{}, errors.ErrInternalServer
+		return entity.Album{}, errors.ErrInternalServer.WithCause(err)
 	}
 	entityAlbum := BuildAlbumEntity(album)
 	return entityAlbum, nil
 }
+
+// GetAlbumsByIDs retrieves the albums with the given IDs in a single query.
+// IDs that do not match a live album are left out of the result, which is in no particular order.
+func (r *AlbumRepository) GetAlbumsByIDs(ctx context.Context, ids []string) ([]entity.Album, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := r.db.QueryContext(ctx, "SELECT "+albumColumns+" FROM album WHERE id IN (?"+strings.Repeat(", ?", len(ids)-1)+") AND deleted_at IS NULL", args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying data: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []entity.Album
+	for rows.Next() {
+		album, err := scanAlbum(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		albums = append(albums, BuildAlbumEntity(album))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+	return albums, nil
+}
+
+// UpdateAlbum replaces the stored fields of an existing album and returns its new version.
+// A non-zero Version makes the update a compare-and-swap that fails with ErrVersionMismatch
+// when the stored album has moved on to another version. Albums in the trash cannot be updated.
+func (r *AlbumRepository) UpdateAlbum(ctx context.Context, entity entity.Album) (int64, error) {
+	album := BuildDBAlbum(entity)
+
+	// LAST_INSERT_ID(expr) hands the incremented version back through the result's LastInsertId
+	query := "UPDATE album SET title = ?, artist = ?, release_date = ?, genre = ?, track_count = ?, version = LAST_INSERT_ID(version + 1) WHERE id = ? AND deleted_at IS NULL"
+	args := []interface{}{album.Title, album.Artist, album.ReleaseDate, album.Genre, album.TrackCount, album.ID}
+	if album.Version != 0 {
+		query += " AND version = ?"
+		args = append(args, album.Version)
+	}
+
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return 0, fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, fmt.Errorf("error executing update: %w", err)
+	}
+
+	if err := checkAlbumAffected(result); err != nil {
+		if album.Version != 0 && errors.IsAlbumNotFound(err) {
+			return 0, albumWriteMissed(ctx, r.db, album.ID)
+		}
+		return 0, err
+	}
+
+	version, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("error reading new version: %w", err)
+	}
+	return version, nil
+}
+
+// DeleteAlbum moves an album to the trash; it stays stored until PurgeAlbums removes it.
+// When cascadeTracks is false an album that still has tracks is refused with ErrAlbumHasTracks;
+// otherwise its tracks are hidden along with it and come back when it is restored.
+// A non-zero version only deletes the album at that version, failing with ErrVersionMismatch otherwise.
+func (r *AlbumRepository) DeleteAlbum(ctx context.Context, id string, version int64, cascadeTracks bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if !cascadeTracks {
+		var hasTracks bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM track WHERE album_id = ?)", id).Scan(&hasTracks); err != nil {
+			return fmt.Errorf("error checking tracks: %w", err)
+		}
+		if hasTracks {
+			return errors.ErrAlbumHasTracks
+		}
+	}
+
+	query := "UPDATE album SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ? AND deleted_at IS NULL"
+	args := []interface{}{id}
+	if version != 0 {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error executing delete: %w", err)
+	}
+	if err := checkAlbumAffected(result); err != nil {
+		if version != 0 && errors.IsAlbumNotFound(err) {
+			return albumWriteMissed(ctx, tx, id)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing delete: %w", err)
+	}
+	return nil
+}
+
+// RestoreAlbum takes an album out of the trash and returns its new version.
+// An album that is not in the trash yields ErrAlbumNotFound.
+func (r *AlbumRepository) RestoreAlbum(ctx context.Context, id string) (int64, error) {
+	stmt, err := r.db.Prepare("UPDATE album SET deleted_at = NULL, version = LAST_INSERT_ID(version + 1) WHERE id = ? AND deleted_at IS NOT NULL")
+	if err != nil {
+		return 0, fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("error executing restore: %w", err)
+	}
+	if err := checkAlbumAffected(result); err != nil {
+		return 0, err
+	}
+
+	version, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("error reading new version: %w", err)
+	}
+	return version, nil
+}
+
+// PurgeAlbums permanently removes the albums moved to the trash before the given time,
+// together with their tracks, and returns the IDs of the removed albums
+func (r *AlbumRepository) PurgeAlbums(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the expired rows so a concurrent restore cannot bring back an album being purged
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM album WHERE deleted_at IS NOT NULL AND deleted_at < ? FOR UPDATE", deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("error querying expired albums: %w", err)
+	}
+	var ids []interface{}
+	var purged []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning expired album: %w", err)
+		}
+		ids = append(ids, id)
+		purged = append(purged, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expired albums: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	in := "(?" + strings.Repeat(", ?", len(ids)-1) + ")"
+	if _, err := tx.ExecContext(ctx, "DELETE FROM track WHERE album_id IN "+in, ids...); err != nil {
+		return nil, fmt.Errorf("error purging tracks: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM album WHERE id IN "+in, ids...); err != nil {
+		return nil, fmt.Errorf("error purging albums: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing purge: %w", err)
+	}
+	return purged, nil
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// albumWriteMissed explains why a conditional write matched no row: either the album
+// does not exist (or is in the trash) or it is at another version than the one the write expected
+func albumWriteMissed(ctx context.Context, db rowQuerier, id string) error {
+	var version int64
+	err := db.QueryRowContext(ctx, "SELECT version FROM album WHERE id = ? AND deleted_at IS NULL", id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return errors.ErrAlbumNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error reading album version: %w", err)
+	}
+	return errors.ErrVersionMismatch
+}
+
+// checkAlbumAffected maps a statement that touched no rows to ErrAlbumNotFound
+func checkAlbumAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errors.ErrAlbumNotFound
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"boilerplate/app/domain/entity"
+)
+
+// SearchMode selects how SearchAlbums matches albums
+type SearchMode string
+
+const (
+	// SearchModeFullText ranks with the FULLTEXT index on title and artist
+	SearchModeFullText SearchMode = "fulltext"
+	// SearchModeLike scans with LIKE, for databases without the FULLTEXT index
+	SearchModeLike SearchMode = "like"
+)
+
+// minFullTextTermLength mirrors InnoDB's default innodb_ft_min_token_size; shorter terms are not indexed
+const minFullTextTermLength = 3
+
+// SearchAlbums returns one page of albums matching the query on title and artist, best matches first.
+// Full-text mode falls back to LIKE for queries made only of terms too short to be indexed,
+// and when the table has no FULLTEXT index.
+func (r *AlbumRepository) SearchAlbums(ctx context.Context, opts entity.AlbumSearchOptions) (entity.AlbumSearchResult, error) {
+	if r.searchMode == SearchModeLike || !hasIndexableTerm(opts.Query) {
+		return r.searchAlbumsLike(ctx, opts)
+	}
+
+	result, err := r.searchAlbumsFullText(ctx, opts)
+	if isMySQLError(err, errNoFullTextIndex) {
+		log.Printf("album table has no FULLTEXT index, falling back to LIKE search")
+		return r.searchAlbumsLike(ctx, opts)
+	}
+	return result, err
+}
+
+// searchAlbumsFullText ranks albums with MATCH ... AGAINST in natural language mode
+func (r *AlbumRepository) searchAlbumsFullText(ctx context.Context, opts entity.AlbumSearchOptions) (entity.AlbumSearchResult, error) {
+	query := "SELECT " + albumColumns + ", MATCH(title, artist) AGAINST (? IN NATURAL LANGUAGE MODE) AS score" +
+		" FROM album WHERE MATCH(title, artist) AGAINST (? IN NATURAL LANGUAGE MODE) AND deleted_at IS NULL" +
+		" ORDER BY score DESC, id ASC LIMIT ? OFFSET ?"
+	return r.querySearch(ctx, opts, query, opts.Query, opts.Query)
+}
+
+// searchAlbumsLike matches the query as a substring. A title starting with the query
+// ranks above a title containing it, which ranks above a match on the artist only.
+func (r *AlbumRepository) searchAlbumsLike(ctx context.Context, opts entity.AlbumSearchOptions) (entity.AlbumSearchResult, error) {
+	escaped := escapeLike(opts.Query)
+	prefix, contains := escaped+"%", "%"+escaped+"%"
+
+	query := "SELECT " + albumColumns + "," +
+		" (CASE WHEN title LIKE ? THEN 3 WHEN title LIKE ? THEN 2 ELSE 0 END + CASE WHEN artist LIKE ? THEN 1 ELSE 0 END) AS score" +
+		" FROM album WHERE (title LIKE ? OR artist LIKE ?) AND deleted_at IS NULL" +
+		" ORDER BY score DESC, id ASC LIMIT ? OFFSET ?"
+	return r.querySearch(ctx, opts, query, prefix, contains, contains, contains, contains)
+}
+
+// querySearch runs a search query selecting albumColumns followed by a score, appending the paging arguments
+func (r *AlbumRepository) querySearch(ctx context.Context, opts entity.AlbumSearchOptions, query string, args ...interface{}) (entity.AlbumSearchResult, error) {
+	// Fetch one extra row to learn whether another page exists
+	args = append(args, opts.Limit+1, opts.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return entity.AlbumSearchResult{}, fmt.Errorf("error querying data: %w", err)
+	}
+	defer rows.Close()
+
+	result := entity.AlbumSearchResult{Hits: []entity.AlbumSearchHit{}}
+	for rows.Next() {
+		var score float64
+		album, err := scanAlbum(rows, &score)
+		if err != nil {
+			return entity.AlbumSearchResult{}, fmt.Errorf("error scanning row: %w", err)
+		}
+		result.Hits = append(result.Hits, entity.AlbumSearchHit{Album: BuildAlbumEntity(album), Score: score})
+	}
+	if err := rows.Err(); err != nil {
+		return entity.AlbumSearchResult{}, fmt.Errorf("error during row iteration: %w", err)
+	}
+
+	if len(result.Hits) > opts.Limit {
+		result.Hits = result.Hits[:opts.Limit]
+		result.HasMore = true
+	}
+	return result, nil
+}
+
+// hasIndexableTerm reports whether the query has a term long enough for the FULLTEXT index
+func hasIndexableTerm(query string) bool {
+	for _, term := range strings.Fields(query) {
+		if utf8.RuneCountInString(term) >= minFullTextTermLength {
+			return true
+		}
+	}
+	return false
+}
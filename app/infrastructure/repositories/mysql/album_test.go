@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 
 	"boilerplate/app/domain/entity"
@@ -16,7 +18,14 @@ import (
 	"boilerplate/app/infrastructure/repositories/mysql"
 )
 
+// albumColumns are the columns selected by every album query
+var albumColumns = []string{"id", "title", "artist", "release_date", "genre", "track_count", "version", "created_at", "updated_at", "deleted_at"}
+
 func TestAlbumRepository(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	releaseDate := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+
 	// Test cases
 	tests := []struct {
 		name           string
@@ -28,52 +37,124 @@ func TestAlbumRepository(t *testing.T) {
 	}{
 		// GetAlbums tests
 		{
-			name: "GetAlbums_Success",
+			name: "GetAlbums_FirstPage",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title"}).
-					AddRow("1", "Album1").
-					AddRow("2", "Album2")
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title FROM album")).
+				rows := sqlmock.NewRows(albumColumns).
+					AddRow("1", "Album1", "", nil, "", 0, 1, createdAt, createdAt, nil).
+					AddRow("2", "Album2", "", nil, "", 0, 1, createdAt, createdAt, nil).
+					AddRow("3", "Album3", "", nil, "", 0, 1, createdAt, createdAt, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album WHERE deleted_at IS NULL ORDER BY id ASC LIMIT ?")).
+					WithArgs(3).
 					WillReturnRows(rows)
 			},
 			action: func(r *mysql.AlbumRepository) interface{} {
-				albums, _ := r.GetAlbums(context.Background())
-				return albums
+				page, _ := r.GetAlbums(context.Background(), entity.AlbumListOptions{Limit: 2, SortBy: entity.AlbumSortByID})
+				return page
 			},
-			expectedResult: []entity.Album{
-				{ID: entity.AlbumID("1"), Title: "Album1"},
-				{ID: entity.AlbumID("2"), Title: "Album2"},
+			expectedResult: entity.AlbumPage{
+				Albums: []entity.Album{
+					{ID: entity.AlbumID("1"), Title: "Album1", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+					{ID: entity.AlbumID("2"), Title: "Album2", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+				},
+				Next: &entity.AlbumCursor{SortBy: entity.AlbumSortByID, SortValue: "2", ID: "2"},
+			},
+			expectError: false,
+		},
+		{
+			name: "GetAlbums_AfterCursorWithPrefix",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(albumColumns).
+					AddRow("4", "Abc", "", nil, "", 0, 1, createdAt, createdAt, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album WHERE deleted_at IS NULL AND title LIKE ? AND (title < ? OR (title = ? AND id < ?)) ORDER BY title DESC, id DESC LIMIT ?")).
+					WithArgs(`A\_%`, "Abd", "Abd", "5", 11).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				page, _ := r.GetAlbums(context.Background(), entity.AlbumListOptions{
+					Limit:       10,
+					SortBy:      entity.AlbumSortByTitle,
+					Descending:  true,
+					TitlePrefix: "A_",
+					Cursor:      &entity.AlbumCursor{SortBy: entity.AlbumSortByTitle, Descending: true, SortValue: "Abd", ID: "5"},
+				})
+				return page
+			},
+			expectedResult: entity.AlbumPage{
+				Albums: []entity.Album{{ID: entity.AlbumID("4"), Title: "Abc", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt}},
+				Prev:   &entity.AlbumCursor{SortBy: entity.AlbumSortByTitle, Descending: true, SortValue: "Abc", ID: "4", Before: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "GetAlbums_BeforeCursor",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(albumColumns).
+					AddRow("4", "Album4", "", nil, "", 0, 1, createdAt, createdAt, nil).
+					AddRow("3", "Album3", "", nil, "", 0, 1, createdAt, createdAt, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album WHERE deleted_at IS NULL AND id < ? ORDER BY id DESC LIMIT ?")).
+					WithArgs("5", 3).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				page, _ := r.GetAlbums(context.Background(), entity.AlbumListOptions{
+					Limit:  2,
+					SortBy: entity.AlbumSortByID,
+					Cursor: &entity.AlbumCursor{SortBy: entity.AlbumSortByID, SortValue: "5", ID: "5", Before: true},
+				})
+				return page
+			},
+			expectedResult: entity.AlbumPage{
+				Albums: []entity.Album{
+					{ID: entity.AlbumID("3"), Title: "Album3", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+					{ID: entity.AlbumID("4"), Title: "Album4", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+				},
+				Next: &entity.AlbumCursor{SortBy: entity.AlbumSortByID, SortValue: "4", ID: "4"},
 			},
 			expectError: false,
 		},
 		{
 			name: "GetAlbums_QueryError",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title FROM album")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album")).
 					WillReturnError(errors.New("query error"))
 			},
 			action: func(r *mysql.AlbumRepository) interface{} {
-				_, err := r.GetAlbums(context.Background())
+				_, err := r.GetAlbums(context.Background(), entity.AlbumListOptions{Limit: 10, SortBy: entity.AlbumSortByID})
 				return err
 			},
 			expectedResult: nil,
 			expectError:    true,
 			expectedErr:    fmt.Errorf("error querying data: query error"),
 		},
+		{
+			name:      "GetAlbums_InvalidSort",
+			setupMock: func(mock sqlmock.Sqlmock) {},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				_, err := r.GetAlbums(context.Background(), entity.AlbumListOptions{Limit: 10, SortBy: "rating"})
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrInvalidInput,
+		},
 
 		// CreateAlbum tests
 		{
 			name: "CreateAlbum_Success",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO album (id, title) VALUES (?, ?)")).
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO album (id, title, artist, release_date, genre, track_count) VALUES (?, ?, ?, ?, ?, ?)")).
 					ExpectExec().
-					WithArgs("1", "New Album").
+					WithArgs("1", "New Album", "Artist", sql.NullTime{Time: releaseDate, Valid: true}, "rock", 12).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			action: func(r *mysql.AlbumRepository) interface{} {
 				id, _ := r.CreateAlbum(context.Background(), entity.Album{
-					ID:    entity.AlbumID("1"),
-					Title: "New Album",
+					ID:          entity.AlbumID("1"),
+					Title:       "New Album",
+					Artist:      "Artist",
+					ReleaseDate: &releaseDate,
+					Genre:       "rock",
+					TrackCount:  12,
 				})
 				return id
 			},
@@ -83,9 +164,9 @@ func TestAlbumRepository(t *testing.T) {
 		{
 			name: "CreateAlbum_ExecError",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO album (id, title) VALUES (?, ?)")).
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO album (id, title, artist, release_date, genre, track_count) VALUES (?, ?, ?, ?, ?, ?)")).
 					ExpectExec().
-					WithArgs("1", "New Album").
+					WithArgs("1", "New Album", "", sql.NullTime{}, "", 0).
 					WillReturnError(errors.New("exec error"))
 			},
 			action: func(r *mysql.AlbumRepository) interface{} {
@@ -100,13 +181,138 @@ func TestAlbumRepository(t *testing.T) {
 			expectedErr:    fmt.Errorf("error executing insert: exec error"),
 		},
 
+		// CreateAlbums tests
+		{
+			name: "CreateAlbums_SkipsExisting",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM album WHERE id IN (?, ?, ?)")).
+					WithArgs("1", "2", "3").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("2"))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO album (id, title, artist, release_date, genre, track_count) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)")).
+					WithArgs("1", "One", "", sql.NullTime{}, "", 0, "3", "Three", "", sql.NullTime{Time: releaseDate, Valid: true}, "jazz", 8).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				skipped, _ := r.CreateAlbums(context.Background(), []entity.Album{
+					{ID: entity.AlbumID("1"), Title: "One"},
+					{ID: entity.AlbumID("2"), Title: "Two"},
+					{ID: entity.AlbumID("3"), Title: "Three", ReleaseDate: &releaseDate, Genre: "jazz", TrackCount: 8},
+				}, false)
+				return skipped
+			},
+			expectedResult: []string{"2"},
+			expectError:    false,
+		},
+		{
+			name: "CreateAlbums_DryRunRollsBack",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM album WHERE id IN (?)")).
+					WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO album (id, title, artist, release_date, genre, track_count) VALUES (?, ?, ?, ?, ?, ?)")).
+					WithArgs("1", "One", "", sql.NullTime{}, "", 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				skipped, err := r.CreateAlbums(context.Background(), []entity.Album{{ID: entity.AlbumID("1"), Title: "One"}}, true)
+				if err != nil {
+					return err
+				}
+				return skipped
+			},
+			expectedResult: []string(nil),
+			expectError:    false,
+		},
+		{
+			name: "CreateAlbums_ConcurrentDuplicate",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM album WHERE id IN (?)")).
+					WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO album")).
+					WillReturnError(&mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry"})
+				mock.ExpectRollback()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				_, err := r.CreateAlbums(context.Background(), []entity.Album{{ID: entity.AlbumID("1"), Title: "One"}}, false)
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrAlbumExists,
+		},
+
+		// SearchAlbums tests
+		{
+			name: "SearchAlbums_FullText",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(albumColumns, "score")).
+					AddRow("1", "Kind of Blue", "Miles Davis", nil, "jazz", 5, 1, createdAt, createdAt, nil, 1.5).
+					AddRow("2", "Blue Train", "John Coltrane", nil, "jazz", 5, 1, createdAt, createdAt, nil, 1.2)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at, MATCH(title, artist) AGAINST (? IN NATURAL LANGUAGE MODE) AS score FROM album WHERE MATCH(title, artist) AGAINST (? IN NATURAL LANGUAGE MODE) AND deleted_at IS NULL ORDER BY score DESC, id ASC LIMIT ? OFFSET ?")).
+					WithArgs("blue", "blue", 2, 10).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				result, _ := r.SearchAlbums(context.Background(), entity.AlbumSearchOptions{Query: "blue", Limit: 1, Offset: 10})
+				return result
+			},
+			expectedResult: entity.AlbumSearchResult{
+				Hits: []entity.AlbumSearchHit{
+					{Album: entity.Album{ID: "1", Title: "Kind of Blue", Artist: "Miles Davis", Genre: "jazz", TrackCount: 5, Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt}, Score: 1.5},
+				},
+				HasMore: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "SearchAlbums_ShortQueryUsesLike",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at, (CASE WHEN title LIKE ? THEN 3 WHEN title LIKE ? THEN 2 ELSE 0 END + CASE WHEN artist LIKE ? THEN 1 ELSE 0 END) AS score FROM album WHERE (title LIKE ? OR artist LIKE ?) AND deleted_at IS NULL ORDER BY score DESC, id ASC LIMIT ? OFFSET ?")).
+					WithArgs("U2%", "%U2%", "%U2%", "%U2%", "%U2%", 21, 0).
+					WillReturnRows(sqlmock.NewRows(append(albumColumns, "score")))
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				result, _ := r.SearchAlbums(context.Background(), entity.AlbumSearchOptions{Query: "U2", Limit: 20})
+				return result
+			},
+			expectedResult: entity.AlbumSearchResult{Hits: []entity.AlbumSearchHit{}},
+			expectError:    false,
+		},
+		{
+			name: "SearchAlbums_FallsBackWithoutIndex",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("MATCH(title, artist)")).
+					WillReturnError(&mysqldriver.MySQLError{Number: 1191, Message: "Can't find FULLTEXT index matching the column list"})
+				mock.ExpectQuery(regexp.QuoteMeta("WHERE (title LIKE ? OR artist LIKE ?)")).
+					WithArgs("50\\%%", "%50\\%%", "%50\\%%", "%50\\%%", "%50\\%%", 21, 0).
+					WillReturnRows(sqlmock.NewRows(append(albumColumns, "score")).
+						AddRow("1", "50% Off", "", nil, "", 0, 1, createdAt, createdAt, nil, 3))
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				result, _ := r.SearchAlbums(context.Background(), entity.AlbumSearchOptions{Query: "50%", Limit: 20})
+				return result
+			},
+			expectedResult: entity.AlbumSearchResult{
+				Hits: []entity.AlbumSearchHit{
+					{Album: entity.Album{ID: "1", Title: "50% Off", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt}, Score: 3},
+				},
+			},
+			expectError: false,
+		},
+
 		// GetAlbumByID tests
 		{
 			name: "GetAlbumByID_Success",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title"}).
-					AddRow("1", "Test Album")
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title FROM album WHERE id = ?")).
+				rows := sqlmock.NewRows(albumColumns).
+					AddRow("1", "Test Album", "Artist", releaseDate, "rock", 12, 1, createdAt, createdAt, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album WHERE id = ? AND deleted_at IS NULL")).
 					WithArgs("1").
 					WillReturnRows(rows)
 			},
@@ -114,13 +320,54 @@ func TestAlbumRepository(t *testing.T) {
 				album, _ := r.GetAlbumByID(context.Background(), "1")
 				return album
 			},
-			expectedResult: entity.Album{ID: entity.AlbumID("1"), Title: "Test Album"},
+			expectedResult: entity.Album{
+				ID:          entity.AlbumID("1"),
+				Title:       "Test Album",
+				Artist:      "Artist",
+				ReleaseDate: &releaseDate,
+				Genre:       "rock",
+				TrackCount:  12,
+				Version:     1,
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			},
+			expectError: false,
+		},
+		{
+			name: "GetAlbumsByIDs_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(albumColumns).
+					AddRow("1", "Album1", "", nil, "", 0, 1, createdAt, createdAt, nil).
+					AddRow("3", "Album3", "", nil, "", 0, 2, createdAt, createdAt, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album WHERE id IN (?, ?, ?) AND deleted_at IS NULL")).
+					WithArgs("1", "2", "3").
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				albums, _ := r.GetAlbumsByIDs(context.Background(), []string{"1", "2", "3"})
+				return albums
+			},
+			expectedResult: []entity.Album{
+				{ID: entity.AlbumID("1"), Title: "Album1", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: entity.AlbumID("3"), Title: "Album3", Version: 2, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+			expectError: false,
+		},
+		{
+			name:      "GetAlbumsByIDs_Empty",
+			setupMock: func(mock sqlmock.Sqlmock) {},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				albums, err := r.GetAlbumsByIDs(context.Background(), nil)
+				assert.NoError(t, err)
+				return albums
+			},
+			expectedResult: []entity.Album(nil),
 			expectError:    false,
 		},
 		{
 			name: "GetAlbumByID_NotFound",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title FROM album WHERE id = ?")).
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album WHERE id = ? AND deleted_at IS NULL")).
 					WithArgs("1").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -132,6 +379,270 @@ func TestAlbumRepository(t *testing.T) {
 			expectError:    true,
 			expectedErr:    customerr.ErrAlbumNotFound,
 		},
+
+		// UpdateAlbum tests
+		{
+			name: "UpdateAlbum_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE album SET title = ?, artist = ?, release_date = ?, genre = ?, track_count = ?, version = LAST_INSERT_ID(version + 1) WHERE id = ? AND deleted_at IS NULL")).
+					ExpectExec().
+					WithArgs("Updated Album", "", sql.NullTime{}, "", 0, "1").
+					WillReturnResult(sqlmock.NewResult(4, 1))
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				version, _ := r.UpdateAlbum(context.Background(), entity.Album{
+					ID:    entity.AlbumID("1"),
+					Title: "Updated Album",
+				})
+				return version
+			},
+			expectedResult: int64(4),
+			expectError:    false,
+		},
+		{
+			name: "UpdateAlbum_MatchingVersion",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE album SET title = ?, artist = ?, release_date = ?, genre = ?, track_count = ?, version = LAST_INSERT_ID(version + 1) WHERE id = ? AND deleted_at IS NULL AND version = ?")).
+					ExpectExec().
+					WithArgs("Updated Album", "", sql.NullTime{}, "", 0, "1", int64(3)).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				version, _ := r.UpdateAlbum(context.Background(), entity.Album{
+					ID:      entity.AlbumID("1"),
+					Title:   "Updated Album",
+					Version: 3,
+				})
+				return version
+			},
+			expectedResult: int64(4),
+			expectError:    false,
+		},
+		{
+			name: "UpdateAlbum_VersionMismatch",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE album SET")).
+					ExpectExec().
+					WithArgs("Updated Album", "", sql.NullTime{}, "", 0, "1", int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM album WHERE id = ? AND deleted_at IS NULL")).
+					WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				_, err := r.UpdateAlbum(context.Background(), entity.Album{
+					ID:      entity.AlbumID("1"),
+					Title:   "Updated Album",
+					Version: 2,
+				})
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrVersionMismatch,
+		},
+		{
+			name: "UpdateAlbum_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE album SET")).
+					ExpectExec().
+					WithArgs("Updated Album", "", sql.NullTime{}, "", 0, "1", int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM album WHERE id = ? AND deleted_at IS NULL")).
+					WithArgs("1").
+					WillReturnError(sql.ErrNoRows)
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				_, err := r.UpdateAlbum(context.Background(), entity.Album{
+					ID:      entity.AlbumID("1"),
+					Title:   "Updated Album",
+					Version: 2,
+				})
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrAlbumNotFound,
+		},
+
+		// DeleteAlbum tests
+		{
+			name: "DeleteAlbum_VersionMismatch",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE album SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ? AND deleted_at IS NULL AND version = ?")).
+					WithArgs("1", int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM album WHERE id = ? AND deleted_at IS NULL")).
+					WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+				mock.ExpectRollback()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				return r.DeleteAlbum(context.Background(), "1", 2, true)
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrVersionMismatch,
+		},
+		{
+			name: "DeleteAlbum_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM track WHERE album_id = ?)")).
+					WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE album SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ? AND deleted_at IS NULL")).
+					WithArgs("1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				return r.DeleteAlbum(context.Background(), "1", 0, false)
+			},
+			expectedResult: nil,
+			expectError:    false,
+		},
+		{
+			name: "DeleteAlbum_CascadeTracksKeepsThem",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE album SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ? AND deleted_at IS NULL")).
+					WithArgs("1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				return r.DeleteAlbum(context.Background(), "1", 0, true)
+			},
+			expectedResult: nil,
+			expectError:    false,
+		},
+		{
+			name: "DeleteAlbum_HasTracks",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM track WHERE album_id = ?)")).
+					WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				return r.DeleteAlbum(context.Background(), "1", 0, false)
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrAlbumHasTracks,
+		},
+		{
+			name: "DeleteAlbum_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE album SET deleted_at = CURRENT_TIMESTAMP")).
+					WithArgs("1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				return r.DeleteAlbum(context.Background(), "1", 0, true)
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrAlbumNotFound,
+		},
+
+		// GetAlbums trash tests
+		{
+			name: "GetAlbums_Trashed",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(albumColumns).
+					AddRow("1", "Album1", "", nil, "", 0, 2, createdAt, createdAt, deletedAt)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, artist, release_date, genre, track_count, version, created_at, updated_at, deleted_at FROM album WHERE deleted_at IS NOT NULL ORDER BY id ASC LIMIT ?")).
+					WithArgs(3).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				page, _ := r.GetAlbums(context.Background(), entity.AlbumListOptions{Limit: 2, SortBy: entity.AlbumSortByID, Trashed: true})
+				return page
+			},
+			expectedResult: entity.AlbumPage{Albums: []entity.Album{
+				{ID: "1", Title: "Album1", Version: 2, CreatedAt: createdAt, UpdatedAt: createdAt, DeletedAt: &deletedAt},
+			}},
+			expectError: false,
+		},
+
+		// RestoreAlbum tests
+		{
+			name: "RestoreAlbum_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE album SET deleted_at = NULL, version = LAST_INSERT_ID(version + 1) WHERE id = ? AND deleted_at IS NOT NULL")).
+					ExpectExec().
+					WithArgs("1").
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				version, _ := r.RestoreAlbum(context.Background(), "1")
+				return version
+			},
+			expectedResult: int64(3),
+			expectError:    false,
+		},
+		{
+			name: "RestoreAlbum_NotInTrash",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE album SET deleted_at = NULL")).
+					ExpectExec().
+					WithArgs("1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				_, err := r.RestoreAlbum(context.Background(), "1")
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrAlbumNotFound,
+		},
+
+		// PurgeAlbums tests
+		{
+			name: "PurgeAlbums_RemovesExpired",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM album WHERE deleted_at IS NOT NULL AND deleted_at < ? FOR UPDATE")).
+					WithArgs(deletedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM track WHERE album_id IN (?, ?)")).
+					WithArgs("1", "2").
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM album WHERE id IN (?, ?)")).
+					WithArgs("1", "2").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				ids, _ := r.PurgeAlbums(context.Background(), deletedAt)
+				return ids
+			},
+			expectedResult: []string{"1", "2"},
+			expectError:    false,
+		},
+		{
+			name: "PurgeAlbums_NothingExpired",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM album WHERE deleted_at IS NOT NULL")).
+					WithArgs(deletedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			action: func(r *mysql.AlbumRepository) interface{} {
+				ids, _ := r.PurgeAlbums(context.Background(), deletedAt)
+				return ids
+			},
+			expectedResult: []string(nil),
+			expectError:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,3 +690,23 @@ func BuildDBAlbum(entity entity.Album) mysql.Album {
 		Title: entity.Title,
 	}
 }
+
+func TestAlbumRepository_GetAlbumByID_KeepsCause(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	connErr := &mysqldriver.MySQLError{Number: 2013, Message: "Lost connection to MySQL server during query"}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM album WHERE id = ?")).
+		WithArgs("1").
+		WillReturnError(connErr)
+
+	repo, err := mysql.NewAlbumRepository(db)
+	assert.NoError(t, err)
+
+	_, err = repo.GetAlbumByID(context.Background(), "1")
+
+	assert.True(t, customerr.IsInternalServer(err))
+	assert.ErrorIs(t, err, connErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,185 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+)
+
+// APIKey represents the structure of an api_key row with db tags for column mapping
+type APIKey struct {
+	ID      string `db:"id"`
+	Name    string `db:"name"`
+	Prefix  string `db:"prefix"`
+	KeyHash string `db:"key_hash"`
+	// Scopes holds the granted scopes separated by commas
+	Scopes     string       `db:"scopes"`
+	ExpiresAt  sql.NullTime `db:"expires_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+	UpdatedAt  time.Time    `db:"updated_at"`
+}
+
+// apiKeyColumns lists the api_key columns in the order scanAPIKey reads them
+const apiKeyColumns = "id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at, updated_at"
+
+// scanAPIKey reads one row selected with apiKeyColumns
+func scanAPIKey(row rowScanner) (APIKey, error) {
+	var apiKey APIKey
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		&apiKey.Scopes,
+		&apiKey.ExpiresAt,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+		&apiKey.CreatedAt,
+		&apiKey.UpdatedAt,
+	)
+	return apiKey, err
+}
+
+// APIKeyRepository implements APIKeyRepositoryInterface for MySQL database operations
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository initializes a new MySQL API key repository
+func NewAPIKeyRepository(db *sql.DB) (*APIKeyRepository, error) {
+	return &APIKeyRepository{db: db}, nil
+}
+
+// GetAPIKeys lists every API key, revoked ones included, oldest first
+func (r *APIKeyRepository) GetAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_key ORDER BY created_at, id")
+	if err != nil {
+		return nil, fmt.Errorf("error querying data: %w", err)
+	}
+	defer rows.Close()
+
+	apiKeys := []entity.APIKey{}
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		apiKeys = append(apiKeys, BuildAPIKeyEntity(apiKey))
+	}
+
+	// Check for errors from iterating over rows.
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// GetAPIKeyByID retrieves an API key by its ID
+func (r *APIKeyRepository) GetAPIKeyByID(ctx context.Context, id string) (entity.APIKey, error) {
+	return r.getAPIKey(ctx, "id", id)
+}
+
+// GetAPIKeyByPrefix retrieves the API key with the given prefix, which is unique
+func (r *APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (entity.APIKey, error) {
+	return r.getAPIKey(ctx, "prefix", prefix)
+}
+
+// getAPIKey retrieves the API key whose column holds value; column is one of the unique ones
+func (r *APIKeyRepository) getAPIKey(ctx context.Context, column, value string) (entity.APIKey, error) {
+	apiKey, err := scanAPIKey(r.db.QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_key WHERE "+column+" = ?", value))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.APIKey{}, errors.ErrAPIKeyNotFound
+		}
+		return entity.APIKey{}, errors.ErrInternalServer.WithCause(err)
+	}
+	return BuildAPIKeyEntity(apiKey), nil
+}
+
+// CreateAPIKey inserts a new API key
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, entity entity.APIKey) error {
+	return insertAPIKey(ctx, r.db, BuildDBAPIKey(entity))
+}
+
+// RotateAPIKey stores the replacement of an API key together with the new expiry or revocation of
+// the key it replaces. A key revoked meanwhile yields ErrAPIKeyInactive and nothing is stored.
+func (r *APIKeyRepository) RotateAPIKey(ctx context.Context, replaced entity.APIKey, replacement entity.APIKey) error {
+	old := BuildDBAPIKey(replaced)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "UPDATE api_key SET expires_at = ?, revoked_at = ? WHERE id = ? AND revoked_at IS NULL",
+		old.ExpiresAt, old.RevokedAt, old.ID)
+	if err != nil {
+		return fmt.Errorf("error executing update: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errors.ErrAPIKeyInactive
+	}
+
+	if err := insertAPIKey(ctx, tx, BuildDBAPIKey(replacement)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing rotation: %w", err)
+	}
+	return nil
+}
+
+// RevokeAPIKey revokes an API key; a key revoked before keeps its revocation time
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id string, revokedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE api_key SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?", revokedAt, id)
+	if err != nil {
+		return fmt.Errorf("error executing update: %w", err)
+	}
+
+	// The connection reports matched rather than changed rows, so a key revoked before still counts
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errors.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey records when an API key was last used, leaving updated_at alone
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id string, usedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE api_key SET last_used_at = ?, updated_at = updated_at WHERE id = ?", usedAt, id); err != nil {
+		return fmt.Errorf("error executing update: %w", err)
+	}
+	return nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertAPIKey inserts an api_key row, alone or as part of a transaction
+func insertAPIKey(ctx context.Context, db execer, apiKey APIKey) error {
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO api_key (id, name, prefix, key_hash, scopes, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		apiKey.ID, apiKey.Name, apiKey.Prefix, apiKey.KeyHash, apiKey.Scopes, apiKey.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("error executing insert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/mysql"
+)
+
+// apiKeyColumns are the columns selected by every api_key query
+var apiKeyColumns = []string{"id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "revoked_at", "created_at", "updated_at"}
+
+func TestAPIKeyRepository(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	apiKey := entity.APIKey{
+		ID:        "k1",
+		Name:      "billing",
+		Prefix:    "0123456789ab",
+		Hash:      "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		Scopes:    []string{"albums:read", "albums:write"},
+		ExpiresAt: &expiresAt,
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(sqlmock.Sqlmock)
+		action         func(*mysql.APIKeyRepository) interface{}
+		expectedResult interface{}
+		expectError    bool
+		expectedErr    error
+	}{
+		// GetAPIKeys tests
+		{
+			name: "GetAPIKeys_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(apiKeyColumns).
+					AddRow("k1", "billing", "0123456789ab", apiKey.Hash, "albums:read,albums:write", expiresAt, nil, nil, createdAt, createdAt).
+					AddRow("k2", "reports", "ba9876543210", apiKey.Hash, "", nil, createdAt, createdAt, createdAt, createdAt)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at, updated_at FROM api_key ORDER BY created_at, id")).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.APIKeyRepository) interface{} {
+				apiKeys, _ := r.GetAPIKeys(context.Background())
+				return apiKeys
+			},
+			expectedResult: []entity.APIKey{
+				{ID: "k1", Name: "billing", Prefix: "0123456789ab", Hash: apiKey.Hash, Scopes: []string{"albums:read", "albums:write"}, ExpiresAt: &expiresAt, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: "k2", Name: "reports", Prefix: "ba9876543210", Hash: apiKey.Hash, LastUsedAt: &createdAt, RevokedAt: &createdAt, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+
+		// GetAPIKeyByPrefix tests
+		{
+			name: "GetAPIKeyByPrefix_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at, updated_at FROM api_key WHERE prefix = ?")).
+					WithArgs("ffffffffffff").
+					WillReturnError(sql.ErrNoRows)
+			},
+			action: func(r *mysql.APIKeyRepository) interface{} {
+				_, err := r.GetAPIKeyByPrefix(context.Background(), "ffffffffffff")
+				return err
+			},
+			expectError: true,
+			expectedErr: customerr.ErrAPIKeyNotFound,
+		},
+
+		// CreateAPIKey tests
+		{
+			name: "CreateAPIKey_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_key (id, name, prefix, key_hash, scopes, expires_at) VALUES (?, ?, ?, ?, ?, ?)")).
+					WithArgs("k1", "billing", "0123456789ab", apiKey.Hash, "albums:read,albums:write", sql.NullTime{Time: expiresAt, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			action: func(r *mysql.APIKeyRepository) interface{} {
+				return r.CreateAPIKey(context.Background(), apiKey)
+			},
+			expectedResult: nil,
+		},
+
+		// RotateAPIKey tests
+		{
+			name: "RotateAPIKey_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE api_key SET expires_at = ?, revoked_at = ? WHERE id = ? AND revoked_at IS NULL")).
+					WithArgs(sql.NullTime{Time: createdAt, Valid: true}, sql.NullTime{}, "k1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_key")).
+					WithArgs("k2", "billing", "ba9876543210", apiKey.Hash, "albums:read,albums:write", sql.NullTime{Time: expiresAt, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			action: func(r *mysql.APIKeyRepository) interface{} {
+				replaced := apiKey
+				replaced.ExpiresAt = &createdAt
+				replacement := apiKey
+				replacement.ID = "k2"
+				replacement.Prefix = "ba9876543210"
+				return r.RotateAPIKey(context.Background(), replaced, replacement)
+			},
+			expectedResult: nil,
+		},
+		{
+			name: "RotateAPIKey_RevokedMeanwhile",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE api_key SET expires_at = ?, revoked_at = ?")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			action: func(r *mysql.APIKeyRepository) interface{} {
+				return r.RotateAPIKey(context.Background(), apiKey, apiKey)
+			},
+			expectError: true,
+			expectedErr: customerr.ErrAPIKeyInactive,
+		},
+
+		// RevokeAPIKey tests
+		{
+			name: "RevokeAPIKey_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE api_key SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?")).
+					WithArgs(createdAt, "k9").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			action: func(r *mysql.APIKeyRepository) interface{} {
+				return r.RevokeAPIKey(context.Background(), "k9", createdAt)
+			},
+			expectError: true,
+			expectedErr: customerr.ErrAPIKeyNotFound,
+		},
+
+		// TouchAPIKey tests
+		{
+			name: "TouchAPIKey_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE api_key SET last_used_at = ?, updated_at = updated_at WHERE id = ?")).
+					WithArgs(createdAt, "k1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			action: func(r *mysql.APIKeyRepository) interface{} {
+				return r.TouchAPIKey(context.Background(), "k1", createdAt)
+			},
+			expectedResult: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock DB
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			// Setup mock expectations
+			tt.setupMock(mock)
+
+			// Create repository
+			repo, err := mysql.NewAPIKeyRepository(db)
+			assert.NoError(t, err)
+
+			// Execute action
+			result := tt.action(repo)
+
+			// Assertions
+			if tt.expectError {
+				assert.Error(t, result.(error))
+				assert.EqualError(t, result.(error), tt.expectedErr.Error())
+			} else if tt.expectedResult == nil {
+				assert.Nil(t, result)
+			} else {
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			// Verify all expectations were met
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
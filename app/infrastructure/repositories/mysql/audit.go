@@ -0,0 +1,140 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+// AuditRecord represents the structure of an audit_log row with db tags for column mapping
+type AuditRecord struct {
+	ID        string `db:"id"`
+	Actor     string `db:"actor"`
+	RequestID string `db:"request_id"`
+	Method    string `db:"method"`
+	Route     string `db:"route"`
+	TargetID  string `db:"target_id"`
+	// Before and After hold JSON documents, NULL when the target did not exist
+	Before     sql.NullString `db:"before_snapshot"`
+	After      sql.NullString `db:"after_snapshot"`
+	Outcome    string         `db:"outcome"`
+	StatusCode int            `db:"status_code"`
+	OccurredAt time.Time      `db:"occurred_at"`
+}
+
+// auditColumns lists the audit_log columns in the order scanAuditRecord reads them
+const auditColumns = "id, actor, request_id, method, route, target_id, before_snapshot, after_snapshot, outcome, status_code, occurred_at"
+
+// scanAuditRecord reads one row selected with auditColumns
+func scanAuditRecord(row rowScanner) (AuditRecord, error) {
+	var record AuditRecord
+	err := row.Scan(
+		&record.ID,
+		&record.Actor,
+		&record.RequestID,
+		&record.Method,
+		&record.Route,
+		&record.TargetID,
+		&record.Before,
+		&record.After,
+		&record.Outcome,
+		&record.StatusCode,
+		&record.OccurredAt,
+	)
+	return record, err
+}
+
+// AuditRepository implements AuditRepositoryInterface for MySQL database operations
+type AuditRepository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository initializes a new MySQL audit log repository
+func NewAuditRepository(db *sql.DB) (*AuditRepository, error) {
+	return &AuditRepository{db: db}, nil
+}
+
+// RecordAudit appends a record to the audit log
+func (r *AuditRepository) RecordAudit(ctx context.Context, entity entity.AuditRecord) error {
+	record := BuildDBAuditRecord(entity)
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO audit_log ("+auditColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		record.ID, record.Actor, record.RequestID, record.Method, record.Route, record.TargetID,
+		record.Before, record.After, record.Outcome, record.StatusCode, record.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("error executing insert: %w", err)
+	}
+	return nil
+}
+
+// GetAuditRecords lists one page of the records matching filter, newest first. filter.Limit must be positive.
+func (r *AuditRepository) GetAuditRecords(ctx context.Context, filter entity.AuditFilter) (entity.AuditPage, error) {
+	var conditions []string
+	var args []interface{}
+	for _, match := range []struct {
+		column string
+		value  string
+	}{
+		{"actor", filter.Actor},
+		{"target_id", filter.TargetID},
+		{"route", filter.Route},
+		{"outcome", filter.Outcome},
+	} {
+		if match.value != "" {
+			conditions = append(conditions, match.column+" = ?")
+			args = append(args, match.value)
+		}
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "occurred_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "occurred_at < ?")
+		args = append(args, filter.To)
+	}
+	if filter.Cursor != nil {
+		conditions = append(conditions, "(occurred_at < ? OR (occurred_at = ? AND id < ?))")
+		args = append(args, filter.Cursor.OccurredAt, filter.Cursor.OccurredAt, filter.Cursor.ID)
+	}
+
+	query := "SELECT " + auditColumns + " FROM audit_log"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	// One row more than the page tells whether another page follows
+	query += " ORDER BY occurred_at DESC, id DESC LIMIT ?"
+	args = append(args, filter.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return entity.AuditPage{}, fmt.Errorf("error querying data: %w", err)
+	}
+	defer rows.Close()
+
+	records := []entity.AuditRecord{}
+	for rows.Next() {
+		record, err := scanAuditRecord(rows)
+		if err != nil {
+			return entity.AuditPage{}, fmt.Errorf("error scanning row: %w", err)
+		}
+		records = append(records, BuildAuditRecordEntity(record))
+	}
+
+	// Check for errors from iterating over rows.
+	if err := rows.Err(); err != nil {
+		return entity.AuditPage{}, fmt.Errorf("error during row iteration: %w", err)
+	}
+
+	page := entity.AuditPage{Records: records}
+	if len(records) > filter.Limit {
+		page.Records = records[:filter.Limit]
+		last := page.Records[filter.Limit-1]
+		page.Next = &entity.AuditCursor{OccurredAt: last.OccurredAt, ID: last.ID}
+	}
+	return page, nil
+}
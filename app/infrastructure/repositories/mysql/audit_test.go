@@ -0,0 +1,165 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/infrastructure/repositories/mysql"
+)
+
+// auditColumns are the columns selected by every audit_log query
+var auditColumns = []string{"id", "actor", "request_id", "method", "route", "target_id", "before_snapshot", "after_snapshot", "outcome", "status_code", "occurred_at"}
+
+func TestAuditRepository(t *testing.T) {
+	occurredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	record := entity.AuditRecord{
+		ID:         "a1",
+		Actor:      "user:user-1",
+		RequestID:  "req-1",
+		Method:     "PATCH",
+		Route:      "/api/v2/albums/:id",
+		TargetID:   "1",
+		Before:     json.RawMessage(`{"id":"1","title":"Blue Train"}`),
+		After:      json.RawMessage(`{"id":"1","title":"Giant Steps"}`),
+		Outcome:    entity.AuditOutcomeSuccess,
+		StatusCode: 200,
+		OccurredAt: occurredAt,
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(sqlmock.Sqlmock)
+		action         func(*mysql.AuditRepository) interface{}
+		expectedResult interface{}
+		expectError    bool
+		expectedErr    error
+	}{
+		// RecordAudit tests
+		{
+			name: "RecordAudit_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_log (id, actor, request_id, method, route, target_id, before_snapshot, after_snapshot, outcome, status_code, occurred_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					WithArgs("a1", "user:user-1", "req-1", "PATCH", "/api/v2/albums/:id", "1",
+						sql.NullString{String: `{"id":"1","title":"Blue Train"}`, Valid: true},
+						sql.NullString{String: `{"id":"1","title":"Giant Steps"}`, Valid: true},
+						"success", 200, occurredAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			action: func(r *mysql.AuditRepository) interface{} {
+				return r.RecordAudit(context.Background(), record)
+			},
+			expectedResult: nil,
+		},
+		{
+			name: "RecordAudit_NoSnapshots",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_log")).
+					WithArgs("a2", "api-key:k1", "req-2", "POST", "/api/v1/albums:action", "",
+						sql.NullString{}, sql.NullString{}, "failure", 403, occurredAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			action: func(r *mysql.AuditRepository) interface{} {
+				return r.RecordAudit(context.Background(), entity.AuditRecord{
+					ID: "a2", Actor: "api-key:k1", RequestID: "req-2", Method: "POST", Route: "/api/v1/albums:action",
+					Outcome: entity.AuditOutcomeFailure, StatusCode: 403, OccurredAt: occurredAt,
+				})
+			},
+			expectedResult: nil,
+		},
+		{
+			name: "RecordAudit_Error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_log")).
+					WillReturnError(errors.New("connection refused"))
+			},
+			action: func(r *mysql.AuditRepository) interface{} {
+				return r.RecordAudit(context.Background(), record)
+			},
+			expectError: true,
+			expectedErr: errors.New("error executing insert: connection refused"),
+		},
+
+		// GetAuditRecords tests
+		{
+			name: "GetAuditRecords_FirstPage",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(auditColumns).
+					AddRow("a1", "user:user-1", "req-1", "PATCH", "/api/v2/albums/:id", "1", `{"id":"1","title":"Blue Train"}`, `{"id":"1","title":"Giant Steps"}`, "success", 200, occurredAt).
+					AddRow("a0", "user:user-1", "req-0", "DELETE", "/api/v2/albums/:id", "1", `{"id":"1"}`, nil, "success", 204, occurredAt)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, actor, request_id, method, route, target_id, before_snapshot, after_snapshot, outcome, status_code, occurred_at FROM audit_log ORDER BY occurred_at DESC, id DESC LIMIT ?")).
+					WithArgs(2).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.AuditRepository) interface{} {
+				page, _ := r.GetAuditRecords(context.Background(), entity.AuditFilter{Limit: 1})
+				return page
+			},
+			expectedResult: entity.AuditPage{
+				Records: []entity.AuditRecord{record},
+				Next:    &entity.AuditCursor{OccurredAt: occurredAt, ID: "a1"},
+			},
+		},
+		{
+			name: "GetAuditRecords_Filtered",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, actor, request_id, method, route, target_id, before_snapshot, after_snapshot, outcome, status_code, occurred_at FROM audit_log WHERE actor = ? AND target_id = ? AND outcome = ? AND occurred_at >= ? AND occurred_at < ? AND (occurred_at < ? OR (occurred_at = ? AND id < ?)) ORDER BY occurred_at DESC, id DESC LIMIT ?")).
+					WithArgs("user:user-1", "1", "failure", occurredAt.Add(-time.Hour), occurredAt.Add(time.Hour), occurredAt, occurredAt, "a1", 21).
+					WillReturnRows(sqlmock.NewRows(auditColumns))
+			},
+			action: func(r *mysql.AuditRepository) interface{} {
+				page, _ := r.GetAuditRecords(context.Background(), entity.AuditFilter{
+					Actor:    "user:user-1",
+					TargetID: "1",
+					Outcome:  entity.AuditOutcomeFailure,
+					From:     occurredAt.Add(-time.Hour),
+					To:       occurredAt.Add(time.Hour),
+					Limit:    20,
+					Cursor:   &entity.AuditCursor{OccurredAt: occurredAt, ID: "a1"},
+				})
+				return page
+			},
+			expectedResult: entity.AuditPage{Records: []entity.AuditRecord{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock DB
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			// Setup mock expectations
+			tt.setupMock(mock)
+
+			// Create repository
+			repo, err := mysql.NewAuditRepository(db)
+			assert.NoError(t, err)
+
+			// Execute action
+			result := tt.action(repo)
+
+			// Assertions
+			if tt.expectError {
+				assert.Error(t, result.(error))
+				assert.EqualError(t, result.(error), tt.expectedErr.Error())
+			} else if tt.expectedResult == nil {
+				assert.Nil(t, result)
+			} else {
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			// Verify all expectations were met
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,8 +3,23 @@ package mysql
 import "boilerplate/app/domain/entity"
 
 func BuildAlbumEntity(album Album) entity.Album {
-	return entity.Album{
-		ID:    entity.AlbumID(album.ID),
-		Title: album.Title,
+	albumEntity := entity.Album{
+		ID:         entity.AlbumID(album.ID),
+		Title:      album.Title,
+		Artist:     album.Artist,
+		Genre:      album.Genre,
+		TrackCount: album.TrackCount,
+		Version:    album.Version,
+		CreatedAt:  album.CreatedAt,
+		UpdatedAt:  album.UpdatedAt,
 	}
+	if album.ReleaseDate.Valid {
+		releaseDate := album.ReleaseDate.Time
+		albumEntity.ReleaseDate = &releaseDate
+	}
+	if album.DeletedAt.Valid {
+		deletedAt := album.DeletedAt.Time
+		albumEntity.DeletedAt = &deletedAt
+	}
+	return albumEntity
 }
@@ -0,0 +1,36 @@
+package mysql
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+func BuildAPIKeyEntity(apiKey APIKey) entity.APIKey {
+	apiKeyEntity := entity.APIKey{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Hash:       apiKey.KeyHash,
+		ExpiresAt:  nullTimePtr(apiKey.ExpiresAt),
+		LastUsedAt: nullTimePtr(apiKey.LastUsedAt),
+		RevokedAt:  nullTimePtr(apiKey.RevokedAt),
+		CreatedAt:  apiKey.CreatedAt,
+		UpdatedAt:  apiKey.UpdatedAt,
+	}
+	if apiKey.Scopes != "" {
+		apiKeyEntity.Scopes = strings.Split(apiKey.Scopes, ",")
+	}
+	return apiKeyEntity
+}
+
+// nullTimePtr returns the time of a nullable column, nil when it is NULL
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
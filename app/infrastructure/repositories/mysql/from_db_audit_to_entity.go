@@ -0,0 +1,28 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"boilerplate/app/domain/entity"
+)
+
+func BuildAuditRecordEntity(record AuditRecord) entity.AuditRecord {
+	recordEntity := entity.AuditRecord{
+		ID:         record.ID,
+		Actor:      record.Actor,
+		RequestID:  record.RequestID,
+		Method:     record.Method,
+		Route:      record.Route,
+		TargetID:   record.TargetID,
+		Outcome:    record.Outcome,
+		StatusCode: record.StatusCode,
+		OccurredAt: record.OccurredAt,
+	}
+	if record.Before.Valid {
+		recordEntity.Before = json.RawMessage(record.Before.String)
+	}
+	if record.After.Valid {
+		recordEntity.After = json.RawMessage(record.After.String)
+	}
+	return recordEntity
+}
@@ -0,0 +1,15 @@
+package mysql
+
+import "boilerplate/app/domain/entity"
+
+func BuildTrackEntity(track Track) entity.Track {
+	return entity.Track{
+		ID:              entity.TrackID(track.ID),
+		AlbumID:         entity.AlbumID(track.AlbumID),
+		Position:        track.Position,
+		Title:           track.Title,
+		DurationSeconds: track.DurationSeconds,
+		CreatedAt:       track.CreatedAt,
+		UpdatedAt:       track.UpdatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"strings"
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+func BuildWebhookEntity(webhook Webhook) entity.Webhook {
+	webhookEntity := entity.Webhook{
+		ID:           webhook.ID,
+		URL:          webhook.URL,
+		Secret:       webhook.Secret,
+		Enabled:      webhook.Enabled,
+		FailureCount: webhook.FailureCount,
+		CreatedAt:    webhook.CreatedAt,
+		UpdatedAt:    webhook.UpdatedAt,
+	}
+	if webhook.Events != "" {
+		webhookEntity.Events = strings.Split(webhook.Events, ",")
+	}
+	if webhook.DisabledAt.Valid {
+		disabledAt := webhook.DisabledAt.Time
+		webhookEntity.DisabledAt = &disabledAt
+	}
+	return webhookEntity
+}
+
+func BuildWebhookDeliveryEntity(delivery WebhookDelivery) entity.WebhookDelivery {
+	return entity.WebhookDelivery{
+		ID:         delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		DeliveryID: delivery.DeliveryID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Attempt:    delivery.Attempt,
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		Duration:   time.Duration(delivery.DurationMS) * time.Millisecond,
+		Succeeded:  delivery.Succeeded,
+		CreatedAt:  delivery.CreatedAt,
+	}
+}
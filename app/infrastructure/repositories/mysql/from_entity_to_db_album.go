@@ -1,10 +1,27 @@
 package mysql
 
-import "boilerplate/app/domain/entity"
+import (
+	"database/sql"
+
+	"boilerplate/app/domain/entity"
+)
 
 func BuildDBAlbum(entity entity.Album) Album {
-	return Album{
-		ID:    entity.ID.String(),
-		Title: entity.Title,
+	album := Album{
+		ID:         entity.ID.String(),
+		Title:      entity.Title,
+		Artist:     entity.Artist,
+		Genre:      entity.Genre,
+		TrackCount: entity.TrackCount,
+		Version:    entity.Version,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+	}
+	if entity.ReleaseDate != nil {
+		album.ReleaseDate = sql.NullTime{Time: *entity.ReleaseDate, Valid: true}
+	}
+	if entity.DeletedAt != nil {
+		album.DeletedAt = sql.NullTime{Time: *entity.DeletedAt, Valid: true}
 	}
+	return album
 }
@@ -0,0 +1,32 @@
+package mysql
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"boilerplate/app/domain/entity"
+)
+
+func BuildDBAPIKey(entity entity.APIKey) APIKey {
+	return APIKey{
+		ID:         entity.ID,
+		Name:       entity.Name,
+		Prefix:     entity.Prefix,
+		KeyHash:    entity.Hash,
+		Scopes:     strings.Join(entity.Scopes, ","),
+		ExpiresAt:  toNullTime(entity.ExpiresAt),
+		LastUsedAt: toNullTime(entity.LastUsedAt),
+		RevokedAt:  toNullTime(entity.RevokedAt),
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+	}
+}
+
+// toNullTime returns the value of a nullable column, NULL when t is nil
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -0,0 +1,28 @@
+package mysql
+
+import (
+	"database/sql"
+
+	"boilerplate/app/domain/entity"
+)
+
+func BuildDBAuditRecord(entity entity.AuditRecord) AuditRecord {
+	record := AuditRecord{
+		ID:         entity.ID,
+		Actor:      entity.Actor,
+		RequestID:  entity.RequestID,
+		Method:     entity.Method,
+		Route:      entity.Route,
+		TargetID:   entity.TargetID,
+		Outcome:    entity.Outcome,
+		StatusCode: entity.StatusCode,
+		OccurredAt: entity.OccurredAt,
+	}
+	if entity.Before != nil {
+		record.Before = sql.NullString{String: string(entity.Before), Valid: true}
+	}
+	if entity.After != nil {
+		record.After = sql.NullString{String: string(entity.After), Valid: true}
+	}
+	return record
+}
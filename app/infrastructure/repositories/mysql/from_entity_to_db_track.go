@@ -0,0 +1,15 @@
+package mysql
+
+import "boilerplate/app/domain/entity"
+
+func BuildDBTrack(entity entity.Track) Track {
+	return Track{
+		ID:              entity.ID.String(),
+		AlbumID:         entity.AlbumID.String(),
+		Position:        entity.Position,
+		Title:           entity.Title,
+		DurationSeconds: entity.DurationSeconds,
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"database/sql"
+	"strings"
+
+	"boilerplate/app/domain/entity"
+)
+
+func BuildDBWebhook(entity entity.Webhook) Webhook {
+	webhook := Webhook{
+		ID:           entity.ID,
+		URL:          entity.URL,
+		Secret:       entity.Secret,
+		Events:       strings.Join(entity.Events, ","),
+		Enabled:      entity.Enabled,
+		FailureCount: entity.FailureCount,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+	if entity.DisabledAt != nil {
+		webhook.DisabledAt = sql.NullTime{Time: *entity.DisabledAt, Valid: true}
+	}
+	return webhook
+}
+
+func BuildDBWebhookDelivery(entity entity.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:         entity.ID,
+		WebhookID:  entity.WebhookID,
+		DeliveryID: entity.DeliveryID,
+		EventID:    entity.EventID,
+		EventType:  entity.EventType,
+		Attempt:    entity.Attempt,
+		StatusCode: entity.StatusCode,
+		Error:      entity.Error,
+		DurationMS: entity.Duration.Milliseconds(),
+		Succeeded:  entity.Succeeded,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
@@ -2,9 +2,10 @@ package mysql
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 // OpenMySQLConnection establishes a connection to the MySQL database
@@ -12,13 +13,27 @@ func OpenMySQLConnection(connectionString string) (*sql.DB, error) {
 	fmt.Printf("connectionString: %s \n", connectionString)
 	db, err := sql.Open("mysql", connectionString)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %v", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	// Ping the database to ensure the connection is good
 	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %v", err)
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	return db, nil
 }
+
+// MySQL server error numbers the repositories translate into domain errors
+const (
+	errDuplicateEntry  uint16 = 1062 // a unique key, such as the primary key, is already taken
+	errRowIsReferenced uint16 = 1451 // a parent row cannot be deleted while child rows reference it
+	errNoReferencedRow uint16 = 1452 // a child row references a parent that does not exist
+	errNoFullTextIndex uint16 = 1191 // MATCH was used on columns without a FULLTEXT index
+)
+
+// isMySQLError reports whether err is a MySQL server error with the given number
+func isMySQLError(err error, number uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == number
+}
@@ -0,0 +1,161 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+)
+
+// Track represents the structure of a track row with db tags for column mapping
+type Track struct {
+	ID              string    `db:"id"`
+	AlbumID         string    `db:"album_id"`
+	Position        int       `db:"position"`
+	Title           string    `db:"title"`
+	DurationSeconds int       `db:"duration_seconds"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// trackColumns lists the track columns in the order scanTrack reads them
+const trackColumns = "id, album_id, position, title, duration_seconds, created_at, updated_at"
+
+// liveAlbumTrack restricts track statements to albums that are not in the trash
+const liveAlbumTrack = "album_id IN (SELECT id FROM album WHERE deleted_at IS NULL)"
+
+// scanTrack reads one row selected with trackColumns
+func scanTrack(row rowScanner) (Track, error) {
+	var track Track
+	err := row.Scan(
+		&track.ID,
+		&track.AlbumID,
+		&track.Position,
+		&track.Title,
+		&track.DurationSeconds,
+		&track.CreatedAt,
+		&track.UpdatedAt,
+	)
+	return track, err
+}
+
+// TrackRepository implements TrackRepositoryInterface for MySQL database operations
+type TrackRepository struct {
+	db *sql.DB
+}
+
+// NewTrackRepository initializes a new MySQL track repository
+func NewTrackRepository(db *sql.DB) (*TrackRepository, error) {
+	return &TrackRepository{db: db}, nil
+}
+
+// GetTracksByAlbumID lists the tracks of an album ordered by position
+func (r *TrackRepository) GetTracksByAlbumID(ctx context.Context, albumID string) ([]entity.Track, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+trackColumns+" FROM track WHERE album_id = ? AND "+liveAlbumTrack+" ORDER BY position, id", albumID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying data: %w", err)
+	}
+	defer rows.Close()
+
+	tracks := []entity.Track{}
+	for rows.Next() {
+		track, err := scanTrack(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		tracks = append(tracks, BuildTrackEntity(track))
+	}
+
+	// Check for errors from iterating over rows.
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+
+	return tracks, nil
+}
+
+// GetTrackByID retrieves a track of the given album
+func (r *TrackRepository) GetTrackByID(ctx context.Context, albumID string, trackID string) (entity.Track, error) {
+	track, err := scanTrack(r.db.QueryRowContext(ctx, "SELECT "+trackColumns+" FROM track WHERE album_id = ? AND id = ? AND "+liveAlbumTrack, albumID, trackID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Track{}, errors.ErrTrackNotFound
+		}
+		return entity.Track{}, errors.ErrInternalServer.WithCause(err)
+	}
+	return BuildTrackEntity(track), nil
+}
+
+// CreateTrack inserts a new track; a missing parent album, or one in the trash, yields ErrAlbumNotFound
+func (r *TrackRepository) CreateTrack(ctx context.Context, entity entity.Track) (string, error) {
+	track := BuildDBTrack(entity)
+
+	// Selecting the values from the live parent album inserts nothing when there is none
+	stmt, err := r.db.Prepare("INSERT INTO track (id, album_id, position, title, duration_seconds) SELECT ?, id, ?, ?, ? FROM album WHERE id = ? AND deleted_at IS NULL")
+	if err != nil {
+		return "", fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, track.ID, track.Position, track.Title, track.DurationSeconds, track.AlbumID)
+	if err != nil {
+		if isMySQLError(err, errNoReferencedRow) {
+			return "", errors.ErrAlbumNotFound
+		}
+		return "", fmt.Errorf("error executing insert: %w", err)
+	}
+	if err := checkAlbumAffected(result); err != nil {
+		return "", err
+	}
+
+	return track.ID, nil
+}
+
+// UpdateTrack replaces the stored fields of an existing track
+func (r *TrackRepository) UpdateTrack(ctx context.Context, entity entity.Track) error {
+	track := BuildDBTrack(entity)
+
+	stmt, err := r.db.Prepare("UPDATE track SET position = ?, title = ?, duration_seconds = ? WHERE album_id = ? AND id = ? AND " + liveAlbumTrack)
+	if err != nil {
+		return fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, track.Position, track.Title, track.DurationSeconds, track.AlbumID, track.ID)
+	if err != nil {
+		return fmt.Errorf("error executing update: %w", err)
+	}
+
+	return checkTrackAffected(result)
+}
+
+// DeleteTrack removes a track of the given album
+func (r *TrackRepository) DeleteTrack(ctx context.Context, albumID string, trackID string) error {
+	stmt, err := r.db.Prepare("DELETE FROM track WHERE album_id = ? AND id = ? AND " + liveAlbumTrack)
+	if err != nil {
+		return fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, albumID, trackID)
+	if err != nil {
+		return fmt.Errorf("error executing delete: %w", err)
+	}
+
+	return checkTrackAffected(result)
+}
+
+// checkTrackAffected maps a statement that touched no rows to ErrTrackNotFound
+func checkTrackAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errors.ErrTrackNotFound
+	}
+	return nil
+}
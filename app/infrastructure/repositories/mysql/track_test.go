@@ -0,0 +1,208 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/mysql"
+)
+
+// trackColumns are the columns selected by every track query
+var trackColumns = []string{"id", "album_id", "position", "title", "duration_seconds", "created_at", "updated_at"}
+
+func TestTrackRepository(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newTrack := entity.Track{
+		ID:              entity.TrackID("t1"),
+		AlbumID:         entity.AlbumID("1"),
+		Position:        1,
+		Title:           "Intro",
+		DurationSeconds: 90,
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(sqlmock.Sqlmock)
+		action         func(*mysql.TrackRepository) interface{}
+		expectedResult interface{}
+		expectError    bool
+		expectedErr    error
+	}{
+		// GetTracksByAlbumID tests
+		{
+			name: "GetTracksByAlbumID_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(trackColumns).
+					AddRow("t1", "1", 1, "Intro", 90, createdAt, createdAt).
+					AddRow("t2", "1", 2, "Outro", 120, createdAt, createdAt)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, album_id, position, title, duration_seconds, created_at, updated_at FROM track WHERE album_id = ? AND album_id IN (SELECT id FROM album WHERE deleted_at IS NULL) ORDER BY position, id")).
+					WithArgs("1").
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				tracks, _ := r.GetTracksByAlbumID(context.Background(), "1")
+				return tracks
+			},
+			expectedResult: []entity.Track{
+				{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro", DurationSeconds: 90, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: "t2", AlbumID: "1", Position: 2, Title: "Outro", DurationSeconds: 120, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+			expectError: false,
+		},
+
+		// GetTrackByID tests
+		{
+			name: "GetTrackByID_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, album_id, position, title, duration_seconds, created_at, updated_at FROM track WHERE album_id = ? AND id = ? AND album_id IN (SELECT id FROM album WHERE deleted_at IS NULL)")).
+					WithArgs("1", "t9").
+					WillReturnError(sql.ErrNoRows)
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				_, err := r.GetTrackByID(context.Background(), "1", "t9")
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrTrackNotFound,
+		},
+
+		// CreateTrack tests
+		{
+			name: "CreateTrack_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO track (id, album_id, position, title, duration_seconds) SELECT ?, id, ?, ?, ? FROM album WHERE id = ? AND deleted_at IS NULL")).
+					ExpectExec().
+					WithArgs("t1", 1, "Intro", 90, "1").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				id, _ := r.CreateTrack(context.Background(), newTrack)
+				return id
+			},
+			expectedResult: "t1",
+			expectError:    false,
+		},
+		{
+			name: "CreateTrack_AlbumMissing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO track (id, album_id, position, title, duration_seconds) SELECT ?, id, ?, ?, ? FROM album WHERE id = ? AND deleted_at IS NULL")).
+					ExpectExec().
+					WithArgs("t1", 1, "Intro", 90, "1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				_, err := r.CreateTrack(context.Background(), newTrack)
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrAlbumNotFound,
+		},
+		{
+			name: "CreateTrack_AlbumPurgedConcurrently",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO track (id, album_id, position, title, duration_seconds) SELECT ?, id, ?, ?, ? FROM album WHERE id = ? AND deleted_at IS NULL")).
+					ExpectExec().
+					WithArgs("t1", 1, "Intro", 90, "1").
+					WillReturnError(&mysqldriver.MySQLError{Number: 1452, Message: "foreign key constraint fails"})
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				_, err := r.CreateTrack(context.Background(), newTrack)
+				return err
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrAlbumNotFound,
+		},
+
+		// UpdateTrack tests
+		{
+			name: "UpdateTrack_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE track SET position = ?, title = ?, duration_seconds = ? WHERE album_id = ? AND id = ? AND album_id IN (SELECT id FROM album WHERE deleted_at IS NULL)")).
+					ExpectExec().
+					WithArgs(1, "Intro", 90, "1", "t1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				return r.UpdateTrack(context.Background(), newTrack)
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    customerr.ErrTrackNotFound,
+		},
+
+		// DeleteTrack tests
+		{
+			name: "DeleteTrack_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM track WHERE album_id = ? AND id = ? AND album_id IN (SELECT id FROM album WHERE deleted_at IS NULL)")).
+					ExpectExec().
+					WithArgs("1", "t1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				return r.DeleteTrack(context.Background(), "1", "t1")
+			},
+			expectedResult: nil,
+			expectError:    false,
+		},
+		{
+			name: "DeleteTrack_ExecError",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM track WHERE album_id = ? AND id = ? AND album_id IN (SELECT id FROM album WHERE deleted_at IS NULL)")).
+					ExpectExec().
+					WithArgs("1", "t1").
+					WillReturnError(errors.New("exec error"))
+			},
+			action: func(r *mysql.TrackRepository) interface{} {
+				return r.DeleteTrack(context.Background(), "1", "t1")
+			},
+			expectedResult: nil,
+			expectError:    true,
+			expectedErr:    fmt.Errorf("error executing delete: exec error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock DB
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			// Setup mock expectations
+			tt.setupMock(mock)
+
+			// Create repository
+			repo, err := mysql.NewTrackRepository(db)
+			assert.NoError(t, err)
+
+			// Execute action
+			result := tt.action(repo)
+
+			// Assertions
+			if tt.expectError {
+				assert.Error(t, result.(error))
+				assert.EqualError(t, result.(error), tt.expectedErr.Error())
+			} else {
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			// Verify all expectations were met
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
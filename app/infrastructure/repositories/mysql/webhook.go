@@ -0,0 +1,270 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+)
+
+// Webhook represents the structure of a webhook row with db tags for column mapping
+type Webhook struct {
+	ID     string `db:"id"`
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+	// Events holds the subscribed event types separated by commas, empty for every event
+	Events       string       `db:"events"`
+	Enabled      bool         `db:"enabled"`
+	FailureCount int          `db:"failure_count"`
+	DisabledAt   sql.NullTime `db:"disabled_at"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+}
+
+// WebhookDelivery represents the structure of a webhook_delivery row with db tags for column mapping
+type WebhookDelivery struct {
+	ID         string    `db:"id"`
+	WebhookID  string    `db:"webhook_id"`
+	DeliveryID string    `db:"delivery_id"`
+	EventID    string    `db:"event_id"`
+	EventType  string    `db:"event_type"`
+	Attempt    int       `db:"attempt"`
+	StatusCode int       `db:"status_code"`
+	Error      string    `db:"error"`
+	DurationMS int64     `db:"duration_ms"`
+	Succeeded  bool      `db:"succeeded"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// webhookColumns lists the webhook columns in the order scanWebhook reads them
+const webhookColumns = "id, url, secret, events, enabled, failure_count, disabled_at, created_at, updated_at"
+
+// webhookDeliveryColumns lists the webhook_delivery columns in the order scanWebhookDelivery reads them
+const webhookDeliveryColumns = "id, webhook_id, delivery_id, event_id, event_type, attempt, status_code, error, duration_ms, succeeded, created_at"
+
+// scanWebhook reads one row selected with webhookColumns
+func scanWebhook(row rowScanner) (Webhook, error) {
+	var webhook Webhook
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.Events,
+		&webhook.Enabled,
+		&webhook.FailureCount,
+		&webhook.DisabledAt,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	return webhook, err
+}
+
+// scanWebhookDelivery reads one row selected with webhookDeliveryColumns
+func scanWebhookDelivery(row rowScanner) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.DeliveryID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Attempt,
+		&delivery.StatusCode,
+		&delivery.Error,
+		&delivery.DurationMS,
+		&delivery.Succeeded,
+		&delivery.CreatedAt,
+	)
+	return delivery, err
+}
+
+// WebhookRepository implements WebhookRepositoryInterface for MySQL database operations
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository initializes a new MySQL webhook repository
+func NewWebhookRepository(db *sql.DB) (*WebhookRepository, error) {
+	return &WebhookRepository{db: db}, nil
+}
+
+// GetWebhooks lists every webhook, oldest first
+func (r *WebhookRepository) GetWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+webhookColumns+" FROM webhook ORDER BY created_at, id")
+	if err != nil {
+		return nil, fmt.Errorf("error querying data: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []entity.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		webhooks = append(webhooks, BuildWebhookEntity(webhook))
+	}
+
+	// Check for errors from iterating over rows.
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhookByID retrieves a webhook by its ID
+func (r *WebhookRepository) GetWebhookByID(ctx context.Context, id string) (entity.Webhook, error) {
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx, "SELECT "+webhookColumns+" FROM webhook WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Webhook{}, errors.ErrWebhookNotFound
+		}
+		return entity.Webhook{}, errors.ErrInternalServer.WithCause(err)
+	}
+	return BuildWebhookEntity(webhook), nil
+}
+
+// CreateWebhook inserts a new webhook
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, entity entity.Webhook) error {
+	webhook := BuildDBWebhook(entity)
+
+	stmt, err := r.db.Prepare("INSERT INTO webhook (id, url, secret, events, enabled) VALUES (?, ?, ?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, webhook.ID, webhook.URL, webhook.Secret, webhook.Events, webhook.Enabled); err != nil {
+		return fmt.Errorf("error executing insert: %w", err)
+	}
+	return nil
+}
+
+// UpdateWebhook replaces the stored fields of an existing webhook, failure tracking included
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, entity entity.Webhook) error {
+	webhook := BuildDBWebhook(entity)
+
+	stmt, err := r.db.Prepare("UPDATE webhook SET url = ?, secret = ?, events = ?, enabled = ?, failure_count = ?, disabled_at = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, webhook.URL, webhook.Secret, webhook.Events, webhook.Enabled, webhook.FailureCount, webhook.DisabledAt, webhook.ID)
+	if err != nil {
+		return fmt.Errorf("error executing update: %w", err)
+	}
+
+	return checkWebhookAffected(result)
+}
+
+// DeleteWebhook removes a webhook; its delivery log goes with it
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	stmt, err := r.db.Prepare("DELETE FROM webhook WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error executing delete: %w", err)
+	}
+
+	return checkWebhookAffected(result)
+}
+
+// RecordWebhookDelivery adds an attempt to the delivery log; a webhook deleted meanwhile yields ErrWebhookNotFound
+func (r *WebhookRepository) RecordWebhookDelivery(ctx context.Context, entity entity.WebhookDelivery) error {
+	delivery := BuildDBWebhookDelivery(entity)
+
+	stmt, err := r.db.Prepare("INSERT INTO webhook_delivery (id, webhook_id, delivery_id, event_id, event_type, attempt, status_code, error, duration_ms, succeeded) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("error preparing statement: %w", err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, delivery.ID, delivery.WebhookID, delivery.DeliveryID, delivery.EventID, delivery.EventType,
+		delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.DurationMS, delivery.Succeeded)
+	if err != nil {
+		if isMySQLError(err, errNoReferencedRow) {
+			return errors.ErrWebhookNotFound
+		}
+		return fmt.Errorf("error executing insert: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookDeliveries lists the latest attempts logged for a webhook, newest first
+func (r *WebhookRepository) GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]entity.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+webhookDeliveryColumns+" FROM webhook_delivery WHERE webhook_id = ? ORDER BY created_at DESC, id DESC LIMIT ?", webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying data: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []entity.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		deliveries = append(deliveries, BuildWebhookDeliveryEntity(delivery))
+	}
+
+	// Check for errors from iterating over rows.
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ResetWebhookFailures clears the count of failed deliveries in a row, after a successful one
+func (r *WebhookRepository) ResetWebhookFailures(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE webhook SET failure_count = 0 WHERE id = ? AND failure_count > 0", id); err != nil {
+		return fmt.Errorf("error executing update: %w", err)
+	}
+	return nil
+}
+
+// RecordWebhookFailure counts a delivery that failed all its attempts, and disables the webhook once
+// disableAfter deliveries in a row have failed. It reports whether this failure disabled the webhook.
+func (r *WebhookRepository) RecordWebhookFailure(ctx context.Context, id string, disableAfter int) (bool, error) {
+	// MySQL assigns single-table UPDATE columns from left to right, so the later assignments
+	// see the incremented failure_count and disabled_at is set while enabled still holds its old value
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE webhook SET failure_count = failure_count + 1, "+
+			"disabled_at = IF(enabled AND failure_count >= ?, CURRENT_TIMESTAMP, disabled_at), "+
+			"enabled = enabled AND failure_count < ? WHERE id = ?",
+		disableAfter, disableAfter, id)
+	if err != nil {
+		return false, fmt.Errorf("error executing update: %w", err)
+	}
+	if err := checkWebhookAffected(result); err != nil {
+		return false, err
+	}
+
+	var disabled bool
+	err = r.db.QueryRowContext(ctx, "SELECT NOT enabled AND failure_count = ? FROM webhook WHERE id = ?", disableAfter, id).Scan(&disabled)
+	if err != nil {
+		return false, fmt.Errorf("error querying data: %w", err)
+	}
+	return disabled, nil
+}
+
+// checkWebhookAffected maps a statement that touched no rows to ErrWebhookNotFound
+func checkWebhookAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errors.ErrWebhookNotFound
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/mysql"
+)
+
+// webhookColumns are the columns selected by every webhook query
+var webhookColumns = []string{"id", "url", "secret", "events", "enabled", "failure_count", "disabled_at", "created_at", "updated_at"}
+
+func TestWebhookRepository(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	webhook := entity.Webhook{
+		ID:      "w1",
+		URL:     "https://partner.example/hooks",
+		Secret:  "0123456789abcdef",
+		Events:  []string{"album.created", "album.deleted"},
+		Enabled: true,
+	}
+	delivery := entity.WebhookDelivery{
+		ID:         "a1",
+		WebhookID:  "w1",
+		DeliveryID: "d1",
+		EventID:    "1-0",
+		EventType:  "album.created",
+		Attempt:    2,
+		StatusCode: 500,
+		Error:      "unexpected status code: 500",
+		Duration:   1500 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(sqlmock.Sqlmock)
+		action         func(*mysql.WebhookRepository) interface{}
+		expectedResult interface{}
+		expectError    bool
+		expectedErr    error
+	}{
+		// GetWebhooks tests
+		{
+			name: "GetWebhooks_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(webhookColumns).
+					AddRow("w1", "https://partner.example/hooks", "0123456789abcdef", "album.created,album.deleted", true, 0, nil, createdAt, createdAt).
+					AddRow("w2", "https://other.example/hooks", "fedcba9876543210", "", false, 5, createdAt, createdAt, createdAt)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, url, secret, events, enabled, failure_count, disabled_at, created_at, updated_at FROM webhook ORDER BY created_at, id")).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				webhooks, _ := r.GetWebhooks(context.Background())
+				return webhooks
+			},
+			expectedResult: []entity.Webhook{
+				{ID: "w1", URL: "https://partner.example/hooks", Secret: "0123456789abcdef", Events: []string{"album.created", "album.deleted"}, Enabled: true, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: "w2", URL: "https://other.example/hooks", Secret: "fedcba9876543210", FailureCount: 5, DisabledAt: &createdAt, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+
+		// GetWebhookByID tests
+		{
+			name: "GetWebhookByID_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, url, secret, events, enabled, failure_count, disabled_at, created_at, updated_at FROM webhook WHERE id = ?")).
+					WithArgs("w9").
+					WillReturnError(sql.ErrNoRows)
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				_, err := r.GetWebhookByID(context.Background(), "w9")
+				return err
+			},
+			expectError: true,
+			expectedErr: customerr.ErrWebhookNotFound,
+		},
+
+		// CreateWebhook tests
+		{
+			name: "CreateWebhook_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO webhook (id, url, secret, events, enabled) VALUES (?, ?, ?, ?, ?)")).
+					ExpectExec().
+					WithArgs("w1", "https://partner.example/hooks", "0123456789abcdef", "album.created,album.deleted", true).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				return r.CreateWebhook(context.Background(), webhook)
+			},
+			expectedResult: nil,
+		},
+
+		// UpdateWebhook tests
+		{
+			name: "UpdateWebhook_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("UPDATE webhook SET url = ?, secret = ?, events = ?, enabled = ?, failure_count = ?, disabled_at = ? WHERE id = ?")).
+					ExpectExec().
+					WithArgs("https://partner.example/hooks", "0123456789abcdef", "album.created,album.deleted", true, 0, sql.NullTime{}, "w1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				return r.UpdateWebhook(context.Background(), webhook)
+			},
+			expectError: true,
+			expectedErr: customerr.ErrWebhookNotFound,
+		},
+
+		// DeleteWebhook tests
+		{
+			name: "DeleteWebhook_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM webhook WHERE id = ?")).
+					ExpectExec().
+					WithArgs("w1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				return r.DeleteWebhook(context.Background(), "w1")
+			},
+			expectedResult: nil,
+		},
+
+		// RecordWebhookDelivery tests
+		{
+			name: "RecordWebhookDelivery_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO webhook_delivery (id, webhook_id, delivery_id, event_id, event_type, attempt, status_code, error, duration_ms, succeeded) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+					ExpectExec().
+					WithArgs("a1", "w1", "d1", "1-0", "album.created", 2, 500, "unexpected status code: 500", int64(1500), false).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				return r.RecordWebhookDelivery(context.Background(), delivery)
+			},
+			expectedResult: nil,
+		},
+		{
+			name: "RecordWebhookDelivery_WebhookDeleted",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO webhook_delivery")).
+					ExpectExec().
+					WillReturnError(&mysqldriver.MySQLError{Number: 1452, Message: "foreign key constraint fails"})
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				return r.RecordWebhookDelivery(context.Background(), delivery)
+			},
+			expectError: true,
+			expectedErr: customerr.ErrWebhookNotFound,
+		},
+
+		// GetWebhookDeliveries tests
+		{
+			name: "GetWebhookDeliveries_Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "webhook_id", "delivery_id", "event_id", "event_type", "attempt", "status_code", "error", "duration_ms", "succeeded", "created_at"}).
+					AddRow("a2", "w1", "d1", "1-0", "album.created", 3, 204, "", 120, true, createdAt)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, webhook_id, delivery_id, event_id, event_type, attempt, status_code, error, duration_ms, succeeded, created_at FROM webhook_delivery WHERE webhook_id = ? ORDER BY created_at DESC, id DESC LIMIT ?")).
+					WithArgs("w1", 20).
+					WillReturnRows(rows)
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				deliveries, _ := r.GetWebhookDeliveries(context.Background(), "w1", 20)
+				return deliveries
+			},
+			expectedResult: []entity.WebhookDelivery{
+				{ID: "a2", WebhookID: "w1", DeliveryID: "d1", EventID: "1-0", EventType: "album.created", Attempt: 3, StatusCode: 204, Duration: 120 * time.Millisecond, Succeeded: true, CreatedAt: createdAt},
+			},
+		},
+
+		// RecordWebhookFailure tests
+		{
+			name: "RecordWebhookFailure_Disables",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE webhook SET failure_count = failure_count + 1, disabled_at = IF(enabled AND failure_count >= ?, CURRENT_TIMESTAMP, disabled_at), enabled = enabled AND failure_count < ? WHERE id = ?")).
+					WithArgs(5, 5, "w1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT NOT enabled AND failure_count = ? FROM webhook WHERE id = ?")).
+					WithArgs(5, "w1").
+					WillReturnRows(sqlmock.NewRows([]string{"disabled"}).AddRow(true))
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				disabled, _ := r.RecordWebhookFailure(context.Background(), "w1", 5)
+				return disabled
+			},
+			expectedResult: true,
+		},
+		{
+			name: "RecordWebhookFailure_NotFound",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE webhook SET failure_count = failure_count + 1")).
+					WithArgs(5, 5, "w9").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			action: func(r *mysql.WebhookRepository) interface{} {
+				_, err := r.RecordWebhookFailure(context.Background(), "w9", 5)
+				return err
+			},
+			expectError: true,
+			expectedErr: customerr.ErrWebhookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock DB
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			// Setup mock expectations
+			tt.setupMock(mock)
+
+			// Create repository
+			repo, err := mysql.NewWebhookRepository(db)
+			assert.NoError(t, err)
+
+			// Execute action
+			result := tt.action(repo)
+
+			// Assertions
+			if tt.expectError {
+				assert.Error(t, result.(error))
+				assert.EqualError(t, result.(error), tt.expectedErr.Error())
+			} else if tt.expectedResult == nil {
+				assert.Nil(t, result)
+			} else {
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			// Verify all expectations were met
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -33,6 +33,23 @@ func (q *Queue) SendMessage(message string) error {
 	return err
 }
 
+// MaxMessageDelay is the longest SQS can delay the delivery of a message
+const MaxMessageDelay = 15 * time.Minute
+
+// SendMessageWithDelay sends a single message that stays hidden from consumers for delay,
+// which is capped at MaxMessageDelay
+func (q *Queue) SendMessageWithDelay(message string, delay time.Duration) error {
+	if delay > MaxMessageDelay {
+		delay = MaxMessageDelay
+	}
+	_, err := q.client.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:     aws.String(q.queueURL),
+		MessageBody:  aws.String(message),
+		DelaySeconds: int32(delay.Seconds()),
+	})
+	return err
+}
+
 // SendMessages sends multiple messages to the queue in batch
 func (q *Queue) SendMessages(messages []string) error {
 	if len(messages) == 0 {
@@ -0,0 +1,117 @@
+// Package auth authenticates and authorizes the callers of every transport serving the API: the
+// REST middleware, the GraphQL handler and the gRPC interceptors check the same credentials and
+// scopes through it, each answering in its own protocol.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"boilerplate/app/domain/entity"
+	domainerrors "boilerplate/app/domain/errors"
+	jwtauth "boilerplate/app/infrastructure/auth"
+)
+
+// Reasons a caller is rejected by Authenticate, next to those of auth.Verifier; the messages are sent to the client
+var (
+	ErrAuthorizationRequired  = errors.New("Authorization header required")
+	ErrAuthorizationFormat    = errors.New("Invalid Authorization header format")
+	ErrAuthorizationAmbiguous = errors.New("Send either an Authorization or an X-API-Key header, not both")
+)
+
+// ErrCredentialsUnchecked wraps the failures to look up an API key, which reject nothing about the
+// caller and are answered as internal errors
+var ErrCredentialsUnchecked = errors.New("credentials could not be checked")
+
+// TokenVerifier checks bearer tokens and returns their claims; it is satisfied by *auth.Verifier
+type TokenVerifier interface {
+	Verify(token string) (*jwtauth.Claims, error)
+}
+
+// APIKeyVerifier checks API keys and returns the stored key; it is satisfied by the API key service.
+// Rejected keys yield an error of kind KindUnauthenticated.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (entity.APIKey, error)
+}
+
+// claimsKey is used as a unique key for storing the token claims in the context
+type claimsKey struct{}
+
+// AuthenticateCredentials checks the credentials of a caller, the value of the Authorization header
+// or metadata and that of X-API-Key: the API key when apiKeys is not nil and one is sent, the
+// bearer token otherwise. Errors wrapping ErrCredentialsUnchecked are failures to look the key up;
+// the others reject the caller.
+func AuthenticateCredentials(ctx context.Context, verifier TokenVerifier, apiKeys APIKeyVerifier, authorization, apiKey string) (*jwtauth.Claims, error) {
+	switch {
+	case apiKeys == nil || apiKey == "":
+		return Authenticate(verifier, authorization)
+	case authorization != "":
+		return nil, ErrAuthorizationAmbiguous
+	}
+
+	claims, err := AuthenticateAPIKey(ctx, apiKeys, apiKey)
+	if err != nil && domainerrors.KindOf(err) != domainerrors.KindUnauthenticated {
+		return nil, fmt.Errorf("%w: %w", ErrCredentialsUnchecked, err)
+	}
+	return claims, err
+}
+
+// Authenticate checks the value of an Authorization header, expecting "Bearer <token>".
+// It is the check behind AuthenticateCredentials, for callers that protect less than a whole route.
+func Authenticate(verifier TokenVerifier, authHeader string) (*jwtauth.Claims, error) {
+	if authHeader == "" {
+		return nil, ErrAuthorizationRequired
+	}
+
+	// Split the header into parts, expecting "Bearer <token>"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, ErrAuthorizationFormat
+	}
+
+	return verifier.Verify(parts[1])
+}
+
+// AuthenticateAPIKey checks the value of an X-API-Key header. The claims of a valid key have the
+// subject "api-key:<id>" and the scopes of the key.
+func AuthenticateAPIKey(ctx context.Context, apiKeys APIKeyVerifier, key string) (*jwtauth.Claims, error) {
+	apiKey, err := apiKeys.VerifyAPIKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	claims := &jwtauth.Claims{Subject: "api-key:" + apiKey.ID, APIKeyID: apiKey.ID, Scopes: apiKey.Scopes}
+	if apiKey.ExpiresAt != nil {
+		claims.ExpiresAt = *apiKey.ExpiresAt
+	}
+	return claims, nil
+}
+
+// Reason returns what the client is told of a rejection: the title of a rejected API key, or the error itself
+func Reason(err error) string {
+	var typed *domainerrors.Error
+	if errors.As(err, &typed) {
+		return typed.Title
+	}
+	return err.Error()
+}
+
+// WithClaims returns a context carrying the claims of the credentials a caller was authenticated with
+func WithClaims(ctx context.Context, claims *jwtauth.Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// GetClaimsFromContext retrieves the claims stored by WithClaims from the context
+func GetClaimsFromContext(ctx context.Context) (*jwtauth.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*jwtauth.Claims)
+	return claims, ok
+}
+
+// Caller names the caller the claims were issued to, "api-key:<key ID>" or "user:<token subject>"
+func Caller(claims *jwtauth.Claims) string {
+	if claims.APIKeyID != "" {
+		return "api-key:" + claims.APIKeyID
+	}
+	return "user:" + claims.Subject
+}
@@ -0,0 +1,90 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/presentation/auth"
+)
+
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(token string) (*jwtauth.Claims, error) {
+	if token != "valid" {
+		return nil, jwtauth.ErrTokenSignature
+	}
+	return &jwtauth.Claims{Subject: "user-1", Scopes: []string{entity.ScopeAlbumsRead}}, nil
+}
+
+type stubAPIKeys struct{ err error }
+
+func (s stubAPIKeys) VerifyAPIKey(_ context.Context, key string) (entity.APIKey, error) {
+	if s.err != nil {
+		return entity.APIKey{}, s.err
+	}
+	return entity.APIKey{ID: "k1", Scopes: []string{entity.ScopeAlbumsWrite}}, nil
+}
+
+func TestAuthenticateCredentials(t *testing.T) {
+	tests := []struct {
+		name           string
+		apiKeys        auth.APIKeyVerifier
+		authorization  string
+		apiKey         string
+		expectedCaller string
+		expectedErr    error
+	}{
+		{name: "Bearer token", authorization: "Bearer valid", expectedCaller: "user:user-1"},
+		{name: "API key", apiKeys: stubAPIKeys{}, apiKey: "key", expectedCaller: "api-key:k1"},
+		{name: "API keys not accepted", authorization: "Bearer valid", apiKey: "key", expectedCaller: "user:user-1"},
+		{name: "No credentials", expectedErr: auth.ErrAuthorizationRequired},
+		{name: "Not a bearer token", authorization: "Basic dXNlcjpwYXNz", expectedErr: auth.ErrAuthorizationFormat},
+		{name: "Invalid token", authorization: "Bearer forged", expectedErr: jwtauth.ErrTokenSignature},
+		{name: "Both credentials", apiKeys: stubAPIKeys{}, authorization: "Bearer valid", apiKey: "key", expectedErr: auth.ErrAuthorizationAmbiguous},
+		{name: "Rejected API key", apiKeys: stubAPIKeys{err: customerr.ErrAPIKeyInvalid}, apiKey: "key", expectedErr: customerr.ErrAPIKeyInvalid},
+		{name: "API key lookup failure", apiKeys: stubAPIKeys{err: errors.New("connection refused")}, apiKey: "key", expectedErr: auth.ErrCredentialsUnchecked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := auth.AuthenticateCredentials(context.Background(), stubVerifier{}, tt.apiKeys, tt.authorization, tt.apiKey)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCaller, auth.Caller(claims))
+		})
+	}
+}
+
+func TestReason(t *testing.T) {
+	assert.Equal(t, "Invalid token signature", auth.Reason(jwtauth.ErrTokenSignature))
+	assert.Equal(t, customerr.ErrAPIKeyInvalid.Title, auth.Reason(customerr.ErrAPIKeyInvalid))
+}
+
+func TestHasScopes(t *testing.T) {
+	claims := &jwtauth.Claims{Scopes: []string{entity.ScopeAlbumsRead}}
+
+	assert.True(t, auth.HasScopes(claims, entity.ScopeAlbumsRead))
+	assert.False(t, auth.HasScopes(claims, entity.ScopeAlbumsRead, entity.ScopeAlbumsWrite))
+	assert.True(t, auth.HasScopes(&jwtauth.Claims{Scopes: []string{entity.ScopeAdmin}}, entity.ScopeAlbumsWrite))
+}
+
+func TestWithClaims(t *testing.T) {
+	_, ok := auth.GetClaimsFromContext(context.Background())
+	assert.False(t, ok)
+
+	claims := &jwtauth.Claims{Subject: "user-1"}
+	stored, ok := auth.GetClaimsFromContext(auth.WithClaims(context.Background(), claims))
+	assert.True(t, ok)
+	assert.Same(t, claims, stored)
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"boilerplate/app/domain/entity"
+	jwtauth "boilerplate/app/infrastructure/auth"
+)
+
+// RoutePermission tells what a route requires of its callers. gRPC methods are listed with the
+// method GRPC and their full name as path.
+type RoutePermission struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Operation names the GraphQL field a permission of /graphql applies to; the endpoint itself is open
+	Operation string `json:"operation,omitempty"`
+	// Authenticated is false for the routes open to anyone
+	Authenticated bool `json:"authenticated"`
+	// Scopes are granted to the caller by a token or an API key; admin grants all of them
+	Scopes []string `json:"scopes"`
+}
+
+// HasScopes tells whether claims grant every one of scopes
+func HasScopes(claims *jwtauth.Claims, scopes ...string) bool {
+	for _, scope := range scopes {
+		if !entity.HasScope(claims.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
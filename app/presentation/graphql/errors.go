@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	stderrors "errors"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+
+	"boilerplate/app/domain/errors"
+)
+
+// Error is how a domain error is reported in the errors of a GraphQL response. The message is the
+// title of the typed error and the extensions carry its code and, for invalid input, the rejected
+// fields, the same information the REST API sends as problem details. The cause is never exposed.
+type Error struct {
+	typed  *errors.Error
+	fields []errors.FieldError
+	// cause is the error returned by the usecase, logged by the handler
+	cause error
+}
+
+// newError describes err for a GraphQL response; errors without a typed error in their chain are
+// reported as internal errors
+func newError(err error) *Error {
+	var existing *Error
+	if stderrors.As(err, &existing) {
+		return existing
+	}
+
+	typed := errors.ErrInternalServer
+	var found *errors.Error
+	if stderrors.As(err, &found) {
+		typed = found
+	}
+	return &Error{typed: typed, fields: errors.ValidationDetails(err), cause: err}
+}
+
+func (e *Error) Error() string {
+	return e.typed.Title
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Extensions implements gqlerrors.ExtendedError
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.typed.Code}
+	if len(e.fields) > 0 {
+		extensions["errors"] = e.fields
+	}
+	return extensions
+}
+
+// requestErrors formats the error of a request that is rejected before it is executed
+func requestErrors(err error) []gqlerrors.FormattedError {
+	graphqlErr := newError(err)
+	return gqlerrors.FormatErrors(gqlerrors.NewError(graphqlErr.Error(), nil, "", nil, nil, graphqlErr))
+}
+
+// resolver adapts a resolver returning domain errors, including from the thunks it returns
+func resolver(fn gql.FieldResolveFn) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		result, err := fn(p)
+		if err != nil {
+			return nil, newError(err)
+		}
+		if thunk, ok := result.(func() (interface{}, error)); ok {
+			return func() (interface{}, error) {
+				value, err := thunk()
+				if err != nil {
+					return nil, newError(err)
+				}
+				return value, nil
+			}, nil
+		}
+		return result, nil
+	}
+}
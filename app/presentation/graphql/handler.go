@@ -0,0 +1,235 @@
+// Package graphql serves the album usecase as a GraphQL API at /graphql, next to the REST API.
+// Album lookups by ID within a request are batched into one usecase call, operations are
+// checked against depth and complexity limits before they run, and the root fields require the
+// same credentials and scopes as the REST routes serving the same data.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"boilerplate/app/domain/errors"
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/rest/middleware"
+	albumservice "boilerplate/app/usecase/interface"
+)
+
+// Request is the body of a GraphQL request. GET requests carry the same fields as query
+// parameters, with the variables encoded as JSON.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// errMalformedRequest is the error of a request whose body or parameters cannot be read
+var errMalformedRequest = errors.NewValidationError(errors.FieldError{
+	Field: "body", Code: "malformed", Message: "must be a JSON object with a query string",
+})
+
+// Handler executes GraphQL requests
+type Handler struct {
+	schema       gql.Schema
+	albumService albumservice.AlbumInterface
+	verifier     auth.TokenVerifier
+	apiKeys      auth.APIKeyVerifier
+	limits       Limits
+}
+
+// NewHandler creates a GraphQL handler over the album usecase. The root fields check bearer tokens
+// with verifier and, when apiKeys is not nil, API keys sent in the X-API-Key header. Mutations are
+// recorded with auditRecorder, unless it is nil.
+func NewHandler(albumService albumservice.AlbumInterface, verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier, auditRecorder middleware.AuditRecorder, limits Limits) (*Handler, error) {
+	schema, err := NewSchema(albumService, auditRecorder)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, albumService: albumService, verifier: verifier, apiKeys: apiKeys, limits: limits}, nil
+}
+
+// Permissions lists what the root fields require of their callers, as operations of the
+// /graphql routes: queries are served over GET and POST, mutations over POST only
+func (h *Handler) Permissions() []auth.RoutePermission {
+	var permissions []auth.RoutePermission
+	for _, root := range []struct {
+		operation string
+		object    *gql.Object
+		methods   []string
+	}{
+		{"query", h.schema.QueryType(), []string{http.MethodGet, http.MethodPost}},
+		{"mutation", h.schema.MutationType(), []string{http.MethodPost}},
+	} {
+		for name := range root.object.Fields() {
+			for _, method := range root.methods {
+				permissions = append(permissions, auth.RoutePermission{
+					Method:        method,
+					Path:          "/graphql",
+					Operation:     root.operation + " " + name,
+					Authenticated: true,
+					Scopes:        append([]string{}, fieldScopes(root.object.Name(), name)...),
+				})
+			}
+		}
+	}
+	return permissions
+}
+
+// Serve handles GET and POST /graphql. Requests that cannot be executed, because they do not parse,
+// do not validate or exceed the limits, are answered with 400 and only errors; executed requests
+// are answered with 200, with the errors of the fields that failed next to the data.
+func (h *Handler) Serve(ctx *gin.Context) {
+	req, err := readRequest(ctx)
+	if errors.KindOf(err) == errors.KindTooLarge {
+		h.reject(ctx, http.StatusRequestEntityTooLarge, requestErrors(err))
+		return
+	}
+	if err != nil {
+		h.reject(ctx, http.StatusBadRequest, requestErrors(err))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		h.reject(ctx, http.StatusBadRequest, gqlerrors.FormatErrors(err))
+		return
+	}
+	if result := gql.ValidateDocument(&h.schema, doc, nil); !result.IsValid {
+		h.reject(ctx, http.StatusBadRequest, result.Errors)
+		return
+	}
+	if err := h.limits.check(h.schema, doc, req.OperationName, req.Variables); err != nil {
+		h.reject(ctx, http.StatusBadRequest, requestErrors(err))
+		return
+	}
+	// Mutations are not allowed over GET, which may be cached or replayed by intermediaries
+	if operation := findOperation(doc, req.OperationName); ctx.Request.Method == http.MethodGet && operation != nil && operation.Operation != ast.OperationTypeQuery {
+		ctx.Header("Allow", http.MethodPost)
+		h.reject(ctx, http.StatusMethodNotAllowed, gqlerrors.FormatErrors(gqlerrors.NewFormattedError("Mutations must be sent with POST")))
+		return
+	}
+
+	requestCtx := withAlbumLoader(ctx.Request.Context(), h.albumService)
+	requestCtx = withAuthorization(requestCtx, h.verifier, h.apiKeys, ctx.GetHeader("Authorization"), ctx.GetHeader("X-API-Key"))
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       requestCtx,
+	})
+
+	// Log the errors for debugging purposes; the response only carries their title and code
+	for _, formatted := range result.Errors {
+		if cause := errorCause(formatted); cause != nil {
+			ctx.Error(cause)
+		}
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// reject answers a request that was not executed
+func (h *Handler) reject(ctx *gin.Context, status int, errs []gqlerrors.FormattedError) {
+	ctx.JSON(status, gin.H{"errors": errs})
+}
+
+// readRequest reads a GraphQL request from the JSON body of a POST or the query of a GET
+func readRequest(ctx *gin.Context) (Request, error) {
+	var req Request
+	if ctx.Request.Method == http.MethodGet {
+		req.Query = ctx.Query("query")
+		req.OperationName = ctx.Query("operationName")
+		if variables := ctx.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return Request{}, errMalformedRequest
+			}
+		}
+	} else if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+		// The body could not be read, as opposed to decoded
+		var typed *errors.Error
+		if stderrors.As(err, &typed) {
+			return Request{}, err
+		}
+		return Request{}, errMalformedRequest
+	}
+
+	if req.Query == "" {
+		return Request{}, errMalformedRequest
+	}
+	return req, nil
+}
+
+// errorCause returns the error a resolver failed with, if the formatted error comes from one
+func errorCause(formatted gqlerrors.FormattedError) error {
+	located, ok := formatted.OriginalError().(*gqlerrors.Error)
+	if !ok {
+		return nil
+	}
+	var resolverErr *Error
+	if stderrors.As(located.OriginalError, &resolverErr) {
+		return resolverErr.cause
+	}
+	return nil
+}
+
+type authorizationKey struct{}
+
+// authorization is the credentials of a request, with their verifiers, checked once by the first
+// root field
+type authorization struct {
+	verifier auth.TokenVerifier
+	apiKeys  auth.APIKeyVerifier
+	header   string
+	apiKey   string
+
+	once   sync.Once
+	claims *jwtauth.Claims
+	err    error
+}
+
+// withAuthorization returns a context carrying the Authorization and X-API-Key headers of the request
+func withAuthorization(ctx context.Context, verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier, header, apiKey string) context.Context {
+	return context.WithValue(ctx, authorizationKey{}, &authorization{verifier: verifier, apiKeys: apiKeys, header: header, apiKey: apiKey})
+}
+
+// authorize applies auth.AuthenticateCredentials to the headers stored by withAuthorization,
+// then checks that the caller is granted every one of scopes. It returns the claims of the caller.
+func authorize(ctx context.Context, scopes ...string) (*jwtauth.Claims, error) {
+	a, ok := ctx.Value(authorizationKey{}).(*authorization)
+	if !ok {
+		return nil, errors.NewError(errors.KindUnauthenticated, "unauthenticated", auth.ErrAuthorizationRequired.Error(), "unauthenticated")
+	}
+	a.once.Do(func() {
+		a.claims, a.err = auth.AuthenticateCredentials(ctx, a.verifier, a.apiKeys, a.header, a.apiKey)
+	})
+
+	switch {
+	case stderrors.Is(a.err, auth.ErrCredentialsUnchecked):
+		return nil, a.err
+	case a.err != nil:
+		return nil, errors.NewError(errors.KindUnauthenticated, "unauthenticated", auth.Reason(a.err), "unauthenticated")
+	case !auth.HasScopes(a.claims, scopes...):
+		return nil, errors.ErrScopeRequired
+	}
+	return a.claims, nil
+}
+
+// authenticated returns the claims of the caller once a root field has checked the credentials of
+// the request, whether or not the caller was granted the scopes of the field
+func authenticated(ctx context.Context) (*jwtauth.Claims, bool) {
+	a, ok := ctx.Value(authorizationKey{}).(*authorization)
+	if !ok || a.claims == nil {
+		return nil, false
+	}
+	return a.claims, true
+}
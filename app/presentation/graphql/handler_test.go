@@ -0,0 +1,463 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/graphql"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// stubVerifier accepts the tokens "valid", granted the album scopes, and "reader", granted albums:read
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(token string) (*jwtauth.Claims, error) {
+	switch token {
+	case "valid":
+		return &jwtauth.Claims{Subject: "user-1", Scopes: []string{entity.ScopeAlbumsRead, entity.ScopeAlbumsWrite}}, nil
+	case "reader":
+		return &jwtauth.Claims{Subject: "user-2", Scopes: []string{entity.ScopeAlbumsRead}}, nil
+	}
+	return nil, jwtauth.ErrTokenSignature
+}
+
+// stubAPIKeys accepts the key "reader-key" only, granted albums:read
+type stubAPIKeys struct{}
+
+func (stubAPIKeys) VerifyAPIKey(_ context.Context, key string) (entity.APIKey, error) {
+	if key != "reader-key" {
+		return entity.APIKey{}, customerr.ErrAPIKeyInvalid
+	}
+	return entity.APIKey{ID: "k1", Scopes: []string{entity.ScopeAlbumsRead}}, nil
+}
+
+// serve sends a GraphQL request through a router serving the handler at /graphql
+func serve(t *testing.T, albumService *mocks.AlbumInterface, limits graphql.Limits, req *http.Request) (int, response) {
+	gin.SetMode(gin.TestMode)
+	handler, err := graphql.NewHandler(albumService, stubVerifier{}, stubAPIKeys{}, nil, limits)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.GET("/graphql", handler.Serve)
+	r.POST("/graphql", handler.Serve)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+	return w.Code, resp
+}
+
+// post returns a GraphQL request sent with a token granted the album scopes
+func post(query string, variables map[string]interface{}) *http.Request {
+	body, _ := json.Marshal(graphql.Request{Query: query, Variables: variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer valid")
+	return req
+}
+
+func TestHandler_AlbumsAreBatched(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAlbumsByIDs", mock.Anything, mock.MatchedBy(func(ids []string) bool {
+		sorted := append([]string(nil), ids...)
+		sort.Strings(sorted)
+		return assert.ObjectsAreEqual([]string{"1", "2", "3"}, sorted)
+	})).Return(map[string]dto.Album{
+		"1": {ID: "1", Title: "Blue Train", Genre: "jazz"},
+		"2": {ID: "2", Title: "Kind of Blue"},
+	}, nil).Once()
+
+	status, resp := serve(t, albumService, graphql.Limits{}, post(`{
+		a: album(id: "1") { title genre }
+		b: album(id: "2") { title genre }
+		c: album(id: "3") { title }
+	}`, nil))
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]interface{}{"title": "Blue Train", "genre": "jazz"}, resp.Data["a"])
+	assert.Equal(t, map[string]interface{}{"title": "Kind of Blue", "genre": nil}, resp.Data["b"])
+	assert.Nil(t, resp.Data["c"])
+}
+
+func TestHandler_Albums(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{Limit: 2, SortBy: entity.AlbumSortByTitle, Descending: true, TitlePrefix: "Bl"}).
+		Return(dto.AlbumList{Albums: []dto.Album{{ID: "1", Title: "Blue Train"}}, NextCursor: "next"}, nil)
+
+	status, resp := serve(t, albumService, graphql.Limits{}, post(`query($limit: Int) {
+		albums(limit: $limit, sort: "-title", titlePrefix: "Bl") { albums { id title } nextCursor prevCursor }
+	}`, map[string]interface{}{"limit": 2}))
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"albums":     []interface{}{map[string]interface{}{"id": "1", "title": "Blue Train"}},
+		"nextCursor": "next",
+		"prevCursor": nil,
+	}, resp.Data["albums"])
+}
+
+func TestHandler_InternalErrorIsHidden(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, errors.New("db password leaked"))
+
+	status, resp := serve(t, albumService, graphql.Limits{}, post(`{ albums { albums { id } } }`, nil))
+
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "Internal Server Error", resp.Errors[0].Message)
+	assert.Equal(t, "internal", resp.Errors[0].Extensions["code"])
+	assert.Equal(t, []interface{}{"albums"}, resp.Errors[0].Path)
+}
+
+func TestHandler_Authorization(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		authorization string
+		apiKey        string
+		expectedMsg   string
+		expectedCode  string
+	}{
+		{name: "Without credentials", query: `{ albums { albums { id } } }`, expectedMsg: "Authorization header required", expectedCode: "unauthenticated"},
+		{name: "With an invalid token", query: `{ jsonposts { id } }`, authorization: "Bearer nope", expectedMsg: "Invalid token signature", expectedCode: "unauthenticated"},
+		{name: "With a rejected API key", query: `{ album(id: "1") { id } }`, apiKey: "nope", expectedMsg: "Invalid API key", expectedCode: "unauthenticated"},
+		{name: "With both credentials", query: `{ album(id: "1") { id } }`, authorization: "Bearer valid", apiKey: "reader-key", expectedMsg: "Send either an Authorization or an X-API-Key header, not both", expectedCode: "unauthenticated"},
+		{name: "Mutation without albums:write", query: `mutation { createAlbum(input: {title: "Blue Train"}) { id } }`, authorization: "Bearer reader", expectedMsg: "Insufficient scope", expectedCode: "insufficient_scope"},
+		{name: "Mutation with a read-only API key", query: `mutation { createAlbum(input: {title: "Blue Train"}) { id } }`, apiKey: "reader-key", expectedMsg: "Insufficient scope", expectedCode: "insufficient_scope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := post(tt.query, nil)
+			req.Header.Del("Authorization")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			status, resp := serve(t, mocks.NewAlbumInterface(t), graphql.Limits{}, req)
+
+			assert.Equal(t, http.StatusOK, status)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.expectedMsg, resp.Errors[0].Message)
+			assert.Equal(t, tt.expectedCode, resp.Errors[0].Extensions["code"])
+		})
+	}
+
+	t.Run("Reads with an API key", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		albumService.On("GetAlbumsByIDs", mock.Anything, []string{"1"}).Return(map[string]dto.Album{"1": {ID: "1", Title: "Blue Train"}}, nil)
+
+		req := post(`{ album(id: "1") { title } }`, nil)
+		req.Header.Del("Authorization")
+		req.Header.Set("X-API-Key", "reader-key")
+		_, resp := serve(t, albumService, graphql.Limits{}, req)
+
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"title": "Blue Train"}, resp.Data["album"])
+	})
+
+	t.Run("Posts with a valid token", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		albumService.On("GetFromThirdPartyAPI", mock.Anything).Return([]dto.Post{{UserID: 1, ID: 2, Title: "Hello", Body: "World"}}, nil)
+
+		_, resp := serve(t, albumService, graphql.Limits{}, post(`{ jsonposts { userId id title } }`, nil))
+
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{map[string]interface{}{"userId": float64(1), "id": float64(2), "title": "Hello"}}, resp.Data["jsonposts"])
+	})
+}
+
+func TestHandler_Permissions(t *testing.T) {
+	handler, err := graphql.NewHandler(mocks.NewAlbumInterface(t), stubVerifier{}, nil, nil, graphql.Limits{})
+	require.NoError(t, err)
+
+	permissions := handler.Permissions()
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i].Operation+permissions[i].Method < permissions[j].Operation+permissions[j].Method
+	})
+	assert.Equal(t, []auth.RoutePermission{
+		{Method: http.MethodPost, Path: "/graphql", Operation: "mutation createAlbum", Authenticated: true, Scopes: []string{entity.ScopeAlbumsWrite}},
+		{Method: http.MethodGet, Path: "/graphql", Operation: "query album", Authenticated: true, Scopes: []string{entity.ScopeAlbumsRead}},
+		{Method: http.MethodPost, Path: "/graphql", Operation: "query album", Authenticated: true, Scopes: []string{entity.ScopeAlbumsRead}},
+		{Method: http.MethodGet, Path: "/graphql", Operation: "query albums", Authenticated: true, Scopes: []string{entity.ScopeAlbumsRead}},
+		{Method: http.MethodPost, Path: "/graphql", Operation: "query albums", Authenticated: true, Scopes: []string{entity.ScopeAlbumsRead}},
+		{Method: http.MethodGet, Path: "/graphql", Operation: "query jsonposts", Authenticated: true, Scopes: []string{}},
+		{Method: http.MethodPost, Path: "/graphql", Operation: "query jsonposts", Authenticated: true, Scopes: []string{}},
+	}, permissions)
+}
+
+func TestHandler_CreateAlbum(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("CreateAlbum", mock.Anything, entity.Album{Title: "Blue Train", Genre: "jazz"}).Return("A1", nil)
+	albumService.On("GetAlbumsByIDs", mock.Anything, []string{"A1"}).Return(map[string]dto.Album{"A1": {ID: "A1", Title: "Blue Train", Version: 1}}, nil)
+
+	status, resp := serve(t, albumService, graphql.Limits{}, post(`mutation($input: CreateAlbumInput!) {
+		createAlbum(input: $input) { id album { title version } }
+	}`, map[string]interface{}{"input": map[string]interface{}{"title": "Blue Train", "genre": "jazz"}}))
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"id":    "A1",
+		"album": map[string]interface{}{"title": "Blue Train", "version": float64(1)},
+	}, resp.Data["createAlbum"])
+}
+
+func TestHandler_CreateAlbum_Invalid(t *testing.T) {
+	status, resp := serve(t, mocks.NewAlbumInterface(t), graphql.Limits{}, post(`mutation {
+		createAlbum(input: {title: "Blue Train", genre: "polka", releaseDate: "1958"}) { id }
+	}`, nil))
+
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "Invalid input", resp.Errors[0].Message)
+	assert.Equal(t, "invalid_input", resp.Errors[0].Extensions["code"])
+	var fields []string
+	for _, field := range resp.Errors[0].Extensions["errors"].([]interface{}) {
+		fields = append(fields, field.(map[string]interface{})["field"].(string))
+	}
+	assert.Equal(t, []string{"input.releaseDate", "input.genre"}, fields)
+}
+
+// auditRecorderFunc adapts a function to middleware.AuditRecorder
+type auditRecorderFunc func(record entity.AuditRecord)
+
+func (f auditRecorderFunc) RecordAudit(record entity.AuditRecord) {
+	f(record)
+}
+
+func TestHandler_CreateAlbum_Audit(t *testing.T) {
+	mutation := `mutation { createAlbum(input: {id: "A1", title: "Blue Train"}) { id } }`
+	tests := []struct {
+		name          string
+		setupMock     func(a *mocks.AlbumInterface)
+		authorization string
+		expected      []entity.AuditRecord
+	}{
+		{
+			name: "Created",
+			setupMock: func(a *mocks.AlbumInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("A1", nil).Once()
+			},
+			authorization: "Bearer valid",
+			expected: []entity.AuditRecord{{
+				Actor: "user:user-1", RequestID: "req-1", Method: "GRAPHQL", Route: "mutation createAlbum", TargetID: "A1",
+				Outcome: entity.AuditOutcomeSuccess, StatusCode: http.StatusOK,
+			}},
+		},
+		{
+			name: "Conflict",
+			setupMock: func(a *mocks.AlbumInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("", customerr.ErrAlbumExists).Once()
+			},
+			authorization: "Bearer valid",
+			expected: []entity.AuditRecord{{
+				Actor: "user:user-1", RequestID: "req-1", Method: "GRAPHQL", Route: "mutation createAlbum", TargetID: "A1",
+				Outcome: entity.AuditOutcomeFailure, StatusCode: http.StatusConflict,
+			}},
+		},
+		{
+			name:          "Insufficient scope",
+			authorization: "Bearer reader",
+			expected: []entity.AuditRecord{{
+				Actor: "user:user-2", RequestID: "req-1", Method: "GRAPHQL", Route: "mutation createAlbum", TargetID: "A1",
+				Outcome: entity.AuditOutcomeDenied, StatusCode: http.StatusForbidden,
+			}},
+		},
+		{
+			name:          "Rejected credentials",
+			authorization: "Bearer forged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			albumService := mocks.NewAlbumInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(albumService)
+			}
+			var records []entity.AuditRecord
+			recorder := auditRecorderFunc(func(record entity.AuditRecord) { records = append(records, record) })
+			handler, err := graphql.NewHandler(albumService, stubVerifier{}, nil, recorder, graphql.Limits{})
+			require.NoError(t, err)
+			r := gin.New()
+			r.Use(middleware.CommonHeadersMiddleware())
+			r.POST("/graphql", handler.Serve)
+
+			req := post(mutation, nil)
+			req.Header.Set("Authorization", tt.authorization)
+			req.Header.Set(middleware.RequestIDHeader, "req-1")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			for i := range records {
+				assert.False(t, records[i].OccurredAt.IsZero())
+				records[i].OccurredAt = time.Time{}
+			}
+			assert.Equal(t, tt.expected, records)
+		})
+	}
+}
+
+func TestHandler_Limits(t *testing.T) {
+	tests := []struct {
+		name           string
+		limits         graphql.Limits
+		query          string
+		variables      map[string]interface{}
+		expectedCode   string
+		expectedReason string
+	}{
+		{
+			name:           "Too deep",
+			limits:         graphql.Limits{MaxDepth: 2},
+			query:          `{ albums { albums { id } } }`,
+			expectedReason: "depth",
+		},
+		{
+			name:           "Too deep through a fragment",
+			limits:         graphql.Limits{MaxDepth: 2},
+			query:          `{ albums { ...page } } fragment page on AlbumConnection { albums { id } }`,
+			expectedReason: "depth",
+		},
+		{
+			name:           "Too complex",
+			limits:         graphql.Limits{MaxComplexity: 100},
+			query:          `{ albums(limit: 50) { albums { id title } } }`,
+			expectedReason: "complexity",
+		},
+		{
+			name:           "Too complex through a variable",
+			limits:         graphql.Limits{MaxComplexity: 100},
+			query:          `query($limit: Int) { albums(limit: $limit) { albums { id title } } }`,
+			variables:      map[string]interface{}{"limit": 50},
+			expectedReason: "complexity",
+		},
+		{
+			name:           "Default page size is counted",
+			limits:         graphql.Limits{MaxComplexity: 50},
+			query:          `{ albums { albums { id title } } }`,
+			expectedReason: "complexity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := serve(t, mocks.NewAlbumInterface(t), tt.limits, post(tt.query, tt.variables))
+
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Nil(t, resp.Data)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, "invalid_input", resp.Errors[0].Extensions["code"])
+			field := resp.Errors[0].Extensions["errors"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, "query", field["field"])
+			assert.Equal(t, tt.expectedReason, field["code"])
+		})
+	}
+
+	t.Run("Within the limits", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		albumService.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, nil)
+
+		status, _ := serve(t, albumService, graphql.Limits{MaxDepth: 3, MaxComplexity: 100}, post(`{ albums(limit: 10) { albums { id title } } }`, nil))
+
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Introspection is not counted", func(t *testing.T) {
+		status, resp := serve(t, mocks.NewAlbumInterface(t), graphql.Limits{MaxDepth: 1, MaxComplexity: 1}, post(`{
+			__schema { types { name fields { name type { name ofType { name } } } } }
+		}`, nil))
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Empty(t, resp.Errors)
+	})
+}
+
+func TestHandler_RejectedRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            *http.Request
+		expectedStatus int
+	}{
+		{
+			name:           "Malformed body",
+			req:            httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":`)),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Syntax error",
+			req:            post(`{ albums {`, nil),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown field",
+			req:            post(`{ artists { id } }`, nil),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Mutation over GET",
+			req:            httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(`mutation { createAlbum(input: {title: "x"}) { id } }`), nil),
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := serve(t, mocks.NewAlbumInterface(t), graphql.Limits{}, tt.req)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.NotEmpty(t, resp.Errors)
+		})
+	}
+}
+
+func TestHandler_QueryOverGET(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAlbumsByIDs", mock.Anything, []string{"1"}).Return(map[string]dto.Album{"1": {ID: "1", Title: "Blue Train"}}, nil)
+
+	query := url.Values{
+		"query":     {`query($id: ID!) { album(id: $id) { title } }`},
+		"variables": {`{"id":"1"}`},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer reader")
+	status, resp := serve(t, albumService, graphql.Limits{}, req)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"title": "Blue Train"}, resp.Data["album"])
+}
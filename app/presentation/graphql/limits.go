@@ -0,0 +1,187 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"boilerplate/app/domain/errors"
+)
+
+// defaultPageSize is the number of items a field taking a limit argument is assumed to return
+// when the query does not set one, the page size the usecase applies by default
+const defaultPageSize = 20
+
+// Limits bounds the cost of an operation, checked before it is executed. A zero limit is not enforced.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields allowed, counting the root fields as 1
+	MaxDepth int
+	// MaxComplexity is the largest number of fields an operation may resolve. Each field counts
+	// as 1, and the fields selected under a field taking a limit argument count once per item.
+	MaxComplexity int
+}
+
+// cost is the depth and complexity of a selection set
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// check returns a ValidationError when the selected operation exceeds the limits.
+// Introspection fields are not counted, so that tools can always load the schema.
+func (l Limits) check(schema gql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	a := &analyzer{
+		schema:    schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+		visiting:  map[string]bool{},
+	}
+
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			a.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	operation := findOperation(doc, operationName)
+	if operation == nil {
+		// The executor reports the missing operation
+		return nil
+	}
+
+	var root gql.Type = schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+	total := a.selectionSet(operation.SelectionSet, root)
+
+	invalid := errors.NewValidationError()
+	if l.MaxDepth > 0 && total.depth > l.MaxDepth {
+		invalid.Add("query", "depth", fmt.Sprintf("is nested %d levels deep, more than the %d allowed", total.depth, l.MaxDepth))
+	}
+	if l.MaxComplexity > 0 && total.complexity > l.MaxComplexity {
+		invalid.Add("query", "complexity", fmt.Sprintf("has a complexity of %d, more than the %d allowed", total.complexity, l.MaxComplexity))
+	}
+	return invalid.OrNil()
+}
+
+// analyzer computes the cost of the selection sets of one document
+type analyzer struct {
+	schema    gql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	// visiting holds the fragments being expanded, so that a cycle, which validation rejects, cannot loop
+	visiting map[string]bool
+}
+
+func (a *analyzer) selectionSet(set *ast.SelectionSet, parent gql.Type) cost {
+	var total cost
+	if set == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = a.field(selection, parent)
+		case *ast.InlineFragment:
+			c = a.selectionSet(selection.SelectionSet, a.conditionType(selection.TypeCondition, parent))
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || a.visiting[name] {
+				continue
+			}
+			a.visiting[name] = true
+			c = a.selectionSet(fragment.SelectionSet, a.conditionType(fragment.TypeCondition, parent))
+			delete(a.visiting, name)
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+	return total
+}
+
+func (a *analyzer) field(field *ast.Field, parent gql.Type) cost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}
+	}
+
+	var definition *gql.FieldDefinition
+	if object, ok := parent.(*gql.Object); ok {
+		definition = object.Fields()[field.Name.Value]
+	}
+	if definition == nil {
+		// Validation reports unknown fields
+		return cost{depth: 1, complexity: 1}
+	}
+
+	fieldType, _ := gql.GetNamed(definition.Type).(gql.Type)
+	children := a.selectionSet(field.SelectionSet, fieldType)
+	return cost{
+		depth:      children.depth + 1,
+		complexity: 1 + a.pageSize(field, definition)*children.complexity,
+	}
+}
+
+// pageSize is the number of items a field returns: its limit argument when it takes one, and 1 otherwise
+func (a *analyzer) pageSize(field *ast.Field, definition *gql.FieldDefinition) int {
+	takesLimit := false
+	for _, arg := range definition.Args {
+		takesLimit = takesLimit || arg.Name() == "limit"
+	}
+	if !takesLimit {
+		return 1
+	}
+
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if limit, err := strconv.Atoi(value.Value); err == nil && limit > 0 {
+				return limit
+			}
+		case *ast.Variable:
+			// Variables decoded from JSON are float64
+			switch limit := a.variables[value.Name.Value].(type) {
+			case float64:
+				if limit > 0 {
+					return int(limit)
+				}
+			case int:
+				if limit > 0 {
+					return limit
+				}
+			}
+		}
+	}
+	return defaultPageSize
+}
+
+// conditionType is the type a fragment applies to, or the parent type when it has no condition
+func (a *analyzer) conditionType(condition *ast.Named, parent gql.Type) gql.Type {
+	if condition == nil || condition.Name == nil {
+		return parent
+	}
+	if named := a.schema.Type(condition.Name.Value); named != nil {
+		return named
+	}
+	return parent
+}
+
+// findOperation returns the operation of the document to execute, or nil when there is none
+func findOperation(doc *ast.Document, operationName string) *ast.OperationDefinition {
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if ok && (operationName == "" || operation.Name != nil && operation.Name.Value == operationName) {
+			return operation
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"boilerplate/app/domain/dto"
+	albumservice "boilerplate/app/usecase/interface"
+)
+
+// albumLoader batches album lookups by ID within one request. Load only records the ID and
+// returns a thunk; the executor calls the thunks once every field at the same level has been
+// resolved, so the first thunk fetches all the IDs recorded so far with a single GetAlbumsByIDs.
+type albumLoader struct {
+	albumService albumservice.GetAlbumInterface
+
+	mu      sync.Mutex
+	pending []string
+	results map[string]dto.Album
+	// errs holds the error of the batch each ID was fetched in
+	errs map[string]error
+}
+
+func newAlbumLoader(albumService albumservice.GetAlbumInterface) *albumLoader {
+	return &albumLoader{
+		albumService: albumService,
+		results:      map[string]dto.Album{},
+		errs:         map[string]error{},
+	}
+}
+
+// Load returns a thunk resolving to the album with the given ID, or to nil when there is none
+func (l *albumLoader) Load(ctx context.Context, id string) func() (interface{}, error) {
+	l.mu.Lock()
+	l.pending = append(l.pending, id)
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, done := l.errs[id]; !done {
+			l.fetchPending(ctx)
+		}
+		if err := l.errs[id]; err != nil {
+			return nil, err
+		}
+		if album, ok := l.results[id]; ok {
+			return album, nil
+		}
+		return nil, nil
+	}
+}
+
+// fetchPending loads every pending ID in one call; l.mu must be held
+func (l *albumLoader) fetchPending(ctx context.Context) {
+	ids := l.pending
+	l.pending = nil
+
+	albums, err := l.albumService.GetAlbumsByIDs(ctx, ids)
+	for _, id := range ids {
+		l.errs[id] = err
+	}
+	for id, album := range albums {
+		l.results[id] = album
+	}
+}
+
+type loaderKey struct{}
+
+// withAlbumLoader returns a context carrying a new album loader for the request
+func withAlbumLoader(ctx context.Context, albumService albumservice.GetAlbumInterface) context.Context {
+	return context.WithValue(ctx, loaderKey{}, newAlbumLoader(albumService))
+}
+
+// albumLoaderFromContext returns the loader stored by withAlbumLoader
+func albumLoaderFromContext(ctx context.Context) *albumLoader {
+	return ctx.Value(loaderKey{}).(*albumLoader)
+}
@@ -0,0 +1,315 @@
+package graphql
+
+import (
+	"net/http"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/presentation/rest/problem"
+	"boilerplate/app/presentation/rest/validation"
+	albumservice "boilerplate/app/usecase/interface"
+)
+
+// Fields of the types below resolve to the DTO field of the same name, ignoring case
+var (
+	albumType = gql.NewObject(gql.ObjectConfig{
+		Name: "Album",
+		Fields: gql.Fields{
+			"id":          &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"title":       &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"artist":      &gql.Field{Type: gql.String, Resolve: optionalString(func(a dto.Album) string { return a.Artist })},
+			"releaseDate": &gql.Field{Type: gql.String, Description: "Release date as YYYY-MM-DD", Resolve: optionalString(func(a dto.Album) string { return a.ReleaseDate })},
+			"genre":       &gql.Field{Type: gql.String, Resolve: optionalString(func(a dto.Album) string { return a.Genre })},
+			"trackCount":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"version":     &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"createdAt":   &gql.Field{Type: gql.DateTime},
+			"updatedAt":   &gql.Field{Type: gql.DateTime},
+		},
+	})
+
+	albumConnectionType = gql.NewObject(gql.ObjectConfig{
+		Name: "AlbumConnection",
+		Fields: gql.Fields{
+			"albums":     &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(albumType)))},
+			"nextCursor": &gql.Field{Type: gql.String, Resolve: optionalString(func(l dto.AlbumList) string { return l.NextCursor })},
+			"prevCursor": &gql.Field{Type: gql.String, Resolve: optionalString(func(l dto.AlbumList) string { return l.PrevCursor })},
+		},
+	})
+
+	postType = gql.NewObject(gql.ObjectConfig{
+		Name: "Post",
+		Fields: gql.Fields{
+			"userId": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"id":     &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"title":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"body":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+
+	createAlbumPayloadType = gql.NewObject(gql.ObjectConfig{
+		Name: "CreateAlbumPayload",
+		Fields: gql.Fields{
+			"id": &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"album": &gql.Field{
+				Type:        albumType,
+				Description: "The stored album, read back only when selected",
+				Resolve: resolver(func(p gql.ResolveParams) (interface{}, error) {
+					payload, _ := p.Source.(createAlbumPayload)
+					return albumLoaderFromContext(p.Context).Load(p.Context, payload.ID), nil
+				}),
+			},
+		},
+	})
+
+	createAlbumInputType = gql.NewInputObject(gql.InputObjectConfig{
+		Name: "CreateAlbumInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"id":          &gql.InputObjectFieldConfig{Type: gql.ID, Description: "Leave empty to have the server assign one"},
+			"title":       &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"artist":      &gql.InputObjectFieldConfig{Type: gql.String},
+			"releaseDate": &gql.InputObjectFieldConfig{Type: gql.String, Description: "Release date as YYYY-MM-DD"},
+			"genre":       &gql.InputObjectFieldConfig{Type: gql.String},
+			"trackCount":  &gql.InputObjectFieldConfig{Type: gql.Int},
+		},
+	})
+)
+
+// createAlbumPayload is the result of the createAlbum mutation
+type createAlbumPayload struct {
+	ID string
+}
+
+// resolvers holds the services the root fields resolve with
+type resolvers struct {
+	albumService albumservice.AlbumInterface
+}
+
+// NewSchema builds the GraphQL schema over the album usecase, recording mutations with
+// auditRecorder unless it is nil
+func NewSchema(albumService albumservice.AlbumInterface, auditRecorder middleware.AuditRecorder) (gql.Schema, error) {
+	r := &resolvers{albumService: albumService}
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: authorized("Query", gql.Fields{
+			"albums": &gql.Field{
+				Type:        gql.NewNonNull(albumConnectionType),
+				Description: "One page of albums, sorted by id, title or created_at, prefixed with - for descending",
+				Args: gql.FieldConfigArgument{
+					"limit":       &gql.ArgumentConfig{Type: gql.Int},
+					"cursor":      &gql.ArgumentConfig{Type: gql.String},
+					"sort":        &gql.ArgumentConfig{Type: gql.String},
+					"titlePrefix": &gql.ArgumentConfig{Type: gql.String},
+				},
+				Resolve: resolver(r.albums),
+			},
+			"album": &gql.Field{
+				Type:        albumType,
+				Description: "The album with the given ID, or null. Lookups in one request are fetched together.",
+				Args: gql.FieldConfigArgument{
+					"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: resolver(r.album),
+			},
+			"jsonposts": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(postType))),
+				Description: "Posts from the third-party API",
+				Resolve:     resolver(r.jsonPosts),
+			},
+		}),
+	})
+
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: audited(auditRecorder, authorized("Mutation", gql.Fields{
+			"createAlbum": &gql.Field{
+				Type:        gql.NewNonNull(createAlbumPayloadType),
+				Description: "Stores a new album, applying the same rules as the REST API",
+				Args: gql.FieldConfigArgument{
+					"input": &gql.ArgumentConfig{Type: gql.NewNonNull(createAlbumInputType)},
+				},
+				Resolve: resolver(r.createAlbum),
+			},
+		})),
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func (r *resolvers) albums(p gql.ResolveParams) (interface{}, error) {
+	opts := entity.AlbumListOptions{}
+	opts.Limit, _ = p.Args["limit"].(int)
+	opts.TitlePrefix, _ = p.Args["titlePrefix"].(string)
+
+	sort, _ := p.Args["sort"].(string)
+	if strings.HasPrefix(sort, "-") {
+		opts.Descending = true
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	opts.SortBy = entity.AlbumSortField(sort)
+
+	if cursor, _ := p.Args["cursor"].(string); cursor != "" {
+		decoded, err := dto.DecodeAlbumCursor(cursor)
+		if err != nil {
+			return nil, errors.NewValidationError(errors.FieldError{
+				Field: "cursor", Code: "malformed", Message: "is not a cursor issued by this API",
+			})
+		}
+		opts.Cursor = decoded
+	}
+
+	return r.albumService.GetAllAlbums(p.Context, opts)
+}
+
+func (r *resolvers) album(p gql.ResolveParams) (interface{}, error) {
+	id, _ := p.Args["id"].(string)
+	return albumLoaderFromContext(p.Context).Load(p.Context, id), nil
+}
+
+func (r *resolvers) jsonPosts(p gql.ResolveParams) (interface{}, error) {
+	return r.albumService.GetFromThirdPartyAPI(p.Context)
+}
+
+func (r *resolvers) createAlbum(p gql.ResolveParams) (interface{}, error) {
+	input, _ := p.Args["input"].(map[string]interface{})
+	album := dto.Album{}
+	album.ID, _ = input["id"].(string)
+	album.Title, _ = input["title"].(string)
+	album.Artist, _ = input["artist"].(string)
+	album.ReleaseDate, _ = input["releaseDate"].(string)
+	album.Genre, _ = input["genre"].(string)
+	album.TrackCount, _ = input["trackCount"].(int)
+	if err := validation.Struct(album); err != nil {
+		return nil, inputFieldErrors(err)
+	}
+
+	entityAlbum, err := dto.BuildAlbumEntity(album)
+	if err != nil {
+		return nil, inputFieldErrors(err)
+	}
+
+	id, err := r.albumService.CreateAlbum(p.Context, entityAlbum)
+	if err != nil {
+		return nil, err
+	}
+	return createAlbumPayload{ID: id}, nil
+}
+
+// inputFieldNames maps the JSON names validation reports fields by to the fields of CreateAlbumInput
+var inputFieldNames = map[string]string{"release_date": "releaseDate", "track_count": "trackCount"}
+
+// inputFieldErrors reports the fields of a ValidationError by their path in the mutation arguments
+func inputFieldErrors(err error) error {
+	fields := errors.ValidationDetails(err)
+	if fields == nil {
+		return err
+	}
+	renamed := errors.NewValidationError()
+	for _, field := range fields {
+		name := field.Field
+		if inputName, ok := inputFieldNames[name]; ok {
+			name = inputName
+		}
+		renamed.Add("input."+name, field.Code, field.Message)
+	}
+	return renamed
+}
+
+// rootFieldScopes are the scopes each root field requires, those of the REST routes serving the
+// same data; jsonposts only requires an authenticated caller, as GET /api/v1/jsonposts does
+var rootFieldScopes = map[string][]string{
+	"Query.albums":         {entity.ScopeAlbumsRead},
+	"Query.album":          {entity.ScopeAlbumsRead},
+	"Query.jsonposts":      {},
+	"Mutation.createAlbum": {entity.ScopeAlbumsWrite},
+}
+
+// fieldScopes returns the scopes a root field requires. Fields missing from rootFieldScopes are
+// kept to admins, so that a new field is not open before its scopes are decided.
+func fieldScopes(typeName, fieldName string) []string {
+	if scopes, ok := rootFieldScopes[typeName+"."+fieldName]; ok {
+		return scopes
+	}
+	return []string{entity.ScopeAdmin}
+}
+
+// authorized protects the root fields of typeName with the checks of middleware.AuthMiddleware and
+// middleware.ScopeMiddleware, applied to the credentials of the request, so that each field of a
+// query fails or resolves on its own. Resolvers find the claims of the caller in their context.
+func authorized(typeName string, fields gql.Fields) gql.Fields {
+	for name, field := range fields {
+		scopes := fieldScopes(typeName, name)
+		resolve := field.Resolve
+		field.Resolve = func(p gql.ResolveParams) (interface{}, error) {
+			claims, err := authorize(p.Context, scopes...)
+			if err != nil {
+				return nil, newError(err)
+			}
+			p.Context = auth.WithClaims(p.Context, claims)
+			return resolve(p)
+		}
+	}
+	return fields
+}
+
+// auditMethod is the method of the audit records of mutations, next to the HTTP methods of REST requests
+const auditMethod = "GRAPHQL"
+
+// audited records every call of the mutation fields with recorder, as middleware.AuditMiddleware
+// records the REST album writes, under the route "mutation <field>". The record names the album the
+// mutation returns, or the one of its input when it fails, with the snapshots the album service
+// captured around its write. As on the REST routes, callers whose credentials are rejected are not
+// recorded, while those lacking a scope are recorded as denied.
+func audited(recorder middleware.AuditRecorder, fields gql.Fields) gql.Fields {
+	if recorder == nil {
+		return fields
+	}
+	for name, field := range fields {
+		route := "mutation " + name
+		resolve := field.Resolve
+		field.Resolve = func(p gql.ResolveParams) (interface{}, error) {
+			p.Context = entity.WithAuditChange(p.Context, &entity.AuditChange{})
+			result, err := resolve(p)
+			claims, ok := authenticated(p.Context)
+			if !ok {
+				return result, err
+			}
+
+			statusCode := http.StatusOK
+			if err != nil {
+				statusCode = problem.Status(errors.KindOf(err))
+			}
+			ctx := auth.WithClaims(p.Context, claims)
+			recorder.RecordAudit(middleware.NewAuditRecord(ctx, auditMethod, route, auditTarget(p, result), statusCode))
+			return result, err
+		}
+	}
+	return fields
+}
+
+// auditTarget returns the ID of the album a mutation returned, or else of the one in its input
+func auditTarget(p gql.ResolveParams, result interface{}) string {
+	if payload, ok := result.(createAlbumPayload); ok {
+		return payload.ID
+	}
+	input, _ := p.Args["input"].(map[string]interface{})
+	id, _ := input["id"].(string)
+	return id
+}
+
+// optionalString resolves an optional string field of a T source to null when it is empty
+func optionalString[T any](get func(T) string) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		source, ok := p.Source.(T)
+		if !ok || get(source) == "" {
+			return nil, nil
+		}
+		return get(source), nil
+	}
+}
@@ -0,0 +1,177 @@
+package album
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/grpc/albumpb"
+	"boilerplate/app/presentation/rest/validation"
+	albumservice "boilerplate/app/usecase/interface"
+)
+
+// streamPageSize is the number of albums StreamAlbums fetches per page, the largest page the usecase allows
+const streamPageSize = 100
+
+// Server implements albumpb.AlbumServiceServer on the album usecase.
+// Handlers return domain errors; interceptor.ErrorUnaryInterceptor turns them into statuses.
+type Server struct {
+	albumpb.UnimplementedAlbumServiceServer
+	albumService albumservice.AlbumInterface
+}
+
+// NewServer creates a new album gRPC server
+func NewServer(albumService albumservice.AlbumInterface) *Server {
+	return &Server{albumService: albumService}
+}
+
+// ListAlbums returns one page of albums
+func (s *Server) ListAlbums(ctx context.Context, req *albumpb.ListAlbumsRequest) (*albumpb.ListAlbumsResponse, error) {
+	opts, err := albumListOptions(req.GetSort(), req.GetTitlePrefix(), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	opts.Limit = int(req.GetLimit())
+
+	page, err := s.albumService.GetAllAlbums(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &albumpb.ListAlbumsResponse{
+		Albums:     albumsToProto(page.Albums),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}, nil
+}
+
+// StreamAlbums sends every album matching the request, following the page cursors until the last page
+func (s *Server) StreamAlbums(req *albumpb.StreamAlbumsRequest, stream albumpb.AlbumService_StreamAlbumsServer) error {
+	opts, err := albumListOptions(req.GetSort(), req.GetTitlePrefix(), "")
+	if err != nil {
+		return err
+	}
+	opts.Limit = streamPageSize
+
+	for {
+		page, err := s.albumService.GetAllAlbums(stream.Context(), opts)
+		if err != nil {
+			return err
+		}
+		for _, album := range page.Albums {
+			if err := stream.Send(albumToProto(album)); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		if opts.Cursor, err = dto.DecodeAlbumCursor(page.NextCursor); err != nil {
+			return err
+		}
+	}
+}
+
+// GetAlbum returns a single album
+func (s *Server) GetAlbum(ctx context.Context, req *albumpb.GetAlbumRequest) (*albumpb.Album, error) {
+	album, err := s.albumService.GetAlbumByID(ctx, req.GetId(), entity.AlbumGetOptions{IncludeTracks: req.GetIncludeTracks()})
+	if err != nil {
+		return nil, err
+	}
+	return albumToProto(album), nil
+}
+
+// CreateAlbum stores a new album, applying the same rules as the REST API
+func (s *Server) CreateAlbum(ctx context.Context, req *albumpb.CreateAlbumRequest) (*albumpb.CreateAlbumResponse, error) {
+	album := dto.Album{
+		ID:          req.GetId(),
+		Title:       req.GetTitle(),
+		Artist:      req.GetArtist(),
+		ReleaseDate: req.GetReleaseDate(),
+		Genre:       req.GetGenre(),
+		TrackCount:  int(req.GetTrackCount()),
+	}
+	if err := validation.Struct(album); err != nil {
+		return nil, err
+	}
+
+	entityAlbum, err := dto.BuildAlbumEntity(album)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.albumService.CreateAlbum(ctx, entityAlbum)
+	if err != nil {
+		return nil, err
+	}
+	return &albumpb.CreateAlbumResponse{Id: id}, nil
+}
+
+// albumListOptions reads the listing options shared by ListAlbums and StreamAlbums
+func albumListOptions(sort, titlePrefix, cursor string) (entity.AlbumListOptions, error) {
+	opts := entity.AlbumListOptions{TitlePrefix: titlePrefix}
+
+	if strings.HasPrefix(sort, "-") {
+		opts.Descending = true
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	opts.SortBy = entity.AlbumSortField(sort)
+
+	if cursor != "" {
+		decoded, err := dto.DecodeAlbumCursor(cursor)
+		if err != nil {
+			return entity.AlbumListOptions{}, errors.NewValidationError(errors.FieldError{
+				Field: "cursor", Code: "malformed", Message: "is not a cursor issued by this API",
+			})
+		}
+		opts.Cursor = decoded
+	}
+
+	return opts, nil
+}
+
+func albumsToProto(albums []dto.Album) []*albumpb.Album {
+	result := make([]*albumpb.Album, len(albums))
+	for i, album := range albums {
+		result[i] = albumToProto(album)
+	}
+	return result
+}
+
+func albumToProto(album dto.Album) *albumpb.Album {
+	result := &albumpb.Album{
+		Id:          album.ID,
+		Title:       album.Title,
+		Artist:      album.Artist,
+		ReleaseDate: album.ReleaseDate,
+		Genre:       album.Genre,
+		TrackCount:  int32(album.TrackCount),
+		Version:     album.Version,
+		CreatedAt:   timestampToProto(album.CreatedAt),
+		UpdatedAt:   timestampToProto(album.UpdatedAt),
+	}
+	for _, track := range album.Tracks {
+		result.Tracks = append(result.Tracks, &albumpb.Track{
+			Id:              track.ID,
+			AlbumId:         track.AlbumID,
+			Position:        int32(track.Position),
+			Title:           track.Title,
+			DurationSeconds: int32(track.DurationSeconds),
+			CreatedAt:       timestampToProto(track.CreatedAt),
+			UpdatedAt:       timestampToProto(track.UpdatedAt),
+		})
+	}
+	return result
+}
+
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,346 @@
+package album_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/auth"
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/grpc/album"
+	"boilerplate/app/presentation/grpc/albumpb"
+	"boilerplate/app/presentation/grpc/server"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+// testSecret signs the tokens of authorized calls
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// dial serves the album service through the full interceptor chain on an in-memory listener
+func dial(t *testing.T, albumService *mocks.AlbumInterface) albumpb.AlbumServiceClient {
+	return dialAudited(t, albumService, nil)
+}
+
+// dialAudited is dial with the album writes recorded with auditRecorder
+func dialAudited(t *testing.T, albumService *mocks.AlbumInterface, auditRecorder middleware.AuditRecorder) albumpb.AlbumServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	keys, err := auth.NewKeySet(auth.KeySources{HMACSecret: testSecret}, 0)
+	require.NoError(t, err)
+	srv := server.NewServer(album.NewServer(albumService), &config.AppConfig{HandlerTimeout: 5 * time.Second}, auth.NewVerifier(keys), stubAPIKeys{}, auditRecorder)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return albumpb.NewAlbumServiceClient(conn)
+}
+
+// stubAPIKeys accepts the key "reader-key" only, granted albums:read
+type stubAPIKeys struct{}
+
+func (stubAPIKeys) VerifyAPIKey(_ context.Context, key string) (entity.APIKey, error) {
+	if key != "reader-key" {
+		return entity.APIKey{}, customerr.ErrAPIKeyInvalid
+	}
+	return entity.APIKey{ID: "k1", Scopes: []string{entity.ScopeAlbumsRead}}, nil
+}
+
+// authorized returns a context carrying a valid bearer token granting the album scopes
+func authorized() context.Context {
+	return authorizedWithScope(entity.ScopeAlbumsRead + " " + entity.ScopeAlbumsWrite)
+}
+
+// authorizedWithScope returns a context carrying a valid bearer token with the given scope claim
+func authorizedWithScope(scope string) context.Context {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "user-1",
+		"scope": scope,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testSecret))
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestServer_ListAlbums(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cursor := &entity.AlbumCursor{SortBy: entity.AlbumSortByTitle, Descending: true, SortValue: "Abc", ID: "4"}
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{
+		Limit:       5,
+		SortBy:      entity.AlbumSortByTitle,
+		Descending:  true,
+		TitlePrefix: "Ab",
+		Cursor:      cursor,
+	}).Return(dto.AlbumList{Albums: []dto.Album{{ID: "1", Title: "Abbey Road", CreatedAt: &createdAt}}, NextCursor: "next"}, nil)
+
+	resp, err := dial(t, albumService).ListAlbums(authorized(), &albumpb.ListAlbumsRequest{
+		Limit:       5,
+		Sort:        "-title",
+		TitlePrefix: "Ab",
+		Cursor:      dto.EncodeAlbumCursor(cursor),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Albums, 1)
+	assert.Equal(t, "Abbey Road", resp.Albums[0].Title)
+	assert.Equal(t, createdAt, resp.Albums[0].CreatedAt.AsTime())
+	assert.Equal(t, "next", resp.NextCursor)
+}
+
+func TestServer_ListAlbums_InvalidCursor(t *testing.T) {
+	_, err := dial(t, mocks.NewAlbumInterface(t)).ListAlbums(authorized(), &albumpb.ListAlbumsRequest{Cursor: "not-a-cursor"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_StreamAlbums(t *testing.T) {
+	next := &entity.AlbumCursor{SortBy: entity.AlbumSortByID, SortValue: "2", ID: "2"}
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{Limit: 100, SortBy: entity.AlbumSortByID}).
+		Return(dto.AlbumList{Albums: []dto.Album{{ID: "1", Title: "One"}, {ID: "2", Title: "Two"}}, NextCursor: dto.EncodeAlbumCursor(next)}, nil)
+	albumService.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{Limit: 100, SortBy: entity.AlbumSortByID, Cursor: next}).
+		Return(dto.AlbumList{Albums: []dto.Album{{ID: "3", Title: "Three"}}}, nil)
+
+	stream, err := dial(t, albumService).StreamAlbums(authorized(), &albumpb.StreamAlbumsRequest{Sort: "id"})
+	require.NoError(t, err)
+
+	var ids []string
+	for {
+		album, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, album.Id)
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestServer_StreamAlbums_Error(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, errors.New("db error"))
+
+	stream, err := dial(t, albumService).StreamAlbums(authorized(), &albumpb.StreamAlbumsRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestServer_GetAlbum(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{IncludeTracks: true}).
+		Return(dto.Album{ID: "1", Title: "Blue Train", Tracks: []dto.Track{{ID: "t1", AlbumID: "1", Position: 1, Title: "Blue Train"}}}, nil)
+
+	album, err := dial(t, albumService).GetAlbum(authorized(), &albumpb.GetAlbumRequest{Id: "1", IncludeTracks: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Blue Train", album.Title)
+	require.Len(t, album.Tracks, 1)
+	assert.Equal(t, int32(1), album.Tracks[0].Position)
+}
+
+func TestServer_GetAlbum_NotFound(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{}, customerr.ErrAlbumNotFound)
+
+	_, err := dial(t, albumService).GetAlbum(authorized(), &albumpb.GetAlbumRequest{Id: "1"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "album_not_found", st.Details()[0].(*errdetails.ErrorInfo).Reason)
+}
+
+func TestServer_CreateAlbum(t *testing.T) {
+	releaseDate := time.Date(1958, 1, 1, 0, 0, 0, 0, time.UTC)
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("CreateAlbum", mock.Anything, entity.Album{Title: "Blue Train", Genre: "jazz", ReleaseDate: &releaseDate}).Return("A1", nil)
+
+	resp, err := dial(t, albumService).CreateAlbum(authorized(), &albumpb.CreateAlbumRequest{Title: "Blue Train", Genre: "jazz", ReleaseDate: "1958-01-01"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "A1", resp.Id)
+}
+
+func TestServer_CreateAlbum_Invalid(t *testing.T) {
+	_, err := dial(t, mocks.NewAlbumInterface(t)).CreateAlbum(authorized(), &albumpb.CreateAlbumRequest{Id: "not valid!", Genre: "polka"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field+":"+violation.Reason)
+			}
+		}
+	}
+	assert.Equal(t, []string{"id:pattern", "title:required", "genre:enum"}, fields)
+}
+
+func TestServer_CreateAlbum_Exists(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("CreateAlbum", mock.Anything, mock.Anything).Return("", customerr.ErrAlbumExists)
+
+	_, err := dial(t, albumService).CreateAlbum(authorized(), &albumpb.CreateAlbumRequest{Id: "A1", Title: "Blue Train"})
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+// auditRecorderFunc adapts a function to middleware.AuditRecorder
+type auditRecorderFunc func(record entity.AuditRecord)
+
+func (f auditRecorderFunc) RecordAudit(record entity.AuditRecord) {
+	f(record)
+}
+
+func TestServer_CreateAlbum_Audit(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(a *mocks.AlbumInterface)
+		ctx       context.Context
+		expected  []entity.AuditRecord
+	}{
+		{
+			name: "Created",
+			setupMock: func(a *mocks.AlbumInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("A1", nil).Once()
+			},
+			ctx: authorized(),
+			expected: []entity.AuditRecord{{
+				Actor: "user:user-1", RequestID: "req-1", Method: "GRPC", Route: albumpb.AlbumService_CreateAlbum_FullMethodName, TargetID: "A1",
+				Outcome: entity.AuditOutcomeSuccess, StatusCode: http.StatusOK,
+			}},
+		},
+		{
+			name: "Conflict",
+			setupMock: func(a *mocks.AlbumInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("", customerr.ErrAlbumExists).Once()
+			},
+			ctx: authorized(),
+			expected: []entity.AuditRecord{{
+				Actor: "user:user-1", RequestID: "req-1", Method: "GRPC", Route: albumpb.AlbumService_CreateAlbum_FullMethodName, TargetID: "A1",
+				Outcome: entity.AuditOutcomeFailure, StatusCode: http.StatusConflict,
+			}},
+		},
+		{
+			name: "Unauthorized",
+			ctx:  authorizedWithScope(entity.ScopeAlbumsRead),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albumService := mocks.NewAlbumInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(albumService)
+			}
+			records := make(chan entity.AuditRecord, 1)
+			client := dialAudited(t, albumService, auditRecorderFunc(func(record entity.AuditRecord) { records <- record }))
+
+			ctx := metadata.AppendToOutgoingContext(tt.ctx, "x-request-id", "req-1")
+			_, _ = client.CreateAlbum(ctx, &albumpb.CreateAlbumRequest{Id: "A1", Title: "Blue Train"})
+
+			close(records)
+			var recorded []entity.AuditRecord
+			for record := range records {
+				assert.False(t, record.OccurredAt.IsZero())
+				record.OccurredAt = time.Time{}
+				recorded = append(recorded, record)
+			}
+			assert.Equal(t, tt.expected, recorded)
+		})
+	}
+}
+
+func TestServer_Unauthenticated(t *testing.T) {
+	client := dial(t, mocks.NewAlbumInterface(t))
+
+	_, err := client.GetAlbum(context.Background(), &albumpb.GetAlbumRequest{Id: "1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := client.StreamAlbums(context.Background(), &albumpb.StreamAlbumsRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServer_Scopes(t *testing.T) {
+	t.Run("Reads need albums:read", func(t *testing.T) {
+		_, err := dial(t, mocks.NewAlbumInterface(t)).GetAlbum(authorizedWithScope(entity.ScopeAlbumsWrite), &albumpb.GetAlbumRequest{Id: "1"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		stream, err := dial(t, mocks.NewAlbumInterface(t)).StreamAlbums(authorizedWithScope(entity.ScopeAlbumsWrite), &albumpb.StreamAlbumsRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Writes need albums:write", func(t *testing.T) {
+		_, err := dial(t, mocks.NewAlbumInterface(t)).CreateAlbum(authorizedWithScope(entity.ScopeAlbumsRead), &albumpb.CreateAlbumRequest{Title: "Blue Train"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+		assert.Equal(t, "Insufficient scope", st.Message())
+	})
+
+	t.Run("Admin grants every scope", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		albumService.On("CreateAlbum", mock.Anything, mock.Anything).Return("A1", nil)
+
+		_, err := dial(t, albumService).CreateAlbum(authorizedWithScope(entity.ScopeAdmin), &albumpb.CreateAlbumRequest{Title: "Blue Train"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestServer_APIKey(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{ID: "1", Title: "Blue Train"}, nil)
+	client := dial(t, albumService)
+
+	_, err := client.GetAlbum(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "reader-key"), &albumpb.GetAlbumRequest{Id: "1"})
+	require.NoError(t, err)
+
+	_, err = client.CreateAlbum(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "reader-key"), &albumpb.CreateAlbumRequest{Title: "Blue Train"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.GetAlbum(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "unknown"), &albumpb.GetAlbumRequest{Id: "1"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unauthenticated, st.Code())
+	assert.Equal(t, "Invalid API key", st.Message())
+}
+
+func TestServer_RequestID(t *testing.T) {
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{ID: "1", Title: "Blue Train"}, nil)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(authorized(), "x-request-id", "req-1")
+	_, err := dial(t, albumService).GetAlbum(ctx, &albumpb.GetAlbumRequest{Id: "1"}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+}
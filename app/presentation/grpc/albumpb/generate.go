@@ -0,0 +1,5 @@
+// Package albumpb holds the protobuf messages and gRPC service of the album API generated from album.proto.
+// Run go generate after changing album.proto; it needs protoc, protoc-gen-go and protoc-gen-go-grpc.
+package albumpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative album.proto
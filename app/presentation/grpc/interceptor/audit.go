@@ -0,0 +1,57 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/presentation/rest/problem"
+)
+
+// auditMethod is the method of the audit records of calls, next to the HTTP methods of REST requests
+const auditMethod = "GRPC"
+
+// identified is a request or response naming the album it is about
+type identified interface {
+	GetId() string
+}
+
+// AuditUnaryInterceptor records the calls of methods with recorder, as middleware.AuditMiddleware
+// records the REST album writes, under their full method name. The record names the album the
+// response carries, or else the one of the request, with the snapshots the album service captured
+// around its write. It runs after AuthUnaryInterceptor, so only the
+// calls of authorized callers are recorded. A nil recorder records nothing.
+func AuditUnaryInterceptor(recorder middleware.AuditRecorder, methods map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if recorder == nil || !methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		ctx = entity.WithAuditChange(ctx, &entity.AuditChange{})
+		resp, err := handler(ctx, req)
+
+		statusCode := http.StatusOK
+		if err != nil {
+			statusCode = problem.Status(errors.KindOf(err))
+		}
+		record := middleware.NewAuditRecord(ctx, auditMethod, info.FullMethod, auditTarget(req, resp), statusCode)
+		record.RequestID = requestIDFromContext(ctx)
+		recorder.RecordAudit(record)
+		return resp, err
+	}
+}
+
+// auditTarget returns the ID of the album named by the response, or else by the request
+func auditTarget(req, resp interface{}) string {
+	if r, ok := resp.(identified); ok && r.GetId() != "" {
+		return r.GetId()
+	}
+	if r, ok := req.(identified); ok {
+		return r.GetId()
+	}
+	return ""
+}
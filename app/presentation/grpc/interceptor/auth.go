@@ -0,0 +1,76 @@
+package interceptor
+
+import (
+	"context"
+	stderrors "errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/auth"
+)
+
+// MethodScopes maps the full name of each method to the scopes its callers must be granted
+type MethodScopes map[string][]string
+
+// scopes returns the scopes method requires. Methods missing from the map are kept to admins, so
+// that a new method is not open before its scopes are decided.
+func (m MethodScopes) scopes(method string) []string {
+	if scopes, ok := m[method]; ok {
+		return scopes
+	}
+	return []string{entity.ScopeAdmin}
+}
+
+// authorize checks the authorization and x-api-key metadata with auth.AuthenticateCredentials, as
+// the REST and GraphQL transports check their headers, then that the caller is granted the scopes
+// of method, answered with PermissionDenied by ErrorUnaryInterceptor. It returns a context carrying
+// the claims of the caller for auth.GetClaimsFromContext.
+func authorize(ctx context.Context, verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier, scopes MethodScopes, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var authorization, apiKey string
+	if values := md.Get("authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		apiKey = values[0]
+	}
+
+	claims, err := auth.AuthenticateCredentials(ctx, verifier, apiKeys, authorization, apiKey)
+	if stderrors.Is(err, auth.ErrCredentialsUnchecked) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, auth.Reason(err))
+	}
+	if !auth.HasScopes(claims, scopes.scopes(method)...) {
+		return nil, errors.ErrScopeRequired
+	}
+	return auth.WithClaims(ctx, claims), nil
+}
+
+// AuthUnaryInterceptor rejects unary calls without a valid bearer token or API key granting the scopes of the method
+func AuthUnaryInterceptor(verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier, scopes MethodScopes) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, verifier, apiKeys, scopes, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor rejects streaming calls without a valid bearer token or API key granting the scopes of the method
+func AuthStreamInterceptor(verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier, scopes MethodScopes) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), verifier, apiKeys, scopes, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,105 @@
+// Package interceptor holds the gRPC server interceptors, mirroring the gin middleware of the REST API
+// so that both transports authenticate, log, time out and recover the same way.
+package interceptor
+
+import (
+	"context"
+	stderrors "errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"boilerplate/app/domain/errors"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to every error status
+const ErrorDomain = "album"
+
+// kindCodes maps each error kind to its status code
+var kindCodes = map[errors.Kind]codes.Code{
+	errors.KindInternal:             codes.Internal,
+	errors.KindNotFound:             codes.NotFound,
+	errors.KindConflict:             codes.FailedPrecondition,
+	errors.KindInvalidInput:         codes.InvalidArgument,
+	errors.KindPreconditionFailed:   codes.Aborted,
+	errors.KindPreconditionRequired: codes.FailedPrecondition,
+	errors.KindUnsupportedMediaType: codes.InvalidArgument,
+	errors.KindUnauthenticated:      codes.Unauthenticated,
+	errors.KindNotAcceptable:        codes.InvalidArgument,
+	errors.KindUnavailable:          codes.Unavailable,
+	errors.KindTooLarge:             codes.ResourceExhausted,
+	errors.KindForbidden:            codes.PermissionDenied,
+	errors.KindTooManyRequests:      codes.ResourceExhausted,
+	errors.KindUnprocessable:        codes.FailedPrecondition,
+	errors.KindTimeout:              codes.DeadlineExceeded,
+}
+
+// errorCodes overrides the status code of errors whose kind is too coarse
+var errorCodes = map[errors.Code]codes.Code{
+	errors.ErrAlbumExists.Code: codes.AlreadyExists,
+}
+
+// Status describes err as a gRPC status. Errors that already are a status are kept as they are,
+// context errors become DeadlineExceeded or Canceled, and errors without a typed error in their
+// chain are reported as internal errors. The cause of an error is never exposed.
+func Status(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, context.Canceled) {
+		return status.FromContextError(err)
+	}
+
+	typed := errors.ErrInternalServer
+	var found *errors.Error
+	if stderrors.As(err, &found) {
+		typed = found
+	}
+
+	code, ok := errorCodes[typed.Code]
+	if !ok {
+		code = kindCodes[typed.Kind]
+	}
+
+	st := status.New(code, typed.Title)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: string(typed.Code), Domain: ErrorDomain}}
+	if fields := errors.ValidationDetails(err); len(fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+				Reason:      field.Code,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st
+}
+
+// ErrorUnaryInterceptor converts the errors returned by unary handlers with Status
+func ErrorUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, Status(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// ErrorStreamInterceptor converts the errors returned by streaming handlers with Status
+func ErrorStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return Status(err).Err()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,208 @@
+package interceptor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/grpc/interceptor"
+)
+
+var unaryInfo = &grpc.UnaryServerInfo{FullMethod: "/album.v1.AlbumService/GetAlbum"}
+
+// stubVerifier accepts the tokens "valid", granted albums:read, and "writer", granted albums:write
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(token string) (*jwtauth.Claims, error) {
+	switch token {
+	case "valid":
+		return &jwtauth.Claims{Subject: "user-1", Scopes: []string{"albums:read"}}, nil
+	case "writer":
+		return &jwtauth.Claims{Subject: "user-1", Scopes: []string{"albums:write"}}, nil
+	}
+	return nil, jwtauth.ErrTokenExpired
+}
+
+// stubAPIKeys accepts the key "k1-secret" only, granted albums:read, and fails to look up "k2-secret"
+type stubAPIKeys struct{}
+
+func (stubAPIKeys) VerifyAPIKey(_ context.Context, key string) (entity.APIKey, error) {
+	switch key {
+	case "k1-secret":
+		return entity.APIKey{ID: "k1", Scopes: []string{"albums:read"}}, nil
+	case "k2-secret":
+		return entity.APIKey{}, errors.New("connection refused")
+	}
+	return entity.APIKey{}, customerr.ErrAPIKeyInvalid
+}
+
+// unaryScopes requires albums:read to get an album
+var unaryScopes = interceptor.MethodScopes{"/album.v1.AlbumService/GetAlbum": {"albums:read"}}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedCode   codes.Code
+		expectedMsg    string
+		expectedReason string
+		expectedFields []*errdetails.BadRequest_FieldViolation
+	}{
+		{name: "Not found", err: fmt.Errorf("service: %w", customerr.ErrAlbumNotFound), expectedCode: codes.NotFound, expectedMsg: "Album not found", expectedReason: "album_not_found"},
+		{name: "Album exists", err: customerr.ErrAlbumExists, expectedCode: codes.AlreadyExists, expectedMsg: "Album already exists", expectedReason: "album_exists"},
+		{name: "Album has tracks", err: customerr.ErrAlbumHasTracks, expectedCode: codes.FailedPrecondition, expectedMsg: "Album still has tracks", expectedReason: "album_has_tracks"},
+		{name: "Version mismatch", err: customerr.ErrVersionMismatch, expectedCode: codes.Aborted, expectedMsg: "Album was modified by another request", expectedReason: "version_mismatch"},
+		{
+			name:           "Validation error",
+			err:            customerr.NewValidationError(customerr.FieldError{Field: "title", Code: "required", Message: "is required"}),
+			expectedCode:   codes.InvalidArgument,
+			expectedMsg:    "Invalid input",
+			expectedReason: "invalid_input",
+			expectedFields: []*errdetails.BadRequest_FieldViolation{{Field: "title", Reason: "required", Description: "is required"}},
+		},
+		{name: "Cause is hidden", err: customerr.ErrInternalServer.WithCause(errors.New("db password leaked")), expectedCode: codes.Internal, expectedMsg: "Internal Server Error", expectedReason: "internal"},
+		{name: "Untyped error", err: errors.New("boom"), expectedCode: codes.Internal, expectedMsg: "Internal Server Error", expectedReason: "internal"},
+		{name: "Deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), expectedCode: codes.DeadlineExceeded},
+		{name: "Status is kept", err: status.Error(codes.Unauthenticated, "Invalid token"), expectedCode: codes.Unauthenticated, expectedMsg: "Invalid token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := interceptor.Status(tt.err)
+
+			assert.Equal(t, tt.expectedCode, st.Code())
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, st.Message())
+			}
+
+			var reason string
+			var fields []*errdetails.BadRequest_FieldViolation
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = d.Reason
+					assert.Equal(t, interceptor.ErrorDomain, d.Domain)
+				case *errdetails.BadRequest:
+					fields = d.FieldViolations
+				}
+			}
+			assert.Equal(t, tt.expectedReason, reason)
+			require.Equal(t, len(tt.expectedFields), len(fields))
+			for i := range fields {
+				assert.Equal(t, tt.expectedFields[i].Field, fields[i].Field)
+				assert.Equal(t, tt.expectedFields[i].Reason, fields[i].Reason)
+				assert.Equal(t, tt.expectedFields[i].Description, fields[i].Description)
+			}
+		})
+	}
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name           string
+		metadata       metadata.MD
+		method         string
+		expectedCaller string
+		expectedCode   codes.Code
+		expectedMsg    string
+	}{
+		{name: "Valid token", metadata: metadata.Pairs("authorization", "Bearer valid"), expectedCaller: "user:user-1", expectedCode: codes.OK},
+		{name: "Valid API key", metadata: metadata.Pairs("x-api-key", "k1-secret"), expectedCaller: "api-key:k1", expectedCode: codes.OK},
+		{name: "Missing header", expectedCode: codes.Unauthenticated, expectedMsg: "Authorization header required"},
+		{name: "Wrong scheme", metadata: metadata.Pairs("authorization", "Basic dXNlcjpwYXNz"), expectedCode: codes.Unauthenticated, expectedMsg: "Invalid Authorization header format"},
+		{name: "Rejected token", metadata: metadata.Pairs("authorization", "Bearer expired"), expectedCode: codes.Unauthenticated, expectedMsg: "Token expired"},
+		{name: "Rejected API key", metadata: metadata.Pairs("x-api-key", "nope"), expectedCode: codes.Unauthenticated, expectedMsg: "Invalid API key"},
+		{name: "Both credentials", metadata: metadata.Pairs("authorization", "Bearer valid", "x-api-key", "k1-secret"), expectedCode: codes.Unauthenticated, expectedMsg: "Send either an Authorization or an X-API-Key header, not both"},
+		{name: "API key lookup failure", metadata: metadata.Pairs("x-api-key", "k2-secret"), expectedCode: codes.Internal, expectedMsg: "Internal Server Error"},
+		{name: "Missing scope", metadata: metadata.Pairs("authorization", "Bearer writer"), expectedCode: codes.PermissionDenied, expectedMsg: "Insufficient scope"},
+		{name: "Method without scopes is kept to admins", metadata: metadata.Pairs("authorization", "Bearer valid"), method: "/album.v1.AlbumService/DeleteAlbum", expectedCode: codes.PermissionDenied, expectedMsg: "Insufficient scope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.metadata)
+			}
+			info := unaryInfo
+			if tt.method != "" {
+				info = &grpc.UnaryServerInfo{FullMethod: tt.method}
+			}
+
+			// The error interceptor reports the domain errors of the auth interceptor, as in the server
+			authorize := interceptor.AuthUnaryInterceptor(stubVerifier{}, stubAPIKeys{}, unaryScopes)
+			_, err := interceptor.ErrorUnaryInterceptor()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return authorize(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					claims, ok := auth.GetClaimsFromContext(ctx)
+					require.True(t, ok)
+					assert.Equal(t, tt.expectedCaller, auth.Caller(claims))
+					return "ok", nil
+				})
+			})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestTimeoutUnaryInterceptor(t *testing.T) {
+	cfg := &config.AppConfig{HandlerTimeout: 20 * time.Millisecond}
+
+	t.Run("Handler finishes in time", func(t *testing.T) {
+		resp, err := interceptor.TimeoutUnaryInterceptor(cfg)(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+			return "ok", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+
+	t.Run("Handler is too slow", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		_, err := interceptor.TimeoutUnaryInterceptor(cfg)(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			<-release
+			return "late", nil
+		})
+
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("Panic reaches the caller", func(t *testing.T) {
+		assert.PanicsWithValue(t, "boom", func() {
+			_, _ = interceptor.TimeoutUnaryInterceptor(cfg)(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+				panic("boom")
+			})
+		})
+	})
+}
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	_, err := interceptor.RecoveryUnaryInterceptor()(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "Internal Server Error", status.Convert(err).Message())
+}
@@ -0,0 +1,69 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the metadata key carrying the ID that ties a call to its logs, like the X-Request-ID header
+const RequestIDKey = "x-request-id"
+
+// requestID returns the request ID sent by the client, or a new one, and echoes it in the response header
+func requestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := ""
+	if values := md.Get(RequestIDKey); len(values) > 0 {
+		id = values[0]
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	return id
+}
+
+type requestIDKey struct{}
+
+// requestIDFromContext returns the request ID the logging interceptors gave the call
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logCall prints a call the way middleware.LatencyLogger prints a request
+func logCall(ctx context.Context, method string, id string, start time.Time, err error) {
+	client := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		client = p.Addr.String()
+	}
+	fmt.Printf("[%s] %s - Request ID: %s, Code: %s, Latency: %v\n", client, method, id, status.Code(err), time.Since(start))
+}
+
+// LoggingUnaryInterceptor logs the outcome and the time taken by each unary call
+func LoggingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		id := requestID(ctx)
+		resp, err := handler(context.WithValue(ctx, requestIDKey{}, id), req)
+		logCall(ctx, info.FullMethod, id, start, err)
+		return resp, err
+	}
+}
+
+// LoggingStreamInterceptor logs the outcome and the time taken by each streaming call
+func LoggingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		id := requestID(ss.Context())
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), requestIDKey{}, id)})
+		logCall(ss.Context(), info.FullMethod, id, start, err)
+		return err
+	}
+}
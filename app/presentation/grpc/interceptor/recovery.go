@@ -0,0 +1,37 @@
+package interceptor
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryUnaryInterceptor turns a panic in a unary handler into an Internal status, like gin.Recovery
+func RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("Panic recovered in %s: %v\n%s", info.FullMethod, p, debug.Stack())
+				err = status.Error(codes.Internal, "Internal Server Error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor turns a panic in a streaming handler into an Internal status
+func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("Panic recovered in %s: %v\n%s", info.FullMethod, p, debug.Stack())
+				err = status.Error(codes.Internal, "Internal Server Error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,66 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"boilerplate/app/infrastructure/config"
+)
+
+// TimeoutUnaryInterceptor bounds unary calls by the handler timeout, like middleware.TimeoutMiddleware.
+// The call is answered with DeadlineExceeded when the handler does not return in time.
+func TimeoutUnaryInterceptor(cfg *config.AppConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, cfg.HandlerTimeout)
+		defer cancel()
+
+		type result struct {
+			resp interface{}
+			err  error
+		}
+		finish := make(chan result, 1)
+		panicChan := make(chan interface{}, 1)
+
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicChan <- p
+				}
+			}()
+			resp, err := handler(ctx, req)
+			finish <- result{resp: resp, err: err}
+		}()
+
+		select {
+		case <-ctx.Done():
+			return nil, status.Error(codes.DeadlineExceeded, "Request timeout")
+		case p := <-panicChan:
+			// Re-panic on the calling goroutine so that the recovery interceptor sees it
+			panic(p)
+		case r := <-finish:
+			return r.resp, r.err
+		}
+	}
+}
+
+// TimeoutStreamInterceptor bounds streaming calls by the handler timeout
+func TimeoutStreamInterceptor(cfg *config.AppConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithTimeout(ss.Context(), cfg.HandlerTimeout)
+		defer cancel()
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream is a server stream whose context has been replaced
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
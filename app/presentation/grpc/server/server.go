@@ -0,0 +1,68 @@
+package server
+
+import (
+	"google.golang.org/grpc"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/grpc/albumpb"
+	"boilerplate/app/presentation/grpc/interceptor"
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+// methodScopes are the scopes each album method requires, those of the REST routes serving the same data
+var methodScopes = interceptor.MethodScopes{
+	albumpb.AlbumService_ListAlbums_FullMethodName:   {entity.ScopeAlbumsRead},
+	albumpb.AlbumService_StreamAlbums_FullMethodName: {entity.ScopeAlbumsRead},
+	albumpb.AlbumService_GetAlbum_FullMethodName:     {entity.ScopeAlbumsRead},
+	albumpb.AlbumService_CreateAlbum_FullMethodName:  {entity.ScopeAlbumsWrite},
+}
+
+// auditedMethods are the album methods recorded in the audit log, those changing albums
+var auditedMethods = map[string]bool{
+	albumpb.AlbumService_CreateAlbum_FullMethodName: true,
+}
+
+// Permissions lists what the methods of the album service require of their callers, for the
+// permissions listing of the REST API
+func Permissions() []auth.RoutePermission {
+	permissions := make([]auth.RoutePermission, 0, len(methodScopes))
+	for method, scopes := range methodScopes {
+		permissions = append(permissions, auth.RoutePermission{
+			Method:        "GRPC",
+			Path:          method,
+			Authenticated: true,
+			Scopes:        append([]string{}, scopes...),
+		})
+	}
+	return permissions
+}
+
+// NewServer creates the gRPC server serving the album service.
+// Interceptors run in the order listed: logging sees the final status of every call, recovery
+// turns panics into Internal, domain errors are mapped to statuses, then the timeout and auth apply,
+// and the album writes of authorized callers are recorded with auditRecorder unless it is nil.
+// Callers authenticate with a bearer token in the authorization metadata or, when apiKeys is not
+// nil, an API key in the x-api-key metadata.
+func NewServer(albumServer albumpb.AlbumServiceServer, cfg *config.AppConfig, verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier, auditRecorder middleware.AuditRecorder) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.LoggingUnaryInterceptor(),
+			interceptor.RecoveryUnaryInterceptor(),
+			interceptor.ErrorUnaryInterceptor(),
+			interceptor.TimeoutUnaryInterceptor(cfg),
+			interceptor.AuthUnaryInterceptor(verifier, apiKeys, methodScopes),
+			interceptor.AuditUnaryInterceptor(auditRecorder, auditedMethods),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.LoggingStreamInterceptor(),
+			interceptor.RecoveryStreamInterceptor(),
+			interceptor.ErrorStreamInterceptor(),
+			interceptor.TimeoutStreamInterceptor(cfg),
+			interceptor.AuthStreamInterceptor(verifier, apiKeys, methodScopes),
+		),
+	)
+	albumpb.RegisterAlbumServiceServer(srv, albumServer)
+	return srv
+}
@@ -0,0 +1,110 @@
+package album
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/dto"
+	v2 "boilerplate/app/presentation/rest/dto/v2"
+	"boilerplate/app/presentation/rest/negotiation"
+	"boilerplate/app/presentation/rest/validation"
+)
+
+// The API key handlers serve /v2/api-keys only, and answer JSON envelopes only.
+
+// GetAPIKeysHandler handles GET /v2/api-keys, listing every API key without the keys themselves
+func (c *Controller) GetAPIKeysHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	apiKeys, err := c.apiKeyService.GetAPIKeys(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAPIKeyCollection(apiKeys))
+}
+
+// CreateAPIKeyHandler handles POST /v2/api-keys. The answer is the only one carrying the key.
+func (c *Controller) CreateAPIKeyHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	var apiKey dto.APIKey
+	if err := ctx.ShouldBindJSON(&apiKey); err != nil {
+		c.handleError(ctx, validation.FromBindError(err))
+		return
+	}
+
+	created, err := c.apiKeyService.CreateAPIKey(ctx, dto.BuildAPIKeyEntity(apiKey))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", v2.APIKeyPath(created.ID))
+	c.render(ctx, http.StatusCreated, mediaType, v2.NewAPIKeyResource(created))
+}
+
+// GetAPIKeyByIDHandler handles GET /v2/api-keys/:id
+func (c *Controller) GetAPIKeyByIDHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	apiKey, err := c.apiKeyService.GetAPIKeyByID(ctx, ctx.Param("id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAPIKeyResource(apiKey))
+}
+
+// RotateAPIKeyHandler handles POST /v2/api-keys/:id/rotate, answering the replacement key. The
+// optional body sets how long the replaced key stays valid.
+func (c *Controller) RotateAPIKeyHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	var rotation dto.APIKeyRotation
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&rotation); err != nil {
+			c.handleError(ctx, validation.FromBindError(err))
+			return
+		}
+	}
+
+	rotated, err := c.apiKeyService.RotateAPIKey(ctx, ctx.Param("id"), time.Duration(rotation.GracePeriodSeconds)*time.Second)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", v2.APIKeyPath(rotated.ID))
+	c.render(ctx, http.StatusCreated, mediaType, v2.NewAPIKeyResource(rotated))
+}
+
+// RevokeAPIKeyHandler handles POST /v2/api-keys/:id/revoke. Revoked keys stay listed; revoking a
+// key twice is not an error.
+func (c *Controller) RevokeAPIKeyHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	revoked, err := c.apiKeyService.RevokeAPIKey(ctx, ctx.Param("id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAPIKeyResource(revoked))
+}
@@ -0,0 +1,145 @@
+package album_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestAPIKeyHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(365 * 24 * time.Hour)
+
+	tests := []struct {
+		name             string
+		setupMock        func(*mocks.APIKeyInterface)
+		method           string
+		url              string
+		body             string
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
+	}{
+		{
+			name: "List leaves the keys out",
+			setupMock: func(m *mocks.APIKeyInterface) {
+				m.On("GetAPIKeys", mock.Anything).Return([]dto.APIKey{{ID: "k1", Name: "billing", Prefix: "0123456789ab", Scopes: []string{"albums:read"}, LastUsedAt: &createdAt, CreatedAt: &createdAt, UpdatedAt: &createdAt}}, nil)
+			},
+			method:         "GET",
+			url:            "/v2/api-keys",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"id":"k1","name":"billing","prefix":"0123456789ab","scopes":["albums:read"],"expires_at":null,"last_used_at":"2024-01-02T03:04:05Z","revoked_at":null,"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z"}],` +
+				`"meta":{"count":1},"links":{"self":"/api/v2/api-keys"}}`,
+		},
+		{
+			name: "Create answers the key",
+			setupMock: func(m *mocks.APIKeyInterface) {
+				m.On("CreateAPIKey", mock.Anything, entity.APIKey{Name: "billing", Scopes: []string{"albums:read", "albums:write"}, ExpiresAt: &expiresAt}).
+					Return(dto.APIKey{ID: "k1", Name: "billing", Prefix: "0123456789ab", Key: "ak_0123456789ab_secret", Scopes: []string{"albums:read", "albums:write"}, ExpiresAt: &expiresAt}, nil)
+			},
+			method:         "POST",
+			url:            "/v2/api-keys",
+			body:           `{"name":"billing","scopes":["albums:read","albums:write"],"expires_at":"2025-01-01T03:04:05Z"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{"data":{"id":"k1","name":"billing","prefix":"0123456789ab","key":"ak_0123456789ab_secret","scopes":["albums:read","albums:write"],"expires_at":"2025-01-01T03:04:05Z","last_used_at":null,"revoked_at":null,"created_at":null,"updated_at":null},` +
+				`"meta":{},"links":{"self":"/api/v2/api-keys/k1"}}`,
+			expectedLocation: "/api/v2/api-keys/k1",
+		},
+		{
+			name:           "Create with an unknown scope",
+			setupMock:      func(*mocks.APIKeyInterface) {},
+			method:         "POST",
+			url:            "/v2/api-keys",
+			body:           `{"name":"billing","scopes":["albums:delete"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/v2/api-keys",` +
+				`"errors":[{"field":"scopes[0]","code":"enum","message":"must be one of: albums:read, albums:write, admin"}]}`,
+		},
+		{
+			name: "Rotate with a grace period",
+			setupMock: func(m *mocks.APIKeyInterface) {
+				m.On("RotateAPIKey", mock.Anything, "k1", time.Hour).
+					Return(dto.APIKey{ID: "k2", Name: "billing", Prefix: "ba9876543210", Key: "ak_ba9876543210_secret", Scopes: []string{"albums:read"}}, nil)
+			},
+			method:         "POST",
+			url:            "/v2/api-keys/k1/rotate",
+			body:           `{"grace_period_seconds":3600}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{"data":{"id":"k2","name":"billing","prefix":"ba9876543210","key":"ak_ba9876543210_secret","scopes":["albums:read"],"expires_at":null,"last_used_at":null,"revoked_at":null,"created_at":null,"updated_at":null},` +
+				`"meta":{},"links":{"self":"/api/v2/api-keys/k2"}}`,
+			expectedLocation: "/api/v2/api-keys/k2",
+		},
+		{
+			name: "Rotate a revoked key",
+			setupMock: func(m *mocks.APIKeyInterface) {
+				m.On("RotateAPIKey", mock.Anything, "k1", time.Duration(0)).Return(dto.APIKey{}, customerr.ErrAPIKeyInactive)
+			},
+			method:         "POST",
+			url:            "/v2/api-keys/k1/rotate",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"/problems/api_key_inactive","title":"API key is revoked or expired","status":409,"code":"api_key_inactive","instance":"/v2/api-keys/k1/rotate"}`,
+		},
+		{
+			name: "Revoke",
+			setupMock: func(m *mocks.APIKeyInterface) {
+				m.On("RevokeAPIKey", mock.Anything, "k1").Return(dto.APIKey{ID: "k1", Name: "billing", Prefix: "0123456789ab", Scopes: []string{"albums:read"}, RevokedAt: &createdAt}, nil)
+			},
+			method:         "POST",
+			url:            "/v2/api-keys/k1/revoke",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"id":"k1","name":"billing","prefix":"0123456789ab","scopes":["albums:read"],"expires_at":null,"last_used_at":null,"revoked_at":"2024-01-02T03:04:05Z","created_at":null,"updated_at":null},` +
+				`"meta":{},"links":{"self":"/api/v2/api-keys/k1"}}`,
+		},
+		{
+			name: "Get unknown key",
+			setupMock: func(m *mocks.APIKeyInterface) {
+				m.On("GetAPIKeyByID", mock.Anything, "k9").Return(dto.APIKey{}, customerr.ErrAPIKeyNotFound)
+			},
+			method:         "GET",
+			url:            "/v2/api-keys/k9",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/api_key_not_found","title":"API key not found","status":404,"code":"api_key_not_found","instance":"/v2/api-keys/k9"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyService := mocks.NewAPIKeyInterface(t)
+			tt.setupMock(apiKeyService)
+
+			controller := album.NewController(mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t), album.WithAPIKeyService(apiKeyService))
+
+			router := gin.New()
+			router.GET("/v2/api-keys", controller.GetAPIKeysHandler)
+			router.POST("/v2/api-keys", controller.CreateAPIKeyHandler)
+			router.GET("/v2/api-keys/:id", controller.GetAPIKeyByIDHandler)
+			router.POST("/v2/api-keys/:id/rotate", controller.RotateAPIKeyHandler)
+			router.POST("/v2/api-keys/:id/revoke", controller.RevokeAPIKeyHandler)
+
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package album
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+// GetAuditRecordsHandler handles GET /v1/audit, listing the audit records of mutating album requests
+// page by page, newest first. Query parameters: actor, target_id, route, outcome, from and to
+// (RFC 3339 times, from included and to excluded), limit and cursor. Answers JSON only.
+func (c *Controller) GetAuditRecordsHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	filter, err := parseAuditFilter(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	records, err := c.auditService.GetAuditRecords(ctx, filter)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAuditList(records))
+}
+
+// parseAuditFilter reads the audit listing filters from the query string
+func parseAuditFilter(ctx *gin.Context) (entity.AuditFilter, error) {
+	filter := entity.AuditFilter{
+		Actor:    ctx.Query("actor"),
+		TargetID: ctx.Query("target_id"),
+		Route:    ctx.Query("route"),
+		Outcome:  ctx.Query("outcome"),
+	}
+
+	invalid := errors.NewValidationError()
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			invalid.Add("limit", "type", "must be an integer")
+		}
+		filter.Limit = n
+	}
+	for _, bound := range []struct {
+		field string
+		value *time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		if value := ctx.Query(bound.field); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				invalid.Add(bound.field, "format", "must be an RFC 3339 date-time")
+			}
+			*bound.value = t
+		}
+	}
+	if token := ctx.Query("cursor"); token != "" {
+		cursor, err := dto.DecodeAuditCursor(token)
+		if err != nil {
+			invalid.Add("cursor", "malformed", "is not a cursor issued by this API")
+		}
+		filter.Cursor = cursor
+	}
+
+	return filter, invalid.OrNil()
+}
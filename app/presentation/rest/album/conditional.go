@@ -0,0 +1,114 @@
+package album
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/dto"
+)
+
+// albumNotModified answers 304 Not Modified to a conditional GET of an album the client already
+// holds, deciding from the album version alone, which the service reads without loading the album.
+// Any other request, including one embedding related resources, is left to the handler.
+func (c *Controller) albumNotModified(ctx *gin.Context, mediaType string) bool {
+	if !isConditional(ctx) || ctx.Query("include") != "" {
+		return false
+	}
+
+	// Errors are answered by the handler, which runs into them again when loading the album
+	version, err := c.albumService.GetAlbumVersion(ctx, ctx.Param("id"))
+	if err != nil || version.Version == 0 {
+		return false
+	}
+	return notModified(ctx, mediaType, albumETag(version.Version, mediaType), version.UpdatedAt)
+}
+
+// albumValidators returns the entity tag and last modification time of an album representation.
+// The entity tag of an album is its version and the media type, the version being what If-Match
+// takes back, unless tracks are embedded: their changes leave the album version and update time
+// as they were, so the tag is then computed from the content and the time left out.
+func albumValidators(mediaType string, album dto.Album) (string, *time.Time) {
+	if len(album.Tracks) > 0 {
+		return contentETag(mediaType, album), nil
+	}
+	if album.Version == 0 {
+		return contentETag(mediaType, album), album.UpdatedAt
+	}
+	return albumETag(album.Version, mediaType), album.UpdatedAt
+}
+
+// contentETag is a strong entity tag computed from the body of a response and its media type,
+// for resources without a version of their own such as pages of a list
+func contentETag(mediaType string, body interface{}) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.New()
+	hash.Write([]byte(mediaType + "\n"))
+	hash.Write(data)
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// notModified sets the ETag and Last-Modified headers, each when known, and answers 304 Not Modified
+// if the request shows the client already holds the representation they identify. The 304 names
+// mediaType as its Content-Type for the compression middleware, which decides Vary and the coding
+// of the tag from it and drops it.
+func notModified(ctx *gin.Context, mediaType, etag string, lastModified *time.Time) bool {
+	if etag != "" {
+		ctx.Header("ETag", etag)
+	}
+	if lastModified != nil {
+		ctx.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if !isFresh(ctx.Request.Header, etag, lastModified) {
+		return false
+	}
+	ctx.Header("Content-Type", mediaType)
+	ctx.Status(http.StatusNotModified)
+	return true
+}
+
+// isConditional tells whether a GET request carries a condition notModified evaluates
+func isConditional(ctx *gin.Context) bool {
+	return ctx.GetHeader("If-None-Match") != "" || ctx.GetHeader("If-Modified-Since") != ""
+}
+
+// isFresh evaluates If-None-Match, or when absent If-Modified-Since, as RFC 9110 section 13.2.2
+// orders them for GET. If-None-Match compares entity tags weakly; If-Modified-Since works at the
+// second precision of HTTP dates and is ignored when malformed.
+func isFresh(header http.Header, etag string, lastModified *time.Time) bool {
+	if values := header.Values("If-None-Match"); len(values) > 0 {
+		return etag != "" && etagListMatches(strings.Join(values, ","), etag)
+	}
+
+	ifModifiedSince := header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified == nil {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagListMatches tells whether a comma-separated list of entity tags, or "*", matches etag
+// under weak comparison, where the W/ prefix is disregarded
+func etagListMatches(list string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package album_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestConditionalGet(t *testing.T) {
+	// Set gin to test mode
+	gin.SetMode(gin.TestMode)
+
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	stored := dto.Album{ID: "1", Title: "Test", Version: 3, UpdatedAt: &updatedAt}
+	page := dto.AlbumList{Albums: []dto.Album{{ID: "1", Title: "Test"}}, NextCursor: "next"}
+
+	tests := []struct {
+		name                 string
+		setupMock            func(*mocks.AlbumInterface)
+		url                  string
+		headers              map[string]string
+		expectedStatus       int
+		expectedETag         string
+		expectedLastModified string
+	}{
+		{
+			name: "Album_NotModified_FromVersion",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 3, UpdatedAt: &updatedAt}, nil)
+			},
+			url:                  "/albums/1",
+			headers:              map[string]string{"If-None-Match": `"2-json", W/"3-json"`},
+			expectedStatus:       http.StatusNotModified,
+			expectedETag:         `"3-json"`,
+			expectedLastModified: "Tue, 02 Jan 2024 03:04:05 GMT",
+		},
+		{
+			name: "Album_Modified",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 3, UpdatedAt: &updatedAt}, nil)
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(stored, nil)
+			},
+			url:                  "/albums/1",
+			headers:              map[string]string{"If-None-Match": `"2-json"`},
+			expectedStatus:       http.StatusOK,
+			expectedETag:         `"3-json"`,
+			expectedLastModified: "Tue, 02 Jan 2024 03:04:05 GMT",
+		},
+		{
+			name: "Album_OtherMediaTypeHasOtherTag",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 3, UpdatedAt: &updatedAt}, nil)
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(stored, nil)
+			},
+			url:                  "/albums/1",
+			headers:              map[string]string{"If-None-Match": `"3-json"`, "Accept": "application/xml"},
+			expectedStatus:       http.StatusOK,
+			expectedETag:         `"3-xml"`,
+			expectedLastModified: "Tue, 02 Jan 2024 03:04:05 GMT",
+		},
+		{
+			name: "Album_NotModifiedSince",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 3, UpdatedAt: &updatedAt}, nil)
+			},
+			url:                  "/albums/1",
+			headers:              map[string]string{"If-Modified-Since": "Tue, 02 Jan 2024 03:04:05 GMT"},
+			expectedStatus:       http.StatusNotModified,
+			expectedETag:         `"3-json"`,
+			expectedLastModified: "Tue, 02 Jan 2024 03:04:05 GMT",
+		},
+		{
+			name: "Album_ModifiedSince",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 3, UpdatedAt: &updatedAt}, nil)
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(stored, nil)
+			},
+			url:                  "/albums/1",
+			headers:              map[string]string{"If-Modified-Since": "Tue, 02 Jan 2024 03:04:04 GMT"},
+			expectedStatus:       http.StatusOK,
+			expectedETag:         `"3-json"`,
+			expectedLastModified: "Tue, 02 Jan 2024 03:04:05 GMT",
+		},
+		{
+			name: "Album_IfNoneMatchTakesPrecedence",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 3, UpdatedAt: &updatedAt}, nil)
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(stored, nil)
+			},
+			url:                  "/albums/1",
+			headers:              map[string]string{"If-None-Match": `"2-json"`, "If-Modified-Since": "Tue, 02 Jan 2024 03:04:05 GMT"},
+			expectedStatus:       http.StatusOK,
+			expectedETag:         `"3-json"`,
+			expectedLastModified: "Tue, 02 Jan 2024 03:04:05 GMT",
+		},
+		{
+			name: "Album_NotFound",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{}, customerr.ErrAlbumNotFound)
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{}, customerr.ErrAlbumNotFound)
+			},
+			url:            "/albums/1",
+			headers:        map[string]string{"If-None-Match": "*"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "AlbumWithTracks_NotModified_FromContent",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{IncludeTracks: true}).
+					Return(dto.Album{ID: "1", Title: "Test", Tracks: []dto.Track{{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro", DurationSeconds: 60}}}, nil)
+			},
+			url:            "/albums/1?include=tracks",
+			headers:        map[string]string{"If-None-Match": `"bd929932e2dfda975d9f264542701296"`},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"bd929932e2dfda975d9f264542701296"`,
+		},
+		{
+			name: "List_NotModified",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{}).Return(page, nil)
+			},
+			url:            "/albums",
+			headers:        map[string]string{"If-None-Match": `"4e80cfbd150d51a5fe5af29ca2454d90"`},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"4e80cfbd150d51a5fe5af29ca2454d90"`,
+		},
+		{
+			name: "List_OtherMediaTypeHasOtherTag",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{}).Return(page, nil)
+			},
+			url:            "/albums",
+			headers:        map[string]string{"If-None-Match": `"4e80cfbd150d51a5fe5af29ca2454d90"`, "Accept": "text/csv"},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"9165c2e1a0ba9912bd385566eb79ba03"`,
+		},
+		{
+			name: "List_IfModifiedSinceIgnored",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{}).Return(page, nil)
+			},
+			url:            "/albums",
+			headers:        map[string]string{"If-Modified-Since": "Tue, 02 Jan 2024 03:04:05 GMT"},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4e80cfbd150d51a5fe5af29ca2454d90"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock service
+			albumService := mocks.NewAlbumInterface(t)
+			tt.setupMock(albumService)
+
+			controller := album.NewController(albumService, mocks.NewTrackInterface(t))
+
+			// Set up gin router
+			router := gin.New()
+			router.GET("/albums", controller.GetAlbumsHandler)
+			router.GET("/albums/:id", controller.GetAlbumByIDHandler)
+
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, tt.expectedLastModified, w.Header().Get("Last-Modified"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
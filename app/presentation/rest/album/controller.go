@@ -2,77 +2,372 @@ package album
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"boilerplate/app/domain/dto"
 	"boilerplate/app/domain/entity"
 	"boilerplate/app/domain/errors"
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/presentation/rest/negotiation"
+	"boilerplate/app/presentation/rest/problem"
+	"boilerplate/app/presentation/rest/validation"
 
 	albumservice "boilerplate/app/usecase/interface"
 )
 
 type Controller struct {
 	albumService albumservice.AlbumInterface
+	trackService albumservice.TrackInterface
+
+	// Registers the webhooks album events are delivered to
+	webhookService albumservice.WebhookInterface
+
+	// Issues the API keys of calling services
+	apiKeyService albumservice.APIKeyInterface
+
+	// Lists the audit log of mutating album requests
+	auditService albumservice.AuditInterface
+
+	// How often idle album change streams send a heartbeat
+	streamHeartbeat time.Duration
+}
+
+// Option customises optional Controller behaviour
+type Option func(*Controller)
+
+// WithStreamHeartbeat sets how often idle album change streams send a heartbeat
+func WithStreamHeartbeat(interval time.Duration) Option {
+	return func(c *Controller) {
+		if interval > 0 {
+			c.streamHeartbeat = interval
+		}
+	}
+}
+
+// WithWebhookService serves the webhook routes with the given service
+func WithWebhookService(webhookService albumservice.WebhookInterface) Option {
+	return func(c *Controller) {
+		c.webhookService = webhookService
+	}
+}
+
+// WithAPIKeyService serves the API key routes with the given service
+func WithAPIKeyService(apiKeyService albumservice.APIKeyInterface) Option {
+	return func(c *Controller) {
+		c.apiKeyService = apiKeyService
+	}
+}
+
+// WithAuditService serves the audit log route with the given service
+func WithAuditService(auditService albumservice.AuditInterface) Option {
+	return func(c *Controller) {
+		c.auditService = auditService
+	}
 }
 
 func NewController(
 	albumService albumservice.AlbumInterface,
+	trackService albumservice.TrackInterface,
+	opts ...Option,
 ) *Controller {
-	return &Controller{
-		albumService: albumService,
+	c := &Controller{
+		albumService:    albumService,
+		trackService:    trackService,
+		streamHeartbeat: DefaultStreamHeartbeat,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetAlbumsHandler handles GET requests to list albums page by page.
+// Query parameters: limit, cursor, sort (id, title or created_at, prefixed with "-" for descending) and title_prefix.
+// Pages carry an ETag computed from their content and are answered 304 to a matching If-None-Match.
 func (c *Controller) GetAlbumsHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
+		return
+	}
+
 	// headers, ok := middleware.GetCommonHeadersFromContext(ctx.Request.Context())
 	// if ok {
 	// 	log.Printf("Request ID: %s, User-Agent: %s", headers.RequestID, headers.UserAgent)
 	// }
-	data, err := c.albumService.GetAllAlbums(ctx)
+	page, err := c.listAlbums(ctx, false)
 	if err != nil {
 		// Handle the error
 		c.handleError(ctx, err)
 		return
 	}
-	ctx.IndentedJSON(http.StatusOK, data)
+	if notModified(ctx, mediaType, contentETag(mediaType, page), nil) {
+		return
+	}
+	c.renderAlbumListV1(ctx, mediaType, v1.NewAlbumList(page))
 }
 
-// CreateAlbumHandler handles POST requests to create a album
-func (c *Controller) CreateAlbumHandler(ctx *gin.Context) {
-	var album dto.Album
-	if err := ctx.ShouldBindJSON(&album); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+// listAlbums lists one page of albums, or of albums in the trash, as asked by the query string
+func (c *Controller) listAlbums(ctx *gin.Context, trashed bool) (dto.AlbumList, error) {
+	opts, err := parseAlbumListOptions(ctx)
+	if err != nil {
+		return dto.AlbumList{}, err
+	}
+	opts.Trashed = trashed
+
+	return c.albumService.GetAllAlbums(ctx, opts)
+}
+
+// parseAlbumListOptions reads the album listing options from the query string
+func parseAlbumListOptions(ctx *gin.Context) (entity.AlbumListOptions, error) {
+	opts := entity.AlbumListOptions{
+		TitlePrefix: ctx.Query("title_prefix"),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return entity.AlbumListOptions{}, errors.NewValidationError(errors.FieldError{
+				Field: "limit", Code: "type", Message: "must be an integer",
+			})
+		}
+		opts.Limit = n
+	}
+
+	sort := ctx.Query("sort")
+	if strings.HasPrefix(sort, "-") {
+		opts.Descending = true
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	opts.SortBy = entity.AlbumSortField(sort)
+
+	if token := ctx.Query("cursor"); token != "" {
+		cursor, err := dto.DecodeAlbumCursor(token)
+		if err != nil {
+			return entity.AlbumListOptions{}, errors.NewValidationError(errors.FieldError{
+				Field: "cursor", Code: "malformed", Message: "is not a cursor issued by this API",
+			})
+		}
+		opts.Cursor = cursor
+	}
+
+	return opts, nil
+}
+
+// SearchAlbumsHandler handles GET requests searching albums by title and artist.
+// Query parameters: q (required), limit and offset.
+func (c *Controller) SearchAlbumsHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
 		return
 	}
 
-	entityAlbum := entity.Album{
-		ID:    entity.AlbumID(album.ID),
-		Title: album.Title,
+	results, err := c.searchAlbums(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
 	}
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAlbumSearchResults(results))
+}
 
-	id, err := c.albumService.CreateAlbum(ctx, entityAlbum)
+func (c *Controller) searchAlbums(ctx *gin.Context) (dto.AlbumSearchResults, error) {
+	opts := entity.AlbumSearchOptions{Query: ctx.Query("q")}
+
+	invalid := errors.NewValidationError()
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			invalid.Add("limit", "type", "must be an integer")
+		}
+		opts.Limit = n
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil {
+			invalid.Add("offset", "type", "must be an integer")
+		}
+		opts.Offset = n
+	}
+	if err := invalid.OrNil(); err != nil {
+		return dto.AlbumSearchResults{}, err
+	}
+
+	return c.albumService.SearchAlbums(ctx, opts)
+}
+
+// CreateAlbumHandler handles POST requests to create a album.
+// The body may be JSON, XML or MessagePack, as its Content-Type says.
+func (c *Controller) CreateAlbumHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	id, err := c.createAlbum(ctx)
 	if err != nil {
 		c.handleError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"id": id, "message": "Album created successfully"})
+	c.render(ctx, http.StatusCreated, mediaType, v1.Created{ID: id, Message: "Album created successfully"})
+}
+
+// createAlbum stores the album in the request body and returns its ID
+func (c *Controller) createAlbum(ctx *gin.Context) (string, error) {
+	var album dto.Album
+	if err := negotiation.Bind(ctx, &album); err != nil {
+		return "", err
+	}
+
+	entityAlbum, err := dto.BuildAlbumEntity(album)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := c.albumService.CreateAlbum(ctx, entityAlbum)
+	if err != nil {
+		return "", err
+	}
+	middleware.SetAuditTarget(ctx, id)
+	return id, nil
 }
 
+// GetAlbumByIDHandler handles GET requests for a single album.
+// The include query parameter embeds related resources; "tracks" is the only one supported.
+// The album is answered 304 when If-None-Match or If-Modified-Since shows the client holds it.
 func (c *Controller) GetAlbumByIDHandler(ctx *gin.Context) {
-	id := ctx.Param("id")
-	album, err := c.albumService.GetAlbumByID(ctx, id)
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok || c.albumNotModified(ctx, mediaType) {
+		return
+	}
+
+	album, err := c.getAlbum(ctx)
 	if err != nil {
-		if err.Error() == "album not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "Album not found"})
-		} else {
-			c.handleError(ctx, err)
+		c.handleError(ctx, err)
+		return
+	}
+
+	etag, lastModified := albumValidators(mediaType, album)
+	if notModified(ctx, mediaType, etag, lastModified) {
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAlbum(album))
+}
+
+func (c *Controller) getAlbum(ctx *gin.Context) (dto.Album, error) {
+	var opts entity.AlbumGetOptions
+	if include := ctx.Query("include"); include != "" {
+		for _, resource := range strings.Split(include, ",") {
+			switch strings.TrimSpace(resource) {
+			case "tracks":
+				opts.IncludeTracks = true
+			default:
+				return dto.Album{}, errors.NewValidationError(errors.FieldError{
+					Field: "include", Code: "enum", Message: "must be one of: tracks",
+				})
+			}
 		}
+	}
+
+	return c.albumService.GetAlbumByID(ctx, ctx.Param("id"), opts)
+}
+
+// UpdateAlbumHandler handles PUT requests to replace an album.
+// The If-Match header must carry the album's current ETag, or "*".
+func (c *Controller) UpdateAlbumHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	updated, err := c.updateAlbum(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	setAlbumETag(ctx, updated.Version, mediaType)
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAlbum(updated))
+}
+
+func (c *Controller) updateAlbum(ctx *gin.Context) (dto.Album, error) {
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		return dto.Album{}, err
+	}
+
+	var album dto.Album
+	if err := ctx.ShouldBindJSON(&album); err != nil {
+		return dto.Album{}, validation.FromBindError(err)
+	}
+
+	entityAlbum, err := dto.BuildAlbumEntity(album)
+	if err != nil {
+		return dto.Album{}, err
+	}
+	entityAlbum.ID = entity.AlbumID(ctx.Param("id"))
+	entityAlbum.Version = version
+
+	return c.albumService.UpdateAlbum(ctx, entityAlbum)
+}
+
+// PatchAlbumHandler handles PATCH requests to partially update an album.
+// The If-Match header must carry the album's current ETag, or "*".
+func (c *Controller) PatchAlbumHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	updated, err := c.patchAlbum(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, album)
+	setAlbumETag(ctx, updated.Version, mediaType)
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAlbum(updated))
+}
+
+func (c *Controller) patchAlbum(ctx *gin.Context) (dto.Album, error) {
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		return dto.Album{}, err
+	}
+
+	var patch dto.AlbumPatch
+	if err := ctx.ShouldBindJSON(&patch); err != nil {
+		return dto.Album{}, validation.FromBindError(err)
+	}
+
+	patchEntity, err := dto.BuildAlbumPatchEntity(patch)
+	if err != nil {
+		return dto.Album{}, err
+	}
+
+	return c.albumService.PatchAlbum(ctx, ctx.Param("id"), patchEntity, version)
+}
+
+// DeleteAlbumHandler handles DELETE requests to remove an album.
+// The If-Match header must carry the album's current ETag, or "*".
+// It answers the same way in every API version.
+func (c *Controller) DeleteAlbumHandler(ctx *gin.Context) {
+	version, err := parseIfMatch(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	if err := c.albumService.DeleteAlbum(ctx, ctx.Param("id"), version); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
 }
 
 func (c *Controller) GetJsonPostHandler(ctx *gin.Context) {
@@ -85,23 +380,11 @@ func (c *Controller) GetJsonPostHandler(ctx *gin.Context) {
 	ctx.IndentedJSON(http.StatusOK, data)
 }
 
-// handleError is a helper method to manage error responses
+// handleError is a helper method to manage error responses; it answers with problem details
+// whose status follows the kind of the error and whose code identifies it
 func (c *Controller) handleError(ctx *gin.Context, err error) {
-	// Determine the HTTP status code based on the error type
-	status := http.StatusInternalServerError
-	message := "Internal Server Error"
-
-	if errors.IsAlbumNotFound(err) {
-		status = http.StatusNotFound
-		message = "Album not found"
-	} else if errors.IsInvalidInput(err) {
-		status = http.StatusBadRequest
-		message = "Invalid input"
-	}
-
 	// Log the error for debugging purposes
 	ctx.Error(err)
 
-	// Respond with the appropriate status and message
-	ctx.JSON(status, gin.H{"error": message})
+	problem.Write(ctx, err)
 }
@@ -29,29 +29,89 @@ func TestController(t *testing.T) {
 		method         string
 		url            string
 		body           interface{}
+		headers        map[string]string
 		expectedStatus int
 		expectedBody   string // Changed to string
+		expectedETag   string
 	}{
 		// GetAlbumsHandler tests
 		{
 			name: "GetAlbums_Success",
 			setupMock: func(m *mocks.AlbumInterface) {
-				m.On("GetAllAlbums", mock.Anything).Return([]dto.Album{{ID: "1", Title: "Test"}}, nil)
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{}).
+					Return(dto.AlbumList{Albums: []dto.Album{{ID: "1", Title: "Test"}}, NextCursor: "next"}, nil)
 			},
 			method:         "GET",
 			url:            "/albums",
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"id":"1","title":"Test"}]`,
+			expectedBody:   `{"albums":[{"id":"1","title":"Test"}],"next_cursor":"next"}`,
+			expectedETag:   `"4e80cfbd150d51a5fe5af29ca2454d90"`,
+		},
+		{
+			name: "GetAlbums_WithOptions",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{
+					Limit:       5,
+					SortBy:      entity.AlbumSortByTitle,
+					Descending:  true,
+					TitlePrefix: "Ab",
+					Cursor:      &entity.AlbumCursor{SortBy: entity.AlbumSortByTitle, Descending: true, SortValue: "Abc", ID: "4"},
+				}).Return(dto.AlbumList{Albums: []dto.Album{}}, nil)
+			},
+			method: "GET",
+			url: "/albums?limit=5&sort=-title&title_prefix=Ab&cursor=" + dto.EncodeAlbumCursor(
+				&entity.AlbumCursor{SortBy: entity.AlbumSortByTitle, Descending: true, SortValue: "Abc", ID: "4"},
+			),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"albums":[]}`,
+			expectedETag:   `"9f4fbcef07e1b99fa7bc36fa02273c3d"`,
+		},
+		{
+			name:           "GetAlbums_InvalidCursor",
+			method:         "GET",
+			url:            "/albums?cursor=not-a-cursor",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums","errors":[{"field":"cursor","code":"malformed","message":"is not a cursor issued by this API"}]}`,
 		},
 		{
 			name: "GetAlbums_Error",
 			setupMock: func(m *mocks.AlbumInterface) {
-				m.On("GetAllAlbums", mock.Anything).Return([]dto.Album{}, errors.New("db error"))
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{}).Return(dto.AlbumList{}, errors.New("db error"))
 			},
 			method:         "GET",
 			url:            "/albums",
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"error":"Internal Server Error"}`,
+			expectedBody:   `{"type":"/problems/internal","title":"Internal Server Error","status":500,"code":"internal","instance":"/albums"}`,
+		},
+
+		// SearchAlbumsHandler tests
+		{
+			name: "SearchAlbums_Success",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("SearchAlbums", mock.Anything, entity.AlbumSearchOptions{Query: "blue", Limit: 1, Offset: 2}).
+					Return(dto.AlbumSearchResults{
+						Results: []dto.AlbumSearchHit{{
+							Album:      dto.Album{ID: "1", Title: "Kind of Blue"},
+							Score:      1.5,
+							Highlights: map[string]string{"title": "Kind of <em>Blue</em>"},
+						}},
+						NextOffset: 3,
+					}, nil)
+			},
+			method:         "GET",
+			url:            "/albums/search?q=blue&limit=1&offset=2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"results":[{"album":{"id":"1","title":"Kind of Blue"},"score":1.5,"highlights":{"title":"Kind of \u003cem\u003eBlue\u003c/em\u003e"}}],"next_offset":3}`,
+		},
+		{
+			name:           "SearchAlbums_InvalidPaging",
+			method:         "GET",
+			url:            "/albums/search?q=blue&limit=ten&offset=x",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums/search","errors":[
+				{"field":"limit","code":"type","message":"must be an integer"},
+				{"field":"offset","code":"type","message":"must be an integer"}
+			]}`,
 		},
 
 		// CreateAlbumHandler tests
@@ -73,29 +133,243 @@ func TestController(t *testing.T) {
 			url:            "/albums",
 			body:           "invalid json",
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"error":"Invalid input"}`,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums","errors":[{"field":"body","code":"malformed","message":"request body is not valid JSON"}]}`,
+		},
+
+		{
+			name: "CreateAlbum_DuplicateID",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("CreateAlbum", mock.Anything, entity.Album{ID: entity.AlbumID("1"), Title: "Test"}).
+					Return("", customerr.ErrAlbumExists)
+			},
+			method:         "POST",
+			url:            "/albums",
+			body:           dto.Album{ID: "1", Title: "Test"},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"/problems/album_exists","title":"Album already exists","status":409,"code":"album_exists","instance":"/albums"}`,
+		},
+		{
+			name:           "CreateAlbum_InvalidReleaseDate",
+			method:         "POST",
+			url:            "/albums",
+			body:           dto.Album{ID: "1", Title: "Test", ReleaseDate: "yesterday"},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums","errors":[{"field":"release_date","code":"format","message":"must be a date formatted as YYYY-MM-DD"}]}`,
+		},
+		{
+			name:           "CreateAlbum_FieldErrors",
+			method:         "POST",
+			url:            "/albums",
+			body:           `{"id":"not an id!","title":"","genre":"polka","track_count":-1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums","errors":[
+				{"field":"id","code":"pattern","message":"must be 1 to 64 letters, digits, '-' or '_'"},
+				{"field":"title","code":"required","message":"is required"},
+				{"field":"genre","code":"enum","message":"must be one of: rock, pop, jazz, classical, hip-hop, electronic, folk, country, blues, metal, soundtrack, other"},
+				{"field":"track_count","code":"range","message":"must be at least 0"}
+			]}`,
+		},
+		{
+			name:           "CreateAlbum_WrongType",
+			method:         "POST",
+			url:            "/albums",
+			body:           `{"title":"Test","track_count":"ten"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums","errors":[{"field":"track_count","code":"type","message":"must be of type int"}]}`,
+		},
+		{
+			name: "CreateAlbum_DomainValidation",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("CreateAlbum", mock.Anything, entity.Album{Title: " "}).
+					Return("", customerr.NewValidationError(customerr.FieldError{Field: "title", Code: "required", Message: "must not be blank"}))
+			},
+			method:         "POST",
+			url:            "/albums",
+			body:           dto.Album{Title: " "},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums","errors":[{"field":"title","code":"required","message":"must not be blank"}]}`,
 		},
 
 		// GetAlbumByIDHandler tests
 		{
 			name: "GetAlbumByID_Success",
 			setupMock: func(m *mocks.AlbumInterface) {
-				m.On("GetAlbumByID", mock.Anything, "1").Return(dto.Album{ID: "1", Title: "Test"}, nil)
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{ID: "1", Title: "Test", Version: 3}, nil)
 			},
 			method:         "GET",
 			url:            "/albums/1",
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":"1","title":"Test"}`,
+			expectedBody:   `{"id":"1","title":"Test","version":3}`,
+			expectedETag:   `"3-json"`,
 		},
 		{
 			name: "GetAlbumByID_NotFound",
 			setupMock: func(m *mocks.AlbumInterface) {
-				m.On("GetAlbumByID", mock.Anything, "1").Return(dto.Album{}, customerr.ErrAlbumNotFound)
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{}, customerr.ErrAlbumNotFound)
 			},
 			method:         "GET",
 			url:            "/albums/1",
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   `{"error":"Album not found"}`,
+			expectedBody:   `{"type":"/problems/album_not_found","title":"Album not found","status":404,"code":"album_not_found","instance":"/albums/1"}`,
+		},
+
+		{
+			name: "GetAlbumByID_IncludeTracks",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{IncludeTracks: true}).
+					Return(dto.Album{ID: "1", Title: "Test", Tracks: []dto.Track{{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro", DurationSeconds: 60}}}, nil)
+			},
+			method:         "GET",
+			url:            "/albums/1?include=tracks",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","title":"Test","tracks":[{"id":"t1","album_id":"1","position":1,"title":"Intro","duration_seconds":60}]}`,
+			expectedETag:   `"bd929932e2dfda975d9f264542701296"`,
+		},
+		{
+			name:           "GetAlbumByID_UnknownInclude",
+			method:         "GET",
+			url:            "/albums/1?include=reviews",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums/1","errors":[{"field":"include","code":"enum","message":"must be one of: tracks"}]}`,
+		},
+
+		// UpdateAlbumHandler tests
+		{
+			name: "UpdateAlbum_Success",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("UpdateAlbum", mock.Anything, entity.Album{ID: entity.AlbumID("1"), Title: "Updated", Version: 3}).
+					Return(dto.Album{ID: "1", Title: "Updated", Version: 4}, nil)
+			},
+			method:         "PUT",
+			url:            "/albums/1",
+			body:           dto.Album{Title: "Updated"},
+			headers:        map[string]string{"If-Match": `"3"`},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","title":"Updated","version":4}`,
+			expectedETag:   `"4-json"`,
+		},
+		{
+			name:           "UpdateAlbum_MissingIfMatch",
+			method:         "PUT",
+			url:            "/albums/1",
+			body:           dto.Album{Title: "Updated"},
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedBody:   `{"type":"/problems/if_match_required","title":"If-Match header is required","status":428,"code":"if_match_required","instance":"/albums/1"}`,
+		},
+		{
+			name: "UpdateAlbum_VersionMismatch",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("UpdateAlbum", mock.Anything, entity.Album{ID: entity.AlbumID("1"), Title: "Updated", Version: 2}).
+					Return(dto.Album{}, customerr.ErrVersionMismatch)
+			},
+			method:         "PUT",
+			url:            "/albums/1",
+			body:           dto.Album{Title: "Updated"},
+			headers:        map[string]string{"If-Match": `"2"`},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   `{"type":"/problems/version_mismatch","title":"Album was modified by another request","status":412,"code":"version_mismatch","instance":"/albums/1"}`,
+		},
+		{
+			name:           "UpdateAlbum_WeakETag",
+			method:         "PUT",
+			url:            "/albums/1",
+			body:           dto.Album{Title: "Updated"},
+			headers:        map[string]string{"If-Match": `W/"3"`},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   `{"type":"/problems/version_mismatch","title":"Album was modified by another request","status":412,"code":"version_mismatch","instance":"/albums/1"}`,
+		},
+		{
+			name: "UpdateAlbum_NotFound",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("UpdateAlbum", mock.Anything, entity.Album{ID: entity.AlbumID("1"), Title: "Updated"}).
+					Return(dto.Album{}, customerr.ErrAlbumNotFound)
+			},
+			method:         "PUT",
+			url:            "/albums/1",
+			body:           dto.Album{Title: "Updated"},
+			headers:        map[string]string{"If-Match": "*"},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/album_not_found","title":"Album not found","status":404,"code":"album_not_found","instance":"/albums/1"}`,
+		},
+
+		// PatchAlbumHandler tests
+		{
+			name: "PatchAlbum_Success",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("PatchAlbum", mock.Anything, "1", mock.AnythingOfType("entity.AlbumPatch"), int64(7)).
+					Return(dto.Album{ID: "1", Title: "Patched", Version: 8}, nil)
+			},
+			method:         "PATCH",
+			url:            "/albums/1",
+			body:           `{"title":"Patched"}`,
+			headers:        map[string]string{"If-Match": `"7-json"`},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","title":"Patched","version":8}`,
+			expectedETag:   `"8-json"`,
+		},
+		{
+			name:           "PatchAlbum_InvalidInput",
+			method:         "PATCH",
+			url:            "/albums/1",
+			body:           "invalid json",
+			headers:        map[string]string{"If-Match": "*"},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums/1","errors":[{"field":"body","code":"malformed","message":"request body is not valid JSON"}]}`,
+		},
+		{
+			name:           "PatchAlbum_EmptyTitle",
+			method:         "PATCH",
+			url:            "/albums/1",
+			body:           `{"title":"","release_date":"2020-13-01"}`,
+			headers:        map[string]string{"If-Match": "*"},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums/1","errors":[
+				{"field":"title","code":"length","message":"must be at least 1 characters long"},
+				{"field":"release_date","code":"format","message":"must be a date formatted as YYYY-MM-DD"}
+			]}`,
+		},
+
+		// DeleteAlbumHandler tests
+		{
+			name: "DeleteAlbum_Success",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("DeleteAlbum", mock.Anything, "1", int64(5)).Return(nil)
+			},
+			method:         "DELETE",
+			url:            "/albums/1",
+			headers:        map[string]string{"If-Match": `"5"`},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "DeleteAlbum_NotFound",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("DeleteAlbum", mock.Anything, "1", int64(0)).Return(customerr.ErrAlbumNotFound)
+			},
+			method:         "DELETE",
+			url:            "/albums/1",
+			headers:        map[string]string{"If-Match": "*"},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/album_not_found","title":"Album not found","status":404,"code":"album_not_found","instance":"/albums/1"}`,
+		},
+
+		{
+			name: "DeleteAlbum_HasTracks",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("DeleteAlbum", mock.Anything, "1", int64(0)).Return(customerr.ErrAlbumHasTracks)
+			},
+			method:         "DELETE",
+			url:            "/albums/1",
+			headers:        map[string]string{"If-Match": "*"},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"/problems/album_has_tracks","title":"Album still has tracks","status":409,"code":"album_has_tracks","instance":"/albums/1"}`,
+		},
+		{
+			name:           "DeleteAlbum_MultipleETags",
+			method:         "DELETE",
+			url:            "/albums/1",
+			headers:        map[string]string{"If-Match": `"5", "6"`},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums/1","errors":[{"field":"If-Match","code":"invalid","message":"must hold a single entity tag"}]}`,
 		},
 
 		// GetJsonPostHandler tests
@@ -122,13 +396,17 @@ func TestController(t *testing.T) {
 			}
 
 			// Create controller
-			controller := album.NewController(mockService)
+			controller := album.NewController(mockService, mocks.NewTrackInterface(t))
 
 			// Set up gin router
 			router := gin.New()
 			router.GET("/albums", controller.GetAlbumsHandler)
 			router.POST("/albums", controller.CreateAlbumHandler)
+			router.GET("/albums/search", controller.SearchAlbumsHandler)
 			router.GET("/albums/:id", controller.GetAlbumByIDHandler)
+			router.PUT("/albums/:id", controller.UpdateAlbumHandler)
+			router.PATCH("/albums/:id", controller.PatchAlbumHandler)
+			router.DELETE("/albums/:id", controller.DeleteAlbumHandler)
 			router.GET("/json", controller.GetJsonPostHandler)
 
 			// Create request
@@ -141,21 +419,29 @@ func TestController(t *testing.T) {
 				}
 			}
 			req, _ := http.NewRequest(tt.method, tt.url, bytes.NewBuffer(bodyBytes))
-			if tt.method == "POST" {
+			if tt.body != nil {
 				req.Header.Set("Content-Type", "application/json")
 			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
 
 			// Create response recorder
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 
-			// Assert status code
+			// Assert status code and entity tag
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
 
 			// Compare response body as string
 			// Remove whitespace and newlines for consistent comparison
 			actualBody := w.Body.String()
-			assert.JSONEq(t, tt.expectedBody, actualBody, "Response body should match expected JSON")
+			if tt.expectedBody == "" {
+				assert.Empty(t, actualBody, "Response body should be empty")
+			} else {
+				assert.JSONEq(t, tt.expectedBody, actualBody, "Response body should match expected JSON")
+			}
 
 			// Verify mock expectations
 			mockService.AssertExpectations(t)
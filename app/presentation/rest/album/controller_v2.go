@@ -0,0 +1,248 @@
+package album
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	v2 "boilerplate/app/presentation/rest/dto/v2"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+// The v2 handlers take the same requests as their v1 counterparts and answer with the
+// envelopes of the v2 package. DeleteAlbumHandler, DeleteTrackHandler and errors answer
+// the same way in both versions.
+
+// GetAlbumsV2Handler handles GET /v2/albums, listing albums page by page
+func (c *Controller) GetAlbumsV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
+		return
+	}
+
+	page, err := c.listAlbums(ctx, false)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	if notModified(ctx, mediaType, contentETag(mediaType, page), nil) {
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumPage(ctx.Request.URL, page))
+}
+
+// SearchAlbumsV2Handler handles GET /v2/albums/search
+func (c *Controller) SearchAlbumsV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
+		return
+	}
+
+	results, err := c.searchAlbums(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumSearchPage(ctx.Request.URL, results))
+}
+
+// CreateAlbumV2Handler handles POST /v2/albums, answering with the location of the new album
+func (c *Controller) CreateAlbumV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	id, err := c.createAlbum(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	location := v2.AlbumPath(id)
+	ctx.Header("Location", location)
+	c.render(ctx, http.StatusCreated, mediaType, v2.NewCreated(id, location))
+}
+
+// GetAlbumByIDV2Handler handles GET /v2/albums/:id
+func (c *Controller) GetAlbumByIDV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok || c.albumNotModified(ctx, mediaType) {
+		return
+	}
+
+	album, err := c.getAlbum(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	etag, lastModified := albumValidators(mediaType, album)
+	if notModified(ctx, mediaType, etag, lastModified) {
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumResource(album))
+}
+
+// UpdateAlbumV2Handler handles PUT /v2/albums/:id
+func (c *Controller) UpdateAlbumV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	updated, err := c.updateAlbum(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	setAlbumETag(ctx, updated.Version, mediaType)
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumResource(updated))
+}
+
+// PatchAlbumV2Handler handles PATCH /v2/albums/:id
+func (c *Controller) PatchAlbumV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	updated, err := c.patchAlbum(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	setAlbumETag(ctx, updated.Version, mediaType)
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumResource(updated))
+}
+
+// AlbumActionV2Handler dispatches POST /v2/albums:import and /v2/albums:purge
+func (c *Controller) AlbumActionV2Handler(ctx *gin.Context) {
+	action := albumAction(ctx)
+	if action == "" {
+		c.handleError(ctx, errUnknownAction)
+		return
+	}
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	switch action {
+	case albumActionImport:
+		report, err := c.importAlbums(ctx)
+		if err != nil {
+			c.handleError(ctx, err)
+			return
+		}
+		c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumImportReport(ctx.Request.URL, report))
+	case albumActionPurge:
+		report, err := c.albumService.PurgeAlbums(ctx)
+		if err != nil {
+			c.handleError(ctx, err)
+			return
+		}
+		c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumPurgeReport(ctx.Request.URL, report))
+	}
+}
+
+// GetTrashedAlbumsV2Handler handles GET /v2/albums/trash
+func (c *Controller) GetTrashedAlbumsV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
+		return
+	}
+
+	page, err := c.listAlbums(ctx, true)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumPage(ctx.Request.URL, page))
+}
+
+// RestoreAlbumV2Handler handles POST /v2/albums/:id/restore
+func (c *Controller) RestoreAlbumV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	album, err := c.albumService.RestoreAlbum(ctx, ctx.Param("id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	setAlbumETag(ctx, album.Version, mediaType)
+	c.render(ctx, http.StatusOK, mediaType, v2.NewAlbumResource(album))
+}
+
+// GetTracksV2Handler handles GET /v2/albums/:id/tracks
+func (c *Controller) GetTracksV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
+		return
+	}
+
+	albumID := ctx.Param("id")
+	tracks, err := c.trackService.GetTracks(ctx, albumID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, mediaType, v2.NewTrackCollection(albumID, tracks))
+}
+
+// GetTrackByIDV2Handler handles GET /v2/albums/:id/tracks/:trackId
+func (c *Controller) GetTrackByIDV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	track, err := c.trackService.GetTrackByID(ctx, ctx.Param("id"), ctx.Param("trackId"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, mediaType, v2.NewTrackResource(track))
+}
+
+// CreateTrackV2Handler handles POST /v2/albums/:id/tracks, answering with the location of the new track
+func (c *Controller) CreateTrackV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	id, err := c.createTrack(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	location := v2.TrackPath(ctx.Param("id"), id)
+	ctx.Header("Location", location)
+	c.render(ctx, http.StatusCreated, mediaType, v2.NewCreated(id, location))
+}
+
+// UpdateTrackV2Handler handles PUT /v2/albums/:id/tracks/:trackId
+func (c *Controller) UpdateTrackV2Handler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	updated, err := c.updateTrack(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, mediaType, v2.NewTrackResource(updated))
+}
@@ -0,0 +1,232 @@
+package album_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"boilerplate/app/domain/dto"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestV2Handlers(t *testing.T) {
+	// Set gin to test mode
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	blueTrain := dto.Album{ID: "1", Title: "Blue Train", Artist: "John Coltrane", ReleaseDate: "1958-01-01", Genre: "jazz", TrackCount: 5, Version: 2, CreatedAt: &now, UpdatedAt: &now}
+	blueTrainJSON := `{"id":"1","title":"Blue Train","artist":"John Coltrane","release_date":"1958-01-01","genre":"jazz","track_count":5,"version":2,"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z"}`
+
+	tests := []struct {
+		name            string
+		setupMock       func(*mocks.AlbumInterface, *mocks.TrackInterface)
+		method          string
+		url             string
+		body            string
+		headers         map[string]string
+		expectedStatus  int
+		expectedHeaders map[string]string
+		expectedBody    string
+	}{
+		{
+			name: "GetAlbums_LinksNeighbourPages",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{blueTrain}, NextCursor: "n", PrevCursor: "p"}, nil)
+			},
+			method:         "GET",
+			url:            "/v2/albums?limit=1&sort=title",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[` + blueTrainJSON + `],"meta":{"count":1},` +
+				`"links":{"self":"/v2/albums?limit=1&sort=title","next":"/v2/albums?cursor=n&limit=1&sort=title","prev":"/v2/albums?cursor=p&limit=1&sort=title"}}`,
+		},
+		{
+			name: "GetAlbums_Empty",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, nil)
+			},
+			method:         "GET",
+			url:            "/v2/albums",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[],"meta":{"count":0},"links":{"self":"/v2/albums"}}`,
+		},
+		{
+			name: "GetAlbumByID_NullFields",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(dto.Album{ID: "1", Title: "Blue Train", Version: 1}, nil)
+			},
+			method:          "GET",
+			url:             "/v2/albums/1",
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"ETag": `"1-json"`},
+			expectedBody: `{"data":{"id":"1","title":"Blue Train","artist":"","release_date":null,"genre":null,"track_count":0,"version":1,"created_at":null,"updated_at":null},` +
+				`"meta":{},"links":{"self":"/api/v2/albums/1","tracks":"/api/v2/albums/1/tracks"}}`,
+		},
+		{
+			name: "GetAlbumByID_NotFound",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(dto.Album{}, customerr.ErrAlbumNotFound)
+			},
+			method:         "GET",
+			url:            "/v2/albums/1",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/album_not_found","title":"Album not found","status":404,"code":"album_not_found","instance":"/v2/albums/1"}`,
+		},
+		{
+			name: "CreateAlbum_Location",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("new id", nil)
+			},
+			method:          "POST",
+			url:             "/v2/albums",
+			body:            `{"title":"Blue Train"}`,
+			expectedStatus:  http.StatusCreated,
+			expectedHeaders: map[string]string{"Location": "/api/v2/albums/new%20id"},
+			expectedBody:    `{"data":{"id":"new id"},"meta":{},"links":{"self":"/api/v2/albums/new%20id"}}`,
+		},
+		{
+			name: "SearchAlbums_LinksNextPage",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("SearchAlbums", mock.Anything, mock.Anything).Return(dto.AlbumSearchResults{
+					Results:    []dto.AlbumSearchHit{{Album: blueTrain, Score: 1.5}},
+					NextOffset: 1,
+				}, nil)
+			},
+			method:         "GET",
+			url:            "/v2/albums/search?q=blue&limit=1",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"album":` + blueTrainJSON + `,"score":1.5,"highlights":{}}],"meta":{"count":1},` +
+				`"links":{"self":"/v2/albums/search?q=blue&limit=1","next":"/v2/albums/search?limit=1&offset=1&q=blue"}}`,
+		},
+		{
+			name:           "PatchAlbum_IfMatchRequired",
+			method:         "PATCH",
+			url:            "/v2/albums/1",
+			body:           `{"artist":"Coltrane"}`,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedBody:   `{"type":"/problems/if_match_required","title":"If-Match header is required","status":428,"code":"if_match_required","instance":"/v2/albums/1"}`,
+		},
+		{
+			name: "PatchAlbum_Success",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("PatchAlbum", mock.Anything, "1", mock.Anything, int64(1)).Return(blueTrain, nil)
+			},
+			method:          "PATCH",
+			url:             "/v2/albums/1",
+			body:            `{"artist":"John Coltrane"}`,
+			headers:         map[string]string{"If-Match": `"1"`},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"ETag": `"2-json"`},
+			expectedBody:    `{"data":` + blueTrainJSON + `,"meta":{},"links":{"self":"/api/v2/albums/1","tracks":"/api/v2/albums/1/tracks"}}`,
+		},
+		{
+			name: "PurgeAlbums",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("PurgeAlbums", mock.Anything).Return(dto.BuildAlbumPurgeReportDTO(now, nil), nil)
+			},
+			method:         "POST",
+			url:            "/v2/albums:purge",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"deleted_before":"2024-01-02T03:04:05Z","purged":0,"ids":[]},"meta":{},"links":{"self":"/v2/albums:purge"}}`,
+		},
+		{
+			name:           "AlbumAction_Unknown",
+			method:         "POST",
+			url:            "/v2/albums:archive",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/action_not_found","title":"Not found","status":404,"code":"action_not_found","instance":"/v2/albums:archive"}`,
+		},
+		{
+			name:           "AlbumAction_WithoutColon",
+			method:         "POST",
+			url:            "/v2/albumspurge",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/action_not_found","title":"Not found","status":404,"code":"action_not_found","instance":"/v2/albumspurge"}`,
+		},
+		{
+			name: "GetTracks",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("GetTracks", mock.Anything, "1").Return([]dto.Track{{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro", DurationSeconds: 90}}, nil)
+			},
+			method:         "GET",
+			url:            "/v2/albums/1/tracks",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"id":"t1","album_id":"1","position":1,"title":"Intro","duration_seconds":90,"created_at":null,"updated_at":null}],` +
+				`"meta":{"count":1},"links":{"self":"/api/v2/albums/1/tracks","album":"/api/v2/albums/1"}}`,
+		},
+		{
+			name: "CreateTrack_Location",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("CreateTrack", mock.Anything, mock.Anything).Return("t1", nil)
+			},
+			method:          "POST",
+			url:             "/v2/albums/1/tracks",
+			body:            `{"position":1,"title":"Intro"}`,
+			expectedStatus:  http.StatusCreated,
+			expectedHeaders: map[string]string{"Location": "/api/v2/albums/1/tracks/t1"},
+			expectedBody:    `{"data":{"id":"t1"},"meta":{},"links":{"self":"/api/v2/albums/1/tracks/t1"}}`,
+		},
+		{
+			name: "UpdateTrack",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("UpdateTrack", mock.Anything, mock.Anything).Return(dto.Track{ID: "t1", AlbumID: "1", Position: 2, Title: "Intro"}, nil)
+			},
+			method:         "PUT",
+			url:            "/v2/albums/1/tracks/t1",
+			body:           `{"position":2,"title":"Intro"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"id":"t1","album_id":"1","position":2,"title":"Intro","duration_seconds":0,"created_at":null,"updated_at":null},` +
+				`"meta":{},"links":{"self":"/api/v2/albums/1/tracks/t1","album":"/api/v2/albums/1"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock services
+			albumService := mocks.NewAlbumInterface(t)
+			trackService := mocks.NewTrackInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(albumService, trackService)
+			}
+
+			controller := album.NewController(albumService, trackService)
+
+			// Set up gin router
+			router := gin.New()
+			router.GET("/v2/albums", controller.GetAlbumsV2Handler)
+			router.POST("/v2/albums", controller.CreateAlbumV2Handler)
+			router.POST("/v2/albums:action", controller.AlbumActionV2Handler)
+			router.GET("/v2/albums/search", controller.SearchAlbumsV2Handler)
+			router.GET("/v2/albums/:id", controller.GetAlbumByIDV2Handler)
+			router.PATCH("/v2/albums/:id", controller.PatchAlbumV2Handler)
+			router.GET("/v2/albums/:id/tracks", controller.GetTracksV2Handler)
+			router.POST("/v2/albums/:id/tracks", controller.CreateTrackV2Handler)
+			router.PUT("/v2/albums/:id/tracks/:trackId", controller.UpdateTrackV2Handler)
+
+			// Create request
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for key, value := range tt.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(key), key)
+			}
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package album
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+	"boilerplate/app/presentation/rest/negotiation"
+	"boilerplate/app/presentation/rest/validation"
+)
+
+// Content types accepted by ImportAlbumsHandler
+const (
+	contentTypeNDJSON = "application/x-ndjson"
+	contentTypeCSV    = "text/csv"
+)
+
+// Errors answered by the collection actions themselves
+var (
+	errUnknownAction          = errors.NewError(errors.KindNotFound, "action_not_found", "Not found", "unknown album collection action")
+	errUnsupportedImportMedia = errors.NewError(errors.KindUnsupportedMediaType, "unsupported_media_type", "Content-Type must be "+contentTypeNDJSON+" or "+contentTypeCSV, "unsupported import content type")
+)
+
+// maxImportLineSize bounds a single NDJSON line so one bad row cannot exhaust memory
+const maxImportLineSize = 1 << 20
+
+// AlbumActionHandler dispatches the custom methods on the album collection, POST /albums:import and /albums:purge.
+// gin cannot register a literal colon in a path, so the route captures ":<action>" as a parameter.
+func (c *Controller) AlbumActionHandler(ctx *gin.Context) {
+	switch albumAction(ctx) {
+	case albumActionImport:
+		c.ImportAlbumsHandler(ctx)
+	case albumActionPurge:
+		c.PurgeAlbumsHandler(ctx)
+	default:
+		c.handleError(ctx, errUnknownAction)
+	}
+}
+
+// Custom methods on the album collection
+const (
+	albumActionImport = "import"
+	albumActionPurge  = "purge"
+)
+
+// albumAction returns the custom method a request to the album collection names, or "" when it
+// names none. gin matches the :action of "/albums:action" as a wildcard following "/albums", so
+// the segment must start with the colon itself: "/albumsimport" is no action but an unknown path.
+func albumAction(ctx *gin.Context) string {
+	action, ok := strings.CutPrefix(ctx.Param("action"), ":")
+	if !ok || (action != albumActionImport && action != albumActionPurge) {
+		return ""
+	}
+	return action
+}
+
+// ImportAlbumsHandler handles POST requests importing albums from an NDJSON or CSV body.
+// CSV bodies start with a header row naming the columns. The dry_run query parameter
+// validates and checks every row without storing anything.
+func (c *Controller) ImportAlbumsHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	report, err := c.importAlbums(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAlbumImportReport(report))
+}
+
+func (c *Controller) importAlbums(ctx *gin.Context) (dto.AlbumImportReport, error) {
+	var opts entity.AlbumImportOptions
+	if dryRun := ctx.Query("dry_run"); dryRun != "" {
+		value, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			return dto.AlbumImportReport{}, errors.NewValidationError(errors.FieldError{
+				Field: "dry_run", Code: "type", Message: "must be true or false",
+			})
+		}
+		opts.DryRun = value
+	}
+
+	var rows entity.AlbumImportReader
+	switch ctx.ContentType() {
+	case contentTypeNDJSON:
+		rows = newNDJSONAlbumReader(ctx.Request.Body)
+	case contentTypeCSV:
+		csvRows, err := newCSVAlbumReader(ctx.Request.Body)
+		if err != nil {
+			return dto.AlbumImportReport{}, err
+		}
+		rows = csvRows
+	default:
+		return dto.AlbumImportReport{}, errUnsupportedImportMedia
+	}
+
+	return c.albumService.ImportAlbums(ctx, rows, opts)
+}
+
+// buildImportRow validates a decoded album the same way the create endpoint does
+func buildImportRow(line int, album dto.Album) entity.AlbumImportRow {
+	if err := binding.Validator.ValidateStruct(&album); err != nil {
+		return entity.AlbumImportRow{Line: line, Album: entity.Album{ID: entity.AlbumID(album.ID)}, Err: validation.FromBindError(err)}
+	}
+	albumEntity, err := dto.BuildAlbumEntity(album)
+	return entity.AlbumImportRow{Line: line, Album: albumEntity, Err: err}
+}
+
+// ndjsonAlbumReader reads one JSON album per line, skipping blank lines
+type ndjsonAlbumReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNDJSONAlbumReader(body io.Reader) *ndjsonAlbumReader {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+	return &ndjsonAlbumReader{scanner: scanner}
+}
+
+func (r *ndjsonAlbumReader) Next() (entity.AlbumImportRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var album dto.Album
+		if err := json.Unmarshal(data, &album); err != nil {
+			return entity.AlbumImportRow{Line: r.line, Err: validation.FromBindError(err)}, nil
+		}
+		return buildImportRow(r.line, album), nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return entity.AlbumImportRow{}, err
+	}
+	return entity.AlbumImportRow{}, io.EOF
+}
+
+// csvImportColumns are the columns a CSV import may contain
+var csvImportColumns = map[string]bool{
+	"id": true, "title": true, "artist": true, "release_date": true, "genre": true, "track_count": true,
+}
+
+// csvAlbumReader reads albums from CSV records whose columns are named by the header row
+type csvAlbumReader struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+// newCSVAlbumReader reads and checks the header row
+func newCSVAlbumReader(body io.Reader) (*csvAlbumReader, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.NewValidationError(errors.FieldError{
+			Field: "header", Code: "required", Message: "the first row must name the columns",
+		})
+	}
+
+	invalid := errors.NewValidationError()
+	hasTitle := false
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		header[i] = column
+		if !csvImportColumns[column] {
+			invalid.Add("header", "unknown_column", "unknown column "+strconv.Quote(column))
+		}
+		hasTitle = hasTitle || column == "title"
+	}
+	if !hasTitle {
+		invalid.Add("header", "required", "a title column is required")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return nil, err
+	}
+
+	// The header fixes the number of fields every record must have
+	reader.FieldsPerRecord = len(header)
+	return &csvAlbumReader{reader: reader, columns: header}, nil
+}
+
+func (r *csvAlbumReader) Next() (entity.AlbumImportRow, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return entity.AlbumImportRow{}, io.EOF
+	}
+	line, _ := r.reader.FieldPos(0)
+	if err != nil {
+		var parseErr *csv.ParseError
+		if !stderrors.As(err, &parseErr) {
+			return entity.AlbumImportRow{}, err
+		}
+		return entity.AlbumImportRow{Line: parseErr.StartLine, Err: errors.NewValidationError(errors.FieldError{
+			Field: "row", Code: "malformed", Message: parseErr.Err.Error(),
+		})}, nil
+	}
+
+	var album dto.Album
+	var trackCountErr error
+	for i, column := range r.columns {
+		value := record[i]
+		switch column {
+		case "id":
+			album.ID = value
+		case "title":
+			album.Title = value
+		case "artist":
+			album.Artist = value
+		case "release_date":
+			album.ReleaseDate = value
+		case "genre":
+			album.Genre = value
+		case "track_count":
+			if value == "" {
+				continue
+			}
+			trackCount, err := strconv.Atoi(value)
+			if err != nil {
+				trackCountErr = errors.NewValidationError(errors.FieldError{
+					Field: "track_count", Code: "type", Message: "must be of type int",
+				})
+			}
+			album.TrackCount = trackCount
+		}
+	}
+	if trackCountErr != nil {
+		return entity.AlbumImportRow{Line: line, Album: entity.Album{ID: entity.AlbumID(album.ID)}, Err: trackCountErr}, nil
+	}
+	return buildImportRow(line, album), nil
+}
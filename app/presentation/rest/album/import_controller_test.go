@@ -0,0 +1,136 @@
+package album_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestImportAlbumsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	releaseDate := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		url            string
+		contentType    string
+		body           string
+		expectImport   bool
+		expectedOpts   entity.AlbumImportOptions
+		expectedRows   []entity.AlbumImportRow
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:         "NDJSON",
+			url:          "/albums:import",
+			contentType:  "application/x-ndjson",
+			body:         "{\"id\":\"a1\",\"title\":\"One\",\"release_date\":\"2023-06-30\"}\n\n{\"title\":\"\"}\nnot json\n",
+			expectImport: true,
+			expectedRows: []entity.AlbumImportRow{
+				{Line: 1, Album: entity.Album{ID: "a1", Title: "One", ReleaseDate: &releaseDate}},
+				{Line: 3, Err: customerr.NewValidationError(customerr.FieldError{Field: "title", Code: "required", Message: "is required"})},
+				{Line: 4, Err: customerr.NewValidationError(customerr.FieldError{Field: "body", Code: "malformed", Message: "request body is not valid JSON"})},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"dry_run":false,"created":0,"skipped":0,"failed":0,"rows":[]}`,
+		},
+		{
+			name:         "CSV dry run",
+			url:          "/albums:import?dry_run=true",
+			contentType:  "text/csv; charset=utf-8",
+			body:         "title,id,genre,track_count\nOne,a1,rock,12\n\"Two, Live\",a2,,\nThree,a3,jazz,many\n",
+			expectImport: true,
+			expectedOpts: entity.AlbumImportOptions{DryRun: true},
+			expectedRows: []entity.AlbumImportRow{
+				{Line: 2, Album: entity.Album{ID: "a1", Title: "One", Genre: "rock", TrackCount: 12}},
+				{Line: 3, Album: entity.Album{ID: "a2", Title: "Two, Live"}},
+				{Line: 4, Album: entity.Album{ID: "a3"}, Err: customerr.NewValidationError(customerr.FieldError{Field: "track_count", Code: "type", Message: "must be of type int"})},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"dry_run":false,"created":0,"skipped":0,"failed":0,"rows":[]}`,
+		},
+		{
+			name:           "CSV with unknown column",
+			url:            "/albums:import",
+			contentType:    "text/csv",
+			body:           "title,label\nOne,Blue Note\n",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums:import","errors":[{"field":"header","code":"unknown_column","message":"unknown column \"label\""}]}`,
+		},
+		{
+			name:           "Unsupported content type",
+			url:            "/albums:import",
+			contentType:    "application/json",
+			body:           `[]`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"type":"/problems/unsupported_media_type","title":"Content-Type must be application/x-ndjson or text/csv","status":415,"code":"unsupported_media_type","instance":"/albums:import"}`,
+		},
+		{
+			name:           "Unknown action",
+			url:            "/albums:export",
+			contentType:    "text/csv",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/action_not_found","title":"Not found","status":404,"code":"action_not_found","instance":"/albums:export"}`,
+		},
+		{
+			name:           "Action without its colon",
+			url:            "/albumsimport",
+			contentType:    "text/csv",
+			body:           "title,artist\nBlue Train,John Coltrane\n",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/action_not_found","title":"Not found","status":404,"code":"action_not_found","instance":"/albumsimport"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albumService := mocks.NewAlbumInterface(t)
+			if tt.expectImport {
+				albumService.On("ImportAlbums", mock.Anything, mock.Anything, tt.expectedOpts).
+					Run(func(args mock.Arguments) {
+						// Drain the reader to check how the body was parsed
+						reader := args.Get(1).(entity.AlbumImportReader)
+						var rows []entity.AlbumImportRow
+						for {
+							row, err := reader.Next()
+							if err == io.EOF {
+								break
+							}
+							assert.NoError(t, err)
+							rows = append(rows, row)
+						}
+						assert.Equal(t, tt.expectedRows, rows)
+					}).
+					Return(dto.AlbumImportReport{Rows: []dto.AlbumImportRowInfo{}}, nil)
+			}
+
+			controller := album.NewController(albumService, mocks.NewTrackInterface(t))
+
+			router := gin.New()
+			router.POST("/albums:action", controller.AlbumActionHandler)
+
+			req, _ := http.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package album
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+// negotiate picks the media type of the response among offers, answering 406 when the Accept
+// header rules them all out. Handlers call it before acting, so that nothing changes for a
+// request whose response could not be sent.
+func (c *Controller) negotiate(ctx *gin.Context, offers ...string) (string, bool) {
+	mediaType, err := negotiation.Negotiate(ctx, offers...)
+	if err != nil {
+		c.handleError(ctx, err)
+		return "", false
+	}
+	return mediaType, true
+}
+
+// render answers body encoded as mediaType
+func (c *Controller) render(ctx *gin.Context, status int, mediaType string, body interface{}) {
+	if err := negotiation.Render(ctx, status, mediaType, body); err != nil {
+		c.handleError(ctx, err)
+	}
+}
+
+// renderAlbumListV1 answers a v1 album list, which has always been indented as JSON
+func (c *Controller) renderAlbumListV1(ctx *gin.Context, mediaType string, list v1.AlbumList) {
+	if mediaType == negotiation.MIMEJSON {
+		ctx.IndentedJSON(http.StatusOK, list)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, list)
+}
@@ -0,0 +1,162 @@
+package album_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/presentation/rest/album"
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestContentNegotiation(t *testing.T) {
+	// Set gin to test mode
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name                string
+		setupMock           func(*mocks.AlbumInterface)
+		method              string
+		url                 string
+		accept              string
+		contentType         string
+		body                string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name: "GetAlbumByID_XML",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{ID: "1", Title: "Test", Version: 3}, nil)
+			},
+			method:              "GET",
+			url:                 "/albums/1",
+			accept:              "application/xml",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/xml; charset=utf-8",
+			expectedBody:        `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<album><id>1</id><title>Test</title><version>3</version></album>`,
+		},
+		{
+			name: "GetAlbums_CSV",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{}).
+					Return(dto.AlbumList{Albums: []dto.Album{{ID: "1", Title: "Blue Train, Remastered", Artist: "John Coltrane", TrackCount: 5}}}, nil)
+			},
+			method:              "GET",
+			url:                 "/albums",
+			accept:              "text/csv",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "id,title,artist,release_date,genre,track_count,version,created_at,updated_at,deleted_at\n" +
+				"1,\"Blue Train, Remastered\",John Coltrane,,,5,0,,,\n",
+		},
+		{
+			name:                "GetAlbumByID_CSVNotAcceptable",
+			method:              "GET",
+			url:                 "/albums/1",
+			accept:              "text/csv",
+			expectedStatus:      http.StatusNotAcceptable,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"/problems/not_acceptable","title":"Accept must allow application/json, application/xml or application/msgpack","status":406,"code":"not_acceptable","instance":"/albums/1"}`,
+		},
+		{
+			name: "CreateAlbum_XML",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("CreateAlbum", mock.Anything, entity.Album{ID: entity.AlbumID("1"), Title: "Test", TrackCount: 5}).
+					Return("1", nil)
+			},
+			method:              "POST",
+			url:                 "/albums",
+			accept:              "application/xml",
+			contentType:         "application/xml",
+			body:                `<album><id>1</id><title>Test</title><track_count>5</track_count></album>`,
+			expectedStatus:      http.StatusCreated,
+			expectedContentType: "application/xml; charset=utf-8",
+			expectedBody:        `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<created><id>1</id><message>Album created successfully</message></created>`,
+		},
+		{
+			name:                "CreateAlbum_UnsupportedMediaType",
+			method:              "POST",
+			url:                 "/albums",
+			contentType:         "text/plain",
+			body:                "Test",
+			expectedStatus:      http.StatusUnsupportedMediaType,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"/problems/unsupported_media_type","title":"Content-Type must be application/json, application/xml or application/msgpack","status":415,"code":"unsupported_media_type","instance":"/albums"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock service
+			albumService := mocks.NewAlbumInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(albumService)
+			}
+
+			controller := album.NewController(albumService, mocks.NewTrackInterface(t))
+
+			// Set up gin router
+			router := gin.New()
+			router.GET("/albums", controller.GetAlbumsHandler)
+			router.POST("/albums", controller.CreateAlbumHandler)
+			router.GET("/albums/:id", controller.GetAlbumByIDHandler)
+
+			// Create request
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			if strings.HasPrefix(tt.expectedContentType, "application/problem+json") {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			} else {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestContentNegotiation_MessagePack(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAlbumByID", mock.Anything, "1", entity.AlbumGetOptions{}).Return(dto.Album{ID: "1", Title: "Test", Version: 3}, nil)
+	controller := album.NewController(albumService, mocks.NewTrackInterface(t))
+
+	router := gin.New()
+	router.GET("/albums/:id", controller.GetAlbumByIDHandler)
+
+	req, _ := http.NewRequest("GET", "/albums/1", nil)
+	req.Header.Set("Accept", "application/x-msgpack")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/msgpack", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"3-msgpack"`, w.Header().Get("ETag"))
+
+	var decoded v1.Album
+	require.NoError(t, codec.NewDecoderBytes(w.Body.Bytes(), &codec.MsgpackHandle{}).Decode(&decoded))
+	assert.Equal(t, v1.Album{ID: "1", Title: "Test", Version: 3}, decoded)
+}
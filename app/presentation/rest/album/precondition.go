@@ -0,0 +1,54 @@
+package album
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/errors"
+)
+
+// errIfMatchRequired rejects a mutating request that does not say which album version it expects
+var errIfMatchRequired = errors.NewError(errors.KindPreconditionRequired, "if_match_required", "If-Match header is required", "if-match header is required")
+
+// albumETag is the entity tag of an album at the given version in a media type, such as "3-json":
+// each representation has a tag of its own, and If-Match reads the version back from any of them
+func albumETag(version int64, mediaType string) string {
+	_, subtype, _ := strings.Cut(mediaType, "/")
+	return `"` + strconv.FormatInt(version, 10) + "-" + strings.TrimPrefix(subtype, "x-") + `"`
+}
+
+// setAlbumETag exposes the album version as the ETag header of its representation in mediaType,
+// if the version is known
+func setAlbumETag(ctx *gin.Context, version int64, mediaType string) {
+	if version != 0 {
+		ctx.Header("ETag", albumETag(version, mediaType))
+	}
+}
+
+// parseIfMatch reads the album version a mutating request expects from its If-Match header.
+// "*" matches any version and yields 0.
+func parseIfMatch(ctx *gin.Context) (int64, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		return 0, errIfMatchRequired
+	}
+	if header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, errors.NewValidationError(errors.FieldError{
+			Field: "If-Match", Code: "invalid", Message: "must hold a single entity tag",
+		})
+	}
+
+	// Weak tags never match under If-Match, and a tag this API did not issue cannot match either.
+	// The version comes first, whichever representation the tag was issued for.
+	versionTag, _, _ := strings.Cut(strings.Trim(header, `"`), "-")
+	version, err := strconv.ParseInt(versionTag, 10, 64)
+	if strings.HasPrefix(header, "W/") || !strings.HasPrefix(header, `"`) || err != nil || version < 1 {
+		return 0, errors.ErrVersionMismatch
+	}
+	return version, nil
+}
@@ -0,0 +1,162 @@
+package album
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+)
+
+// DefaultStreamHeartbeat is how often idle album change streams send a heartbeat unless configured otherwise
+const DefaultStreamHeartbeat = 15 * time.Second
+
+const (
+	// streamWriteTimeout bounds every write to a stream, so that a client that stops reading is let go
+	streamWriteTimeout = 10 * time.Second
+	// streamRetry is how long EventSource clients wait before reconnecting
+	streamRetry = 3 * time.Second
+)
+
+// upgrader accepts WebSocket connections from pages of the same origin only, the default of
+// gorilla/websocket, since browsers send cookies along with cross-origin WebSocket requests
+var upgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 4096}
+
+// StreamAlbumsHandler handles GET requests streaming album changes as Server-Sent Events.
+// A client resumes after the last event it received with the Last-Event-ID header, which
+// EventSource sends by itself when reconnecting, or the last_event_id query parameter.
+// A comment is sent at every heartbeat. The stream ends when the client falls too far behind;
+// it then reconnects and resumes.
+func (c *Controller) StreamAlbumsHandler(ctx *gin.Context) {
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+	events, err := c.albumService.SubscribeAlbumEvents(ctx.Request.Context(), lastEventID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// Keeps proxies such as nginx from holding the events back
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	writer := http.NewResponseController(ctx.Writer)
+	write := func(message string) bool {
+		_ = writer.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := io.WriteString(ctx.Writer, message); err != nil {
+			return false
+		}
+		ctx.Writer.Flush()
+		return true
+	}
+
+	if !write(fmt.Sprintf("retry: %d\n\n", streamRetry.Milliseconds())) {
+		return
+	}
+	heartbeat := time.NewTicker(c.streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok || !write(serverSentEvent(event)) {
+				return
+			}
+		case <-heartbeat.C:
+			if !write(": heartbeat\n\n") {
+				return
+			}
+		}
+	}
+}
+
+// serverSentEvent formats an album change as a Server-Sent Event named after its type
+func serverSentEvent(event dto.AlbumEvent) string {
+	data, _ := json.Marshal(v1.NewAlbumEvent(event))
+	message := "event: " + event.Type + "\ndata: " + string(data) + "\n\n"
+	if event.ID != "" {
+		message = "id: " + event.ID + "\n" + message
+	}
+	return message
+}
+
+// StreamAlbumsWebSocketHandler handles GET requests upgraded to a WebSocket streaming album
+// changes, one JSON text message per event. A client resumes after the last event it received
+// with the last_event_id query parameter. The server pings at every heartbeat and lets clients go
+// when they stop answering; one falling too far behind is closed with 1013 (try again later) and
+// should reconnect and resume. Messages sent by the client are ignored.
+func (c *Controller) StreamAlbumsWebSocketHandler(ctx *gin.Context) {
+	if !websocket.IsWebSocketUpgrade(ctx.Request) {
+		c.handleError(ctx, errors.NewValidationError(errors.FieldError{Field: "Upgrade", Code: "required", Message: "must be websocket"}))
+		return
+	}
+
+	// Hijacked connections outlive the request context, so the stream ends when reading fails
+	streamCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	events, err := c.albumService.SubscribeAlbumEvents(streamCtx, ctx.Query("last_event_id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// Upgrade answered the request already
+		_ = ctx.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	// Reading handles pongs and the closing handshake
+	pongWait := 2 * c.streamHeartbeat
+	conn.SetReadLimit(512)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(c.streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if streamCtx.Err() == nil {
+					message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "fell behind, resume from the last event")
+					_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(streamWriteTimeout))
+				}
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(v1.NewAlbumEvent(event)); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
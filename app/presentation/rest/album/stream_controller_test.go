@@ -0,0 +1,135 @@
+package album_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/dto"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+// closedEventStream returns a stream holding events that ends once they are read,
+// as it does when a subscriber falls behind
+func closedEventStream(events ...dto.AlbumEvent) <-chan dto.AlbumEvent {
+	stream := make(chan dto.AlbumEvent, len(events))
+	for _, event := range events {
+		stream <- event
+	}
+	close(stream)
+	return stream
+}
+
+func TestStreamAlbumsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	occurredAt := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	created := dto.AlbumEvent{ID: "1-0", Type: dto.AlbumCreated, AlbumID: "1", Album: &dto.Album{ID: "1", Title: "Blue Train", Version: 1}, OccurredAt: occurredAt}
+	deleted := dto.AlbumEvent{ID: "2-0", Type: dto.AlbumDeleted, AlbumID: "1", OccurredAt: occurredAt}
+
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.AlbumInterface)
+		url            string
+		lastEventID    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Streams events",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("SubscribeAlbumEvents", mock.Anything, "").Return(closedEventStream(created, deleted), nil)
+			},
+			url:            "/albums/stream",
+			expectedStatus: http.StatusOK,
+			expectedBody: "retry: 3000\n\n" +
+				"id: 1-0\nevent: album.created\ndata: {\"id\":\"1-0\",\"type\":\"album.created\",\"album_id\":\"1\",\"album\":{\"id\":\"1\",\"title\":\"Blue Train\",\"version\":1},\"occurred_at\":\"2024-02-03T04:05:06Z\"}\n\n" +
+				"id: 2-0\nevent: album.deleted\ndata: {\"id\":\"2-0\",\"type\":\"album.deleted\",\"album_id\":\"1\",\"occurred_at\":\"2024-02-03T04:05:06Z\"}\n\n",
+		},
+		{
+			name: "Resumes from the Last-Event-ID header",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("SubscribeAlbumEvents", mock.Anything, "1-0").
+					Return(closedEventStream(dto.AlbumEvent{Type: dto.EventsLost, OccurredAt: occurredAt}), nil)
+			},
+			url:            "/albums/stream?last_event_id=0-0",
+			lastEventID:    "1-0",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "retry: 3000\n\nevent: events.lost\ndata: {\"type\":\"events.lost\",\"occurred_at\":\"2024-02-03T04:05:06Z\"}\n\n",
+		},
+		{
+			name: "Events disabled",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("SubscribeAlbumEvents", mock.Anything, "").Return(nil, customerr.ErrEventsDisabled)
+			},
+			url:            "/albums/stream",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"type":"/problems/events_disabled","title":"Album change events are not enabled","status":503,"code":"events_disabled","instance":"/albums/stream"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albumService := mocks.NewAlbumInterface(t)
+			tt.setupMock(albumService)
+
+			controller := album.NewController(albumService, mocks.NewTrackInterface(t))
+
+			router := gin.New()
+			router.GET("/albums/stream", controller.StreamAlbumsHandler)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+				assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			} else {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestStreamAlbumsWebSocketHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	occurredAt := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("SubscribeAlbumEvents", mock.Anything, "1-0").
+		Return(closedEventStream(dto.AlbumEvent{ID: "2-0", Type: dto.AlbumDeleted, AlbumID: "1", OccurredAt: occurredAt}), nil)
+
+	controller := album.NewController(albumService, mocks.NewTrackInterface(t), album.WithStreamHeartbeat(time.Minute))
+	router := gin.New()
+	router.GET("/albums/stream/ws", controller.StreamAlbumsWebSocketHandler)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/albums/stream/ws?last_event_id=1-0", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"2-0","type":"album.deleted","album_id":"1","occurred_at":"2024-02-03T04:05:06Z"}`, string(message))
+
+	// The stream ended, as it does for a client falling behind
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), "unexpected error: %v", err)
+}
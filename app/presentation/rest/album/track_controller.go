@@ -0,0 +1,114 @@
+package album
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+	"boilerplate/app/presentation/rest/negotiation"
+	"boilerplate/app/presentation/rest/validation"
+)
+
+// GetTracksHandler handles GET requests listing the tracks of an album
+func (c *Controller) GetTracksHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
+		return
+	}
+
+	tracks, err := c.trackService.GetTracks(ctx, ctx.Param("id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, mediaType, v1.NewTracks(tracks))
+}
+
+// GetTrackByIDHandler handles GET requests for a single track of an album
+func (c *Controller) GetTrackByIDHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	track, err := c.trackService.GetTrackByID(ctx, ctx.Param("id"), ctx.Param("trackId"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, mediaType, v1.NewTrack(track))
+}
+
+// CreateTrackHandler handles POST requests to add a track to an album
+func (c *Controller) CreateTrackHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	id, err := c.createTrack(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusCreated, mediaType, v1.Created{ID: id, Message: "Track created successfully"})
+}
+
+// createTrack stores the track in the request body and returns its ID
+func (c *Controller) createTrack(ctx *gin.Context) (string, error) {
+	var track dto.Track
+	if err := ctx.ShouldBindJSON(&track); err != nil {
+		return "", validation.FromBindError(err)
+	}
+
+	entityTrack := dto.BuildTrackEntity(track)
+	entityTrack.AlbumID = entity.AlbumID(ctx.Param("id"))
+
+	return c.trackService.CreateTrack(ctx, entityTrack)
+}
+
+// UpdateTrackHandler handles PUT requests to replace a track of an album
+func (c *Controller) UpdateTrackHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	updated, err := c.updateTrack(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, mediaType, v1.NewTrack(updated))
+}
+
+func (c *Controller) updateTrack(ctx *gin.Context) (dto.Track, error) {
+	var track dto.Track
+	if err := ctx.ShouldBindJSON(&track); err != nil {
+		return dto.Track{}, validation.FromBindError(err)
+	}
+
+	entityTrack := dto.BuildTrackEntity(track)
+	entityTrack.AlbumID = entity.AlbumID(ctx.Param("id"))
+	entityTrack.ID = entity.TrackID(ctx.Param("trackId"))
+
+	return c.trackService.UpdateTrack(ctx, entityTrack)
+}
+
+// DeleteTrackHandler handles DELETE requests to remove a track from an album.
+// It answers the same way in every API version.
+func (c *Controller) DeleteTrackHandler(ctx *gin.Context) {
+	if err := c.trackService.DeleteTrack(ctx, ctx.Param("id"), ctx.Param("trackId")); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
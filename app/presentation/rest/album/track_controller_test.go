@@ -0,0 +1,138 @@
+package album_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestTrackHandlers(t *testing.T) {
+	// Set gin to test mode
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.TrackInterface)
+		method         string
+		url            string
+		body           interface{}
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "GetTracks_Success",
+			setupMock: func(m *mocks.TrackInterface) {
+				m.On("GetTracks", mock.Anything, "1").Return([]dto.Track{{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro", DurationSeconds: 90}}, nil)
+			},
+			method:         "GET",
+			url:            "/albums/1/tracks",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"id":"t1","album_id":"1","position":1,"title":"Intro","duration_seconds":90}]`,
+		},
+		{
+			name: "GetTracks_AlbumNotFound",
+			setupMock: func(m *mocks.TrackInterface) {
+				m.On("GetTracks", mock.Anything, "1").Return([]dto.Track{}, customerr.ErrAlbumNotFound)
+			},
+			method:         "GET",
+			url:            "/albums/1/tracks",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/album_not_found","title":"Album not found","status":404,"code":"album_not_found","instance":"/albums/1/tracks"}`,
+		},
+		{
+			name: "GetTrackByID_NotFound",
+			setupMock: func(m *mocks.TrackInterface) {
+				m.On("GetTrackByID", mock.Anything, "1", "t9").Return(dto.Track{}, customerr.ErrTrackNotFound)
+			},
+			method:         "GET",
+			url:            "/albums/1/tracks/t9",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/track_not_found","title":"Track not found","status":404,"code":"track_not_found","instance":"/albums/1/tracks/t9"}`,
+		},
+		{
+			name: "CreateTrack_Success",
+			setupMock: func(m *mocks.TrackInterface) {
+				m.On("CreateTrack", mock.Anything, entity.Track{AlbumID: "1", Position: 1, Title: "Intro", DurationSeconds: 90}).
+					Return("t1", nil)
+			},
+			method:         "POST",
+			url:            "/albums/1/tracks",
+			body:           dto.Track{Position: 1, Title: "Intro", DurationSeconds: 90},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"t1","message":"Track created successfully"}`,
+		},
+		{
+			name: "UpdateTrack_Success",
+			setupMock: func(m *mocks.TrackInterface) {
+				m.On("UpdateTrack", mock.Anything, entity.Track{ID: "t1", AlbumID: "1", Position: 2, Title: "Intro"}).
+					Return(dto.Track{ID: "t1", AlbumID: "1", Position: 2, Title: "Intro"}, nil)
+			},
+			method:         "PUT",
+			url:            "/albums/1/tracks/t1",
+			body:           dto.Track{Position: 2, Title: "Intro"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"t1","album_id":"1","position":2,"title":"Intro","duration_seconds":0}`,
+		},
+		{
+			name: "DeleteTrack_Success",
+			setupMock: func(m *mocks.TrackInterface) {
+				m.On("DeleteTrack", mock.Anything, "1", "t1").Return(nil)
+			},
+			method:         "DELETE",
+			url:            "/albums/1/tracks/t1",
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock services
+			trackService := mocks.NewTrackInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(trackService)
+			}
+
+			controller := album.NewController(mocks.NewAlbumInterface(t), trackService)
+
+			// Set up gin router
+			router := gin.New()
+			router.GET("/albums/:id/tracks", controller.GetTracksHandler)
+			router.POST("/albums/:id/tracks", controller.CreateTrackHandler)
+			router.GET("/albums/:id/tracks/:trackId", controller.GetTrackByIDHandler)
+			router.PUT("/albums/:id/tracks/:trackId", controller.UpdateTrackHandler)
+			router.DELETE("/albums/:id/tracks/:trackId", controller.DeleteTrackHandler)
+
+			// Create request
+			var bodyBytes []byte
+			if tt.body != nil {
+				bodyBytes, _ = json.Marshal(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.url, bytes.NewBuffer(bodyBytes))
+			if tt.body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody == "" {
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package album
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "boilerplate/app/presentation/rest/dto/v1"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+// GetTrashedAlbumsHandler handles GET requests listing the albums in the trash.
+// It takes the same paging and sorting query parameters as GetAlbumsHandler.
+func (c *Controller) GetTrashedAlbumsHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ListTypes...)
+	if !ok {
+		return
+	}
+
+	page, err := c.listAlbums(ctx, true)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.renderAlbumListV1(ctx, mediaType, v1.NewAlbumList(page))
+}
+
+// RestoreAlbumHandler handles POST requests taking an album out of the trash
+func (c *Controller) RestoreAlbumHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	album, err := c.albumService.RestoreAlbum(ctx, ctx.Param("id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	setAlbumETag(ctx, album.Version, mediaType)
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAlbum(album))
+}
+
+// PurgeAlbumsHandler handles POST /albums:purge, permanently removing the albums
+// that have been in the trash for longer than the configured retention
+func (c *Controller) PurgeAlbumsHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.ResourceTypes...)
+	if !ok {
+		return
+	}
+
+	report, err := c.albumService.PurgeAlbums(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v1.NewAlbumPurgeReport(report))
+}
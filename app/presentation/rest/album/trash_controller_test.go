@@ -0,0 +1,101 @@
+package album_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestTrashHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	deletedAt := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	deletedBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		setupMock      func(*mocks.AlbumInterface)
+		method         string
+		url            string
+		expectedStatus int
+		expectedBody   string
+		expectedETag   string
+	}{
+		{
+			name: "List trash",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("GetAllAlbums", mock.Anything, entity.AlbumListOptions{Limit: 1, Trashed: true}).
+					Return(dto.AlbumList{Albums: []dto.Album{{ID: "1", Title: "Gone", Version: 2, DeletedAt: &deletedAt}}}, nil)
+			},
+			method:         "GET",
+			url:            "/albums/trash?limit=1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"albums":[{"id":"1","title":"Gone","version":2,"deleted_at":"2024-02-03T04:05:06Z"}]}`,
+		},
+		{
+			name: "Restore",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("RestoreAlbum", mock.Anything, "1").Return(dto.Album{ID: "1", Title: "Back", Version: 3}, nil)
+			},
+			method:         "POST",
+			url:            "/albums/1/restore",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","title":"Back","version":3}`,
+			expectedETag:   `"3-json"`,
+		},
+		{
+			name: "Restore album not in the trash",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("RestoreAlbum", mock.Anything, "1").Return(dto.Album{}, customerr.ErrAlbumNotFound)
+			},
+			method:         "POST",
+			url:            "/albums/1/restore",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/album_not_found","title":"Album not found","status":404,"code":"album_not_found","instance":"/albums/1/restore"}`,
+		},
+		{
+			name: "Purge",
+			setupMock: func(m *mocks.AlbumInterface) {
+				m.On("PurgeAlbums", mock.Anything).
+					Return(dto.BuildAlbumPurgeReportDTO(deletedBefore, []string{"1"}), nil)
+			},
+			method:         "POST",
+			url:            "/albums:purge",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"deleted_before":"2024-01-01T00:00:00Z","purged":1,"ids":["1"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albumService := mocks.NewAlbumInterface(t)
+			tt.setupMock(albumService)
+
+			controller := album.NewController(albumService, mocks.NewTrackInterface(t))
+
+			router := gin.New()
+			router.POST("/albums:action", controller.AlbumActionHandler)
+			router.GET("/albums/trash", controller.GetTrashedAlbumsHandler)
+			router.POST("/albums/:id/restore", controller.RestoreAlbumHandler)
+
+			req, _ := http.NewRequest(tt.method, tt.url, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package album
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	v2 "boilerplate/app/presentation/rest/dto/v2"
+	"boilerplate/app/presentation/rest/negotiation"
+	"boilerplate/app/presentation/rest/validation"
+)
+
+// The webhook handlers serve /v2/webhooks only, and answer JSON envelopes only.
+
+// GetWebhooksHandler handles GET /v2/webhooks, listing every webhook
+func (c *Controller) GetWebhooksHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	webhooks, err := c.webhookService.GetWebhooks(ctx)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewWebhookCollection(webhooks))
+}
+
+// CreateWebhookHandler handles POST /v2/webhooks. The answer is the only one carrying the secret
+// deliveries are signed with.
+func (c *Controller) CreateWebhookHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	var webhook dto.Webhook
+	if err := ctx.ShouldBindJSON(&webhook); err != nil {
+		c.handleError(ctx, validation.FromBindError(err))
+		return
+	}
+
+	created, err := c.webhookService.CreateWebhook(ctx, dto.BuildWebhookEntity(webhook))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", v2.WebhookPath(created.ID))
+	c.render(ctx, http.StatusCreated, mediaType, v2.NewWebhookResource(created))
+}
+
+// GetWebhookByIDHandler handles GET /v2/webhooks/:id
+func (c *Controller) GetWebhookByIDHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	webhook, err := c.webhookService.GetWebhookByID(ctx, ctx.Param("id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewWebhookResource(webhook))
+}
+
+// UpdateWebhookHandler handles PUT /v2/webhooks/:id. A missing secret keeps the current one, and
+// enabling a webhook disabled for failing clears its failures.
+func (c *Controller) UpdateWebhookHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	var webhook dto.Webhook
+	if err := ctx.ShouldBindJSON(&webhook); err != nil {
+		c.handleError(ctx, validation.FromBindError(err))
+		return
+	}
+	entityWebhook := dto.BuildWebhookEntity(webhook)
+	entityWebhook.ID = ctx.Param("id")
+
+	updated, err := c.webhookService.UpdateWebhook(ctx, entityWebhook)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewWebhookResource(updated))
+}
+
+// DeleteWebhookHandler handles DELETE /v2/webhooks/:id
+func (c *Controller) DeleteWebhookHandler(ctx *gin.Context) {
+	if err := c.webhookService.DeleteWebhook(ctx, ctx.Param("id")); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveriesHandler handles GET /v2/webhooks/:id/deliveries, listing the latest delivery
+// attempts, newest first. Query parameters: limit.
+func (c *Controller) GetWebhookDeliveriesHandler(ctx *gin.Context) {
+	mediaType, ok := c.negotiate(ctx, negotiation.MIMEJSON)
+	if !ok {
+		return
+	}
+
+	var limit int
+	if value := ctx.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			c.handleError(ctx, errors.NewValidationError(errors.FieldError{
+				Field: "limit", Code: "type", Message: "must be an integer",
+			}))
+			return
+		}
+		limit = n
+	}
+
+	deliveries, err := c.webhookService.GetWebhookDeliveries(ctx, ctx.Param("id"), limit)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	c.render(ctx, http.StatusOK, mediaType, v2.NewWebhookDeliveryCollection(ctx.Request.URL, deliveries))
+}
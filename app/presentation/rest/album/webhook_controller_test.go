@@ -0,0 +1,148 @@
+package album_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/album"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+func TestWebhookHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	enabled := true
+
+	tests := []struct {
+		name             string
+		setupMock        func(*mocks.WebhookInterface)
+		method           string
+		url              string
+		body             string
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
+	}{
+		{
+			name: "List leaves the secrets out",
+			setupMock: func(m *mocks.WebhookInterface) {
+				m.On("GetWebhooks", mock.Anything).Return([]dto.Webhook{{ID: "w1", URL: "https://partner.example/hooks", Enabled: &enabled, CreatedAt: &createdAt, UpdatedAt: &createdAt}}, nil)
+			},
+			method:         "GET",
+			url:            "/v2/webhooks",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"id":"w1","url":"https://partner.example/hooks","events":[],"enabled":true,"failure_count":0,"disabled_at":null,"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z"}],` +
+				`"meta":{"count":1},"links":{"self":"/api/v2/webhooks"}}`,
+		},
+		{
+			name: "Create answers the secret",
+			setupMock: func(m *mocks.WebhookInterface) {
+				m.On("CreateWebhook", mock.Anything, entity.Webhook{URL: "https://partner.example/hooks", Events: []string{"album.deleted"}, Enabled: true}).
+					Return(dto.Webhook{ID: "w1", URL: "https://partner.example/hooks", Secret: "0123456789abcdef", Events: []string{"album.deleted"}, Enabled: &enabled}, nil)
+			},
+			method:         "POST",
+			url:            "/v2/webhooks",
+			body:           `{"url":"https://partner.example/hooks","events":["album.deleted"]}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{"data":{"id":"w1","url":"https://partner.example/hooks","secret":"0123456789abcdef","events":["album.deleted"],"enabled":true,"failure_count":0,"disabled_at":null,"created_at":null,"updated_at":null},` +
+				`"meta":{},"links":{"self":"/api/v2/webhooks/w1","deliveries":"/api/v2/webhooks/w1/deliveries"}}`,
+			expectedLocation: "/api/v2/webhooks/w1",
+		},
+		{
+			name:           "Create with an unknown event",
+			setupMock:      func(*mocks.WebhookInterface) {},
+			method:         "POST",
+			url:            "/v2/webhooks",
+			body:           `{"url":"https://partner.example/hooks","events":["album.played"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/v2/webhooks",` +
+				`"errors":[{"field":"events[0]","code":"enum","message":"must be one of: album.created, album.updated, album.deleted, album.restored"}]}`,
+		},
+		{
+			name: "Update takes the ID from the path",
+			setupMock: func(m *mocks.WebhookInterface) {
+				m.On("UpdateWebhook", mock.Anything, entity.Webhook{ID: "w1", URL: "https://partner.example/v2", Events: []string{}, Enabled: false}).
+					Return(dto.Webhook{ID: "w1", URL: "https://partner.example/v2", Events: []string{}, Enabled: new(bool)}, nil)
+			},
+			method:         "PUT",
+			url:            "/v2/webhooks/w1",
+			body:           `{"url":"https://partner.example/v2","events":[],"enabled":false}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"id":"w1","url":"https://partner.example/v2","events":[],"enabled":false,"failure_count":0,"disabled_at":null,"created_at":null,"updated_at":null},` +
+				`"meta":{},"links":{"self":"/api/v2/webhooks/w1","deliveries":"/api/v2/webhooks/w1/deliveries"}}`,
+		},
+		{
+			name: "Get unknown webhook",
+			setupMock: func(m *mocks.WebhookInterface) {
+				m.On("GetWebhookByID", mock.Anything, "w9").Return(dto.Webhook{}, customerr.ErrWebhookNotFound)
+			},
+			method:         "GET",
+			url:            "/v2/webhooks/w9",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"/problems/webhook_not_found","title":"Webhook not found","status":404,"code":"webhook_not_found","instance":"/v2/webhooks/w9"}`,
+		},
+		{
+			name: "Deliveries",
+			setupMock: func(m *mocks.WebhookInterface) {
+				m.On("GetWebhookDeliveries", mock.Anything, "w1", 2).Return([]dto.WebhookDelivery{
+					{ID: "a2", WebhookID: "w1", DeliveryID: "d1", EventID: "1-0", EventType: "album.created", Attempt: 2, StatusCode: 204, DurationMS: 12, Succeeded: true, CreatedAt: createdAt},
+					{ID: "a1", WebhookID: "w1", DeliveryID: "d1", EventID: "1-0", EventType: "album.created", Attempt: 1, Error: "request timeout", DurationMS: 10000, CreatedAt: createdAt},
+				}, nil)
+			},
+			method:         "GET",
+			url:            "/v2/webhooks/w1/deliveries?limit=2",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[` +
+				`{"id":"a2","delivery_id":"d1","event_id":"1-0","event_type":"album.created","attempt":2,"status_code":204,"error":null,"duration_ms":12,"succeeded":true,"created_at":"2024-01-02T03:04:05Z"},` +
+				`{"id":"a1","delivery_id":"d1","event_id":"1-0","event_type":"album.created","attempt":1,"status_code":null,"error":"request timeout","duration_ms":10000,"succeeded":false,"created_at":"2024-01-02T03:04:05Z"}],` +
+				`"meta":{"count":2},"links":{"self":"/v2/webhooks/w1/deliveries?limit=2"}}`,
+		},
+		{
+			name:           "Deliveries with an invalid limit",
+			setupMock:      func(*mocks.WebhookInterface) {},
+			method:         "GET",
+			url:            "/v2/webhooks/w1/deliveries?limit=ten",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/v2/webhooks/w1/deliveries",` +
+				`"errors":[{"field":"limit","code":"type","message":"must be an integer"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookService := mocks.NewWebhookInterface(t)
+			tt.setupMock(webhookService)
+
+			controller := album.NewController(mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t), album.WithWebhookService(webhookService))
+
+			router := gin.New()
+			router.GET("/v2/webhooks", controller.GetWebhooksHandler)
+			router.POST("/v2/webhooks", controller.CreateWebhookHandler)
+			router.GET("/v2/webhooks/:id", controller.GetWebhookByIDHandler)
+			router.PUT("/v2/webhooks/:id", controller.UpdateWebhookHandler)
+			router.GET("/v2/webhooks/:id/deliveries", controller.GetWebhookDeliveriesHandler)
+
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package v1 maps usecase results to the response bodies of the /api/v1 routes.
+// The v1 API is frozen: these types copy the shape v1 clients were given and must not change,
+// even when the usecase DTOs do. New fields and shapes belong to the v2 package.
+package v1
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+type Album struct {
+	XMLName     xml.Name                    `json:"-" xml:"album"`
+	ID          string                      `json:"id" xml:"id"`
+	Title       string                      `json:"title" xml:"title"`
+	Artist      string                      `json:"artist,omitempty" xml:"artist,omitempty"`
+	ReleaseDate string                      `json:"release_date,omitempty" xml:"release_date,omitempty"`
+	Genre       string                      `json:"genre,omitempty" xml:"genre,omitempty"`
+	TrackCount  int                         `json:"track_count,omitempty" xml:"track_count,omitempty"`
+	Version     int64                       `json:"version,omitempty" xml:"version,omitempty"`
+	CreatedAt   *time.Time                  `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt   *time.Time                  `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+	DeletedAt   *time.Time                  `json:"deleted_at,omitempty" xml:"deleted_at,omitempty"`
+	Tracks      negotiation.Elements[Track] `json:"tracks,omitempty" xml:"tracks,omitempty"`
+}
+
+type AlbumList struct {
+	XMLName    xml.Name `json:"-" xml:"album_list"`
+	Albums     []Album  `json:"albums" xml:"albums>album"`
+	NextCursor string   `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+	PrevCursor string   `json:"prev_cursor,omitempty" xml:"prev_cursor,omitempty"`
+}
+
+type AlbumSearchResults struct {
+	XMLName    xml.Name         `json:"-" xml:"search_results"`
+	Results    []AlbumSearchHit `json:"results" xml:"results>result"`
+	NextOffset int              `json:"next_offset,omitempty" xml:"next_offset,omitempty"`
+}
+
+type AlbumSearchHit struct {
+	Album      Album           `json:"album" xml:"album"`
+	Score      float64         `json:"score" xml:"score"`
+	Highlights negotiation.Map `json:"highlights,omitempty" xml:"highlights,omitempty"`
+}
+
+type AlbumImportReport struct {
+	XMLName xml.Name         `json:"-" xml:"import_report"`
+	DryRun  bool             `json:"dry_run" xml:"dry_run"`
+	Created int              `json:"created" xml:"created"`
+	Skipped int              `json:"skipped" xml:"skipped"`
+	Failed  int              `json:"failed" xml:"failed"`
+	Rows    []AlbumImportRow `json:"rows" xml:"rows>row"`
+}
+
+type AlbumImportRow struct {
+	Line    int                                     `json:"line" xml:"line"`
+	ID      string                                  `json:"id,omitempty" xml:"id,omitempty"`
+	Status  string                                  `json:"status" xml:"status"`
+	Reason  string                                  `json:"reason,omitempty" xml:"reason,omitempty"`
+	Details negotiation.Elements[errors.FieldError] `json:"details,omitempty" xml:"details,omitempty"`
+}
+
+type AlbumPurgeReport struct {
+	XMLName       xml.Name  `json:"-" xml:"purge_report"`
+	DeletedBefore time.Time `json:"deleted_before" xml:"deleted_before"`
+	Purged        int       `json:"purged" xml:"purged"`
+	IDs           []string  `json:"ids" xml:"ids>id"`
+}
+
+// Created is the body answered when a resource is created
+type Created struct {
+	XMLName xml.Name `json:"-" xml:"created"`
+	ID      string   `json:"id" xml:"id"`
+	Message string   `json:"message" xml:"message"`
+}
+
+// albumCSVHeader names the columns of an album in CSV lists
+var albumCSVHeader = []string{"id", "title", "artist", "release_date", "genre", "track_count", "version", "created_at", "updated_at", "deleted_at"}
+
+func (a Album) csvRecord() []string {
+	return []string{
+		a.ID, a.Title, a.Artist, a.ReleaseDate, a.Genre, strconv.Itoa(a.TrackCount), strconv.FormatInt(a.Version, 10),
+		csvTime(a.CreatedAt), csvTime(a.UpdatedAt), csvTime(a.DeletedAt),
+	}
+}
+
+// CSVHeader implements negotiation.Table; the cursors are left out of CSV lists
+func (l AlbumList) CSVHeader() []string {
+	return albumCSVHeader
+}
+
+// CSVRecords implements negotiation.Table
+func (l AlbumList) CSVRecords() [][]string {
+	records := make([][]string, len(l.Albums))
+	for i, album := range l.Albums {
+		records[i] = album.csvRecord()
+	}
+	return records
+}
+
+// CSVHeader implements negotiation.Table; highlights are left out of CSV lists
+func (r AlbumSearchResults) CSVHeader() []string {
+	return append([]string{"score"}, albumCSVHeader...)
+}
+
+// CSVRecords implements negotiation.Table
+func (r AlbumSearchResults) CSVRecords() [][]string {
+	records := make([][]string, len(r.Results))
+	for i, hit := range r.Results {
+		records[i] = append([]string{strconv.FormatFloat(hit.Score, 'f', -1, 64)}, hit.Album.csvRecord()...)
+	}
+	return records
+}
+
+// csvTime formats a time the way JSON bodies do, or as an empty field when it is unknown
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func NewAlbum(album dto.Album) Album {
+	return Album{
+		ID:          album.ID,
+		Title:       album.Title,
+		Artist:      album.Artist,
+		ReleaseDate: album.ReleaseDate,
+		Genre:       album.Genre,
+		TrackCount:  album.TrackCount,
+		Version:     album.Version,
+		CreatedAt:   album.CreatedAt,
+		UpdatedAt:   album.UpdatedAt,
+		DeletedAt:   album.DeletedAt,
+		Tracks:      newTracksOrNil(album.Tracks),
+	}
+}
+
+func NewAlbumList(list dto.AlbumList) AlbumList {
+	albums := make([]Album, len(list.Albums))
+	for i, album := range list.Albums {
+		albums[i] = NewAlbum(album)
+	}
+	return AlbumList{Albums: albums, NextCursor: list.NextCursor, PrevCursor: list.PrevCursor}
+}
+
+func NewAlbumSearchResults(results dto.AlbumSearchResults) AlbumSearchResults {
+	hits := make([]AlbumSearchHit, len(results.Results))
+	for i, hit := range results.Results {
+		hits[i] = AlbumSearchHit{Album: NewAlbum(hit.Album), Score: hit.Score, Highlights: hit.Highlights}
+	}
+	return AlbumSearchResults{Results: hits, NextOffset: results.NextOffset}
+}
+
+func NewAlbumImportReport(report dto.AlbumImportReport) AlbumImportReport {
+	rows := make([]AlbumImportRow, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = AlbumImportRow{Line: row.Line, ID: row.ID, Status: row.Status, Reason: row.Reason, Details: row.Details}
+	}
+	return AlbumImportReport{DryRun: report.DryRun, Created: report.Created, Skipped: report.Skipped, Failed: report.Failed, Rows: rows}
+}
+
+func NewAlbumPurgeReport(report dto.AlbumPurgeReport) AlbumPurgeReport {
+	return AlbumPurgeReport{DeletedBefore: report.DeletedBefore, Purged: report.Purged, IDs: report.IDs}
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"time"
+
+	"boilerplate/app/domain/dto"
+)
+
+// AlbumEvent is a message of the album change stream
+type AlbumEvent struct {
+	ID         string    `json:"id,omitempty"`
+	Type       string    `json:"type"`
+	AlbumID    string    `json:"album_id,omitempty"`
+	Album      *Album    `json:"album,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func NewAlbumEvent(event dto.AlbumEvent) AlbumEvent {
+	result := AlbumEvent{ID: event.ID, Type: event.Type, AlbumID: event.AlbumID, OccurredAt: event.OccurredAt}
+	if event.Album != nil {
+		album := NewAlbum(*event.Album)
+		result.Album = &album
+	}
+	return result
+}
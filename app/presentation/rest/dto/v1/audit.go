@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"encoding/json"
+	"time"
+
+	"boilerplate/app/domain/dto"
+)
+
+// AuditRecord is an entry of the audit log of mutating album requests
+type AuditRecord struct {
+	ID         string          `json:"id"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Method     string          `json:"method"`
+	Route      string          `json:"route"`
+	TargetID   string          `json:"target_id,omitempty"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Outcome    string          `json:"outcome"`
+	StatusCode int             `json:"status_code"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+type AuditList struct {
+	Records    []AuditRecord `json:"records"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+func NewAuditList(list dto.AuditList) AuditList {
+	records := make([]AuditRecord, len(list.Records))
+	for i, record := range list.Records {
+		records[i] = AuditRecord{
+			ID:         record.ID,
+			Actor:      record.Actor,
+			RequestID:  record.RequestID,
+			Method:     record.Method,
+			Route:      record.Route,
+			TargetID:   record.TargetID,
+			Before:     record.Before,
+			After:      record.After,
+			Outcome:    record.Outcome,
+			StatusCode: record.StatusCode,
+			OccurredAt: record.OccurredAt,
+		}
+	}
+	return AuditList{Records: records, NextCursor: list.NextCursor}
+}
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+type Track struct {
+	XMLName         xml.Name   `json:"-" xml:"track"`
+	ID              string     `json:"id" xml:"id"`
+	AlbumID         string     `json:"album_id" xml:"album_id"`
+	Position        int        `json:"position" xml:"position"`
+	Title           string     `json:"title" xml:"title"`
+	DurationSeconds int        `json:"duration_seconds" xml:"duration_seconds"`
+	CreatedAt       *time.Time `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+// Tracks is the body listing the tracks of an album
+type Tracks []Track
+
+// MarshalXML implements xml.Marshaler, wrapping the tracks in a tracks element
+func (t Tracks) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	start := xml.StartElement{Name: xml.Name{Local: "tracks"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, track := range t {
+		if err := e.Encode(track); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// CSVHeader implements negotiation.Table
+func (t Tracks) CSVHeader() []string {
+	return []string{"id", "album_id", "position", "title", "duration_seconds", "created_at", "updated_at"}
+}
+
+// CSVRecords implements negotiation.Table
+func (t Tracks) CSVRecords() [][]string {
+	records := make([][]string, len(t))
+	for i, track := range t {
+		records[i] = []string{
+			track.ID, track.AlbumID, strconv.Itoa(track.Position), track.Title, strconv.Itoa(track.DurationSeconds),
+			csvTime(track.CreatedAt), csvTime(track.UpdatedAt),
+		}
+	}
+	return records
+}
+
+func NewTrack(track dto.Track) Track {
+	return Track{
+		ID:              track.ID,
+		AlbumID:         track.AlbumID,
+		Position:        track.Position,
+		Title:           track.Title,
+		DurationSeconds: track.DurationSeconds,
+		CreatedAt:       track.CreatedAt,
+		UpdatedAt:       track.UpdatedAt,
+	}
+}
+
+// NewTracks maps a list of tracks; the result is never nil, so an empty list is answered as []
+func NewTracks(tracks []dto.Track) Tracks {
+	result := make(Tracks, len(tracks))
+	for i, track := range tracks {
+		result[i] = NewTrack(track)
+	}
+	return result
+}
+
+// newTracksOrNil maps the tracks embedded in an album, which are left out of the body when there are none
+func newTracksOrNil(tracks []dto.Track) negotiation.Elements[Track] {
+	if len(tracks) == 0 {
+		return nil
+	}
+	return negotiation.Elements[Track](NewTracks(tracks))
+}
@@ -0,0 +1,220 @@
+package v2
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+// Album is an album in v2 bodies. Unlike v1, every field is always present, unknown values are null.
+type Album struct {
+	XMLName     xml.Name   `json:"-" xml:"album"`
+	ID          string     `json:"id" xml:"id"`
+	Title       string     `json:"title" xml:"title"`
+	Artist      string     `json:"artist" xml:"artist"`
+	ReleaseDate *string    `json:"release_date" xml:"release_date,omitempty"`
+	Genre       *string    `json:"genre" xml:"genre,omitempty"`
+	TrackCount  int        `json:"track_count" xml:"track_count"`
+	Version     int64      `json:"version" xml:"version"`
+	CreatedAt   *time.Time `json:"created_at" xml:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at" xml:"updated_at,omitempty"`
+	// DeletedAt is only set on albums in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty" xml:"deleted_at,omitempty"`
+	// Tracks is only set when the request asked for them
+	Tracks negotiation.Elements[Track] `json:"tracks,omitempty" xml:"tracks,omitempty"`
+}
+
+// Albums is a collection of albums
+type Albums []Album
+
+type AlbumSearchHit struct {
+	XMLName    xml.Name        `json:"-" xml:"search_hit"`
+	Album      Album           `json:"album" xml:"album"`
+	Score      float64         `json:"score" xml:"score"`
+	Highlights negotiation.Map `json:"highlights" xml:"highlights"`
+}
+
+// AlbumSearchHits is a collection of search results
+type AlbumSearchHits []AlbumSearchHit
+
+type AlbumImportReport struct {
+	XMLName xml.Name         `json:"-" xml:"import_report"`
+	DryRun  bool             `json:"dry_run" xml:"dry_run"`
+	Created int              `json:"created" xml:"created"`
+	Skipped int              `json:"skipped" xml:"skipped"`
+	Failed  int              `json:"failed" xml:"failed"`
+	Rows    []AlbumImportRow `json:"rows" xml:"rows>row"`
+}
+
+type AlbumImportRow struct {
+	Line    int                                     `json:"line" xml:"line"`
+	ID      string                                  `json:"id,omitempty" xml:"id,omitempty"`
+	Status  string                                  `json:"status" xml:"status"`
+	Reason  string                                  `json:"reason,omitempty" xml:"reason,omitempty"`
+	Details negotiation.Elements[errors.FieldError] `json:"details,omitempty" xml:"details,omitempty"`
+}
+
+type AlbumPurgeReport struct {
+	XMLName       xml.Name  `json:"-" xml:"purge_report"`
+	DeletedBefore time.Time `json:"deleted_before" xml:"deleted_before"`
+	Purged        int       `json:"purged" xml:"purged"`
+	IDs           []string  `json:"ids" xml:"ids>id"`
+}
+
+// Created is the data answered when a resource is created; links.self locates it
+type Created struct {
+	XMLName xml.Name `json:"-" xml:"created"`
+	ID      string   `json:"id" xml:"id"`
+}
+
+// albumCSVHeader names the columns of an album in CSV collections
+var albumCSVHeader = []string{"id", "title", "artist", "release_date", "genre", "track_count", "version", "created_at", "updated_at", "deleted_at"}
+
+func (a Album) csvRecord() []string {
+	return []string{
+		a.ID, a.Title, a.Artist, csvString(a.ReleaseDate), csvString(a.Genre), strconv.Itoa(a.TrackCount), strconv.FormatInt(a.Version, 10),
+		csvTime(a.CreatedAt), csvTime(a.UpdatedAt), csvTime(a.DeletedAt),
+	}
+}
+
+// CSVHeader implements negotiation.Table
+func (a Albums) CSVHeader() []string {
+	return albumCSVHeader
+}
+
+// CSVRecords implements negotiation.Table
+func (a Albums) CSVRecords() [][]string {
+	records := make([][]string, len(a))
+	for i, album := range a {
+		records[i] = album.csvRecord()
+	}
+	return records
+}
+
+// CSVHeader implements negotiation.Table; highlights are left out of CSV collections
+func (h AlbumSearchHits) CSVHeader() []string {
+	return append([]string{"score"}, albumCSVHeader...)
+}
+
+// CSVRecords implements negotiation.Table
+func (h AlbumSearchHits) CSVRecords() [][]string {
+	records := make([][]string, len(h))
+	for i, hit := range h {
+		records[i] = append([]string{strconv.FormatFloat(hit.Score, 'f', -1, 64)}, hit.Album.csvRecord()...)
+	}
+	return records
+}
+
+func NewAlbum(album dto.Album) Album {
+	result := Album{
+		ID:          album.ID,
+		Title:       album.Title,
+		Artist:      album.Artist,
+		ReleaseDate: nullable(album.ReleaseDate),
+		Genre:       nullable(album.Genre),
+		TrackCount:  album.TrackCount,
+		Version:     album.Version,
+		CreatedAt:   album.CreatedAt,
+		UpdatedAt:   album.UpdatedAt,
+		DeletedAt:   album.DeletedAt,
+	}
+	if len(album.Tracks) > 0 {
+		result.Tracks = make(negotiation.Elements[Track], len(album.Tracks))
+		for i, track := range album.Tracks {
+			result.Tracks[i] = NewTrack(track)
+		}
+	}
+	return result
+}
+
+// NewAlbumResource wraps a single album
+func NewAlbumResource(album dto.Album) Envelope {
+	return NewResource(NewAlbum(album), Links{Self: AlbumPath(album.ID), Tracks: TracksPath(album.ID)})
+}
+
+// NewAlbumPage wraps a page of albums listed by the request at self, linking to its neighbours
+func NewAlbumPage(self *url.URL, page dto.AlbumList) Envelope {
+	albums := make(Albums, len(page.Albums))
+	for i, album := range page.Albums {
+		albums[i] = NewAlbum(album)
+	}
+	return NewCollection(albums, Links{
+		Self: selfLink(self),
+		Next: pageLink(self, "cursor", page.NextCursor),
+		Prev: pageLink(self, "cursor", page.PrevCursor),
+	})
+}
+
+// NewAlbumSearchPage wraps a page of search results, linking to the next page
+func NewAlbumSearchPage(self *url.URL, results dto.AlbumSearchResults) Envelope {
+	hits := make(AlbumSearchHits, len(results.Results))
+	for i, hit := range results.Results {
+		highlights := hit.Highlights
+		if highlights == nil {
+			highlights = map[string]string{}
+		}
+		hits[i] = AlbumSearchHit{Album: NewAlbum(hit.Album), Score: hit.Score, Highlights: highlights}
+	}
+	next := ""
+	if results.NextOffset > 0 {
+		next = strconv.Itoa(results.NextOffset)
+	}
+	return NewCollection(hits, Links{
+		Self: selfLink(self),
+		Next: pageLink(self, "offset", next),
+	})
+}
+
+// NewAlbumImportReport wraps the outcome of an import
+func NewAlbumImportReport(self *url.URL, report dto.AlbumImportReport) Envelope {
+	rows := make([]AlbumImportRow, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = AlbumImportRow{Line: row.Line, ID: row.ID, Status: row.Status, Reason: row.Reason, Details: row.Details}
+	}
+	return NewResource(AlbumImportReport{
+		DryRun:  report.DryRun,
+		Created: report.Created,
+		Skipped: report.Skipped,
+		Failed:  report.Failed,
+		Rows:    rows,
+	}, Links{Self: selfLink(self)})
+}
+
+// NewAlbumPurgeReport wraps the outcome of a purge
+func NewAlbumPurgeReport(self *url.URL, report dto.AlbumPurgeReport) Envelope {
+	ids := report.IDs
+	if ids == nil {
+		ids = []string{}
+	}
+	return NewResource(AlbumPurgeReport{
+		DeletedBefore: report.DeletedBefore,
+		Purged:        report.Purged,
+		IDs:           ids,
+	}, Links{Self: selfLink(self)})
+}
+
+// NewCreated wraps the ID of a resource created at path
+func NewCreated(id, path string) Envelope {
+	return NewResource(Created{ID: id}, Links{Self: path})
+}
+
+// nullable is nil for an empty string, which v2 answers as null
+func nullable(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// csvString writes a null value as an empty field
+func csvString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,64 @@
+package v2
+
+import (
+	"net/url"
+	"time"
+
+	"boilerplate/app/domain/dto"
+)
+
+// APIKey is answered by the API key routes, which only speak JSON
+type APIKey struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Prefix string `json:"prefix"`
+	// Key is only answered when the key is issued or rotated
+	Key        string     `json:"key,omitempty"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+func NewAPIKey(apiKey dto.APIKey) APIKey {
+	result := APIKey{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Key:        apiKey.Key,
+		Scopes:     apiKey.Scopes,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+		CreatedAt:  apiKey.CreatedAt,
+		UpdatedAt:  apiKey.UpdatedAt,
+	}
+	if result.Scopes == nil {
+		result.Scopes = []string{}
+	}
+	return result
+}
+
+// NewAPIKeyResource wraps a single API key
+func NewAPIKeyResource(apiKey dto.APIKey) Envelope {
+	return NewResource(NewAPIKey(apiKey), Links{Self: APIKeyPath(apiKey.ID)})
+}
+
+// NewAPIKeyCollection wraps every API key
+func NewAPIKeyCollection(apiKeys []dto.APIKey) Envelope {
+	result := make([]APIKey, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		result[i] = NewAPIKey(apiKey)
+	}
+	return NewCollection(result, Links{Self: APIKeysPath})
+}
+
+// APIKeysPath is the path of the API key collection
+const APIKeysPath = BasePath + "/api-keys"
+
+// APIKeyPath is the path of an API key
+func APIKeyPath(id string) string {
+	return APIKeysPath + "/" + url.PathEscape(id)
+}
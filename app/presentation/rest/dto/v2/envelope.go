@@ -0,0 +1,129 @@
+// Package v2 maps usecase results to the response bodies of the /api/v2 routes.
+// Every v2 body is an Envelope: the resource or collection under data, facts about the
+// response under meta, and links to the resource itself and its neighbours under links.
+// Errors are answered as problem details, as in v1.
+package v2
+
+import (
+	"encoding/xml"
+	"net/url"
+	"time"
+
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+// BasePath prefixes every v2 route
+const BasePath = "/api/v2"
+
+// Envelope is the body of every successful v2 response
+type Envelope struct {
+	Data  interface{} `json:"data"`
+	Meta  Meta        `json:"meta"`
+	Links Links       `json:"links"`
+}
+
+// Meta describes the response rather than the resource
+type Meta struct {
+	// Count is the number of items in data, for collections
+	Count *int `json:"count,omitempty" xml:"count,omitempty"`
+}
+
+// Links are URI references relative to the API host
+type Links struct {
+	Self string `json:"self" xml:"self"`
+	// Next and Prev lead to the neighbouring pages of a collection
+	Next string `json:"next,omitempty" xml:"next,omitempty"`
+	Prev string `json:"prev,omitempty" xml:"prev,omitempty"`
+	// Album and Tracks lead to the resources related to the one in data
+	Album  string `json:"album,omitempty" xml:"album,omitempty"`
+	Tracks string `json:"tracks,omitempty" xml:"tracks,omitempty"`
+	// Deliveries leads to the delivery log of the webhook in data
+	Deliveries string `json:"deliveries,omitempty" xml:"deliveries,omitempty"`
+}
+
+// xmlEnvelope is the XML form of an Envelope. The data element holds the resource, or one element
+// per item of a collection, each named after its type: <data><album>...</album></data>.
+type xmlEnvelope struct {
+	XMLName xml.Name `xml:"envelope"`
+	Data    struct {
+		Value interface{}
+	} `xml:"data"`
+	Meta  Meta  `xml:"meta"`
+	Links Links `xml:"links"`
+}
+
+// MarshalXML implements xml.Marshaler
+func (e Envelope) MarshalXML(enc *xml.Encoder, _ xml.StartElement) error {
+	envelope := xmlEnvelope{Meta: e.Meta, Links: e.Links}
+	envelope.Data.Value = e.Data
+	return enc.Encode(envelope)
+}
+
+// CSVHeader implements negotiation.Table for collections; CSV leaves the meta and links out
+func (e Envelope) CSVHeader() []string {
+	if table, ok := e.Data.(negotiation.Table); ok {
+		return table.CSVHeader()
+	}
+	return nil
+}
+
+// CSVRecords implements negotiation.Table for collections
+func (e Envelope) CSVRecords() [][]string {
+	if table, ok := e.Data.(negotiation.Table); ok {
+		return table.CSVRecords()
+	}
+	return nil
+}
+
+// NewResource wraps a single resource
+func NewResource(data interface{}, links Links) Envelope {
+	return Envelope{Data: data, Links: links}
+}
+
+// NewCollection wraps a list of items, counting them
+func NewCollection[S ~[]T, T any](items S, links Links) Envelope {
+	if items == nil {
+		items = S{}
+	}
+	count := len(items)
+	return Envelope{Data: items, Meta: Meta{Count: &count}, Links: links}
+}
+
+// AlbumPath is the path of an album
+func AlbumPath(id string) string {
+	return BasePath + "/albums/" + url.PathEscape(id)
+}
+
+// TracksPath is the path of the tracks of an album
+func TracksPath(albumID string) string {
+	return AlbumPath(albumID) + "/tracks"
+}
+
+// TrackPath is the path of a track of an album
+func TrackPath(albumID, trackID string) string {
+	return TracksPath(albumID) + "/" + url.PathEscape(trackID)
+}
+
+// pageLink is the request URI with one query parameter replaced, or "" when value is empty
+func pageLink(self *url.URL, param, value string) string {
+	if value == "" {
+		return ""
+	}
+	query := self.Query()
+	query.Set(param, value)
+	link := url.URL{Path: self.Path, RawQuery: query.Encode()}
+	return link.String()
+}
+
+// selfLink is the request URI relative to the API host
+func selfLink(self *url.URL) string {
+	return self.RequestURI()
+}
+
+// csvTime formats a time the way JSON bodies do, or as an empty field when it is unknown
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
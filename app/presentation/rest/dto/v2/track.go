@@ -0,0 +1,66 @@
+package v2
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"boilerplate/app/domain/dto"
+)
+
+type Track struct {
+	XMLName         xml.Name   `json:"-" xml:"track"`
+	ID              string     `json:"id" xml:"id"`
+	AlbumID         string     `json:"album_id" xml:"album_id"`
+	Position        int        `json:"position" xml:"position"`
+	Title           string     `json:"title" xml:"title"`
+	DurationSeconds int        `json:"duration_seconds" xml:"duration_seconds"`
+	CreatedAt       *time.Time `json:"created_at" xml:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at" xml:"updated_at,omitempty"`
+}
+
+// Tracks is a collection of tracks
+type Tracks []Track
+
+// CSVHeader implements negotiation.Table
+func (t Tracks) CSVHeader() []string {
+	return []string{"id", "album_id", "position", "title", "duration_seconds", "created_at", "updated_at"}
+}
+
+// CSVRecords implements negotiation.Table
+func (t Tracks) CSVRecords() [][]string {
+	records := make([][]string, len(t))
+	for i, track := range t {
+		records[i] = []string{
+			track.ID, track.AlbumID, strconv.Itoa(track.Position), track.Title, strconv.Itoa(track.DurationSeconds),
+			csvTime(track.CreatedAt), csvTime(track.UpdatedAt),
+		}
+	}
+	return records
+}
+
+func NewTrack(track dto.Track) Track {
+	return Track{
+		ID:              track.ID,
+		AlbumID:         track.AlbumID,
+		Position:        track.Position,
+		Title:           track.Title,
+		DurationSeconds: track.DurationSeconds,
+		CreatedAt:       track.CreatedAt,
+		UpdatedAt:       track.UpdatedAt,
+	}
+}
+
+// NewTrackResource wraps a single track
+func NewTrackResource(track dto.Track) Envelope {
+	return NewResource(NewTrack(track), Links{Self: TrackPath(track.AlbumID, track.ID), Album: AlbumPath(track.AlbumID)})
+}
+
+// NewTrackCollection wraps the tracks of an album
+func NewTrackCollection(albumID string, tracks []dto.Track) Envelope {
+	result := make(Tracks, len(tracks))
+	for i, track := range tracks {
+		result[i] = NewTrack(track)
+	}
+	return NewCollection(result, Links{Self: TracksPath(albumID), Album: AlbumPath(albumID)})
+}
@@ -0,0 +1,101 @@
+package v2
+
+import (
+	"net/url"
+	"time"
+
+	"boilerplate/app/domain/dto"
+)
+
+// Webhook is answered by the webhook routes, which only speak JSON
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret is only answered when the webhook is created
+	Secret       string     `json:"secret,omitempty"`
+	Events       []string   `json:"events"`
+	Enabled      bool       `json:"enabled"`
+	FailureCount int        `json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery is one attempt at delivering an event to a webhook
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	DeliveryID string    `json:"delivery_id"`
+	EventID    *string   `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode *int      `json:"status_code"`
+	Error      *string   `json:"error"`
+	DurationMS int64     `json:"duration_ms"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewWebhook(webhook dto.Webhook) Webhook {
+	result := Webhook{
+		ID:           webhook.ID,
+		URL:          webhook.URL,
+		Secret:       webhook.Secret,
+		Events:       webhook.Events,
+		FailureCount: webhook.FailureCount,
+		DisabledAt:   webhook.DisabledAt,
+		CreatedAt:    webhook.CreatedAt,
+		UpdatedAt:    webhook.UpdatedAt,
+	}
+	if webhook.Enabled != nil {
+		result.Enabled = *webhook.Enabled
+	}
+	if result.Events == nil {
+		result.Events = []string{}
+	}
+	return result
+}
+
+// NewWebhookResource wraps a single webhook
+func NewWebhookResource(webhook dto.Webhook) Envelope {
+	return NewResource(NewWebhook(webhook), Links{Self: WebhookPath(webhook.ID), Deliveries: WebhookPath(webhook.ID) + "/deliveries"})
+}
+
+// NewWebhookCollection wraps every webhook
+func NewWebhookCollection(webhooks []dto.Webhook) Envelope {
+	result := make([]Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		result[i] = NewWebhook(webhook)
+	}
+	return NewCollection(result, Links{Self: WebhooksPath})
+}
+
+// NewWebhookDeliveryCollection wraps the latest delivery attempts of a webhook
+func NewWebhookDeliveryCollection(self *url.URL, deliveries []dto.WebhookDelivery) Envelope {
+	result := make([]WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = WebhookDelivery{
+			ID:         delivery.ID,
+			DeliveryID: delivery.DeliveryID,
+			EventID:    nullable(delivery.EventID),
+			EventType:  delivery.EventType,
+			Attempt:    delivery.Attempt,
+			Error:      nullable(delivery.Error),
+			DurationMS: delivery.DurationMS,
+			Succeeded:  delivery.Succeeded,
+			CreatedAt:  delivery.CreatedAt,
+		}
+		if delivery.StatusCode != 0 {
+			statusCode := delivery.StatusCode
+			result[i].StatusCode = &statusCode
+		}
+	}
+	return NewCollection(result, Links{Self: selfLink(self)})
+}
+
+// WebhooksPath is the path of the webhook collection
+const WebhooksPath = BasePath + "/webhooks"
+
+// WebhookPath is the path of a webhook
+func WebhookPath(id string) string {
+	return WebhooksPath + "/" + url.PathEscape(id)
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/presentation/auth"
+)
+
+// AuditRecorder keeps the audit records of mutating requests without holding them up; it is
+// satisfied by the audit service
+type AuditRecorder interface {
+	RecordAudit(record entity.AuditRecord)
+}
+
+// auditTargetKey is the gin context key SetAuditTarget stores the target ID under
+const auditTargetKey = "audit_target"
+
+// SetAuditTarget names the target of a request whose route does not carry its ID, such as the
+// album a POST creates
+func SetAuditTarget(c *gin.Context, targetID string) {
+	c.Set(auditTargetKey, targetID)
+}
+
+// AuditMiddleware creates a gin middleware handing a record of every request it serves to recorder:
+// the caller authenticated by AuthMiddleware, the request ID given by CommonHeadersMiddleware, the
+// route, the target (the id route parameter, or the ID given to SetAuditTarget) and how the request
+// ended. The before and after snapshots of the target are those the use case captured around its
+// write, through the entity.AuditChange put in the request context. Responses replayed by
+// IdempotencyMiddleware are not recorded again. A nil recorder records nothing.
+func AuditMiddleware(recorder AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if recorder == nil {
+			c.Next()
+			return
+		}
+
+		c.Request = c.Request.WithContext(entity.WithAuditChange(c.Request.Context(), &entity.AuditChange{}))
+		c.Next()
+
+		// A replayed response changed nothing; the request that first got it was recorded
+		if IsIdempotentReplay(c) {
+			return
+		}
+
+		record := NewAuditRecord(c.Request.Context(), c.Request.Method, c.FullPath(), c.Param("id"), c.Writer.Status())
+		if id := c.GetString(auditTargetKey); id != "" {
+			record.TargetID = id
+		}
+		recorder.RecordAudit(record)
+	}
+}
+
+// NewAuditRecord describes an operation the way AuditMiddleware describes requests, for the
+// transports serving album writes outside gin routes: the caller and request ID are read from ctx,
+// and statusCode is the HTTP status of the outcome, that of the REST API for the same error when
+// the transport answers with none. The snapshots are those of the entity.AuditChange in ctx, and a
+// 403 is recorded as denied: the caller lacked a scope and nothing was written.
+func NewAuditRecord(ctx context.Context, method, route, targetID string, statusCode int) entity.AuditRecord {
+	record := entity.AuditRecord{
+		Actor:      "anonymous",
+		Method:     method,
+		Route:      route,
+		TargetID:   targetID,
+		Outcome:    entity.AuditOutcomeFailure,
+		StatusCode: statusCode,
+		OccurredAt: time.Now(),
+	}
+	if claims, ok := auth.GetClaimsFromContext(ctx); ok {
+		record.Actor = auth.Caller(claims)
+	}
+	if headers, ok := GetCommonHeadersFromContext(ctx); ok {
+		record.RequestID = headers.RequestID
+	}
+	if change := entity.AuditChangeFromContext(ctx); change != nil {
+		record.Before, record.After = change.Snapshots()
+	}
+	switch {
+	case statusCode < http.StatusBadRequest:
+		record.Outcome = entity.AuditOutcomeSuccess
+	case statusCode == http.StatusForbidden:
+		record.Outcome = entity.AuditOutcomeDenied
+	}
+	return record
+}
@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/entity"
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+// auditRecorderFunc adapts a function to middleware.AuditRecorder
+type auditRecorderFunc func(record entity.AuditRecord)
+
+func (f auditRecorderFunc) RecordAudit(record entity.AuditRecord) {
+	f(record)
+}
+
+// auditedRouter serves the album writes behind the audit middleware, as the caller holding claims
+func auditedRouter(recorder middleware.AuditRecorder, claims *jwtauth.Claims) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.CommonHeadersMiddleware())
+	authenticate := func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+	}
+	audit := middleware.AuditMiddleware(recorder)
+
+	router.POST("/albums", authenticate, audit, func(c *gin.Context) {
+		middleware.SetAuditTarget(c, "2")
+		c.Status(http.StatusCreated)
+	})
+	router.POST("/albums:action", authenticate, audit, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.DELETE("/albums/:id", authenticate, audit, func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		expected entity.AuditRecord
+	}{
+		{
+			name:   "Delete",
+			method: http.MethodDelete, url: "/albums/1",
+			expected: entity.AuditRecord{
+				Actor: "api-key:k1", RequestID: "req-1", Method: http.MethodDelete, Route: "/albums/:id", TargetID: "1",
+				Outcome: entity.AuditOutcomeSuccess, StatusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "Create",
+			method: http.MethodPost, url: "/albums",
+			expected: entity.AuditRecord{
+				Actor: "api-key:k1", RequestID: "req-1", Method: http.MethodPost, Route: "/albums", TargetID: "2",
+				Outcome: entity.AuditOutcomeSuccess, StatusCode: http.StatusCreated,
+			},
+		},
+		{
+			name:   "No target",
+			method: http.MethodPost, url: "/albums:import",
+			expected: entity.AuditRecord{
+				Actor: "api-key:k1", RequestID: "req-1", Method: http.MethodPost, Route: "/albums:action",
+				Outcome: entity.AuditOutcomeSuccess, StatusCode: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var records []entity.AuditRecord
+			recorder := auditRecorderFunc(func(record entity.AuditRecord) { records = append(records, record) })
+			router := auditedRouter(recorder, &jwtauth.Claims{Subject: "billing", APIKeyID: "k1"})
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			req.Header.Set(middleware.RequestIDHeader, "req-1")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Len(t, records, 1)
+			assert.False(t, records[0].OccurredAt.IsZero())
+			records[0].OccurredAt = tt.expected.OccurredAt
+			assert.Equal(t, tt.expected, records[0])
+		})
+	}
+
+	t.Run("Idempotent replays are not recorded", func(t *testing.T) {
+		var records []entity.AuditRecord
+		recorder := auditRecorderFunc(func(record entity.AuditRecord) { records = append(records, record) })
+		router := gin.New()
+		router.POST("/albums", middleware.AuditMiddleware(recorder), middleware.IdempotencyMiddleware(newMemoryStore(), time.Hour), func(c *gin.Context) {
+			c.Status(http.StatusCreated)
+		})
+
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodPost, "/albums", nil)
+			req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+		}
+		assert.Len(t, records, 1)
+	})
+
+	t.Run("Nil recorder", func(t *testing.T) {
+		router := auditedRouter(nil, &jwtauth.Claims{Subject: "user-1"})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/albums/1", nil))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
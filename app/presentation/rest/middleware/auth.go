@@ -1,36 +1,67 @@
 package middleware
 
 import (
-	"strings"
+	"errors"
+	"log"
 
 	"github.com/gin-gonic/gin"
+
+	domainerrors "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/auth"
 )
 
-// AuthMiddleware creates a gin middleware for handling authentication
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware creates a gin middleware for handling authentication. Requests must carry either a
+// JWT bearer token or, when apiKeys is not nil, an API key in the X-API-Key header. Requests without
+// valid credentials are answered with 401 and the reason; the claims of valid ones are stored in
+// the request context, for auth.GetClaimsFromContext.
+func AuthMiddleware(verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get the Authorization header value
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Authorization header required"})
+		claims, err := auth.AuthenticateCredentials(c.Request.Context(), verifier, apiKeys, c.GetHeader("Authorization"), c.GetHeader("X-API-Key"))
+		if errors.Is(err, auth.ErrCredentialsUnchecked) {
+			log.Printf("Error verifying API key: %v", err)
+			abortWithProblem(c, domainerrors.ErrInternalServer.WithCause(err))
 			return
 		}
-
-		// Split the header into parts, expecting "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Invalid Authorization header format"})
-			return
-		}
-
-		// Here, you would typically validate the token. For this example, we'll just check if the token is "valid"
-		token := parts[1]
-		if token != "valid" { // In real scenarios, you'd check against a token store or JWT validation
-			c.AbortWithStatusJSON(401, gin.H{"error": "Invalid token"})
+		if err != nil {
+			c.Header("WWW-Authenticate", WWWAuthenticate(err))
+			abortWithProblem(c, unauthenticated(err))
 			return
 		}
 
 		// If authentication is successful, proceed to the next handler
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
 		c.Next()
 	}
 }
+
+// unauthenticated returns the typed error a caller rejected with err is answered with: the error
+// itself when it is one, such as a rejected API key, or else an error titled with auth.Reason
+func unauthenticated(err error) error {
+	var typed *domainerrors.Error
+	if errors.As(err, &typed) {
+		return err
+	}
+	return domainerrors.NewError(domainerrors.KindUnauthenticated, "unauthenticated", auth.Reason(err), "unauthenticated").WithCause(err)
+}
+
+// WWWAuthenticate returns the WWW-Authenticate challenge of a request rejected by auth.Authenticate (RFC 6750)
+func WWWAuthenticate(err error) string {
+	switch {
+	case errors.Is(err, auth.ErrAuthorizationRequired), domainerrors.KindOf(err) == domainerrors.KindUnauthenticated:
+		// Rejected API keys are no bearer token the challenge could describe
+		return "Bearer"
+	case errors.Is(err, auth.ErrAuthorizationFormat), errors.Is(err, auth.ErrAuthorizationAmbiguous):
+		return `Bearer error="invalid_request", error_description="` + err.Error() + `"`
+	default:
+		return `Bearer error="invalid_token", error_description="` + err.Error() + `"`
+	}
+}
+
+// requestClient tells the clients of a request apart, by credentials once they are authenticated
+// and by address before
+func requestClient(c *gin.Context) string {
+	if claims, ok := auth.GetClaimsFromContext(c.Request.Context()); ok {
+		return auth.Caller(claims)
+	}
+	return "ip:" + c.ClientIP()
+}
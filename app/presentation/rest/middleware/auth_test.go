@@ -0,0 +1,194 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const secret = "0123456789abcdef0123456789abcdef"
+	keys, err := jwtauth.NewKeySet(jwtauth.KeySources{HMACSecret: secret}, 0)
+	require.NoError(t, err)
+	verifier := jwtauth.NewVerifier(keys, jwtauth.WithIssuer("https://issuer.example"))
+
+	token := func(claims jwt.MapClaims) string {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		require.NoError(t, err)
+		return signed
+	}
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name              string
+		authorization     string
+		expectedStatus    int
+		expectedBody      string
+		expectedChallenge string
+	}{
+		{
+			name:           "Valid token",
+			authorization:  "Bearer " + token(jwt.MapClaims{"sub": "user-1", "iss": "https://issuer.example", "exp": expiresAt}),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"subject":"user-1"}`,
+		},
+		{
+			name:              "Missing header",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/unauthenticated","title":"Authorization header required","status":401,"code":"unauthenticated","instance":"/jsonposts"}`,
+			expectedChallenge: `Bearer`,
+		},
+		{
+			name:              "Wrong scheme",
+			authorization:     "Basic dXNlcjpwYXNz",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/unauthenticated","title":"Invalid Authorization header format","status":401,"code":"unauthenticated","instance":"/jsonposts"}`,
+			expectedChallenge: `Bearer error="invalid_request", error_description="Invalid Authorization header format"`,
+		},
+		{
+			name:              "Expired token",
+			authorization:     "Bearer " + token(jwt.MapClaims{"sub": "user-1", "iss": "https://issuer.example", "exp": time.Now().Add(-time.Hour).Unix()}),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/unauthenticated","title":"Token expired","status":401,"code":"unauthenticated","instance":"/jsonposts"}`,
+			expectedChallenge: `Bearer error="invalid_token", error_description="Token expired"`,
+		},
+		{
+			name:              "Other issuer",
+			authorization:     "Bearer " + token(jwt.MapClaims{"sub": "user-1", "iss": "https://other.example", "exp": expiresAt}),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/unauthenticated","title":"Invalid token issuer","status":401,"code":"unauthenticated","instance":"/jsonposts"}`,
+			expectedChallenge: `Bearer error="invalid_token", error_description="Invalid token issuer"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/jsonposts", middleware.AuthMiddleware(verifier, nil), func(c *gin.Context) {
+				claims, ok := auth.GetClaimsFromContext(c.Request.Context())
+				require.True(t, ok)
+				c.JSON(http.StatusOK, gin.H{"subject": claims.Subject})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/jsonposts", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedChallenge, w.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+// apiKeyVerifierFunc adapts a function to auth.APIKeyVerifier
+type apiKeyVerifierFunc func(ctx context.Context, key string) (entity.APIKey, error)
+
+func (f apiKeyVerifierFunc) VerifyAPIKey(ctx context.Context, key string) (entity.APIKey, error) {
+	return f(ctx, key)
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	keys, err := jwtauth.NewKeySet(jwtauth.KeySources{HMACSecret: "0123456789abcdef0123456789abcdef"}, 0)
+	require.NoError(t, err)
+	verifier := jwtauth.NewVerifier(keys)
+
+	apiKeys := apiKeyVerifierFunc(func(_ context.Context, key string) (entity.APIKey, error) {
+		switch key {
+		case "ak_valid":
+			return entity.APIKey{ID: "k1", Scopes: []string{"albums:read"}}, nil
+		case "ak_revoked":
+			return entity.APIKey{}, customerr.ErrAPIKeyRevoked
+		case "ak_down":
+			return entity.APIKey{}, customerr.ErrInternalServer.WithCause(errors.New("connection refused"))
+		default:
+			return entity.APIKey{}, customerr.ErrAPIKeyInvalid
+		}
+	})
+
+	tests := []struct {
+		name              string
+		apiKey            string
+		authorization     string
+		expectedStatus    int
+		expectedBody      string
+		expectedChallenge string
+	}{
+		{
+			name:           "Valid key",
+			apiKey:         "ak_valid",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"subject":"api-key:k1","scopes":["albums:read"]}`,
+		},
+		{
+			name:              "Unknown key",
+			apiKey:            "ak_unknown",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/api_key_invalid","title":"Invalid API key","status":401,"code":"api_key_invalid","instance":"/jsonposts"}`,
+			expectedChallenge: `Bearer`,
+		},
+		{
+			name:              "Revoked key",
+			apiKey:            "ak_revoked",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/api_key_revoked","title":"API key revoked","status":401,"code":"api_key_revoked","instance":"/jsonposts"}`,
+			expectedChallenge: `Bearer`,
+		},
+		{
+			name:              "Key and token",
+			apiKey:            "ak_valid",
+			authorization:     "Bearer token",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/unauthenticated","title":"Send either an Authorization or an X-API-Key header, not both","status":401,"code":"unauthenticated","instance":"/jsonposts"}`,
+			expectedChallenge: `Bearer error="invalid_request", error_description="Send either an Authorization or an X-API-Key header, not both"`,
+		},
+		{
+			name:           "Verification failing",
+			apiKey:         "ak_down",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"/problems/internal","title":"Internal Server Error","status":500,"code":"internal","instance":"/jsonposts"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/jsonposts", middleware.AuthMiddleware(verifier, apiKeys), func(c *gin.Context) {
+				claims, ok := auth.GetClaimsFromContext(c.Request.Context())
+				require.True(t, ok)
+				c.JSON(http.StatusOK, gin.H{"subject": claims.Subject, "scopes": claims.Scopes})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/jsonposts", nil)
+			req.Header.Set("X-API-Key", tt.apiKey)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedChallenge, w.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControlMiddleware creates a gin middleware setting the Cache-Control header of the routes
+// it guards to value. Only successful and 304 Not Modified responses carry it, so that caches do
+// not keep errors as long as resources. An empty value leaves the header out.
+func CacheControlMiddleware(value string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if value == "" {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, value: value}
+		c.Next()
+	}
+}
+
+// cacheControlWriter sets Cache-Control as the status of the response is written
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	w.setHeader(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	w.setHeader(w.Status())
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.setHeader(w.Status())
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.setHeader(w.Status())
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *cacheControlWriter) setHeader(status int) {
+	if w.Written() {
+		return
+	}
+	if status < http.StatusBadRequest {
+		w.Header().Set("Cache-Control", w.value)
+	} else {
+		w.Header().Del("Cache-Control")
+	}
+}
@@ -0,0 +1,63 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+func TestCacheControlMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		value    string
+		handler  gin.HandlerFunc
+		expected string
+	}{
+		{
+			name:     "Success",
+			value:    "private, max-age=60",
+			handler:  func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"id": "1"}) },
+			expected: "private, max-age=60",
+		},
+		{
+			name:     "Not modified",
+			value:    "private, max-age=60",
+			handler:  func(c *gin.Context) { c.Status(http.StatusNotModified) },
+			expected: "private, max-age=60",
+		},
+		{
+			name:     "Body written without a status",
+			value:    "no-cache",
+			handler:  func(c *gin.Context) { _, _ = c.Writer.WriteString("ok") },
+			expected: "no-cache",
+		},
+		{
+			name:    "Error",
+			value:   "private, max-age=60",
+			handler: func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"code": "album_not_found"}) },
+		},
+		{
+			name:    "Not configured",
+			handler: func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"id": "1"}) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/albums/:id", middleware.CacheControlMiddleware(tt.value), tt.handler)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/albums/1", nil))
+
+			assert.Equal(t, tt.expected, w.Header().Get("Cache-Control"))
+		})
+	}
+}
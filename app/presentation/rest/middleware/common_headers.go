@@ -4,8 +4,12 @@ import (
 	"context"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
+// RequestIDHeader carries the ID that ties a request to its logs and error responses
+const RequestIDHeader = "X-Request-ID"
+
 // CommonHeaders stores the headers we want to capture from the request
 type CommonHeaders struct {
 	RequestID string
@@ -16,13 +20,18 @@ type CommonHeaders struct {
 // commonHeadersKey is used as a unique key for storing CommonHeaders in the context
 type commonHeadersKey struct{}
 
-// CommonHeadersMiddleware creates a gin middleware for capturing common headers
+// CommonHeadersMiddleware creates a gin middleware for capturing common headers.
+// Requests arriving without a request ID are given one, and the ID is echoed in the response.
 func CommonHeadersMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		headers := &CommonHeaders{
-			RequestID: c.GetHeader("X-Request-ID"), // Custom header for request tracking
-			UserAgent: c.GetHeader("User-Agent"),   // Browser or client identifier
+			RequestID: c.GetHeader(RequestIDHeader), // Custom header for request tracking
+			UserAgent: c.GetHeader("User-Agent"),    // Browser or client identifier
+		}
+		if headers.RequestID == "" {
+			headers.RequestID = uuid.NewString()
 		}
+		c.Header(RequestIDHeader, headers.RequestID)
 
 		// Store the headers in the context
 		ctx := context.WithValue(c.Request.Context(), commonHeadersKey{}, headers)
@@ -0,0 +1,386 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+
+	customerr "boilerplate/app/domain/errors"
+)
+
+// Content codings the compression middleware reads and writes
+const (
+	encodingBrotli   = "br"
+	encodingGzip     = "gzip"
+	encodingIdentity = "identity"
+)
+
+// Reasons a request body is refused by CompressionMiddleware
+var (
+	ErrUnsupportedContentEncoding = customerr.NewError(customerr.KindUnsupportedMediaType, "unsupported_content_encoding", "Content-Encoding must be gzip, br or identity", "unsupported content encoding")
+	ErrMalformedRequestBody       = customerr.NewError(customerr.KindInvalidInput, "malformed_request_body", "Request body does not match its Content-Encoding", "request body does not match its content encoding")
+)
+
+// ErrRequestBodyTooLarge is the error read from a compressed request body once its content goes
+// past the size CompressionMiddleware allows
+var ErrRequestBodyTooLarge = customerr.NewError(customerr.KindTooLarge, "request_body_too_large", "Request body is too large", "request body too large")
+
+// CompressionMiddleware creates a gin middleware compressing responses with brotli or gzip, as
+// the Accept-Encoding header of the request prefers, and decompressing request bodies sent with
+// either coding. Only bodies of a compressible content type and at least minSize bytes long are
+// compressed; every response of a compressible type varies with Accept-Encoding, whichever its size.
+// The coding is appended to the entity tag of compressed responses, "3-json" becoming "3-json-gzip",
+// and taken off the tags of If-None-Match, so that handlers compare the tags they issue.
+// A 304 carries no body to decide from: handlers give it the Content-Type of the representation it
+// stands for, which is dropped here once Vary is set from it, and its tag takes the coding only when
+// the tag the client revalidates has it, the full representation having been compressed.
+// Reading more than maxBodySize bytes out of a compressed request body fails with
+// ErrRequestBodyTooLarge, which handlers answer with 413.
+// It must run outside TimeoutMiddleware, which hands it the response once the handler is done.
+func CompressionMiddleware(minSize int, maxBodySize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := decompressRequest(c.Request, maxBodySize); err != nil {
+			abortWithProblem(c, err)
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, encoding: negotiateEncoding(c.GetHeader("Accept-Encoding")), minSize: minSize}
+		c.Writer = writer
+		if values := c.Request.Header.Values("If-None-Match"); writer.encoding != "" && len(values) > 0 {
+			writer.ifNoneMatch = strings.Join(values, ",")
+			c.Request.Header.Set("If-None-Match", trimETagCoding(writer.ifNoneMatch, writer.encoding))
+		}
+		// A panic leaves the response to the recovery middleware, written as is
+		defer func() { c.Writer = writer.ResponseWriter }()
+
+		c.Next()
+
+		if err := writer.close(); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
+
+// decompressRequest replaces a request body compressed with gzip or brotli by its content, of at
+// most maxBodySize bytes. The Content-Encoding and Content-Length headers go with the compression,
+// so the handlers see the request as if it were sent as is.
+func decompressRequest(req *http.Request, maxBodySize int64) error {
+	var reader io.Reader
+	switch strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding"))) {
+	case "", encodingIdentity:
+		req.Header.Del("Content-Encoding")
+		return nil
+	case encodingGzip, "x-gzip":
+		if req.ContentLength == 0 {
+			break
+		}
+		gzipReader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return ErrMalformedRequestBody
+		}
+		reader = gzipReader
+	case encodingBrotli:
+		if req.ContentLength == 0 {
+			break
+		}
+		reader = brotli.NewReader(req.Body)
+	default:
+		return ErrUnsupportedContentEncoding
+	}
+
+	req.Header.Del("Content-Encoding")
+	if reader != nil {
+		req.Body = &decompressedBody{reader: reader, remaining: maxBodySize, body: req.Body}
+		req.Header.Del("Content-Length")
+		req.ContentLength = -1
+	}
+	return nil
+}
+
+// decompressedBody reads a request body through its decompressor, failing with
+// ErrRequestBodyTooLarge past remaining bytes, and closes the original body
+type decompressedBody struct {
+	reader    io.Reader
+	remaining int64
+	body      io.ReadCloser
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Only a body with more to read is too large
+		n, err := b.reader.Read(make([]byte, 1))
+		if n > 0 {
+			return 0, ErrRequestBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.reader.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *decompressedBody) Close() error {
+	return b.body.Close()
+}
+
+// trimETagCoding takes the coding appended by CompressionMiddleware off the entity tags of a list
+func trimETagCoding(list string, encoding string) string {
+	tags := strings.Split(list, ",")
+	for i, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		tags[i] = strings.Replace(tag, "-"+encoding+`"`, `"`, 1)
+	}
+	return strings.Join(tags, ", ")
+}
+
+// hasETag tells whether an If-None-Match list holds etag, compared weakly
+func hasETag(list string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// withETagCoding appends a content coding to an entity tag, weak or strong
+func withETagCoding(etag string, encoding string) string {
+	if !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// negotiateEncoding returns the content coding an Accept-Encoding header prefers among brotli
+// and gzip, or "" when the body is better sent as is. Codings the header leaves out take the
+// weight of "*" if present; identity is acceptable unless refused, and compression wins ties.
+func negotiateEncoding(header string) string {
+	if strings.TrimSpace(header) == "" {
+		return ""
+	}
+
+	weights := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		coding, weight, ok := parseEncoding(part)
+		if !ok {
+			continue
+		}
+		if coding == "x-gzip" {
+			coding = encodingGzip
+		}
+		weights[coding] = weight
+	}
+	weightOf := func(coding string, fallback float64) float64 {
+		if weight, ok := weights[coding]; ok {
+			return weight
+		}
+		if weight, ok := weights["*"]; ok {
+			return weight
+		}
+		return fallback
+	}
+
+	best, bestWeight := "", 0.0
+	for _, coding := range []string{encodingBrotli, encodingGzip} {
+		if weight := weightOf(coding, 0); weight > bestWeight {
+			best, bestWeight = coding, weight
+		}
+	}
+	if best == "" || bestWeight < weightOf(encodingIdentity, 1) {
+		return ""
+	}
+	return best
+}
+
+// parseEncoding splits an Accept-Encoding element into its lowercased coding and weight
+func parseEncoding(part string) (string, float64, bool) {
+	coding, params, _ := strings.Cut(part, ";")
+	coding = strings.ToLower(strings.TrimSpace(coding))
+	if coding == "" {
+		return "", 0, false
+	}
+
+	weight := 1.0
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "q") {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			return "", 0, false
+		}
+		weight = parsed
+	}
+	return coding, weight, true
+}
+
+// isCompressible tells whether a content type is text-based and therefore worth compressing
+func isCompressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-ndjson", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// compressWriter holds back the start of a response body until minSize bytes have been written
+// or the handler is done, then decides from them whether to compress the response
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	// ifNoneMatch is the If-None-Match header of the request, before the coding is taken off its tags
+	ifNoneMatch string
+	buffer      []byte
+	decided     bool
+	encoder     io.WriteCloser
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer = append(w.buffer, data...)
+		if len(w.buffer) < w.minSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow sends the headers, deciding on compression from what has been written so far
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush sends what has been written so far, compressed if it is being compressed
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) Size() int {
+	if !w.decided {
+		if len(w.buffer) == 0 {
+			return w.ResponseWriter.Size()
+		}
+		return len(w.buffer)
+	}
+	return w.ResponseWriter.Size()
+}
+
+func (w *compressWriter) Written() bool {
+	return len(w.buffer) > 0 || w.ResponseWriter.Written()
+}
+
+// decide sets the headers of the response, compressed or not, and sends the buffered body
+func (w *compressWriter) decide() error {
+	w.decided = true
+	header := w.Header()
+	etag := header.Get("ETag")
+	if w.Status() == http.StatusNotModified {
+		// A 304 answers for the representation the client holds, compressed if its tag says so
+		if isCompressible(header.Get("Content-Type")) {
+			header.Add("Vary", "Accept-Encoding")
+		}
+		header.Del("Content-Type")
+		if etag != "" && w.encoding != "" && hasETag(w.ifNoneMatch, withETagCoding(etag, w.encoding)) {
+			header.Set("ETag", withETagCoding(etag, w.encoding))
+		}
+	} else if w.compressible(header) {
+		header.Add("Vary", "Accept-Encoding")
+		if w.encoding != "" && len(w.buffer) >= w.minSize && len(w.buffer) > 0 {
+			header.Set("Content-Encoding", w.encoding)
+			header.Del("Content-Length")
+			w.encoder = newEncoder(w.encoding, w.ResponseWriter)
+		}
+	}
+	if etag != "" && w.encoder != nil {
+		header.Set("ETag", withETagCoding(etag, w.encoding))
+	}
+
+	buffered := w.buffer
+	w.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buffered)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buffered)
+	return err
+}
+
+// compressible tells whether the response may carry a compressed body: it has a body, is not
+// encoded already and its content type is compressible
+func (w *compressWriter) compressible(header http.Header) bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	return isCompressible(header.Get("Content-Type"))
+}
+
+// close sends what is still buffered and ends the compressed stream
+func (w *compressWriter) close() error {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+	if w.encoder != nil {
+		return w.encoder.Close()
+	}
+	return nil
+}
+
+func newEncoder(encoding string, w io.Writer) io.WriteCloser {
+	if encoding == encodingBrotli {
+		return brotli.NewWriterLevel(w, brotli.DefaultCompression)
+	}
+	return gzip.NewWriter(w)
+}
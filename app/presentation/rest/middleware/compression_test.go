@@ -0,0 +1,286 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+func TestCompressionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	large := strings.Repeat("Blue Train ", 20)
+	jsonHandler := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"title": large}) }
+
+	tests := []struct {
+		name             string
+		acceptEncoding   string
+		handler          gin.HandlerFunc
+		expectedEncoding string
+		expectedVary     []string
+	}{
+		{name: "Gzip", acceptEncoding: "gzip", handler: jsonHandler, expectedEncoding: "gzip", expectedVary: []string{"Accept-Encoding"}},
+		{name: "Brotli preferred on ties", acceptEncoding: "gzip, deflate, br", handler: jsonHandler, expectedEncoding: "br", expectedVary: []string{"Accept-Encoding"}},
+		{name: "Highest weight wins", acceptEncoding: "br;q=0.5, gzip", handler: jsonHandler, expectedEncoding: "gzip", expectedVary: []string{"Accept-Encoding"}},
+		{name: "Wildcard", acceptEncoding: "*", handler: jsonHandler, expectedEncoding: "br", expectedVary: []string{"Accept-Encoding"}},
+		{name: "Identity preferred", acceptEncoding: "gzip;q=0.5, identity", handler: jsonHandler, expectedVary: []string{"Accept-Encoding"}},
+		{name: "Codings refused", acceptEncoding: "br;q=0, gzip;q=0", handler: jsonHandler, expectedVary: []string{"Accept-Encoding"}},
+		{name: "No Accept-Encoding", handler: jsonHandler, expectedVary: []string{"Accept-Encoding"}},
+		{
+			name:           "Body below the minimum size",
+			acceptEncoding: "gzip",
+			handler:        func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"title": "Blue Train"}) },
+			expectedVary:   []string{"Accept-Encoding"},
+		},
+		{
+			name:           "Content type not compressible",
+			acceptEncoding: "gzip",
+			handler:        func(c *gin.Context) { c.Data(http.StatusOK, "image/png", []byte(large)) },
+		},
+		{
+			name:           "Body encoded already",
+			acceptEncoding: "gzip",
+			handler: func(c *gin.Context) {
+				c.Header("Content-Encoding", "br")
+				c.Data(http.StatusOK, "application/json", []byte(large))
+			},
+			expectedEncoding: "br",
+		},
+		{
+			name:           "Not modified",
+			acceptEncoding: "gzip",
+			handler:        func(c *gin.Context) { c.Status(http.StatusNotModified) },
+		},
+		{
+			name:           "Vary appended to other values",
+			acceptEncoding: "gzip",
+			handler: func(c *gin.Context) {
+				c.Writer.Header().Add("Vary", "Accept")
+				jsonHandler(c)
+			},
+			expectedEncoding: "gzip",
+			expectedVary:     []string{"Accept", "Accept-Encoding"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without compression, the handler's response is sent as is
+			plain := httptest.NewRecorder()
+			uncompressed := gin.New()
+			uncompressed.GET("/albums", tt.handler)
+			uncompressed.ServeHTTP(plain, httptest.NewRequest(http.MethodGet, "/albums", nil))
+
+			router := gin.New()
+			router.Use(middleware.CompressionMiddleware(100, 1<<20))
+			router.GET("/albums", tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/albums", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, plain.Code, w.Code)
+			assert.Equal(t, tt.expectedEncoding, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.expectedVary, w.Header().Values("Vary"))
+
+			// Only the coding added by the middleware is undone
+			added := w.Header().Get("Content-Encoding")
+			if added == plain.Header().Get("Content-Encoding") {
+				added = ""
+			}
+			assert.Equal(t, plain.Body.String(), decode(t, added, w.Body.Bytes()))
+			if added != "" {
+				assert.Less(t, w.Body.Len(), plain.Body.Len())
+			}
+		})
+	}
+}
+
+func TestCompressionMiddleware_RequestBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := `{"title":"Blue Train","artist":"John Coltrane","price":56.99}`
+
+	tests := []struct {
+		name            string
+		contentEncoding string
+		body            []byte
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{name: "Gzip", contentEncoding: "gzip", body: encode(t, "gzip", body), expectedStatus: http.StatusOK, expectedBody: body},
+		{name: "Brotli", contentEncoding: "br", body: encode(t, "br", body), expectedStatus: http.StatusOK, expectedBody: body},
+		{name: "Identity", contentEncoding: "identity", body: []byte(body), expectedStatus: http.StatusOK, expectedBody: body},
+		{name: "Not encoded", body: []byte(body), expectedStatus: http.StatusOK, expectedBody: body},
+		{name: "Unsupported coding", contentEncoding: "deflate", body: []byte(body), expectedStatus: http.StatusUnsupportedMediaType, expectedBody: `{"type":"/problems/unsupported_content_encoding","title":"Content-Encoding must be gzip, br or identity","status":415,"code":"unsupported_content_encoding","instance":"/albums"}` + "\n"},
+		{name: "Not valid gzip", contentEncoding: "gzip", body: []byte(body), expectedStatus: http.StatusBadRequest, expectedBody: `{"type":"/problems/malformed_request_body","title":"Request body does not match its Content-Encoding","status":400,"code":"malformed_request_body","instance":"/albums"}` + "\n"},
+		{name: "Content too large", contentEncoding: "gzip", body: encode(t, "gzip", body+" "), expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "Brotli content too large", contentEncoding: "br", body: encode(t, "br", strings.Repeat(body, 1000)), expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "Uncompressed body not limited", body: []byte(body + " "), expectedStatus: http.StatusOK, expectedBody: body + " "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			// The body is limited to the size of its content
+			router.Use(middleware.CompressionMiddleware(1024, int64(len(body))))
+			router.POST("/albums", func(c *gin.Context) {
+				assert.Empty(t, c.GetHeader("Content-Encoding"))
+				data, err := io.ReadAll(c.Request.Body)
+				if errors.Is(err, middleware.ErrRequestBodyTooLarge) {
+					c.Status(http.StatusRequestEntityTooLarge)
+					return
+				}
+				require.NoError(t, err)
+				c.String(http.StatusOK, string(data))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/albums", bytes.NewReader(tt.body))
+			if tt.contentEncoding != "" {
+				req.Header.Set("Content-Encoding", tt.contentEncoding)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompressionMiddleware_WithTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	large := strings.Repeat("Blue Train ", 200)
+
+	router := gin.New()
+	router.Use(middleware.CompressionMiddleware(1024, 1<<20))
+	router.Use(middleware.TimeoutMiddleware(&config.AppConfig{HandlerTimeout: time.Second}))
+	router.GET("/albums", func(c *gin.Context) {
+		// Written in pieces, each below the minimum size
+		c.Header("Content-Type", "text/plain; charset=utf-8")
+		for _, word := range strings.SplitAfter(large, " ") {
+			_, _ = c.Writer.WriteString(word)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/albums", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Equal(t, large, decode(t, "gzip", w.Body.Bytes()))
+}
+
+func TestCompressionMiddleware_ETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	large := strings.Repeat("Blue Train ", 20)
+
+	// Each route answers 304 as the controllers do, naming the type of the representation
+	route := func(etag, contentType string, body []byte) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Header("ETag", etag)
+			if c.GetHeader("If-None-Match") == etag {
+				c.Header("Content-Type", contentType)
+				c.Status(http.StatusNotModified)
+				return
+			}
+			c.Data(http.StatusOK, contentType, body)
+		}
+	}
+	router := gin.New()
+	router.Use(middleware.CompressionMiddleware(100, 1<<20))
+	router.GET("/albums/1", route(`"3-json"`, "application/json; charset=utf-8", []byte(`{"title":"`+large+`"}`)))
+	router.GET("/albums/2", route(`"4-json"`, "application/json; charset=utf-8", []byte(`{"title":"Giant Steps"}`)))
+	router.GET("/albums/3", route(`"3-msgpack"`, "application/x-msgpack", []byte(large)))
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		ifNoneMatch    string
+		expectedStatus int
+		expectedETag   string
+		expectedVary   string
+	}{
+		{name: "Compressed", path: "/albums/1", acceptEncoding: "gzip", expectedStatus: http.StatusOK, expectedETag: `"3-json-gzip"`, expectedVary: "Accept-Encoding"},
+		{name: "Not compressed", path: "/albums/1", expectedStatus: http.StatusOK, expectedETag: `"3-json"`, expectedVary: "Accept-Encoding"},
+		{name: "Compressed tag revalidated", path: "/albums/1", acceptEncoding: "gzip", ifNoneMatch: `"3-json-gzip"`, expectedStatus: http.StatusNotModified, expectedETag: `"3-json-gzip"`, expectedVary: "Accept-Encoding"},
+		{name: "Other coding", path: "/albums/1", acceptEncoding: "br", ifNoneMatch: `"3-json-gzip"`, expectedStatus: http.StatusOK, expectedETag: `"3-json-br"`, expectedVary: "Accept-Encoding"},
+		{name: "Compressed tag without compression", path: "/albums/1", ifNoneMatch: `"3-json-gzip"`, expectedStatus: http.StatusOK, expectedETag: `"3-json"`, expectedVary: "Accept-Encoding"},
+		{name: "Too small to compress", path: "/albums/2", acceptEncoding: "gzip", expectedStatus: http.StatusOK, expectedETag: `"4-json"`, expectedVary: "Accept-Encoding"},
+		{name: "Uncompressed tag revalidated", path: "/albums/2", acceptEncoding: "gzip", ifNoneMatch: `"4-json"`, expectedStatus: http.StatusNotModified, expectedETag: `"4-json"`, expectedVary: "Accept-Encoding"},
+		{name: "Not compressible", path: "/albums/3", acceptEncoding: "gzip", expectedStatus: http.StatusOK, expectedETag: `"3-msgpack"`},
+		{name: "Not compressible revalidated", path: "/albums/3", acceptEncoding: "gzip", ifNoneMatch: `"3-msgpack"`, expectedStatus: http.StatusNotModified, expectedETag: `"3-msgpack"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, tt.expectedVary, w.Header().Get("Vary"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+// encode compresses s with a content coding
+func encode(t *testing.T, encoding string, s string) []byte {
+	var buf bytes.Buffer
+	var writer io.WriteCloser = gzip.NewWriter(&buf)
+	if encoding == "br" {
+		writer = brotli.NewWriter(&buf)
+	}
+	_, err := writer.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+// decode returns the content of a body sent with a content coding, if any
+func decode(t *testing.T, encoding string, body []byte) string {
+	var reader io.Reader = bytes.NewReader(body)
+	switch encoding {
+	case "gzip":
+		gzipReader, err := gzip.NewReader(reader)
+		require.NoError(t, err)
+		reader = gzipReader
+	case "br":
+		reader = brotli.NewReader(reader)
+	}
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecationMiddleware creates a gin middleware announcing that the routes it guards are deprecated.
+// The Deprecation header (RFC 9745) carries when they were deprecated and the Sunset header (RFC 8594)
+// when they stop being served; each is left out while its time is zero. The link points clients to
+// the documentation of the replacement.
+func DeprecationMiddleware(deprecatedAt, sunsetAt time.Time, link string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !deprecatedAt.IsZero() {
+			c.Header("Deprecation", "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+			if link != "" {
+				c.Header("Link", "<"+link+`>; rel="deprecation"`)
+			}
+		}
+		if !sunsetAt.IsZero() {
+			c.Header("Sunset", sunsetAt.UTC().Format(http.TimeFormat))
+		}
+
+		c.Next()
+	}
+}
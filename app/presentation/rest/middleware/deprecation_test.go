@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+func serveDeprecated(deprecatedAt, sunsetAt time.Time) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/old", middleware.DeprecationMiddleware(deprecatedAt, sunsetAt, "/docs"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/old", nil))
+	return w
+}
+
+func TestDeprecationMiddleware_SetsHeaders(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2027, 4, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	w := serveDeprecated(deprecatedAt, sunsetAt)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "@1790812800", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Thu, 01 Apr 2027 10:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</docs>; rel="deprecation"`, w.Header().Get("Link"))
+}
+
+func TestDeprecationMiddleware_OmitsUnsetHeaders(t *testing.T) {
+	w := serveDeprecated(time.Time{}, time.Time{})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+	assert.Empty(t, w.Header().Get("Link"))
+}
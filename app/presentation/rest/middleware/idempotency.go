@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	customerr "boilerplate/app/domain/errors"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client-chosen idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Reasons a request is refused by IdempotencyMiddleware
+var (
+	ErrIdempotencyKeyInUse  = customerr.NewError(customerr.KindConflict, "idempotency_key_in_use", "Request with this Idempotency-Key is being processed", "idempotency key in use")
+	ErrIdempotencyKeyReused = customerr.NewError(customerr.KindUnprocessable, "idempotency_key_reused", "Idempotency-Key was already used with a different request", "idempotency key reused")
+)
+
+// IdempotencyStore is the storage used to remember responses per idempotency key.
+// It is satisfied by *redis.RedisCache.
+type IdempotencyStore interface {
+	GetFromCache(key string) ([]byte, error)
+	SetToCache(key string, value interface{}, expiration time.Duration) error
+	SetToCacheIfAbsent(key string, value interface{}, expiration time.Duration) (bool, error)
+	DeleteFromCache(keys ...string) error
+}
+
+// idempotentReplayKey is the gin context key marking a response replayed from the store
+const idempotentReplayKey = "idempotent_replay"
+
+// IsIdempotentReplay tells whether IdempotencyMiddleware answered the request with the stored
+// response of an earlier one, without running the handler
+func IsIdempotentReplay(c *gin.Context) bool {
+	return c.GetBool(idempotentReplayKey)
+}
+
+// idempotencyRecord is what is stored per key: the request fingerprint and, once finished, the response
+type idempotencyRecord struct {
+	RequestHash string `json:"request_hash"`
+	Pending     bool   `json:"pending,omitempty"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// IdempotencyMiddleware replays the first response to requests repeated by the same client with the
+// same Idempotency-Key; keys chosen by different clients never collide.
+// Reusing a key with a different request body is answered with 422, and reusing it while the
+// first request is still running with 409. Requests without the header are passed through.
+// What is remembered is the response of the handler, even when it finished past the timeout of
+// TimeoutMiddleware and the client was answered with 408, since the change it made is kept.
+func IdempotencyMiddleware(store IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if errors.Is(err, ErrRequestBodyTooLarge) {
+			abortWithProblem(c, err)
+			return
+		}
+		if err != nil {
+			abortWithProblem(c, customerr.ErrInvalidInput.WithCause(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(sum[:])
+		storeKey := "idempotency:" + requestClient(c) + ":" + c.Request.Method + ":" + c.FullPath() + ":" + key
+
+		acquired, err := store.SetToCacheIfAbsent(storeKey, idempotencyRecord{RequestHash: requestHash, Pending: true}, ttl)
+		if err != nil {
+			// Don't fail the request if the store is unavailable; it is simply not deduplicated
+			log.Printf("Idempotency store unavailable for key %s: %v", key, err)
+			c.Next()
+			return
+		}
+
+		if !acquired {
+			replayIdempotentResponse(c, store, storeKey, requestHash)
+			return
+		}
+
+		release := func() {
+			if err := store.DeleteFromCache(storeKey); err != nil {
+				log.Printf("Failed to release idempotency key %s: %v", key, err)
+			}
+		}
+		// A panicking handler leaves nothing to replay, so the key is freed for a retry
+		defer func() {
+			if p := recover(); p != nil {
+				release()
+				panic(p)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Server errors are not remembered so that the client can retry them
+		status := recorder.handlerStatus()
+		if status >= http.StatusInternalServerError {
+			release()
+			return
+		}
+
+		record := idempotencyRecord{
+			RequestHash: requestHash,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.SetToCache(storeKey, record, ttl); err != nil {
+			log.Printf("Failed to store idempotent response for key %s: %v", key, err)
+		}
+	}
+}
+
+// replayIdempotentResponse answers a repeated request from the stored record
+func replayIdempotentResponse(c *gin.Context, store IdempotencyStore, storeKey string, requestHash string) {
+	data, err := store.GetFromCache(storeKey)
+	if err != nil {
+		// The record expired between the two calls; let the client retry
+		abortWithProblem(c, ErrIdempotencyKeyInUse)
+		return
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		abortWithProblem(c, customerr.ErrInternalServer.WithCause(err))
+		return
+	}
+
+	switch {
+	case record.RequestHash != requestHash:
+		abortWithProblem(c, ErrIdempotencyKeyReused)
+	case record.Pending:
+		abortWithProblem(c, ErrIdempotencyKeyInUse)
+	default:
+		c.Set(idempotentReplayKey, true)
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(record.Status, record.ContentType, record.Body)
+		c.Abort()
+	}
+}
+
+// responseRecorder keeps a copy of the status and body the handler wrote while writing them
+// through. The copy is the handler's own, even when the writer underneath drops them because the
+// request timed out.
+type responseRecorder struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if code > 0 && r.body.Len() == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// handlerStatus returns the status the handler answered with, 200 unless it set one
+func (r *responseRecorder) handlerStatus() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
@@ -0,0 +1,228 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+// memoryStore is an in-memory IdempotencyStore
+type memoryStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{data: map[string][]byte{}}
+}
+
+func (m *memoryStore) GetFromCache(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.data[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return value, nil
+}
+
+func (m *memoryStore) SetToCache(key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = data
+	return nil
+}
+
+func (m *memoryStore) SetToCacheIfAbsent(key string, value interface{}, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	_, exists := m.data[key]
+	m.mu.Unlock()
+	if exists {
+		return false, nil
+	}
+	return true, m.SetToCache(key, value, expiration)
+}
+
+func (m *memoryStore) DeleteFromCache(keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.data, key)
+	}
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := newMemoryStore()
+	calls := 0
+	status := http.StatusCreated
+
+	router := gin.New()
+	router.POST("/albums", middleware.IdempotencyMiddleware(store, time.Hour), func(c *gin.Context) {
+		calls++
+		c.JSON(status, gin.H{"call": calls})
+	})
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/albums", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// First request runs the handler
+	first := send("key-1", `{"title":"A"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.JSONEq(t, `{"call":1}`, first.Body.String())
+
+	// A retry with the same body is replayed without running the handler
+	retry := send("key-1", `{"title":"A"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.JSONEq(t, `{"call":1}`, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, calls)
+
+	// The same key with another body is rejected
+	mismatch := send("key-1", `{"title":"B"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+	assert.Equal(t, 1, calls)
+
+	// Requests without a key are never deduplicated
+	send("", `{"title":"A"}`)
+	send("", `{"title":"A"}`)
+	assert.Equal(t, 3, calls)
+
+	// Server errors are forgotten so the client can retry
+	status = http.StatusInternalServerError
+	assert.Equal(t, http.StatusInternalServerError, send("key-2", `{}`).Code)
+	status = http.StatusCreated
+	assert.Equal(t, http.StatusCreated, send("key-2", `{}`).Code)
+	assert.Equal(t, 5, calls)
+}
+
+func TestIdempotencyMiddleware_PerCaller(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := newMemoryStore()
+	calls := 0
+
+	router := gin.New()
+	authenticate := func(c *gin.Context) {
+		claims := &jwtauth.Claims{Subject: c.GetHeader("X-Test-Subject")}
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+	}
+	router.POST("/albums", authenticate, middleware.IdempotencyMiddleware(store, time.Hour), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	send := func(subject string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/albums", strings.NewReader(`{"title":"A"}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		req.Header.Set("X-Test-Subject", subject)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Two callers choosing the same key each get their own response
+	assert.JSONEq(t, `{"call":1}`, send("user-1").Body.String())
+	second := send("user-2")
+	assert.JSONEq(t, `{"call":2}`, second.Body.String())
+	assert.Empty(t, second.Header().Get("Idempotent-Replayed"))
+
+	// Each caller's retry is still replayed
+	assert.JSONEq(t, `{"call":1}`, send("user-1").Body.String())
+	assert.JSONEq(t, `{"call":2}`, send("user-2").Body.String())
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_Timeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := newMemoryStore()
+	delay := 100 * time.Millisecond
+	calls := 0
+
+	router := gin.New()
+	router.Use(middleware.TimeoutMiddleware(&config.AppConfig{HandlerTimeout: 50 * time.Millisecond}))
+	router.POST("/albums", middleware.IdempotencyMiddleware(store, time.Hour), func(c *gin.Context) {
+		calls++
+		time.Sleep(delay)
+		c.JSON(http.StatusCreated, gin.H{"id": "x"})
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/albums", strings.NewReader(`{"title":"A"}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// A request timing out is answered with 408, but the album its handler went on to create is
+	// remembered
+	assert.Equal(t, http.StatusRequestTimeout, send().Code)
+
+	// so its retry is answered with the handler's response instead of creating another one
+	delay = 0
+	retry := send()
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.JSONEq(t, `{"id":"x"}`, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_Panic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := newMemoryStore()
+	panics := true
+
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.POST("/albums", middleware.IdempotencyMiddleware(store, time.Hour), func(c *gin.Context) {
+		if panics {
+			panic("boom")
+		}
+		c.JSON(http.StatusCreated, gin.H{"id": "x"})
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/albums", strings.NewReader(`{"title":"A"}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, send().Code)
+
+	// The key is released, so the retry runs the handler rather than being told it is in progress
+	panics = false
+	assert.Equal(t, http.StatusCreated, send().Code)
+}
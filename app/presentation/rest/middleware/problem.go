@@ -0,0 +1,14 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/presentation/rest/problem"
+)
+
+// abortWithProblem answers the request with the problem details of err, as the controllers answer
+// their errors, and stops the handlers after the calling middleware
+func abortWithProblem(c *gin.Context, err error) {
+	problem.Write(c, err)
+	c.Abort()
+}
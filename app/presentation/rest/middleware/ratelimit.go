@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/infrastructure/redis"
+)
+
+// ErrTooManyRequests rejects the requests of a client over its limit
+var ErrTooManyRequests = customerr.NewError(customerr.KindTooManyRequests, "too_many_requests", "Too many requests", "rate limit exceeded")
+
+// RateLimitStore holds the token buckets shared by every instance; it is satisfied by *redis.RedisCache
+type RateLimitStore interface {
+	TakeToken(key string, capacity int, window time.Duration) (redis.TokenBucket, error)
+}
+
+// RateLimiter takes tokens from the bucket of each client and route group, stored in Redis so that
+// limits hold across instances. While Redis fails, each instance limits clients on its own from
+// buckets held in memory.
+type RateLimiter struct {
+	store    RateLimitStore
+	local    *memoryBuckets
+	now      func() time.Time
+	degraded atomic.Bool
+	// retryAt is when, in Unix nanoseconds, Redis is tried again after failing
+	retryAt atomic.Int64
+}
+
+// storeRetryInterval is how long requests are limited in memory after Redis fails before Redis is
+// tried again, so that requests don't each wait for a Redis that is down to time out
+const storeRetryInterval = 5 * time.Second
+
+// NewRateLimiter creates a rate limiter storing its buckets in store, or in memory when store is nil
+func NewRateLimiter(store RateLimitStore) *RateLimiter {
+	return &RateLimiter{store: store, local: newMemoryBuckets(time.Now), now: time.Now}
+}
+
+// Middleware creates a gin middleware allowing each client limit.Requests requests to the routes
+// of group per limit.Window, in bursts of up to limit.Requests. Clients are told apart by the API
+// key or token subject AuthMiddleware authenticated them with, or by IP address. Responses carry
+// the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers, and
+// requests over the limit are answered with 429 and Retry-After. A zero limit lets every request through.
+func (l *RateLimiter) Middleware(group string, limit config.RateLimit) gin.HandlerFunc {
+	return l.middleware(group, limit, requestClient)
+}
+
+// AddressMiddleware creates a gin middleware like Middleware, telling clients apart by IP address
+// only, for the requests whose credentials are yet to be checked
+func (l *RateLimiter) AddressMiddleware(group string, limit config.RateLimit) gin.HandlerFunc {
+	return l.middleware(group, limit, func(c *gin.Context) string {
+		return "ip:" + c.ClientIP()
+	})
+}
+
+// middleware limits the requests of each client told apart by client
+func (l *RateLimiter) middleware(group string, limit config.RateLimit, client func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit.Requests <= 0 {
+			c.Next()
+			return
+		}
+
+		bucket := l.take("ratelimit:"+group+":"+client(c), limit)
+
+		// Tokens come back at a steady rate, Requests per Window
+		perToken := limit.Window / time.Duration(limit.Requests)
+		resetAfter := time.Duration((float64(limit.Requests) - bucket.Tokens) * float64(perToken))
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("RateLimit-Remaining", strconv.Itoa(int(math.Floor(bucket.Tokens))))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(resetAfter)))
+		c.Header("RateLimit-Policy", strconv.Itoa(limit.Requests)+";w="+strconv.Itoa(ceilSeconds(limit.Window)))
+
+		if !bucket.Allowed {
+			retryAfter := time.Duration((1 - bucket.Tokens) * float64(perToken))
+			c.Header("Retry-After", strconv.Itoa(max(ceilSeconds(retryAfter), 1)))
+			abortWithProblem(c, ErrTooManyRequests)
+			return
+		}
+		c.Next()
+	}
+}
+
+// take takes a token from Redis, or from memory while Redis fails. Once Redis fails, it is left
+// alone for storeRetryInterval.
+func (l *RateLimiter) take(key string, limit config.RateLimit) redis.TokenBucket {
+	if l.store != nil && l.now().UnixNano() >= l.retryAt.Load() {
+		bucket, err := l.store.TakeToken(key, limit.Requests, limit.Window)
+		if err == nil {
+			if l.degraded.CompareAndSwap(true, false) {
+				log.Printf("Rate limits are stored in Redis again")
+			}
+			return bucket
+		}
+		l.retryAt.Store(l.now().Add(storeRetryInterval).UnixNano())
+		if l.degraded.CompareAndSwap(false, true) {
+			log.Printf("Error taking a rate limit token from Redis, limiting in memory: %v", err)
+		}
+	}
+	return l.local.take(key, limit.Requests, limit.Window)
+}
+
+// ceilSeconds rounds d up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// memoryBuckets are token buckets held by one instance, the same way the store holds them
+type memoryBuckets struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	window    time.Duration
+}
+
+// memorySweepInterval is how often buckets left alone for a whole window, full again, are dropped
+const memorySweepInterval = time.Minute
+
+func newMemoryBuckets(now func() time.Time) *memoryBuckets {
+	return &memoryBuckets{buckets: map[string]*memoryBucket{}, now: now, lastSweep: now()}
+}
+
+// take refills the bucket at key for the time elapsed and takes a token when there is one
+func (m *memoryBuckets) take(key string, capacity int, window time.Duration) redis.TokenBucket {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= memorySweepInterval {
+		for k, b := range m.buckets {
+			if now.Sub(b.updatedAt) >= b.window {
+				delete(m.buckets, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(capacity), updatedAt: now}
+		m.buckets[key] = b
+	}
+	elapsed := max(now.Sub(b.updatedAt), 0)
+	b.tokens = math.Min(float64(capacity), b.tokens+float64(elapsed)*float64(capacity)/float64(window))
+	b.updatedAt = now
+	b.window = window
+
+	if b.tokens < 1 {
+		return redis.TokenBucket{Tokens: b.tokens}
+	}
+	b.tokens--
+	return redis.TokenBucket{Allowed: true, Tokens: b.tokens}
+}
@@ -0,0 +1,182 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/infrastructure/redis"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+// fakeRateLimitStore answers every TakeToken with bucket, or fails with err, recording the keys
+type fakeRateLimitStore struct {
+	bucket redis.TokenBucket
+	err    error
+	keys   []string
+}
+
+func (s *fakeRateLimitStore) TakeToken(key string, _ int, _ time.Duration) (redis.TokenBucket, error) {
+	s.keys = append(s.keys, key)
+	return s.bucket, s.err
+}
+
+// rateLimitedRouter serves GET /albums behind limiter, authenticating requests carrying the
+// X-Subject header as that subject
+func rateLimitedRouter(limiter *middleware.RateLimiter, limit config.RateLimit) *gin.Engine {
+	router := gin.New()
+	authenticate := func(c *gin.Context) {
+		if subject := c.GetHeader("X-Subject"); subject != "" {
+			claims := &jwtauth.Claims{Subject: subject}
+			c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+		}
+	}
+	router.GET("/albums", authenticate, limiter.Middleware("read", limit), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limit := config.RateLimit{Requests: 10, Window: time.Minute}
+
+	tests := []struct {
+		name            string
+		bucket          redis.TokenBucket
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:           "Allowed",
+			bucket:         redis.TokenBucket{Allowed: true, Tokens: 7.5},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "7",
+				"RateLimit-Reset":     "15",
+				"RateLimit-Policy":    "10;w=60",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:           "Over the limit",
+			bucket:         redis.TokenBucket{Tokens: 0.25},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "59",
+				"Retry-After":         "5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeRateLimitStore{bucket: tt.bucket}
+			router := rateLimitedRouter(middleware.NewRateLimiter(store), limit)
+
+			req := httptest.NewRequest(http.MethodGet, "/albums", nil)
+			req.Header.Set("X-Subject", "user-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for name, value := range tt.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(name), name)
+			}
+			assert.Equal(t, []string{"ratelimit:read:user:user-1"}, store.keys)
+		})
+	}
+}
+
+func TestRateLimiter_Middleware_InMemory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limit := config.RateLimit{Requests: 2, Window: time.Hour}
+
+	send := func(router *gin.Engine, subject string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/albums", nil)
+		if subject != "" {
+			req.Header.Set("X-Subject", subject)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Falls back to memory while the store fails", func(t *testing.T) {
+		router := rateLimitedRouter(middleware.NewRateLimiter(&fakeRateLimitStore{err: errors.New("connection refused")}), limit)
+
+		assert.Equal(t, http.StatusOK, send(router, "user-1").Code)
+		assert.Equal(t, http.StatusOK, send(router, "user-1").Code)
+		w := send(router, "user-1")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "1800", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("Leaves a failing store alone for a while", func(t *testing.T) {
+		store := &fakeRateLimitStore{err: errors.New("i/o timeout")}
+		router := rateLimitedRouter(middleware.NewRateLimiter(store), limit)
+
+		assert.Equal(t, http.StatusOK, send(router, "user-1").Code)
+		assert.Equal(t, http.StatusOK, send(router, "user-2").Code)
+		assert.Equal(t, http.StatusOK, send(router, "user-1").Code)
+		assert.Equal(t, []string{"ratelimit:read:user:user-1"}, store.keys)
+	})
+
+	t.Run("Limits each client on its own", func(t *testing.T) {
+		router := rateLimitedRouter(middleware.NewRateLimiter(nil), limit)
+
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, http.StatusOK, send(router, "user-1").Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, send(router, "user-1").Code)
+		assert.Equal(t, http.StatusOK, send(router, "user-2").Code)
+		assert.Equal(t, http.StatusOK, send(router, "").Code)
+	})
+
+	t.Run("Zero limit", func(t *testing.T) {
+		router := rateLimitedRouter(middleware.NewRateLimiter(nil), config.RateLimit{})
+
+		for i := 0; i < 3; i++ {
+			w := send(router, "user-1")
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
+
+func TestRateLimiter_AddressMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies(nil))
+	limiter := middleware.NewRateLimiter(nil)
+	router.GET("/albums", limiter.AddressMiddleware("auth", config.RateLimit{Requests: 2, Window: time.Hour}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/albums", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Each request claims another address, which only a trusted proxy may tell
+	assert.Equal(t, http.StatusOK, send("192.0.2.1:1234", "198.51.100.1"))
+	assert.Equal(t, http.StatusOK, send("192.0.2.1:1234", "198.51.100.2"))
+	assert.Equal(t, http.StatusTooManyRequests, send("192.0.2.1:1234", "198.51.100.3"))
+	assert.Equal(t, http.StatusOK, send("192.0.2.2:1234", ""))
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/auth"
+)
+
+// ScopeMiddleware creates a gin middleware letting through the callers granted every one of scopes,
+// as told by the claims AuthMiddleware stored. Others are answered with 403 and a WWW-Authenticate
+// challenge naming the scopes (RFC 6750); requests that were not authenticated get 401.
+func ScopeMiddleware(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := auth.GetClaimsFromContext(c.Request.Context())
+		if !ok {
+			c.Header("WWW-Authenticate", WWWAuthenticate(auth.ErrAuthorizationRequired))
+			abortWithProblem(c, unauthenticated(auth.ErrAuthorizationRequired))
+			return
+		}
+
+		if !auth.HasScopes(claims, scopes...) {
+			c.Header("WWW-Authenticate", `Bearer error="insufficient_scope", error_description="`+
+				customerr.ErrScopeRequired.Title+`", scope="`+strings.Join(scopes, " ")+`"`)
+			abortWithProblem(c, customerr.ErrScopeRequired)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Policy records the permissions of the routes registered through its groups, for Permissions
+type Policy struct {
+	authenticate []gin.HandlerFunc
+	routes       map[string]auth.RoutePermission
+	// operations are checked by the transports serving them, such as GraphQL fields and gRPC methods
+	operations []auth.RoutePermission
+}
+
+// NewPolicy creates a policy whose routes authenticate their callers with authenticate, AuthMiddleware
+// preceded by the handlers to run before credentials are checked, such as a limit per IP address
+func NewPolicy(authenticate ...gin.HandlerFunc) *Policy {
+	return &Policy{authenticate: authenticate, routes: map[string]auth.RoutePermission{}}
+}
+
+// Group returns the routes of group requiring an authenticated caller granted every one of scopes
+func (p *Policy) Group(group *gin.RouterGroup, scopes ...string) *ProtectedGroup {
+	return &ProtectedGroup{policy: p, group: group, scopes: scopes}
+}
+
+// AddOperations records the permissions of operations whose transport checks them itself, so that
+// Permissions lists them next to the routes
+func (p *Policy) AddOperations(operations ...auth.RoutePermission) {
+	p.operations = append(p.operations, operations...)
+}
+
+// Permissions returns the permissions of routes and of the operations added, sorted by path,
+// method and operation. Routes registered outside the policy are open to anyone.
+func (p *Policy) Permissions(routes gin.RoutesInfo) []auth.RoutePermission {
+	permissions := make([]auth.RoutePermission, 0, len(routes)+len(p.operations))
+	for _, route := range routes {
+		permission, ok := p.routes[route.Method+" "+route.Path]
+		if !ok {
+			permission = auth.RoutePermission{Method: route.Method, Path: route.Path, Scopes: []string{}}
+		}
+		permissions = append(permissions, permission)
+	}
+	permissions = append(permissions, p.operations...)
+
+	sort.Slice(permissions, func(i, j int) bool {
+		if permissions[i].Path != permissions[j].Path {
+			return permissions[i].Path < permissions[j].Path
+		}
+		if permissions[i].Method != permissions[j].Method {
+			return permissions[i].Method < permissions[j].Method
+		}
+		return permissions[i].Operation < permissions[j].Operation
+	})
+	return permissions
+}
+
+// ProtectedGroup registers routes behind the authentication and the scope check of its policy
+type ProtectedGroup struct {
+	policy   *Policy
+	group    *gin.RouterGroup
+	scopes   []string
+	handlers []gin.HandlerFunc
+}
+
+// Use adds middleware to the routes registered next, run once the caller is authenticated and
+// before the scope check
+func (g *ProtectedGroup) Use(handlers ...gin.HandlerFunc) *ProtectedGroup {
+	g.handlers = append(g.handlers, handlers...)
+	return g
+}
+
+// Handle registers handlers for method and relativePath, after the checks of the group
+func (g *ProtectedGroup) Handle(method, relativePath string, handlers ...gin.HandlerFunc) {
+	fullPath := joinPaths(g.group.BasePath(), relativePath)
+	g.policy.routes[method+" "+fullPath] = auth.RoutePermission{
+		Method:        method,
+		Path:          fullPath,
+		Authenticated: true,
+		Scopes:        append([]string{}, g.scopes...),
+	}
+
+	chain := append([]gin.HandlerFunc{}, g.policy.authenticate...)
+	chain = append(chain, g.handlers...)
+	chain = append(chain, ScopeMiddleware(g.scopes...))
+	chain = append(chain, handlers...)
+	g.group.Handle(method, relativePath, chain...)
+}
+
+// GET is a shortcut for Handle(http.MethodGet, relativePath, handlers...)
+func (g *ProtectedGroup) GET(relativePath string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodGet, relativePath, handlers...)
+}
+
+// POST is a shortcut for Handle(http.MethodPost, relativePath, handlers...)
+func (g *ProtectedGroup) POST(relativePath string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPost, relativePath, handlers...)
+}
+
+// PUT is a shortcut for Handle(http.MethodPut, relativePath, handlers...)
+func (g *ProtectedGroup) PUT(relativePath string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPut, relativePath, handlers...)
+}
+
+// PATCH is a shortcut for Handle(http.MethodPatch, relativePath, handlers...)
+func (g *ProtectedGroup) PATCH(relativePath string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPatch, relativePath, handlers...)
+}
+
+// DELETE is a shortcut for Handle(http.MethodDelete, relativePath, handlers...)
+func (g *ProtectedGroup) DELETE(relativePath string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodDelete, relativePath, handlers...)
+}
+
+// joinPaths joins a group path and a route path the way gin does, keeping a trailing slash
+func joinPaths(basePath, relativePath string) string {
+	if relativePath == "" {
+		return basePath
+	}
+	joined := path.Join(basePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
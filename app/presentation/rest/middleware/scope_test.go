@@ -0,0 +1,113 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/rest/middleware"
+)
+
+// authenticateAs stands in for AuthMiddleware, authenticating every request with scopes
+func authenticateAs(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := &jwtauth.Claims{Subject: "user-1", Scopes: scopes}
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
+	}
+}
+
+func TestScopeMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name              string
+		authenticate      gin.HandlerFunc
+		expectedStatus    int
+		expectedBody      string
+		expectedChallenge string
+	}{
+		{
+			name:           "Granted scope",
+			authenticate:   authenticateAs("albums:read", "albums:write"),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name:           "Admin",
+			authenticate:   authenticateAs("admin"),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name:              "Missing scope",
+			authenticate:      authenticateAs("albums:read"),
+			expectedStatus:    http.StatusForbidden,
+			expectedBody:      `{"type":"/problems/insufficient_scope","title":"Insufficient scope","status":403,"code":"insufficient_scope","instance":"/albums"}`,
+			expectedChallenge: `Bearer error="insufficient_scope", error_description="Insufficient scope", scope="albums:write"`,
+		},
+		{
+			name:              "Not authenticated",
+			authenticate:      func(*gin.Context) {},
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"type":"/problems/unauthenticated","title":"Authorization header required","status":401,"code":"unauthenticated","instance":"/albums"}`,
+			expectedChallenge: `Bearer`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/albums", tt.authenticate, middleware.ScopeMiddleware("albums:write"), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"ok": true})
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/albums", nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedChallenge, w.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestPolicy_Permissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	policy := middleware.NewPolicy(authenticateAs("albums:read"))
+
+	v1 := router.Group("/api/v1")
+	v1.GET("/health", func(*gin.Context) {})
+	policy.Group(v1, "albums:read").GET("/albums", func(*gin.Context) {})
+	policy.Group(v1, "albums:write").POST("/albums", func(*gin.Context) {})
+	policy.Group(v1).GET("/jsonposts", func(*gin.Context) {})
+	router.POST("/graphql", func(*gin.Context) {})
+	policy.AddOperations(
+		auth.RoutePermission{Method: http.MethodPost, Path: "/graphql", Operation: "mutation createAlbum", Authenticated: true, Scopes: []string{"albums:write"}},
+		auth.RoutePermission{Method: http.MethodPost, Path: "/graphql", Operation: "query albums", Authenticated: true, Scopes: []string{"albums:read"}},
+	)
+
+	assert.Equal(t, []auth.RoutePermission{
+		{Method: http.MethodGet, Path: "/api/v1/albums", Authenticated: true, Scopes: []string{"albums:read"}},
+		{Method: http.MethodPost, Path: "/api/v1/albums", Authenticated: true, Scopes: []string{"albums:write"}},
+		{Method: http.MethodGet, Path: "/api/v1/health", Scopes: []string{}},
+		{Method: http.MethodGet, Path: "/api/v1/jsonposts", Authenticated: true, Scopes: []string{}},
+		{Method: http.MethodPost, Path: "/graphql", Scopes: []string{}},
+		{Method: http.MethodPost, Path: "/graphql", Operation: "mutation createAlbum", Authenticated: true, Scopes: []string{"albums:write"}},
+		{Method: http.MethodPost, Path: "/graphql", Operation: "query albums", Authenticated: true, Scopes: []string{"albums:read"}},
+	}, policy.Permissions(router.Routes()))
+
+	// The routes of the policy are checked as well as listed
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/albums", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/albums", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
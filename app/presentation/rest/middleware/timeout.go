@@ -1,23 +1,53 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 
+	customerr "boilerplate/app/domain/errors"
 	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/rest/problem"
 )
 
-// TimeoutMiddleware creates a gin middleware for setting request timeouts
-func TimeoutMiddleware(cfg *config.AppConfig) gin.HandlerFunc {
+// ErrRequestTimeout answers the requests whose handler did not finish in time
+var ErrRequestTimeout = customerr.NewError(customerr.KindTimeout, "request_timeout", "Request timeout", "request timeout")
+
+// TimeoutMiddleware creates a gin middleware for setting request timeouts.
+// Handlers write to a buffer that is sent once they finish, so that a request timing out is
+// answered with the timeout alone rather than part of the handler's response, and so that the
+// middleware around this one receives the response in one piece from a single goroutine.
+// A timed out request is answered at once, but the middleware returns only when the handler
+// does, which the canceled request context tells to stop.
+// Streaming routes, given by their full path, stay open as long as the client and are passed through.
+func TimeoutMiddleware(cfg *config.AppConfig, streamingPaths ...string) gin.HandlerFunc {
+	streaming := make(map[string]bool, len(streamingPaths))
+	for _, path := range streamingPaths {
+		streaming[path] = true
+	}
+
 	return func(c *gin.Context) {
+		if streaming[c.FullPath()] {
+			c.Next()
+			return
+		}
+
 		// Create a context with timeout
 		ctx, cancel := context.WithTimeout(c.Request.Context(), cfg.HandlerTimeout)
 		defer cancel()
 
 		// Replace the request's context with the new timeout context
 		c.Request = c.Request.WithContext(ctx)
+		// The handler may replace the request while the timeout is being answered
+		instance := c.Request.URL.Path
+
+		// The handler writes to the buffer; the response goes out through writer
+		writer := c.Writer
+		buffer := newTimeoutWriter(writer)
+		c.Writer = buffer
 
 		// Use a channel to manage the flow of the request
 		finish := make(chan struct{}, 1)
@@ -37,13 +67,130 @@ func TimeoutMiddleware(cfg *config.AppConfig) gin.HandlerFunc {
 		// Wait for either the request to finish or the context to timeout
 		select {
 		case <-ctx.Done():
-			// If context timeout, abort with an error
-			c.AbortWithStatusJSON(http.StatusRequestTimeout, gin.H{"error": "Request timeout"})
+			// If context timeout, drop whatever the handler writes from now on and answer with an error
+			buffer.timeout()
+			problem.Send(writer, problem.Describe(ErrRequestTimeout, instance, ""))
+			writer.Flush()
+
+			// gin reuses the context once this returns, so wait for the handler to give up too;
+			// a panic past the timeout has nobody left to answer
+			select {
+			case <-finish:
+			case <-panicChan:
+			}
+			c.Writer = writer
 		case p := <-panicChan:
-			// If a panic occurred, handle it
+			// If a panic occurred, handle it; the recovery middleware answers through the original writer
+			c.Writer = writer
 			panic(p)
 		case <-finish:
-			// If the request finished before timeout, do nothing
+			// If the request finished before timeout, send its response
+			c.Writer = writer
+			buffer.flush()
+		}
+	}
+}
+
+// timeoutWriter holds back the response of a handler until TimeoutMiddleware sends or drops it.
+// Headers are kept apart from those of the response too, since the handler may still be setting
+// them while the timeout is being answered.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	mu       sync.Mutex
+	header   http.Header
+	status   int
+	written  bool
+	body     bytes.Buffer
+	timedOut bool
+}
+
+func newTimeoutWriter(w gin.ResponseWriter) *timeoutWriter {
+	return &timeoutWriter{ResponseWriter: w, header: w.Header().Clone(), status: http.StatusOK}
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if code > 0 && !w.written && !w.timedOut {
+		w.status = code
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written = true
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush does nothing: the response cannot be sent before the handler is done
+func (w *timeoutWriter) Flush() {}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.written
+}
+
+// timeout makes the writer drop what the handler writes from now on
+func (w *timeoutWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timedOut = true
+	w.status = http.StatusRequestTimeout
+}
+
+// flush sends the headers, status and body written by the handler
+func (w *timeoutWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	header := w.ResponseWriter.Header()
+	for key := range header {
+		if _, ok := w.header[key]; !ok {
+			header.Del(key)
 		}
 	}
+	for key, values := range w.header {
+		header[key] = values
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	} else if w.written {
+		w.ResponseWriter.WriteHeaderNow()
+	}
 }
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/presentation/rest/problem"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		handler        gin.HandlerFunc
+		expectedStatus int
+		expectedBody   string
+		expectedHeader http.Header
+	}{
+		{
+			name: "Finished in time",
+			handler: func(c *gin.Context) {
+				c.Header("ETag", `"3"`)
+				c.JSON(http.StatusCreated, gin.H{"id": "1"})
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"1"}`,
+			expectedHeader: http.Header{"Content-Type": {"application/json; charset=utf-8"}, "Etag": {`"3"`}, "X-Outer": {"kept"}},
+		},
+		{
+			name: "Header removed by the handler",
+			handler: func(c *gin.Context) {
+				c.Writer.Header().Del("X-Outer")
+				c.Status(http.StatusNoContent)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedHeader: http.Header{},
+		},
+		{
+			name: "Timed out",
+			handler: func(c *gin.Context) {
+				c.Header("ETag", `"3"`)
+				<-c.Request.Context().Done()
+				c.JSON(http.StatusOK, gin.H{"id": "1"})
+			},
+			expectedStatus: http.StatusRequestTimeout,
+			expectedBody:   `{"type":"/problems/request_timeout","title":"Request timeout","status":408,"code":"request_timeout","instance":"/albums/1"}` + "\n",
+			expectedHeader: http.Header{"Content-Type": {problem.ContentType}, "X-Outer": {"kept"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Header("X-Outer", "kept")
+				c.Next()
+			})
+			router.Use(middleware.TimeoutMiddleware(&config.AppConfig{HandlerTimeout: 50 * time.Millisecond}))
+			router.GET("/albums/:id", tt.handler)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/albums/1", nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedHeader, w.Header())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package negotiation
+
+import (
+	stderrors "errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/validation"
+)
+
+// bodyFormats names the request body formats in errors
+var bodyFormats = map[string]string{
+	MIMEXML:     "XML",
+	MIMEMsgPack: "MessagePack",
+}
+
+// Bind decodes the request body into obj according to its Content-Type, one of ResourceTypes,
+// and validates it. Bodies without a Content-Type are read as JSON. The error is
+// ErrUnsupportedMediaType for other types, and a ValidationError for bodies that do not
+// decode or validate.
+func Bind(ctx *gin.Context, obj interface{}) error {
+	mediaType := normalize(ctx.ContentType())
+
+	var b binding.Binding
+	switch mediaType {
+	case "", MIMEJSON:
+		b = binding.JSON
+	case MIMEXML:
+		b = binding.XML
+	case MIMEMsgPack:
+		b = binding.MsgPack
+	default:
+		return ErrUnsupportedMediaType
+	}
+
+	err := ctx.ShouldBindWith(obj, b)
+	if err == nil {
+		return nil
+	}
+	// FromBindError describes decoding errors of JSON bodies only
+	var fieldErrs validator.ValidationErrors
+	var typed *errors.Error
+	if format, ok := bodyFormats[mediaType]; ok && !stderrors.As(err, &fieldErrs) && !stderrors.As(err, &typed) {
+		return errors.NewValidationError(errors.FieldError{
+			Field: "body", Code: "malformed", Message: "request body is not valid " + format,
+		})
+	}
+	return validation.FromBindError(err)
+}
@@ -0,0 +1,127 @@
+// Package negotiation picks the media type of REST responses from the Accept header and decodes
+// request bodies according to their Content-Type. JSON is the default in both directions; XML and
+// MessagePack are available for every body, and CSV for lists.
+package negotiation
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/errors"
+)
+
+// Media types responses can be encoded as
+const (
+	MIMEJSON    = "application/json"
+	MIMEXML     = "application/xml"
+	MIMECSV     = "text/csv"
+	MIMEMsgPack = "application/msgpack"
+)
+
+var (
+	// ResourceTypes are the media types a single resource or report is offered in, JSON first
+	ResourceTypes = []string{MIMEJSON, MIMEXML, MIMEMsgPack}
+	// ListTypes are the media types a list is offered in, JSON first
+	ListTypes = []string{MIMEJSON, MIMEXML, MIMECSV, MIMEMsgPack}
+)
+
+// aliases maps media types clients commonly send to the one this package uses for them
+var aliases = map[string]string{
+	"text/xml":              MIMEXML,
+	"application/x-msgpack": MIMEMsgPack,
+}
+
+// Errors answered when the request rules out JSON, XML and MessagePack
+var (
+	ErrNotAcceptable        = errors.NewError(errors.KindNotAcceptable, "not_acceptable", "Accept must allow "+MIMEJSON+", "+MIMEXML+" or "+MIMEMsgPack, "no acceptable response media type")
+	ErrUnsupportedMediaType = errors.NewError(errors.KindUnsupportedMediaType, "unsupported_media_type", "Content-Type must be "+MIMEJSON+", "+MIMEXML+" or "+MIMEMsgPack, "unsupported request content type")
+)
+
+// Negotiate picks the media type of the response to the request among offers and marks the
+// response as varying with the Accept header
+func Negotiate(ctx *gin.Context, offers ...string) (string, error) {
+	ctx.Writer.Header().Add("Vary", "Accept")
+	return Accept(ctx.GetHeader("Accept"), offers...)
+}
+
+// Accept returns the offer the Accept header prefers. Ties go to the earlier offer, and a missing
+// or unreadable header accepts the first one. ErrNotAcceptable is returned when the header rules
+// out every offer.
+func Accept(header string, offers ...string) (string, error) {
+	ranges := parseAccept(header)
+	if len(ranges) == 0 {
+		return offers[0], nil
+	}
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := quality(ranges, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	if best == "" {
+		return "", ErrNotAcceptable
+	}
+	return best, nil
+}
+
+// mediaRange is one entry of an Accept header, such as text/* or application/xml;q=0.5
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept reads the media ranges of an Accept header, skipping malformed entries
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for _, entry := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(entry))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(value, 64)
+			if err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: normalize(mediaType), q: q})
+	}
+	return ranges
+}
+
+// quality is the weight the most specific range matching offer gives it, 0 when none does
+func quality(ranges []mediaRange, offer string) float64 {
+	offerType, _, _ := strings.Cut(offer, "/")
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		var s int
+		switch {
+		case r.mediaType == offer:
+			s = 2
+		case r.mediaType == offerType+"/*":
+			s = 1
+		case r.mediaType == "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+// normalize lower-cases a media type without parameters and resolves its alias
+func normalize(mediaType string) string {
+	mediaType = strings.ToLower(mediaType)
+	if alias, ok := aliases[mediaType]; ok {
+		return alias
+	}
+	return mediaType
+}
@@ -0,0 +1,213 @@
+package negotiation_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/negotiation"
+)
+
+func TestAccept(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		offers   []string
+		expected string
+		err      error
+	}{
+		{name: "No header picks the first offer", header: "", offers: negotiation.ListTypes, expected: negotiation.MIMEJSON},
+		{name: "Any type picks the first offer", header: "*/*", offers: negotiation.ListTypes, expected: negotiation.MIMEJSON},
+		{name: "Exact type", header: "text/csv", offers: negotiation.ListTypes, expected: negotiation.MIMECSV},
+		{name: "Alias", header: "application/x-msgpack", offers: negotiation.ResourceTypes, expected: negotiation.MIMEMsgPack},
+		{name: "Highest quality wins", header: "application/json;q=0.5, application/xml", offers: negotiation.ResourceTypes, expected: negotiation.MIMEXML},
+		{name: "Ties go to the earlier offer", header: "application/xml, application/json", offers: negotiation.ResourceTypes, expected: negotiation.MIMEJSON},
+		{name: "Most specific range applies", header: "text/*;q=0.2, text/csv;q=0, application/xml;q=0.1", offers: negotiation.ListTypes, expected: negotiation.MIMEXML},
+		{name: "Browser header", header: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", offers: negotiation.ResourceTypes, expected: negotiation.MIMEXML},
+		{name: "Malformed header is ignored", header: ";;;", offers: negotiation.ResourceTypes, expected: negotiation.MIMEJSON},
+		{name: "CSV is not offered for resources", header: "text/csv", offers: negotiation.ResourceTypes, err: negotiation.ErrNotAcceptable},
+		{name: "Every offer refused", header: "*/*;q=0", offers: negotiation.ListTypes, err: negotiation.ErrNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mediaType, err := negotiation.Accept(tt.header, tt.offers...)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mediaType)
+		})
+	}
+}
+
+// list is a body offered in every media type
+type list struct {
+	Items negotiation.Elements[item] `json:"items" xml:"items"`
+	Notes negotiation.Elements[item] `json:"notes,omitempty" xml:"notes,omitempty"`
+}
+
+type item struct {
+	Name string          `json:"name" xml:"name"`
+	Tags negotiation.Map `json:"tags,omitempty" xml:"tags,omitempty"`
+}
+
+func (l list) CSVHeader() []string {
+	return []string{"name"}
+}
+
+func (l list) CSVRecords() [][]string {
+	records := make([][]string, len(l.Items))
+	for i, item := range l.Items {
+		records[i] = []string{item.Name}
+	}
+	return records
+}
+
+func TestRender(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := list{Items: []item{{Name: "Blue Train", Tags: negotiation.Map{"genre": "jazz", "decade": "1950s"}}, {Name: "A, B"}}}
+
+	tests := []struct {
+		name                string
+		mediaType           string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "JSON",
+			mediaType:           negotiation.MIMEJSON,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"items":[{"name":"Blue Train","tags":{"decade":"1950s","genre":"jazz"}},{"name":"A, B"}]}`,
+		},
+		{
+			name:                "XML",
+			mediaType:           negotiation.MIMEXML,
+			expectedContentType: "application/xml; charset=utf-8",
+			expectedBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<list><items><item><name>Blue Train</name><tags><entry key="decade">1950s</entry><entry key="genre">jazz</entry></tags></item>` +
+				`<item><name>A, B</name></item></items></list>`,
+		},
+		{
+			name:                "CSV",
+			mediaType:           negotiation.MIMECSV,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "name\nBlue Train\n\"A, B\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			require.NoError(t, negotiation.Render(ctx, http.StatusOK, tt.mediaType, body))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+
+	t.Run("MessagePack", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		require.NoError(t, negotiation.Render(ctx, http.StatusOK, negotiation.MIMEMsgPack, body))
+
+		assert.Equal(t, negotiation.MIMEMsgPack, w.Header().Get("Content-Type"))
+		var decoded list
+		require.NoError(t, codec.NewDecoderBytes(w.Body.Bytes(), &codec.MsgpackHandle{}).Decode(&decoded))
+		assert.Equal(t, body, decoded)
+	})
+
+	t.Run("CSV of a body that is not a table", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+
+		assert.Error(t, negotiation.Render(ctx, http.StatusOK, negotiation.MIMECSV, item{Name: "Blue Train"}))
+		assert.False(t, ctx.Writer.Written())
+	})
+}
+
+// album is a request body with the validation tags of the album DTO
+type album struct {
+	Title      string `json:"title" xml:"title" binding:"required"`
+	TrackCount int    `json:"track_count" xml:"track_count"`
+}
+
+func TestBind(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var msgpackBody []byte
+	require.NoError(t, codec.NewEncoderBytes(&msgpackBody, &codec.MsgpackHandle{}).Encode(map[string]interface{}{"title": "Blue Train", "track_count": 5}))
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		expected    album
+		err         error
+		fields      []customerr.FieldError
+	}{
+		{name: "JSON", contentType: "application/json", body: []byte(`{"title":"Blue Train","track_count":5}`), expected: album{Title: "Blue Train", TrackCount: 5}},
+		{name: "No Content-Type is JSON", body: []byte(`{"title":"Blue Train"}`), expected: album{Title: "Blue Train"}},
+		{name: "XML", contentType: "text/xml; charset=utf-8", body: []byte(`<album><title>Blue Train</title><track_count>5</track_count></album>`), expected: album{Title: "Blue Train", TrackCount: 5}},
+		{name: "MessagePack", contentType: "application/msgpack", body: msgpackBody, expected: album{Title: "Blue Train", TrackCount: 5}},
+		{
+			name: "Invalid XML", contentType: "application/xml", body: []byte(`<album><title>`),
+			fields: []customerr.FieldError{{Field: "body", Code: "malformed", Message: "request body is not valid XML"}},
+		},
+		{
+			name: "XML failing validation", contentType: "application/xml", body: []byte(`<album><track_count>5</track_count></album>`),
+			fields: []customerr.FieldError{{Field: "title", Code: "required", Message: "is required"}},
+		},
+		{name: "Unsupported type", contentType: "text/plain", body: []byte("Blue Train"), err: negotiation.ErrUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/albums", bytes.NewReader(tt.body))
+			if tt.contentType != "" {
+				ctx.Request.Header.Set("Content-Type", tt.contentType)
+			}
+
+			var got album
+			err := negotiation.Bind(ctx, &got)
+			switch {
+			case tt.err != nil:
+				assert.ErrorIs(t, err, tt.err)
+			case tt.fields != nil:
+				assert.True(t, customerr.IsInvalidInput(err))
+				assert.Equal(t, tt.fields, customerr.ValidationDetails(err))
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNegotiate_VariesWithAccept(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/albums", nil)
+	ctx.Request.Header.Set("Accept", strings.Join([]string{"text/csv", "application/json;q=0.1"}, ", "))
+
+	mediaType, err := negotiation.Negotiate(ctx, negotiation.ListTypes...)
+
+	require.NoError(t, err)
+	assert.Equal(t, negotiation.MIMECSV, mediaType)
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+}
@@ -0,0 +1,104 @@
+package negotiation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+)
+
+// Table is a list that can be written as CSV, one record per item under a header row
+type Table interface {
+	CSVHeader() []string
+	CSVRecords() [][]string
+}
+
+// Map is a string map that can be written as XML, which encoding/xml cannot do for maps,
+// as one entry element per key: <entry key="title">value</entry>
+type Map map[string]string
+
+// MarshalXML implements xml.Marshaler, writing the entries in key order
+func (m Map) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		entry := xml.StartElement{Name: xml.Name{Local: "entry"}, Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}}}
+		if err := e.EncodeElement(m[key], entry); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// Elements is a list written in XML as the children of its field's element, each named after
+// its own type: <tracks><track>...</track></tracks>. Unlike a "tracks>track" tag, which always
+// writes the tracks element, an empty Elements tagged omitempty is left out altogether.
+type Elements[T any] []T
+
+// MarshalXML implements xml.Marshaler
+func (l Elements[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, item := range l {
+		if err := e.Encode(item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// Render writes body with the given status, encoded as mediaType. Bodies are encoded before
+// anything is written, so an error leaves the response untouched for the caller to answer.
+func Render(ctx *gin.Context, status int, mediaType string, body interface{}) error {
+	switch mediaType {
+	case MIMEXML:
+		data, err := xml.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode %T as XML: %w", body, err)
+		}
+		ctx.Data(status, MIMEXML+"; charset=utf-8", append([]byte(xml.Header), data...))
+	case MIMECSV:
+		table, ok := body.(Table)
+		if !ok {
+			return fmt.Errorf("%T cannot be encoded as CSV", body)
+		}
+		data, err := encodeCSV(table)
+		if err != nil {
+			return fmt.Errorf("failed to encode %T as CSV: %w", body, err)
+		}
+		ctx.Data(status, MIMECSV+"; charset=utf-8", data)
+	case MIMEMsgPack:
+		var buf bytes.Buffer
+		if err := codec.NewEncoder(&buf, &codec.MsgpackHandle{}).Encode(body); err != nil {
+			return fmt.Errorf("failed to encode %T as MessagePack: %w", body, err)
+		}
+		ctx.Data(status, MIMEMsgPack, buf.Bytes())
+	default:
+		ctx.JSON(status, body)
+	}
+	return nil
+}
+
+func encodeCSV(table Table) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(table.CSVHeader()); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(table.CSVRecords()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,226 @@
+package openapi
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/problem"
+)
+
+// ValidationOption configures ValidationMiddleware
+type ValidationOption func(*validationConfig)
+
+type validationConfig struct {
+	responses bool
+}
+
+// WithResponseValidation also checks every response against the document. A response that does
+// not match, including one with an undocumented status, is replaced with a 500 problem and the
+// violation is attached to the context with ctx.Error. Responses are buffered to do so, which is
+// why this is meant for tests rather than production.
+func WithResponseValidation() ValidationOption {
+	return func(c *validationConfig) {
+		c.responses = true
+	}
+}
+
+// schemaCodes maps the schema keyword a value failed to the code reported to the client,
+// following the codes of the binding validation
+var schemaCodes = map[string]string{
+	"required":  "required",
+	"minLength": "length",
+	"maxLength": "length",
+	"minimum":   "range",
+	"maximum":   "range",
+	"pattern":   "pattern",
+	"format":    "format",
+	"enum":      "enum",
+	"type":      "type",
+}
+
+// ValidationMiddleware rejects requests that do not match the operation the document describes
+// for them with a 400 problem listing the rejected fields. Requests to routes the document does
+// not describe are passed through, as are bodies of a media type the operation does not accept,
+// which the handlers answer themselves. Authentication is left to the route middleware.
+func (s *Spec) ValidationMiddleware(opts ...ValidationOption) gin.HandlerFunc {
+	cfg := validationConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := s.router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+		if body := route.Operation.RequestBody; body != nil && c.Request.ContentLength != 0 && body.Value.Content.Get(c.ContentType()) == nil {
+			input.Options.ExcludeRequestBody = true
+		}
+
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			problem.Write(c, requestValidationError(err))
+			c.Abort()
+			return
+		}
+
+		if !cfg.responses {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		err = openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 writer.status,
+			Header:                 writer.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+			Options:                &openapi3filter.Options{IncludeResponseStatus: true, MultiError: true},
+		})
+		if err != nil {
+			log.Printf("Response to %s %s does not match the OpenAPI document: %v", c.Request.Method, c.Request.URL.Path, err)
+			_ = c.Error(fmt.Errorf("response does not match the OpenAPI document: %w", err))
+			writer.Header().Del("ETag")
+			problem.Write(c, errors.ErrInternalServer.WithCause(err))
+			return
+		}
+		writer.flush()
+	}
+}
+
+// requestValidationError converts the errors reported by ValidateRequest into a ValidationError
+func requestValidationError(err error) *errors.ValidationError {
+	result := errors.NewValidationError()
+	var collect func(err error)
+	collect = func(err error) {
+		if multi, ok := err.(openapi3.MultiError); ok {
+			for _, e := range multi {
+				collect(e)
+			}
+			return
+		}
+
+		var requestErr *openapi3filter.RequestError
+		switch {
+		case !stderrors.As(err, &requestErr):
+			result.Add("request", "invalid", "is invalid")
+		case requestErr.Parameter != nil:
+			result.Fields = append(result.Fields, fieldErrors(requestErr.Parameter.Name, requestErr.Err)...)
+		default:
+			result.Fields = append(result.Fields, fieldErrors("body", requestErr.Err)...)
+		}
+	}
+	collect(err)
+	return result
+}
+
+// fieldErrors describes why the value of field was rejected. Schema violations inside a
+// body are reported under the dotted path of the offending property.
+func fieldErrors(field string, err error) []errors.FieldError {
+	var multi openapi3.MultiError
+	if stderrors.As(err, &multi) {
+		var fields []errors.FieldError
+		for _, e := range multi {
+			fields = append(fields, fieldErrors(field, e)...)
+		}
+		return fields
+	}
+
+	var schemaErr *openapi3.SchemaError
+	var parseErr *openapi3filter.ParseError
+	switch {
+	case stderrors.Is(err, openapi3filter.ErrInvalidRequired), stderrors.Is(err, openapi3filter.ErrInvalidEmptyValue):
+		return []errors.FieldError{{Field: field, Code: "required", Message: "is required"}}
+	case stderrors.As(err, &schemaErr):
+		if path := schemaErr.JSONPointer(); field == "body" && len(path) > 0 {
+			field = strings.Join(path, ".")
+		}
+		code, ok := schemaCodes[schemaErr.SchemaField]
+		if !ok {
+			code = "invalid"
+		}
+		return []errors.FieldError{{Field: field, Code: code, Message: schemaErr.Reason}}
+	case stderrors.As(err, &parseErr):
+		if field == "body" {
+			return []errors.FieldError{{Field: field, Code: "malformed", Message: "request body is not valid JSON"}}
+		}
+		return []errors.FieldError{{Field: field, Code: "type", Message: "has an invalid format"}}
+	}
+	return []errors.FieldError{{Field: field, Code: "invalid", Message: "is invalid"}}
+}
+
+// bufferedWriter holds back the response so that it can be checked before anything is sent
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+// flush sends the buffered response
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
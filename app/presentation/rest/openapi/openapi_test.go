@@ -0,0 +1,167 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/openapi"
+	"boilerplate/app/presentation/rest/problem"
+)
+
+func TestLoad(t *testing.T) {
+	spec, err := openapi.Load()
+
+	require.NoError(t, err)
+	assert.NotNil(t, spec.Doc.Paths.Find("/api/v1/albums/{id}"))
+	assert.NotNil(t, spec.Doc.Paths.Find("/api/v1/albums:import"))
+}
+
+func TestSpecAndDocsHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.GET("/openapi.json", spec.SpecHandler)
+	r.GET("/docs", spec.DocsHandler)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), `fetch("/openapi.json")`)
+}
+
+func TestValidationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		contentType    string
+		body           string
+		handler        gin.HandlerFunc
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Valid request and response",
+			method:         http.MethodGet,
+			url:            "/api/v1/albums/1",
+			handler:        func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"id": "1", "title": "Blue Train"}) },
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","title":"Blue Train"}`,
+		},
+		{
+			name:           "Invalid query parameter",
+			method:         http.MethodGet,
+			url:            "/api/v1/albums?limit=1000&sort=rating",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/api/v1/albums","errors":[{"field":"limit","code":"range","message":"number must be at most 100"},{"field":"sort","code":"enum","message":"value is not one of the allowed values [\"id\",\"-id\",\"title\",\"-title\",\"created_at\",\"-created_at\"]"}]}`,
+		},
+		{
+			name:           "Missing required query parameter",
+			method:         http.MethodGet,
+			url:            "/api/v1/albums/search",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/api/v1/albums/search","errors":[{"field":"q","code":"required","message":"is required"}]}`,
+		},
+		{
+			name:           "Invalid body",
+			method:         http.MethodPost,
+			url:            "/api/v1/albums",
+			contentType:    "application/json",
+			body:           `{"id":"a b","genre":"polka"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/api/v1/albums","errors":[{"field":"genre","code":"enum","message":"value is not one of the allowed values [\"rock\",\"pop\",\"jazz\",\"classical\",\"hip-hop\",\"electronic\",\"folk\",\"country\",\"blues\",\"metal\",\"soundtrack\",\"other\"]"},{"field":"id","code":"pattern","message":"string doesn't match the regular expression \"^[A-Za-z0-9_-]{1,64}$\""},{"field":"title","code":"required","message":"property \"title\" is missing"}]}`,
+		},
+		{
+			name:           "Malformed body",
+			method:         http.MethodPost,
+			url:            "/api/v1/albums",
+			contentType:    "application/json",
+			body:           `{"title":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/api/v1/albums","errors":[{"field":"body","code":"malformed","message":"request body is not valid JSON"}]}`,
+		},
+		{
+			name:        "Unsupported media type is left to the handler",
+			method:      http.MethodPost,
+			url:         "/api/v1/albums:import",
+			contentType: "text/plain",
+			body:        "title\nBlue Train",
+			handler: func(c *gin.Context) {
+				problem.Write(c, customerr.NewError(customerr.KindUnsupportedMediaType, "unsupported_media_type", "Unsupported media type", "unsupported media type"))
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "Undocumented response status",
+			method: http.MethodDelete,
+			url:    "/api/v1/albums/1/tracks/2",
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusAccepted, gin.H{"message": "Track deleted"})
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"/problems/internal","title":"Internal Server Error","status":500,"code":"internal","instance":"/api/v1/albums/1/tracks/2"}`,
+		},
+		{
+			name:           "Response not matching its schema",
+			method:         http.MethodGet,
+			url:            "/api/v1/albums/1",
+			handler:        func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"id": 1}) },
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"/problems/internal","title":"Internal Server Error","status":500,"code":"internal","instance":"/api/v1/albums/1"}`,
+		},
+		{
+			name:           "Route not in the document",
+			method:         http.MethodGet,
+			url:            "/health",
+			handler:        func(c *gin.Context) { c.String(http.StatusOK, "ok") },
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := tt.handler
+			if handler == nil {
+				handler = func(c *gin.Context) { t.Fatal("handler must not be called") }
+			}
+			r := gin.New()
+			r.Use(spec.ValidationMiddleware(openapi.WithResponseValidation()))
+			r.NoRoute(handler)
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, strings.TrimSpace(w.Body.String()))
+			}
+		})
+	}
+}
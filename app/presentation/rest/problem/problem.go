@@ -0,0 +1,115 @@
+// Package problem writes error responses as RFC 7807 problem details
+// extended with the stable error code and the request ID.
+package problem
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"boilerplate/app/domain/errors"
+)
+
+// ContentType is the media type of problem details responses
+const ContentType = "application/problem+json"
+
+// requestIDHeader is the response header middleware.CommonHeadersMiddleware echoes the request ID in
+const requestIDHeader = "X-Request-ID"
+
+// typeBase prefixes the error code to form the problem type URI, relative to the API host
+const typeBase = "/problems/"
+
+// Details is the problem details body
+type Details struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Code      errors.Code         `json:"code"`
+	Instance  string              `json:"instance,omitempty"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []errors.FieldError `json:"errors,omitempty"`
+}
+
+// kindStatus maps each error kind to its response status
+var kindStatus = map[errors.Kind]int{
+	errors.KindInternal:             http.StatusInternalServerError,
+	errors.KindNotFound:             http.StatusNotFound,
+	errors.KindConflict:             http.StatusConflict,
+	errors.KindInvalidInput:         http.StatusBadRequest,
+	errors.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	errors.KindPreconditionRequired: http.StatusPreconditionRequired,
+	errors.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	errors.KindUnauthenticated:      http.StatusUnauthorized,
+	errors.KindNotAcceptable:        http.StatusNotAcceptable,
+	errors.KindUnavailable:          http.StatusServiceUnavailable,
+	errors.KindTooLarge:             http.StatusRequestEntityTooLarge,
+	errors.KindForbidden:            http.StatusForbidden,
+	errors.KindTooManyRequests:      http.StatusTooManyRequests,
+	errors.KindUnprocessable:        http.StatusUnprocessableEntity,
+	errors.KindTimeout:              http.StatusRequestTimeout,
+}
+
+// Status returns the response status of an error kind
+func Status(kind errors.Kind) int {
+	if status, ok := kindStatus[kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// New describes err as problem details for the request of ctx
+func New(ctx *gin.Context, err error) Details {
+	return Describe(err, ctx.Request.URL.Path, ctx.Writer.Header().Get(requestIDHeader))
+}
+
+// Describe describes err as problem details about instance, the path of the request. Errors
+// without a typed error in their chain are reported as internal errors, and the cause of an error
+// is never exposed.
+func Describe(err error, instance, requestID string) Details {
+	typed := errors.ErrInternalServer
+	var found *errors.Error
+	if stderrors.As(err, &found) {
+		typed = found
+	}
+
+	return Details{
+		Type:      typeBase + string(typed.Code),
+		Title:     typed.Title,
+		Status:    Status(typed.Kind),
+		Code:      typed.Code,
+		Instance:  instance,
+		RequestID: requestID,
+		Errors:    errors.ValidationDetails(err),
+	}
+}
+
+// Write responds with the problem details describing err
+func Write(ctx *gin.Context, err error) {
+	details := New(ctx, err)
+	ctx.Render(details.Status, render{details})
+}
+
+// Send responds with details on w, for the responses written past the gin context, such as one
+// answering a request whose handler is still running
+func Send(w http.ResponseWriter, details Details) {
+	r := render{details}
+	r.WriteContentType(w)
+	w.WriteHeader(details.Status)
+	_ = r.Render(w)
+}
+
+// render writes problem details with the problem+json content type; gin's JSON renderer always sends application/json
+type render struct {
+	details Details
+}
+
+func (r render) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.details)
+}
+
+func (r render) WriteContentType(w http.ResponseWriter) {
+	w.Header()["Content-Type"] = []string{ContentType}
+}
@@ -0,0 +1,105 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/presentation/rest/problem"
+)
+
+func TestWrite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		err            error
+		requestID      string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Wrapped typed error",
+			err:            fmt.Errorf("service error deleting album: %w", customerr.ErrAlbumHasTracks),
+			requestID:      "req-1",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"/problems/album_has_tracks","title":"Album still has tracks","status":409,"code":"album_has_tracks","instance":"/albums/1","request_id":"req-1"}`,
+		},
+		{
+			name:           "Validation error",
+			err:            customerr.NewValidationError(customerr.FieldError{Field: "title", Code: "required", Message: "is required"}),
+			requestID:      "req-2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"/problems/invalid_input","title":"Invalid input","status":400,"code":"invalid_input","instance":"/albums/1","request_id":"req-2","errors":[{"field":"title","code":"required","message":"is required"}]}`,
+		},
+		{
+			name:           "Request body too large",
+			err:            fmt.Errorf("service error reading import: %w", middleware.ErrRequestBodyTooLarge),
+			requestID:      "req-4",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"type":"/problems/request_body_too_large","title":"Request body is too large","status":413,"code":"request_body_too_large","instance":"/albums/1","request_id":"req-4"}`,
+		},
+		{
+			name:           "Insufficient scope",
+			err:            customerr.ErrScopeRequired,
+			requestID:      "req-5",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"type":"/problems/insufficient_scope","title":"Insufficient scope","status":403,"code":"insufficient_scope","instance":"/albums/1","request_id":"req-5"}`,
+		},
+		{
+			name:           "Untyped error hides its message",
+			err:            errors.New("dial tcp 10.0.0.5:3306: connection refused"),
+			requestID:      "req-3",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"/problems/internal","title":"Internal Server Error","status":500,"code":"internal","instance":"/albums/1","request_id":"req-3"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(middleware.CommonHeadersMiddleware())
+			router.GET("/albums/:id", func(ctx *gin.Context) {
+				problem.Write(ctx, tt.err)
+			})
+
+			req, _ := http.NewRequest("GET", "/albums/1", nil)
+			req.Header.Set(middleware.RequestIDHeader, tt.requestID)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.requestID, w.Header().Get(middleware.RequestIDHeader))
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestWrite_GeneratesRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.CommonHeadersMiddleware())
+	router.GET("/albums/:id", func(ctx *gin.Context) {
+		problem.Write(ctx, customerr.ErrAlbumNotFound)
+	})
+
+	req, _ := http.NewRequest("GET", "/albums/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	details := problem.Details{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+	assert.NotEmpty(t, details.RequestID)
+	assert.Equal(t, details.RequestID, w.Header().Get(middleware.RequestIDHeader))
+}
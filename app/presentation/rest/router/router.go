@@ -1,37 +1,143 @@
 package router
 
 import (
+	"net/http"
+
+	"boilerplate/app/domain/entity"
 	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/graphql"
 	restcontroller "boilerplate/app/presentation/rest/album"
+	v2 "boilerplate/app/presentation/rest/dto/v2"
 	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/presentation/rest/openapi"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, controller *restcontroller.Controller, cfg *config.AppConfig) {
+func SetupRoutes(router *gin.Engine, controller *restcontroller.Controller, cfg *config.AppConfig, idempotencyStore middleware.IdempotencyStore, spec *openapi.Spec, graphqlHandler *graphql.Handler, verifier auth.TokenVerifier, apiKeys auth.APIKeyVerifier, rateLimitStore middleware.RateLimitStore, auditRecorder middleware.AuditRecorder, operations ...auth.RoutePermission) {
 
 	router.Use(gin.Recovery())
 	router.Use(gin.Logger())
 	router.Use(middleware.LatencyLogger())
-	router.Use(middleware.TimeoutMiddleware(cfg))
+	router.Use(middleware.CompressionMiddleware(cfg.CompressionMinSize, cfg.DecompressedBodyMaxSize))
+	// The album change streams stay open as long as their clients
+	router.Use(middleware.TimeoutMiddleware(cfg, "/api/v1/albums/stream", "/api/v1/albums/stream/ws"))
 	router.Use(middleware.CommonHeadersMiddleware())
+	if cfg.OpenAPIValidateRequests {
+		// Responses are checked too in test mode, so that tests fail on handlers drifting from the document
+		var opts []openapi.ValidationOption
+		if gin.Mode() == gin.TestMode {
+			opts = append(opts, openapi.WithResponseValidation())
+		}
+		router.Use(spec.ValidationMiddleware(opts...))
+	}
+
+	// Each client has its own limit per route group; the open routes limit clients by IP address
+	rateLimiter := middleware.NewRateLimiter(rateLimitStore)
+	publicLimit := rateLimiter.Middleware("public", cfg.RateLimitPublic)
+	readLimit := rateLimiter.Middleware("read", cfg.RateLimitRead)
+	writeLimit := rateLimiter.Middleware("write", cfg.RateLimitWrite)
+	adminLimit := rateLimiter.Middleware("admin", cfg.RateLimitAdmin)
+	// Before their credentials are checked, clients can only be told apart by IP address
+	authLimit := rateLimiter.AddressMiddleware("auth", cfg.RateLimitAuth)
+
+	router.GET("/openapi.json", publicLimit, spec.SpecHandler)
+	router.GET("/docs", publicLimit, spec.DocsHandler)
+
+	// GraphQL applies the checks of the policy to its root fields itself
+	router.GET("/graphql", publicLimit, graphqlHandler.Serve)
+	router.POST("/graphql", publicLimit, graphqlHandler.Serve)
+
+	// Routes registered through the policy require a JWT bearer token or an API key, granted the
+	// scopes of their group; the others are open to anyone
+	policy := middleware.NewPolicy(authLimit, middleware.AuthMiddleware(verifier, apiKeys))
+	// The GraphQL fields and the operations of other transports, such as gRPC methods, check the same
+	// credentials and scopes themselves and are listed next to the routes
+	policy.AddOperations(graphqlHandler.Permissions()...)
+	policy.AddOperations(operations...)
+
+	// Every album write is recorded in the audit log
+	audit := middleware.AuditMiddleware(auditRecorder)
 
 	api := router.Group("/api")
 	{
+		// v1 is frozen and deprecated in favor of v2
 		v1 := api.Group("/v1")
-		v1.GET("/albums", controller.GetAlbumsHandler)
-		v1.POST("/albums", controller.CreateAlbumHandler)
-		v1.GET("/albums/:id", controller.GetAlbumByIDHandler)
-
-		// Apply auth middleware to these routes
-		auth := v1.Group("/")
-		auth.Use(middleware.AuthMiddleware())
-		{
-			auth.GET("/jsonposts", controller.GetJsonPostHandler)
-		}
+		v1.Use(middleware.DeprecationMiddleware(cfg.APIV1DeprecatedAt, cfg.APIV1SunsetAt, "/docs"))
+
+		reads := policy.Group(v1, entity.ScopeAlbumsRead).Use(readLimit)
+		reads.GET("/albums", middleware.CacheControlMiddleware(cfg.AlbumListCacheControl), controller.GetAlbumsHandler)
+		reads.GET("/albums/search", controller.SearchAlbumsHandler)
+		reads.GET("/albums/stream", controller.StreamAlbumsHandler)
+		reads.GET("/albums/stream/ws", controller.StreamAlbumsWebSocketHandler)
+		reads.GET("/albums/trash", controller.GetTrashedAlbumsHandler)
+		reads.GET("/albums/:id", middleware.CacheControlMiddleware(cfg.AlbumCacheControl), controller.GetAlbumByIDHandler)
+		reads.GET("/albums/:id/tracks", controller.GetTracksHandler)
+		reads.GET("/albums/:id/tracks/:trackId", controller.GetTrackByIDHandler)
+
+		writes := policy.Group(v1, entity.ScopeAlbumsWrite).Use(writeLimit, audit)
+		writes.POST("/albums", middleware.IdempotencyMiddleware(idempotencyStore, cfg.IdempotencyTTL), controller.CreateAlbumHandler)
+		writes.POST("/albums:action", controller.AlbumActionHandler) // POST /albums:import, /albums:purge
+		writes.PUT("/albums/:id", controller.UpdateAlbumHandler)
+		writes.PATCH("/albums/:id", controller.PatchAlbumHandler)
+		writes.DELETE("/albums/:id", controller.DeleteAlbumHandler)
+		writes.POST("/albums/:id/restore", controller.RestoreAlbumHandler)
+		writes.POST("/albums/:id/tracks", controller.CreateTrackHandler)
+		writes.PUT("/albums/:id/tracks/:trackId", controller.UpdateTrackHandler)
+		writes.DELETE("/albums/:id/tracks/:trackId", controller.DeleteTrackHandler)
+
+		// Any caller may read the third-party posts, once authenticated
+		policy.Group(v1).Use(readLimit).GET("/jsonposts", controller.GetJsonPostHandler)
+
+		// The audit log tells who changed which album, so only admins read it
+		policy.Group(v1, entity.ScopeAdmin).Use(adminLimit).GET("/audit", controller.GetAuditRecordsHandler)
 	}
 	{
 		v2 := api.Group("/v2")
-		v2.GET("/albums", controller.GetAlbumsHandler)
+
+		reads := policy.Group(v2, entity.ScopeAlbumsRead).Use(readLimit)
+		reads.GET("/albums", middleware.CacheControlMiddleware(cfg.AlbumListCacheControl), controller.GetAlbumsV2Handler)
+		reads.GET("/albums/search", controller.SearchAlbumsV2Handler)
+		reads.GET("/albums/trash", controller.GetTrashedAlbumsV2Handler)
+		reads.GET("/albums/:id", middleware.CacheControlMiddleware(cfg.AlbumCacheControl), controller.GetAlbumByIDV2Handler)
+		reads.GET("/albums/:id/tracks", controller.GetTracksV2Handler)
+		reads.GET("/albums/:id/tracks/:trackId", controller.GetTrackByIDV2Handler)
+
+		writes := policy.Group(v2, entity.ScopeAlbumsWrite).Use(writeLimit, audit)
+		writes.POST("/albums", middleware.IdempotencyMiddleware(idempotencyStore, cfg.IdempotencyTTL), controller.CreateAlbumV2Handler)
+		writes.POST("/albums:action", controller.AlbumActionV2Handler) // POST /albums:import, /albums:purge
+		writes.PUT("/albums/:id", controller.UpdateAlbumV2Handler)
+		writes.PATCH("/albums/:id", controller.PatchAlbumV2Handler)
+		writes.DELETE("/albums/:id", controller.DeleteAlbumHandler)
+		writes.POST("/albums/:id/restore", controller.RestoreAlbumV2Handler)
+		writes.POST("/albums/:id/tracks", controller.CreateTrackV2Handler)
+		writes.PUT("/albums/:id/tracks/:trackId", controller.UpdateTrackV2Handler)
+		writes.DELETE("/albums/:id/tracks/:trackId", controller.DeleteTrackHandler)
+
+		// Webhooks hold the secrets their deliveries are signed with, and API keys are issued
+		// to the services calling the API
+		admin := policy.Group(v2, entity.ScopeAdmin).Use(adminLimit)
+		admin.GET("/webhooks", controller.GetWebhooksHandler)
+		admin.POST("/webhooks", controller.CreateWebhookHandler)
+		admin.GET("/webhooks/:id", controller.GetWebhookByIDHandler)
+		admin.PUT("/webhooks/:id", controller.UpdateWebhookHandler)
+		admin.DELETE("/webhooks/:id", controller.DeleteWebhookHandler)
+		admin.GET("/webhooks/:id/deliveries", controller.GetWebhookDeliveriesHandler)
+		admin.GET("/api-keys", controller.GetAPIKeysHandler)
+		admin.POST("/api-keys", controller.CreateAPIKeyHandler)
+		admin.GET("/api-keys/:id", controller.GetAPIKeyByIDHandler)
+		admin.POST("/api-keys/:id/rotate", controller.RotateAPIKeyHandler)
+		admin.POST("/api-keys/:id/revoke", controller.RevokeAPIKeyHandler)
+
+		// Lists what every route requires, the open ones included
+		v2.GET("/permissions", publicLimit, permissionsHandler(router, policy))
+	}
+}
+
+// permissionsHandler answers the permissions of every route of router, as recorded by policy
+func permissionsHandler(router *gin.Engine, policy *middleware.Policy) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, v2.NewCollection(policy.Permissions(router.Routes()), v2.Links{Self: v2.BasePath + "/permissions"}))
 	}
 }
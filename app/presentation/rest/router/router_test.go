@@ -0,0 +1,1188 @@
+package router_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	jwtauth "boilerplate/app/infrastructure/auth"
+	"boilerplate/app/infrastructure/config"
+	"boilerplate/app/presentation/auth"
+	"boilerplate/app/presentation/graphql"
+	restcontroller "boilerplate/app/presentation/rest/album"
+	"boilerplate/app/presentation/rest/middleware"
+	"boilerplate/app/presentation/rest/openapi"
+	"boilerplate/app/presentation/rest/problem"
+	"boilerplate/app/presentation/rest/router"
+	"boilerplate/app/usecase/interface/mocks"
+)
+
+var (
+	// specPathParam matches a path parameter of the document, {id}
+	specPathParam = regexp.MustCompile(`\{(\w+)\}`)
+	// specCustomMethod matches a custom method of the document, /albums:import, which the
+	// router serves from a single /albums:action route
+	specCustomMethod = regexp.MustCompile(`([^/]):\w+$`)
+)
+
+// testSecret signs the bearer tokens of the tests
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// bearer returns the Authorization header of a valid token granted every scope
+func bearer(t *testing.T) string {
+	return scopedBearer(t, entity.ScopeAdmin)
+}
+
+// scopedBearer returns the Authorization header of a valid token granted scopes alone
+func scopedBearer(t *testing.T, scopes ...string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "user-1",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": strings.Join(scopes, " "),
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
+// withBearer sets the Authorization header of req to the one of bearer
+func withBearer(t *testing.T, req *http.Request) *http.Request {
+	req.Header.Set("Authorization", bearer(t))
+	return req
+}
+
+func setupRouter(t *testing.T, albumService *mocks.AlbumInterface, trackService *mocks.TrackInterface) *gin.Engine {
+	cfg := &config.AppConfig{HandlerTimeout: 5 * time.Second, OpenAPIValidateRequests: true}
+	return setupRouterWithConfig(t, cfg, albumService, trackService)
+}
+
+func setupRouterWithConfig(t *testing.T, cfg *config.AppConfig, albumService *mocks.AlbumInterface, trackService *mocks.TrackInterface, opts ...restcontroller.Option) *gin.Engine {
+	return setupRouterWithAPIKeys(t, cfg, albumService, trackService, nil, opts...)
+}
+
+// setupRouterWithAPIKeys sets up a router accepting the API keys verified by apiKeys next to bearer tokens
+func setupRouterWithAPIKeys(t *testing.T, cfg *config.AppConfig, albumService *mocks.AlbumInterface, trackService *mocks.TrackInterface, apiKeys auth.APIKeyVerifier, opts ...restcontroller.Option) *gin.Engine {
+	return newRouter(t, cfg, albumService, trackService, apiKeys, nil, opts...)
+}
+
+// setupRouterWithAudit sets up a router handing the audit records of album writes to auditRecorder
+func setupRouterWithAudit(t *testing.T, albumService *mocks.AlbumInterface, trackService *mocks.TrackInterface, auditRecorder middleware.AuditRecorder, opts ...restcontroller.Option) *gin.Engine {
+	cfg := &config.AppConfig{HandlerTimeout: 5 * time.Second, OpenAPIValidateRequests: true}
+	return newRouter(t, cfg, albumService, trackService, nil, auditRecorder, opts...)
+}
+
+func newRouter(t *testing.T, cfg *config.AppConfig, albumService *mocks.AlbumInterface, trackService *mocks.TrackInterface, apiKeys auth.APIKeyVerifier, auditRecorder middleware.AuditRecorder, opts ...restcontroller.Option) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	keys, err := jwtauth.NewKeySet(jwtauth.KeySources{HMACSecret: testSecret}, 0)
+	require.NoError(t, err)
+	verifier := jwtauth.NewVerifier(keys)
+
+	graphqlHandler, err := graphql.NewHandler(albumService, verifier, apiKeys, auditRecorder, graphql.Limits{})
+	require.NoError(t, err)
+
+	r := gin.New()
+	router.SetupRoutes(r, restcontroller.NewController(albumService, trackService, opts...), cfg, nil, spec, graphqlHandler, verifier, apiKeys, nil, auditRecorder)
+	return r
+}
+
+// TestSetupRoutes_MatchesOpenAPIDocument fails when a route is registered without being
+// documented, or documented without being registered
+func TestSetupRoutes_MatchesOpenAPIDocument(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+	r := setupRouter(t, mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t))
+
+	var registered []string
+	for _, route := range r.Routes() {
+		if strings.HasPrefix(route.Path, "/api/") {
+			registered = append(registered, route.Method+" "+route.Path)
+		}
+	}
+
+	documentedSet := map[string]bool{}
+	for path, item := range spec.Doc.Paths.Map() {
+		ginPath := specCustomMethod.ReplaceAllString(specPathParam.ReplaceAllString(path, ":$1"), "$1:action")
+		for method := range item.Operations() {
+			documentedSet[method+" "+ginPath] = true
+		}
+	}
+	var documented []string
+	for route := range documentedSet {
+		documented = append(documented, route)
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	assert.Equal(t, documented, registered)
+}
+
+// TestSetupRoutes_PermissionsMatchOpenAPIDocument fails when the permissions of a route, as listed
+// by /api/v2/permissions, differ from the security and x-required-scopes of its operation
+func TestSetupRoutes_PermissionsMatchOpenAPIDocument(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+	r := setupRouter(t, mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/permissions", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Data []auth.RoutePermission `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	documented := map[string]auth.RoutePermission{}
+	for path, item := range spec.Doc.Paths.Map() {
+		ginPath := specCustomMethod.ReplaceAllString(specPathParam.ReplaceAllString(path, ":$1"), "$1:action")
+		for method, operation := range item.Operations() {
+			permission := auth.RoutePermission{Method: method, Path: ginPath, Scopes: []string{}}
+			if operation.Security != nil && len(*operation.Security) > 0 {
+				permission.Authenticated = true
+			}
+			if scopes, ok := operation.Extensions["x-required-scopes"].([]interface{}); ok {
+				for _, scope := range scopes {
+					permission.Scopes = append(permission.Scopes, scope.(string))
+				}
+			}
+			documented[method+" "+ginPath] = permission
+		}
+	}
+
+	for _, permission := range body.Data {
+		if !strings.HasPrefix(permission.Path, "/api/") {
+			continue
+		}
+		assert.Equal(t, documented[permission.Method+" "+permission.Path], permission, permission.Method+" "+permission.Path)
+	}
+	assert.Contains(t, body.Data, auth.RoutePermission{Method: http.MethodPost, Path: "/api/v1/albums", Authenticated: true, Scopes: []string{entity.ScopeAlbumsWrite}})
+	// The GraphQL fields are listed next to the open /graphql routes serving them
+	assert.Contains(t, body.Data, auth.RoutePermission{Method: http.MethodPost, Path: "/graphql", Scopes: []string{}})
+	assert.Contains(t, body.Data, auth.RoutePermission{Method: http.MethodPost, Path: "/graphql", Operation: "mutation createAlbum", Authenticated: true, Scopes: []string{entity.ScopeAlbumsWrite}})
+}
+
+// TestSetupRoutes_ResponsesMatchOpenAPIDocument sends representative requests through every handler
+// with response validation enabled, which answers 500 to any response the document does not describe
+func TestSetupRoutes_ResponsesMatchOpenAPIDocument(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	album := dto.Album{ID: "1", Title: "Blue Train", Artist: "John Coltrane", ReleaseDate: "1958-01-01", Genre: "jazz", TrackCount: 5, Version: 2, CreatedAt: &now, UpdatedAt: &now}
+	track := dto.Track{ID: "t1", AlbumID: "1", Position: 1, Title: "Blue Train", DurationSeconds: 643, CreatedAt: &now, UpdatedAt: &now}
+	albumWithTracks := album
+	albumWithTracks.Tracks = []dto.Track{track}
+	trashed := album
+	trashed.DeletedAt = &now
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.AlbumInterface, *mocks.TrackInterface)
+		method      string
+		url         string
+		contentType string
+		body        string
+		headers     map[string]string
+		// anonymous requests leave out the Authorization header sent by default
+		anonymous      bool
+		expectedStatus int
+	}{
+		{
+			name: "ListAlbums",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{album}, NextCursor: "next"}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums?limit=1&sort=-title", expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "ListAlbums_InvalidCursor",
+			method: http.MethodGet, url: "/api/v1/albums?cursor=not-a-cursor", expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "ListAlbums_RejectedBySpec",
+			method: http.MethodGet, url: "/api/v1/albums?limit=0", expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "CreateAlbum",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("1", nil)
+			},
+			method: http.MethodPost, url: "/api/v1/albums", contentType: "application/json", body: `{"title":"Blue Train","genre":"jazz"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "CreateAlbum_Exists",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("", customerr.ErrAlbumExists)
+			},
+			method: http.MethodPost, url: "/api/v1/albums", contentType: "application/json", body: `{"id":"1","title":"Blue Train"}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "ListAlbums_CSV",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{album}}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums", headers: map[string]string{"Accept": "text/csv"}, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetAlbum_MessagePack",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(albumWithTracks, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/1", headers: map[string]string{"Accept": "application/msgpack"}, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetAlbum_NotModified",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 2, UpdatedAt: &now}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/1", headers: map[string]string{"If-None-Match": `"2-json"`}, expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "ListAlbums_NotModified",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{album}}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums", headers: map[string]string{"If-None-Match": "*"}, expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:   "GetAlbum_NotAcceptable",
+			method: http.MethodGet, url: "/api/v1/albums/1", headers: map[string]string{"Accept": "text/csv"}, expectedStatus: http.StatusNotAcceptable,
+		},
+		{
+			name: "StreamAlbums_Disabled",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("SubscribeAlbumEvents", mock.Anything, "").Return(nil, customerr.ErrEventsDisabled)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/stream", expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "StreamAlbums_InvalidLastEventID",
+			method: http.MethodGet, url: "/api/v1/albums/stream?last_event_id=latest", expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "StreamAlbumsWebSocket_NotUpgraded",
+			method: http.MethodGet, url: "/api/v1/albums/stream/ws", expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "CreateAlbum_XML",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("1", nil)
+			},
+			method: http.MethodPost, url: "/api/v1/albums", contentType: "application/xml", body: `<album><title>Blue Train</title></album>`,
+			headers: map[string]string{"Accept": "application/xml"}, expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "CreateAlbum_UnsupportedMediaType",
+			method: http.MethodPost, url: "/api/v1/albums", contentType: "text/plain", body: "Blue Train",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "ImportAlbums",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("ImportAlbums", mock.Anything, mock.Anything, mock.Anything).Return(dto.AlbumImportReport{
+					Created: 1,
+					Failed:  1,
+					Rows: []dto.AlbumImportRowInfo{
+						{Line: 1, ID: "1", Status: dto.AlbumImportCreated},
+						{Line: 2, Status: dto.AlbumImportFailed, Reason: "invalid input", Details: []customerr.FieldError{{Field: "title", Code: "required", Message: "is required"}}},
+					},
+				}, nil)
+			},
+			method: http.MethodPost, url: "/api/v1/albums:import", contentType: "application/x-ndjson", body: "{\"title\":\"Blue Train\"}\n{}\n",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "ImportAlbums_UnsupportedMediaType",
+			method: http.MethodPost, url: "/api/v1/albums:import", contentType: "text/plain", body: "Blue Train",
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "PurgeAlbums",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("PurgeAlbums", mock.Anything).Return(dto.BuildAlbumPurgeReportDTO(now, []string{"1"}), nil)
+			},
+			method: http.MethodPost, url: "/api/v1/albums:purge", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "SearchAlbums",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("SearchAlbums", mock.Anything, mock.Anything).Return(dto.AlbumSearchResults{
+					Results:    []dto.AlbumSearchHit{{Album: album, Score: 1.5, Highlights: map[string]string{"title": "<em>Blue</em> Train"}}},
+					NextOffset: 1,
+				}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/search?q=blue&limit=1", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ListTrashedAlbums",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{trashed}}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/trash", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetAlbum",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(albumWithTracks, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/1?include=tracks", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetAlbum_NotFound",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(dto.Album{}, customerr.ErrAlbumNotFound)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/1", expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "UpdateAlbum",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("UpdateAlbum", mock.Anything, mock.Anything).Return(album, nil)
+			},
+			method: http.MethodPut, url: "/api/v1/albums/1", contentType: "application/json", body: `{"title":"Blue Train"}`,
+			headers: map[string]string{"If-Match": `"1"`}, expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "UpdateAlbum_IfMatchRequired",
+			method: http.MethodPut, url: "/api/v1/albums/1", contentType: "application/json", body: `{"title":"Blue Train"}`,
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name: "PatchAlbum_VersionMismatch",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("PatchAlbum", mock.Anything, "1", mock.Anything, int64(1)).Return(dto.Album{}, customerr.ErrVersionMismatch)
+			},
+			method: http.MethodPatch, url: "/api/v1/albums/1", contentType: "application/json", body: `{"artist":"Coltrane"}`,
+			headers: map[string]string{"If-Match": `"1"`}, expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name: "DeleteAlbum",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("DeleteAlbum", mock.Anything, "1", int64(0)).Return(nil)
+			},
+			method: http.MethodDelete, url: "/api/v1/albums/1", headers: map[string]string{"If-Match": "*"}, expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "DeleteAlbum_HasTracks",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("DeleteAlbum", mock.Anything, "1", int64(0)).Return(customerr.ErrAlbumHasTracks)
+			},
+			method: http.MethodDelete, url: "/api/v1/albums/1", headers: map[string]string{"If-Match": "*"}, expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "RestoreAlbum",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("RestoreAlbum", mock.Anything, "1").Return(album, nil)
+			},
+			method: http.MethodPost, url: "/api/v1/albums/1/restore", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ListTracks",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("GetTracks", mock.Anything, "1").Return([]dto.Track{track}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/1/tracks", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "CreateTrack",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("CreateTrack", mock.Anything, mock.Anything).Return("t1", nil)
+			},
+			method: http.MethodPost, url: "/api/v1/albums/1/tracks", contentType: "application/json", body: `{"position":1,"title":"Blue Train","duration_seconds":643}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "GetTrack",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("GetTrackByID", mock.Anything, "1", "t1").Return(track, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/albums/1/tracks/t1", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "UpdateTrack",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("UpdateTrack", mock.Anything, mock.Anything).Return(track, nil)
+			},
+			method: http.MethodPut, url: "/api/v1/albums/1/tracks/t1", contentType: "application/json", body: `{"position":1,"title":"Blue Train"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "DeleteTrack_NotFound",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("DeleteTrack", mock.Anything, "1", "t1").Return(customerr.ErrTrackNotFound)
+			},
+			method: http.MethodDelete, url: "/api/v1/albums/1/tracks/t1", expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "DeleteTrack",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("DeleteTrack", mock.Anything, "1", "t1").Return(nil)
+			},
+			method: http.MethodDelete, url: "/api/v1/albums/1/tracks/t1", expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "ListPosts",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetFromThirdPartyAPI", mock.Anything).Return([]dto.Post{{UserID: 1, ID: 1, Title: "title", Body: "body"}}, nil)
+			},
+			method: http.MethodGet, url: "/api/v1/jsonposts", headers: map[string]string{"Authorization": scopedBearer(t)}, expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "ListPosts_Unauthorized",
+			method: http.MethodGet, url: "/api/v1/jsonposts", anonymous: true, expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "CreateAlbum_Unauthorized",
+			method: http.MethodPost, url: "/api/v1/albums", contentType: "application/json", body: `{"title":"Blue Train","genre":"jazz"}`,
+			anonymous: true, expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "CreateAlbum_ReadOnly",
+			method: http.MethodPost, url: "/api/v1/albums", contentType: "application/json", body: `{"title":"Blue Train","genre":"jazz"}`,
+			headers: map[string]string{"Authorization": scopedBearer(t, entity.ScopeAlbumsRead)}, expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "ListAlbumsV2_WriteOnly",
+			method: http.MethodGet, url: "/api/v2/albums",
+			headers: map[string]string{"Authorization": scopedBearer(t, entity.ScopeAlbumsWrite)}, expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "ListPermissions",
+			method: http.MethodGet, url: "/api/v2/permissions", anonymous: true, expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "ListPosts_InvalidToken",
+			method: http.MethodGet, url: "/api/v1/jsonposts", headers: map[string]string{"Authorization": "Bearer valid"}, expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "ListAlbumsV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{album}, NextCursor: "next", PrevCursor: "prev"}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums?limit=1", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ListAlbumsV2_Empty",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "CreateAlbumV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("CreateAlbum", mock.Anything, mock.Anything).Return("1", nil)
+			},
+			method: http.MethodPost, url: "/api/v2/albums", contentType: "application/json", body: `{"title":"Blue Train"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "GetAlbumV2_NotModifiedSince",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 2, UpdatedAt: &now}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/1", headers: map[string]string{"If-Modified-Since": now.Format(http.TimeFormat)}, expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "ListAlbumsV2_XML",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{album}, NextCursor: "next"}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums", headers: map[string]string{"Accept": "application/xml"}, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ImportAlbumsV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("ImportAlbums", mock.Anything, mock.Anything, mock.Anything).Return(dto.AlbumImportReport{
+					Created: 1,
+					Rows:    []dto.AlbumImportRowInfo{{Line: 1, ID: "1", Status: dto.AlbumImportCreated}},
+				}, nil)
+			},
+			method: http.MethodPost, url: "/api/v2/albums:import", contentType: "text/csv", body: "title\nBlue Train\n",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "PurgeAlbumsV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("PurgeAlbums", mock.Anything).Return(dto.BuildAlbumPurgeReportDTO(now, nil), nil)
+			},
+			method: http.MethodPost, url: "/api/v2/albums:purge", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "SearchAlbumsV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("SearchAlbums", mock.Anything, mock.Anything).Return(dto.AlbumSearchResults{
+					Results:    []dto.AlbumSearchHit{{Album: album, Score: 1.5}},
+					NextOffset: 1,
+				}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/search?q=blue&limit=1", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ListTrashedAlbumsV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{Albums: []dto.Album{trashed}}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/trash", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetAlbumV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(albumWithTracks, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/1?include=tracks", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetAlbumV2_NullFields",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(dto.Album{ID: "1", Title: "Blue Train", Version: 1}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/1", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GetAlbumV2_NotFound",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("GetAlbumByID", mock.Anything, "1", mock.Anything).Return(dto.Album{}, customerr.ErrAlbumNotFound)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/1", expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "UpdateAlbumV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("UpdateAlbum", mock.Anything, mock.Anything).Return(album, nil)
+			},
+			method: http.MethodPut, url: "/api/v2/albums/1", contentType: "application/json", body: `{"title":"Blue Train"}`,
+			headers: map[string]string{"If-Match": `"1"`}, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "PatchAlbumV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("PatchAlbum", mock.Anything, "1", mock.Anything, int64(1)).Return(album, nil)
+			},
+			method: http.MethodPatch, url: "/api/v2/albums/1", contentType: "application/json", body: `{"artist":"Coltrane"}`,
+			headers: map[string]string{"If-Match": `"1"`}, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "DeleteAlbumV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("DeleteAlbum", mock.Anything, "1", int64(0)).Return(nil)
+			},
+			method: http.MethodDelete, url: "/api/v2/albums/1", headers: map[string]string{"If-Match": "*"}, expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "RestoreAlbumV2",
+			setupMock: func(a *mocks.AlbumInterface, _ *mocks.TrackInterface) {
+				a.On("RestoreAlbum", mock.Anything, "1").Return(album, nil)
+			},
+			method: http.MethodPost, url: "/api/v2/albums/1/restore", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ListTracksV2",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("GetTracks", mock.Anything, "1").Return([]dto.Track{track}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/1/tracks", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "CreateTrackV2",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("CreateTrack", mock.Anything, mock.Anything).Return("t1", nil)
+			},
+			method: http.MethodPost, url: "/api/v2/albums/1/tracks", contentType: "application/json", body: `{"position":1,"title":"Blue Train"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "GetTrackV2",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("GetTrackByID", mock.Anything, "1", "t1").Return(track, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/albums/1/tracks/t1", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "UpdateTrackV2",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("UpdateTrack", mock.Anything, mock.Anything).Return(track, nil)
+			},
+			method: http.MethodPut, url: "/api/v2/albums/1/tracks/t1", contentType: "application/json", body: `{"position":1,"title":"Blue Train"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "DeleteTrackV2",
+			setupMock: func(_ *mocks.AlbumInterface, tr *mocks.TrackInterface) {
+				tr.On("DeleteTrack", mock.Anything, "1", "t1").Return(nil)
+			},
+			method: http.MethodDelete, url: "/api/v2/albums/1/tracks/t1", expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albumService := mocks.NewAlbumInterface(t)
+			trackService := mocks.NewTrackInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(albumService, trackService)
+			}
+			r := setupRouter(t, albumService, trackService)
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if !tt.anonymous {
+				req.Header.Set("Authorization", bearer(t))
+			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+// TestSetupRoutes_WebhookResponsesMatchOpenAPIDocument checks the webhook routes against the document
+func TestSetupRoutes_WebhookResponsesMatchOpenAPIDocument(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	enabled := true
+	webhook := dto.Webhook{ID: "w1", URL: "https://partner.example/hooks", Events: []string{"album.created"}, Enabled: &enabled, CreatedAt: &createdAt, UpdatedAt: &createdAt}
+	created := webhook
+	created.Secret = "0123456789abcdef0123456789abcdef"
+	authorized := map[string]string{"Authorization": bearer(t)}
+
+	tests := []struct {
+		name           string
+		setupMock      func(w *mocks.WebhookInterface)
+		method         string
+		url            string
+		headers        map[string]string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:   "ListWebhooks_Unauthorized",
+			method: http.MethodGet, url: "/api/v2/webhooks", expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "ListWebhooks",
+			setupMock: func(w *mocks.WebhookInterface) {
+				w.On("GetWebhooks", mock.Anything).Return([]dto.Webhook{webhook}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/webhooks", headers: authorized, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "CreateWebhook",
+			setupMock: func(w *mocks.WebhookInterface) {
+				w.On("CreateWebhook", mock.Anything, mock.Anything).Return(created, nil)
+			},
+			method: http.MethodPost, url: "/api/v2/webhooks", headers: authorized,
+			body: `{"url":"https://partner.example/hooks","events":["album.created"]}`, expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "CreateWebhook_UnknownEvent",
+			method: http.MethodPost, url: "/api/v2/webhooks", headers: authorized,
+			body: `{"url":"https://partner.example/hooks","events":["album.played"]}`, expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "GetWebhook_NotFound",
+			setupMock: func(w *mocks.WebhookInterface) {
+				w.On("GetWebhookByID", mock.Anything, "w9").Return(dto.Webhook{}, customerr.ErrWebhookNotFound)
+			},
+			method: http.MethodGet, url: "/api/v2/webhooks/w9", headers: authorized, expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "UpdateWebhook",
+			setupMock: func(w *mocks.WebhookInterface) {
+				w.On("UpdateWebhook", mock.Anything, mock.Anything).Return(webhook, nil)
+			},
+			method: http.MethodPut, url: "/api/v2/webhooks/w1", headers: authorized,
+			body: `{"url":"https://partner.example/hooks"}`, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "DeleteWebhook",
+			setupMock: func(w *mocks.WebhookInterface) {
+				w.On("DeleteWebhook", mock.Anything, "w1").Return(nil)
+			},
+			method: http.MethodDelete, url: "/api/v2/webhooks/w1", headers: authorized, expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "ListWebhookDeliveries",
+			setupMock: func(w *mocks.WebhookInterface) {
+				w.On("GetWebhookDeliveries", mock.Anything, "w1", 5).Return([]dto.WebhookDelivery{
+					{ID: "a1", WebhookID: "w1", DeliveryID: "d1", EventID: "1-0", EventType: "album.created", Attempt: 1, Error: "request timeout", DurationMS: 10000, CreatedAt: createdAt},
+					{ID: "a2", WebhookID: "w1", DeliveryID: "d1", EventID: "1-0", EventType: "album.created", Attempt: 2, StatusCode: 204, DurationMS: 12, Succeeded: true, CreatedAt: createdAt},
+				}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/webhooks/w1/deliveries?limit=5", headers: authorized, expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookService := mocks.NewWebhookInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(webhookService)
+			}
+			cfg := &config.AppConfig{HandlerTimeout: 5 * time.Second, OpenAPIValidateRequests: true}
+			r := setupRouterWithConfig(t, cfg, mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t), restcontroller.WithWebhookService(webhookService))
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestSetupRoutes_APIKeyResponsesMatchOpenAPIDocument(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	apiKey := dto.APIKey{ID: "k1", Name: "billing", Prefix: "0123456789ab", Scopes: []string{"albums:read"}, CreatedAt: &createdAt, UpdatedAt: &createdAt}
+	issued := apiKey
+	issued.Key = "ak_0123456789ab_0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	authorized := map[string]string{"Authorization": bearer(t)}
+	withAPIKey := map[string]string{"X-API-Key": issued.Key}
+
+	tests := []struct {
+		name           string
+		setupMock      func(a *mocks.APIKeyInterface)
+		method         string
+		url            string
+		headers        map[string]string
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "ListAPIKeys",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("GetAPIKeys", mock.Anything).Return([]dto.APIKey{apiKey}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/api-keys", headers: authorized, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ListAPIKeys_WithAPIKey",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("VerifyAPIKey", mock.Anything, issued.Key).Return(entity.APIKey{ID: "k1", Scopes: []string{"admin"}}, nil)
+				a.On("GetAPIKeys", mock.Anything).Return([]dto.APIKey{apiKey}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/api-keys", headers: withAPIKey, expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ListAPIKeys_RevokedAPIKey",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("VerifyAPIKey", mock.Anything, issued.Key).Return(entity.APIKey{}, customerr.ErrAPIKeyRevoked)
+			},
+			method: http.MethodGet, url: "/api/v2/api-keys", headers: withAPIKey,
+			expectedStatus: http.StatusUnauthorized, expectedError: "API key revoked",
+		},
+		{
+			name: "ListAPIKeys_ReadOnlyAPIKey",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("VerifyAPIKey", mock.Anything, issued.Key).Return(entity.APIKey{ID: "k1", Scopes: []string{entity.ScopeAlbumsRead}}, nil)
+			},
+			method: http.MethodGet, url: "/api/v2/api-keys", headers: withAPIKey,
+			expectedStatus: http.StatusForbidden, expectedError: "Insufficient scope",
+		},
+		{
+			name:   "ListAPIKeys_TokenAndAPIKey",
+			method: http.MethodGet, url: "/api/v2/api-keys", headers: map[string]string{"Authorization": bearer(t), "X-API-Key": issued.Key},
+			expectedStatus: http.StatusUnauthorized, expectedError: "Send either an Authorization or an X-API-Key header, not both",
+		},
+		{
+			name: "CreateAPIKey",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("CreateAPIKey", mock.Anything, mock.Anything).Return(issued, nil)
+			},
+			method: http.MethodPost, url: "/api/v2/api-keys", headers: authorized,
+			body: `{"name":"billing","scopes":["albums:read"]}`, expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "CreateAPIKey_UnknownScope",
+			method: http.MethodPost, url: "/api/v2/api-keys", headers: authorized,
+			body: `{"name":"billing","scopes":["albums:delete"]}`, expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "GetAPIKey_NotFound",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("GetAPIKeyByID", mock.Anything, "k9").Return(dto.APIKey{}, customerr.ErrAPIKeyNotFound)
+			},
+			method: http.MethodGet, url: "/api/v2/api-keys/k9", headers: authorized, expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "RotateAPIKey",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("RotateAPIKey", mock.Anything, "k1", 10*time.Minute).Return(issued, nil)
+			},
+			method: http.MethodPost, url: "/api/v2/api-keys/k1/rotate", headers: authorized,
+			body: `{"grace_period_seconds":600}`, expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "RotateAPIKey_Revoked",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				a.On("RotateAPIKey", mock.Anything, "k1", time.Duration(0)).Return(dto.APIKey{}, customerr.ErrAPIKeyInactive)
+			},
+			method: http.MethodPost, url: "/api/v2/api-keys/k1/rotate", headers: authorized, expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "RevokeAPIKey",
+			setupMock: func(a *mocks.APIKeyInterface) {
+				revoked := apiKey
+				revoked.RevokedAt = &createdAt
+				a.On("RevokeAPIKey", mock.Anything, "k1").Return(revoked, nil)
+			},
+			method: http.MethodPost, url: "/api/v2/api-keys/k1/revoke", headers: authorized, expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyService := mocks.NewAPIKeyInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(apiKeyService)
+			}
+			cfg := &config.AppConfig{HandlerTimeout: 5 * time.Second, OpenAPIValidateRequests: true}
+			r := setupRouterWithAPIKeys(t, cfg, mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t), apiKeyService, restcontroller.WithAPIKeyService(apiKeyService))
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedError != "" {
+				var details problem.Details
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+				assert.Equal(t, tt.expectedError, details.Title)
+				assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+// TestSetupRoutes_DeprecatesV1 checks that only v1 responses announce the deprecation configured
+func TestSetupRoutes_DeprecatesV1(t *testing.T) {
+	cfg := &config.AppConfig{
+		HandlerTimeout:    5 * time.Second,
+		APIV1DeprecatedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		APIV1SunsetAt:     time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, nil)
+	r := setupRouterWithConfig(t, cfg, albumService, mocks.NewTrackInterface(t))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, withBearer(t, httptest.NewRequest(http.MethodGet, "/api/v1/albums", nil)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "@1790812800", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, withBearer(t, httptest.NewRequest(http.MethodGet, "/api/v2/albums", nil)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+}
+
+// TestSetupRoutes_SetsCacheControl checks that album reads carry the Cache-Control configured for their route
+func TestSetupRoutes_SetsCacheControl(t *testing.T) {
+	cfg := &config.AppConfig{
+		HandlerTimeout:          5 * time.Second,
+		OpenAPIValidateRequests: true,
+		AlbumCacheControl:       "private, no-cache",
+		AlbumListCacheControl:   "private, max-age=30",
+	}
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, nil)
+	albumService.On("GetAlbumVersion", mock.Anything, "1").Return(dto.AlbumVersion{Version: 2}, nil)
+	albumService.On("GetAlbumByID", mock.Anything, "2", mock.Anything).Return(dto.Album{}, customerr.ErrAlbumNotFound)
+	albumService.On("SearchAlbums", mock.Anything, mock.Anything).Return(dto.AlbumSearchResults{}, nil)
+	r := setupRouterWithConfig(t, cfg, albumService, mocks.NewTrackInterface(t))
+
+	tests := []struct {
+		url      string
+		headers  map[string]string
+		status   int
+		expected string
+	}{
+		{url: "/api/v1/albums", status: http.StatusOK, expected: "private, max-age=30"},
+		{url: "/api/v2/albums", status: http.StatusOK, expected: "private, max-age=30"},
+		{url: "/api/v2/albums/1", headers: map[string]string{"If-None-Match": `"2-json"`}, status: http.StatusNotModified, expected: "private, no-cache"},
+		{url: "/api/v2/albums/2", status: http.StatusNotFound},
+		{url: "/api/v2/albums/search?q=train", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := withBearer(t, httptest.NewRequest(http.MethodGet, tt.url, nil))
+		for key, value := range tt.headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tt.status, w.Code, tt.url)
+		assert.Equal(t, tt.expected, w.Header().Get("Cache-Control"), tt.url)
+	}
+}
+
+// TestSetupRoutes_LimitsRequestsPerGroup checks that each route group has its own limit, and that
+// requests over it are answered as the document describes
+func TestSetupRoutes_LimitsRequestsPerGroup(t *testing.T) {
+	cfg := &config.AppConfig{
+		HandlerTimeout:          5 * time.Second,
+		OpenAPIValidateRequests: true,
+		RateLimitRead:           config.RateLimit{Requests: 5, Window: time.Minute},
+		RateLimitWrite:          config.RateLimit{Requests: 1, Window: time.Minute},
+	}
+	albumService := mocks.NewAlbumInterface(t)
+	albumService.On("CreateAlbum", mock.Anything, mock.Anything).Return("1", nil).Once()
+	albumService.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, nil)
+	r := setupRouterWithConfig(t, cfg, albumService, mocks.NewTrackInterface(t))
+
+	create := func() *httptest.ResponseRecorder {
+		req := withBearer(t, httptest.NewRequest(http.MethodPost, "/api/v2/albums", strings.NewReader(`{"title":"Blue Train"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := create()
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	w = create()
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, w.Body.String())
+	var details problem.Details
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+	assert.Equal(t, middleware.ErrTooManyRequests.Code, details.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	// Reads are limited apart from writes
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, withBearer(t, httptest.NewRequest(http.MethodGet, "/api/v2/albums", nil)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "4", w.Header().Get("RateLimit-Remaining"))
+}
+
+// TestSetupRoutes_LimitsRequestsBeforeAuthentication checks that the protected routes limit the
+// requests of each IP address before checking their credentials
+func TestSetupRoutes_LimitsRequestsBeforeAuthentication(t *testing.T) {
+	cfg := &config.AppConfig{
+		HandlerTimeout:          5 * time.Second,
+		OpenAPIValidateRequests: true,
+		RateLimitAuth:           config.RateLimit{Requests: 2, Window: time.Minute},
+	}
+	r := setupRouterWithConfig(t, cfg, mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t))
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/albums/1", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Authorization", "Bearer guessed")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, send("192.0.2.1:1234").Code)
+	assert.Equal(t, http.StatusUnauthorized, send("192.0.2.1:1234").Code)
+	w := send("192.0.2.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, w.Body.String())
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, http.StatusUnauthorized, send("192.0.2.2:1234").Code)
+}
+
+// fakeAuditRecorder keeps the records handed to it
+type fakeAuditRecorder struct {
+	records []entity.AuditRecord
+}
+
+func (r *fakeAuditRecorder) RecordAudit(record entity.AuditRecord) {
+	r.records = append(r.records, record)
+}
+
+// TestSetupRoutes_RecordsAlbumWrites checks that album writes are audited with their caller, request
+// ID and target, without reading the album for its snapshots, and that reads are not
+func TestSetupRoutes_RecordsAlbumWrites(t *testing.T) {
+	after := dto.Album{ID: "1", Title: "Blue Train", Artist: "Coltrane", Version: 2}
+
+	send := func(r *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+		req.Header.Set(middleware.RequestIDHeader, "req-1")
+		if req.Body != http.NoBody {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Patch", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		albumService.On("PatchAlbum", mock.Anything, "1", mock.Anything, int64(1)).Return(after, nil).Once()
+		recorder := &fakeAuditRecorder{}
+		r := setupRouterWithAudit(t, albumService, mocks.NewTrackInterface(t), recorder)
+
+		req := withBearer(t, httptest.NewRequest(http.MethodPatch, "/api/v2/albums/1", strings.NewReader(`{"artist":"Coltrane"}`)))
+		req.Header.Set("If-Match", `"1"`)
+		w := send(r, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		require.Len(t, recorder.records, 1)
+		record := recorder.records[0]
+		assert.Equal(t, "user:user-1", record.Actor)
+		assert.Equal(t, "req-1", record.RequestID)
+		assert.Equal(t, http.MethodPatch, record.Method)
+		assert.Equal(t, "/api/v2/albums/:id", record.Route)
+		assert.Equal(t, "1", record.TargetID)
+		assert.Nil(t, record.Before)
+		assert.Nil(t, record.After)
+		assert.Equal(t, entity.AuditOutcomeSuccess, record.Outcome)
+		assert.Equal(t, http.StatusOK, record.StatusCode)
+		assert.False(t, record.OccurredAt.IsZero())
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		albumService.On("CreateAlbum", mock.Anything, mock.Anything).Return("1", nil).Once()
+		recorder := &fakeAuditRecorder{}
+		r := setupRouterWithAudit(t, albumService, mocks.NewTrackInterface(t), recorder)
+
+		w := send(r, withBearer(t, httptest.NewRequest(http.MethodPost, "/api/v1/albums", strings.NewReader(`{"title":"Blue Train"}`))))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		require.Len(t, recorder.records, 1)
+		assert.Equal(t, "1", recorder.records[0].TargetID)
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		recorder := &fakeAuditRecorder{}
+		r := setupRouterWithAudit(t, albumService, mocks.NewTrackInterface(t), recorder)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v2/albums/1", nil)
+		req.Header.Set("Authorization", scopedBearer(t, entity.ScopeAlbumsRead))
+		req.Header.Set("If-Match", "*")
+		w := send(r, req)
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+		require.Len(t, recorder.records, 1)
+		record := recorder.records[0]
+		assert.Equal(t, entity.AuditOutcomeDenied, record.Outcome)
+		assert.Equal(t, http.StatusForbidden, record.StatusCode)
+		assert.Equal(t, "1", record.TargetID)
+	})
+
+	t.Run("Reads are not recorded", func(t *testing.T) {
+		albumService := mocks.NewAlbumInterface(t)
+		albumService.On("GetAllAlbums", mock.Anything, mock.Anything).Return(dto.AlbumList{}, nil).Once()
+		recorder := &fakeAuditRecorder{}
+		r := setupRouterWithAudit(t, albumService, mocks.NewTrackInterface(t), recorder)
+
+		w := send(r, withBearer(t, httptest.NewRequest(http.MethodGet, "/api/v2/albums", nil)))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Empty(t, recorder.records)
+	})
+}
+
+// TestSetupRoutes_AuditResponsesMatchOpenAPIDocument checks the audit log route against the document
+func TestSetupRoutes_AuditResponsesMatchOpenAPIDocument(t *testing.T) {
+	occurredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	list := dto.AuditList{
+		Records: []dto.AuditRecord{
+			{
+				ID: "a2", Actor: "user:user-1", RequestID: "req-2", Method: http.MethodDelete, Route: "/api/v2/albums/:id", TargetID: "1",
+				Before: json.RawMessage(`{"id":"1","title":"Blue Train"}`), After: json.RawMessage("null"),
+				Outcome: entity.AuditOutcomeSuccess, StatusCode: http.StatusNoContent, OccurredAt: occurredAt,
+			},
+			{
+				ID: "a1", Actor: "api-key:k1", Method: http.MethodPost, Route: "/api/v1/albums:action",
+				Before: json.RawMessage("null"), After: json.RawMessage("null"),
+				Outcome: entity.AuditOutcomeDenied, StatusCode: http.StatusForbidden, OccurredAt: occurredAt,
+			},
+		},
+		NextCursor: "next",
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(a *mocks.AuditInterface)
+		url            string
+		authorization  string
+		expectedStatus int
+	}{
+		{
+			name: "ListAuditRecords",
+			setupMock: func(a *mocks.AuditInterface) {
+				a.On("GetAuditRecords", mock.Anything, entity.AuditFilter{
+					Actor:   "user:user-1",
+					Outcome: entity.AuditOutcomeSuccess,
+					From:    occurredAt,
+					Limit:   2,
+				}).Return(list, nil)
+			},
+			url:           "/api/v1/audit?actor=user:user-1&outcome=success&from=2024-01-02T03:04:05Z&limit=2",
+			authorization: bearer(t), expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "ListAuditRecords_InvalidCursor",
+			url:           "/api/v1/audit?cursor=not-a-cursor",
+			authorization: bearer(t), expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "ListAuditRecords_RejectedBySpec",
+			url:           "/api/v1/audit?outcome=pending",
+			authorization: bearer(t), expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "ListAuditRecords_NotAdmin",
+			url:           "/api/v1/audit",
+			authorization: scopedBearer(t, entity.ScopeAlbumsRead, entity.ScopeAlbumsWrite), expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditService := mocks.NewAuditInterface(t)
+			if tt.setupMock != nil {
+				tt.setupMock(auditService)
+			}
+			cfg := &config.AppConfig{HandlerTimeout: 5 * time.Second, OpenAPIValidateRequests: true}
+			r := setupRouterWithConfig(t, cfg, mocks.NewAlbumInterface(t), mocks.NewTrackInterface(t), restcontroller.WithAuditService(auditService))
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("Authorization", tt.authorization)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Package validation registers the request validation rules used by the REST DTOs
+// and translates binding failures into field-level validation errors.
+package validation
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+)
+
+// resourceIDPattern matches client-supplied album and track IDs, which covers UUIDs and ULIDs
+var resourceIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// Report fields by their JSON name so the client can match them to its payload
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	_ = v.RegisterValidation("resource_id", func(fl validator.FieldLevel) bool {
+		return resourceIDPattern.MatchString(fl.Field().String())
+	})
+	_ = v.RegisterValidation("genre", func(fl validator.FieldLevel) bool {
+		return entity.IsValidGenre(fl.Field().String())
+	})
+	_ = v.RegisterValidation("webhook_event", func(fl validator.FieldLevel) bool {
+		return entity.IsValidWebhookEventType(fl.Field().String())
+	})
+	_ = v.RegisterValidation("scope", func(fl validator.FieldLevel) bool {
+		return entity.IsValidScope(fl.Field().String())
+	})
+}
+
+// Struct applies the binding rules of a DTO that did not arrive through gin binding,
+// such as a gRPC request converted to a DTO, and returns a ValidationError when they fail
+func Struct(obj interface{}) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return FromBindError(err)
+	}
+	return nil
+}
+
+// FromBindError converts an error returned by ShouldBind* into a ValidationError. Typed errors,
+// such as those of a request body that could not be read, are returned as they are.
+func FromBindError(err error) error {
+	var typed *errors.Error
+	if stderrors.As(err, &typed) {
+		return err
+	}
+
+	var validationErrs validator.ValidationErrors
+	if stderrors.As(err, &validationErrs) {
+		result := errors.NewValidationError()
+		for _, fieldErr := range validationErrs {
+			result.Add(fieldErr.Field(), code(fieldErr), message(fieldErr))
+		}
+		return result
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) {
+		return errors.NewValidationError(errors.FieldError{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type),
+		})
+	}
+
+	return errors.NewValidationError(errors.FieldError{
+		Field:   "body",
+		Code:    "malformed",
+		Message: "request body is not valid JSON",
+	})
+}
+
+// code maps a validator tag to the code reported to the client
+func code(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "required"
+	case "min", "max":
+		if fieldErr.Kind() == reflect.String {
+			return "length"
+		}
+		return "range"
+	case "resource_id":
+		return "pattern"
+	case "datetime":
+		return "format"
+	case "genre", "webhook_event", "scope":
+		return "enum"
+	}
+	return "invalid"
+}
+
+// message describes a failed rule in plain words
+func message(fieldErr validator.FieldError) string {
+	isString := fieldErr.Kind() == reflect.String
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters long", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters long", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	case "resource_id":
+		return "must be 1 to 64 letters, digits, '-' or '_'"
+	case "datetime":
+		return "must be a date formatted as YYYY-MM-DD"
+	case "genre":
+		return "must be one of: " + strings.Join(entity.AlbumGenres, ", ")
+	case "webhook_event":
+		return "must be one of: " + strings.Join(entity.WebhookEventTypes, ", ")
+	case "scope":
+		return "must be one of: " + strings.Join(entity.Scopes, ", ")
+	}
+	return "is invalid"
+}
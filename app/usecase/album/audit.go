@@ -0,0 +1,38 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// auditBefore records the album in the audit change of the call, when it is audited, right before it is written
+func (s *Service) auditBefore(ctx context.Context, id string) {
+	if change := entity.AuditChangeFromContext(ctx); change != nil {
+		change.SetBefore(s.auditSnapshot(ctx, id))
+	}
+}
+
+// auditAfter records the album in the audit change of the call, when it is audited, right after it was written
+func (s *Service) auditAfter(ctx context.Context, id string) {
+	if change := entity.AuditChangeFromContext(ctx); change != nil {
+		change.SetAfter(s.auditSnapshot(ctx, id))
+	}
+}
+
+// auditSnapshot reads an album and its tracks from the repositories, past the cache, or returns nil
+// when the album does not exist
+func (s *Service) auditSnapshot(ctx context.Context, id string) json.RawMessage {
+	album, err := s.albumRepo.GetAlbumByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	tracks, err := s.trackRepo.GetTracksByAlbumID(ctx, id)
+	if err != nil {
+		fmt.Printf("Failed to read the tracks of album %s for the audit log: %v\n", id, err)
+		return nil
+	}
+	return dto.BuildAlbumSnapshot(album, tracks)
+}
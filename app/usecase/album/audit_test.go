@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_AuditChange(t *testing.T) {
+	t.Run("Snapshots around an update", func(t *testing.T) {
+		album := entity.Album{ID: entity.AlbumID("1"), Title: "Blue Train", Version: 3}
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: entity.AlbumID("1"), Title: "Lush Life", Version: 3}, nil).Once()
+		mockRepo.On("UpdateAlbum", mock.Anything, album).Return(int64(4), nil).Once()
+		mockRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: entity.AlbumID("1"), Title: "Blue Train", Version: 4}, nil)
+		trackRepo := mocks.NewTrackRepositoryInterface(t)
+		trackRepo.On("GetTracksByAlbumID", mock.Anything, "1").Return([]entity.Track{
+			{ID: entity.TrackID("t1"), AlbumID: entity.AlbumID("1"), Position: 1, Title: "Moment's Notice", DurationSeconds: 550},
+		}, nil).Twice()
+
+		service := albumservice.NewService(mockRepo, trackRepo, nil, 0*time.Second, nil)
+		change := &entity.AuditChange{}
+
+		_, err := service.UpdateAlbum(entity.WithAuditChange(context.Background(), change), album)
+
+		assert.NoError(t, err)
+		before, after := change.Snapshots()
+		assert.JSONEq(t, `{"id":"1","title":"Lush Life","version":3,"tracks":[
+			{"id":"t1","album_id":"1","position":1,"title":"Moment's Notice","duration_seconds":550}
+		]}`, string(before))
+		assert.JSONEq(t, `{"id":"1","title":"Blue Train","version":4,"tracks":[
+			{"id":"t1","album_id":"1","position":1,"title":"Moment's Notice","duration_seconds":550}
+		]}`, string(after))
+	})
+
+	t.Run("Failed update keeps the album as it was", func(t *testing.T) {
+		album := entity.Album{ID: entity.AlbumID("1"), Title: "Blue Train"}
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: entity.AlbumID("1"), Title: "Lush Life"}, nil).Once()
+		mockRepo.On("UpdateAlbum", mock.Anything, album).Return(int64(0), errors.New("database error")).Once()
+		trackRepo := mocks.NewTrackRepositoryInterface(t)
+		trackRepo.On("GetTracksByAlbumID", mock.Anything, "1").Return(nil, nil).Once()
+
+		service := albumservice.NewService(mockRepo, trackRepo, nil, 0*time.Second, nil)
+		change := &entity.AuditChange{}
+
+		_, err := service.UpdateAlbum(entity.WithAuditChange(context.Background(), change), album)
+
+		assert.Error(t, err)
+		before, after := change.Snapshots()
+		assert.JSONEq(t, `{"id":"1","title":"Lush Life"}`, string(before))
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("Deleted album has no after snapshot", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: entity.AlbumID("1"), Title: "Blue Train"}, nil).Once()
+		mockRepo.On("DeleteAlbum", mock.Anything, "1", int64(0), false).Return(nil).Once()
+		mockRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{}, errors.New("album not found")).Once()
+		trackRepo := mocks.NewTrackRepositoryInterface(t)
+		trackRepo.On("GetTracksByAlbumID", mock.Anything, "1").Return(nil, nil).Once()
+
+		service := albumservice.NewService(mockRepo, trackRepo, nil, 0*time.Second, nil)
+		change := &entity.AuditChange{}
+
+		err := service.DeleteAlbum(entity.WithAuditChange(context.Background(), change), "1", 0)
+
+		assert.NoError(t, err)
+		before, after := change.Snapshots()
+		assert.JSONEq(t, `{"id":"1","title":"Blue Train"}`, string(before))
+		assert.Nil(t, after)
+	})
+}
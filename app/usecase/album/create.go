@@ -1,16 +1,39 @@
 package services
 
 import (
+	"boilerplate/app/domain/dto"
 	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
 	"context"
 	"fmt"
 )
 
-// CreateAlbum creates a new album using the repository
+// CreateAlbum creates a new album using the repository, generating its ID when none is given
 func (s *Service) CreateAlbum(ctx context.Context, album entity.Album) (string, error) {
+	if err := validateAlbum(album); err != nil {
+		return "", err
+	}
+
+	if album.ID == "" {
+		id, err := s.newID()
+		if err != nil {
+			return "", fmt.Errorf("service error creating album: %w", err)
+		}
+		album.ID = entity.AlbumID(id)
+	}
+
 	id, err := s.albumRepo.CreateAlbum(ctx, album)
 	if err != nil {
-		return "", fmt.Errorf("service error creating album: %v", err)
+		if errors.IsAlbumExists(err) {
+			return "", errors.ErrAlbumExists
+		}
+		return "", fmt.Errorf("service error creating album: %w", err)
 	}
+
+	s.auditAfter(ctx, id)
+
+	created := dto.BuildAlbumDTO(album)
+	created.ID = id
+	s.publishAlbumEvent(ctx, dto.AlbumCreated, id, &created)
 	return id, nil
 }
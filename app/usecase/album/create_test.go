@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
 	"boilerplate/app/infrastructure/repositories/interface/mocks"
 	albumservice "boilerplate/app/usecase/album"
 
@@ -30,6 +31,7 @@ func TestService_CreateAlbum(t *testing.T) {
 	tests := []struct {
 		name          string
 		album         entity.Album
+		storedAlbum   *entity.Album // Album expected at the repository when it differs from album
 		mockID        string
 		mockError     error
 		expectedID    string
@@ -58,16 +60,31 @@ func TestService_CreateAlbum(t *testing.T) {
 			expectedError: errors.New("service error creating album: database error"),
 		},
 		{
-			name: "Empty album",
+			name: "Album without ID gets a generated ID",
 			album: entity.Album{
 				ID:    entity.AlbumID(""),
-				Title: "",
+				Title: "Test Album",
+			},
+			storedAlbum: &entity.Album{
+				ID:    entity.AlbumID("generated-id"),
+				Title: "Test Album",
 			},
-			mockID:        "album-empty",
+			mockID:        "generated-id",
 			mockError:     nil,
-			expectedID:    "album-empty",
+			expectedID:    "generated-id",
 			expectedError: nil,
 		},
+		{
+			name: "Duplicate ID",
+			album: entity.Album{
+				ID:    entity.AlbumID("album-123"),
+				Title: "Test Album",
+			},
+			mockID:        "",
+			mockError:     customerr.ErrAlbumExists,
+			expectedID:    "",
+			expectedError: customerr.ErrAlbumExists,
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,7 +93,11 @@ func TestService_CreateAlbum(t *testing.T) {
 			mockRepo := mocks.NewRepositoryInterface(t)
 
 			// Set up mock expectations
-			mockRepo.On("CreateAlbum", mock.Anything, tt.album).
+			storedAlbum := tt.album
+			if tt.storedAlbum != nil {
+				storedAlbum = *tt.storedAlbum
+			}
+			mockRepo.On("CreateAlbum", mock.Anything, storedAlbum).
 				Return(tt.mockID, tt.mockError).
 				Once()
 
@@ -84,8 +105,10 @@ func TestService_CreateAlbum(t *testing.T) {
 			service := albumservice.NewService(
 				mockRepo,
 				nil,
+				nil,
 				0*time.Second,
 				nil,
+				albumservice.WithIDGenerator(func() (string, error) { return "generated-id", nil }),
 			)
 
 			// Call the method
@@ -107,3 +130,25 @@ func TestService_CreateAlbum(t *testing.T) {
 		})
 	}
 }
+
+func TestService_CreateAlbum_InvalidAlbum(t *testing.T) {
+	future := time.Now().AddDate(1, 0, 0)
+	mockRepo := mocks.NewRepositoryInterface(t)
+
+	service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+	_, err := service.CreateAlbum(context.Background(), entity.Album{
+		Title:       "  ",
+		Genre:       "polka",
+		TrackCount:  albumservice.MaxAlbumTrackCount + 1,
+		ReleaseDate: &future,
+	})
+
+	assert.True(t, customerr.IsInvalidInput(err))
+	assert.Equal(t, []customerr.FieldError{
+		{Field: "title", Code: "required", Message: "must not be blank"},
+		{Field: "genre", Code: "enum", Message: "must be one of: rock, pop, jazz, classical, hip-hop, electronic, folk, country, blues, metal, soundtrack, other"},
+		{Field: "track_count", Code: "range", Message: "must be between 0 and 500"},
+		{Field: "release_date", Code: "future", Message: "must not be in the future"},
+	}, customerr.ValidationDetails(err))
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// DeleteAlbum moves an album to the trash and evicts its cached copy. An album that still has tracks
+// is refused with ErrAlbumHasTracks unless the service cascades track deletes. A non-zero
+// version is the version the caller last read; the delete fails with ErrVersionMismatch
+// if the album has changed since.
+func (s *Service) DeleteAlbum(ctx context.Context, id string, version int64) error {
+	s.auditBefore(ctx, id)
+	if err := s.albumRepo.DeleteAlbum(ctx, id, version, s.cascadeTrackDeletes); err != nil {
+		if errors.IsAlbumNotFound(err) {
+			return errors.ErrAlbumNotFound
+		}
+		if errors.IsAlbumHasTracks(err) {
+			return errors.ErrAlbumHasTracks
+		}
+		if errors.IsVersionMismatch(err) {
+			return errors.ErrVersionMismatch
+		}
+		return fmt.Errorf("service error deleting album: %w", err)
+	}
+
+	s.evictAlbum(id)
+	s.auditAfter(ctx, id)
+	s.publishAlbumEvent(ctx, dto.AlbumDeleted, id, nil)
+	return nil
+}
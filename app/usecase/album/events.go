@@ -0,0 +1,305 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/redis"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of the album change stream unless configured otherwise
+const (
+	// DefaultEventLogSize is about how many events are kept for clients resuming a stream
+	DefaultEventLogSize = 1000
+	// DefaultEventBuffer is how many events may wait for a subscriber before it is dropped as too slow
+	DefaultEventBuffer = 64
+)
+
+// Redis keys of the album change stream
+const (
+	albumEventLogKey  = "album_events"
+	albumEventChannel = "album_events"
+)
+
+// albumEventReadSize is how many logged events are read per request when replaying the log
+const albumEventReadSize = 100
+
+// eventIDPattern matches the IDs the event log assigns, which are Redis stream IDs
+var eventIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// EventStore keeps the log of album change events and carries them between API instances.
+// It is satisfied by *redis.RedisCache.
+type EventStore interface {
+	AppendToStream(stream string, maxLen int64, data []byte) (string, error)
+	ReadStreamAfter(stream string, after string, count int64) ([]redis.StreamEntry, error)
+	PublishToChannel(channel string, data []byte) error
+	SubscribeToChannel(ctx context.Context, channel string, handle func(data []byte)) error
+}
+
+// EventDispatcher passes album changes on to systems outside the API, such as webhooks
+type EventDispatcher interface {
+	DispatchAlbumEvent(ctx context.Context, event dto.AlbumEvent) error
+}
+
+// WithEventDispatcher makes the service hand every album change to the dispatcher, once, from the
+// API instance where it happened
+func WithEventDispatcher(dispatcher EventDispatcher) Option {
+	return func(s *Service) {
+		s.eventDispatcher = dispatcher
+	}
+}
+
+// WithEventStore makes the service publish album changes to the store, and stream them to subscribers
+func WithEventStore(store EventStore) Option {
+	return func(s *Service) {
+		s.eventStore = store
+	}
+}
+
+// WithEventLogSize sets about how many events are kept for clients resuming a stream
+func WithEventLogSize(size int64) Option {
+	return func(s *Service) {
+		if size > 0 {
+			s.eventLogSize = size
+		}
+	}
+}
+
+// WithEventBuffer sets how many events may wait for a subscriber before it is dropped as too slow
+func WithEventBuffer(size int) Option {
+	return func(s *Service) {
+		if size > 0 {
+			s.eventBuffer = size
+		}
+	}
+}
+
+// SubscribeAlbumEvents streams album changes until ctx is done. A subscriber passing the ID of the
+// last event it received first gets the logged events that followed it, preceded by an EventsLost
+// event when that one is no longer in the log. The channel is closed when ctx is done, or earlier
+// when the subscriber falls more than the event buffer behind; it may then resume from its last event.
+func (s *Service) SubscribeAlbumEvents(ctx context.Context, lastEventID string) (<-chan dto.AlbumEvent, error) {
+	if s.eventStore == nil {
+		return nil, errors.ErrEventsDisabled
+	}
+	if lastEventID != "" && !eventIDPattern.MatchString(lastEventID) {
+		return nil, errors.NewValidationError(errors.FieldError{Field: "last_event_id", Code: "format", Message: "is not an event ID"})
+	}
+
+	// Subscribe before reading the log so that no event falls in between; events both read
+	// from the log and received are sent once
+	subscriber := s.events.subscribe(s.eventBuffer)
+	replay, err := s.albumEventsAfter(lastEventID)
+	if err != nil {
+		s.events.unsubscribe(subscriber)
+		return nil, fmt.Errorf("service error reading album events: %w", err)
+	}
+
+	events := make(chan dto.AlbumEvent)
+	go func() {
+		defer close(events)
+		defer s.events.unsubscribe(subscriber)
+
+		last := lastEventID
+		send := func(event dto.AlbumEvent) bool {
+			if event.ID != "" && last != "" && !eventIDAfter(event.ID, last) {
+				return true
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return false
+			}
+			if event.ID != "" {
+				last = event.ID
+			}
+			return true
+		}
+
+		for _, event := range replay {
+			if !send(event) {
+				return
+			}
+		}
+		for {
+			select {
+			case event, ok := <-subscriber:
+				if !ok || !send(event) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// RunAlbumEvents relays the album events published by every API instance to the subscribers of
+// this one until ctx is done
+func (s *Service) RunAlbumEvents(ctx context.Context) error {
+	if s.eventStore == nil {
+		return errors.ErrEventsDisabled
+	}
+	return s.eventStore.SubscribeToChannel(ctx, albumEventChannel, func(data []byte) {
+		var event dto.AlbumEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			log.Printf("Failed to decode album event: %v", err)
+			return
+		}
+		s.events.broadcast(event)
+	})
+}
+
+// publishAlbumEvent logs a change to an album, announces it to every API instance and hands it to
+// the event dispatcher. Failures are logged without failing the request, since the change itself is
+// stored already.
+func (s *Service) publishAlbumEvent(ctx context.Context, eventType string, albumID string, album *dto.Album) {
+	event := dto.BuildAlbumEventDTO(eventType, albumID, album)
+	if s.eventStore != nil {
+		event = s.logAlbumEvent(event)
+	}
+
+	if s.eventDispatcher != nil {
+		// The change is made, so its dispatch outlives a request cancelled meanwhile
+		if err := s.eventDispatcher.DispatchAlbumEvent(context.WithoutCancel(ctx), event); err != nil {
+			log.Printf("Failed to dispatch %s event of album %s: %v", eventType, albumID, err)
+		}
+	}
+}
+
+// logAlbumEvent appends an event to the event log and publishes it, returning it with the ID the
+// log gave it
+func (s *Service) logAlbumEvent(event dto.AlbumEvent) dto.AlbumEvent {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode %s event of album %s: %v", event.Type, event.AlbumID, err)
+		return event
+	}
+	id, err := s.eventStore.AppendToStream(albumEventLogKey, s.eventLogSize, data)
+	if err != nil {
+		log.Printf("Failed to log %s event of album %s: %v", event.Type, event.AlbumID, err)
+		return event
+	}
+	event.ID = id
+
+	// The published event carries the ID the log gave it
+	data, err = json.Marshal(event)
+	if err == nil {
+		err = s.eventStore.PublishToChannel(albumEventChannel, data)
+	}
+	if err != nil {
+		log.Printf("Failed to publish %s event of album %s: %v", event.Type, event.AlbumID, err)
+	}
+	return event
+}
+
+// albumEventsAfter reads the events logged after the one with the ID lastEventID, if any
+func (s *Service) albumEventsAfter(lastEventID string) ([]dto.AlbumEvent, error) {
+	if lastEventID == "" {
+		return nil, nil
+	}
+
+	var events []dto.AlbumEvent
+	after := lastEventID
+	oldest, err := s.eventStore.ReadStreamAfter(albumEventLogKey, "", 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(oldest) > 0 && eventIDAfter(oldest[0].ID, lastEventID) {
+		// The event was trimmed from the log, and maybe some of those that followed it
+		events = append(events, dto.AlbumEvent{Type: dto.EventsLost, OccurredAt: time.Now().UTC()})
+		after = ""
+	}
+
+	for {
+		entries, err := s.eventStore.ReadStreamAfter(albumEventLogKey, after, albumEventReadSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			var event dto.AlbumEvent
+			if err := json.Unmarshal(entry.Data, &event); err != nil {
+				log.Printf("Failed to decode album event %s: %v", entry.ID, err)
+				continue
+			}
+			event.ID = entry.ID
+			events = append(events, event)
+		}
+		if len(entries) < albumEventReadSize {
+			return events, nil
+		}
+		after = entries[len(entries)-1].ID
+	}
+}
+
+// eventIDAfter tells whether the event with the ID a was logged after the one with the ID b
+func eventIDAfter(a, b string) bool {
+	aTime, aSeq := splitEventID(a)
+	bTime, bSeq := splitEventID(b)
+	if aTime != bTime {
+		return aTime > bTime
+	}
+	return aSeq > bSeq
+}
+
+// splitEventID returns the two numbers of an event ID: the time it was logged in milliseconds and
+// its position among the events logged in that millisecond
+func splitEventID(id string) (uint64, uint64) {
+	timePart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(timePart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
+
+// eventHub hands the album events received by this instance to its subscribers
+type eventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan dto.AlbumEvent]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[chan dto.AlbumEvent]struct{})}
+}
+
+// subscribe registers a subscriber that may fall up to buffer events behind
+func (h *eventHub) subscribe(buffer int) chan dto.AlbumEvent {
+	subscriber := make(chan dto.AlbumEvent, buffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+// unsubscribe removes a subscriber unless it was dropped already
+func (h *eventHub) unsubscribe(subscriber chan dto.AlbumEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[subscriber]; ok {
+		delete(h.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+// broadcast sends an event to every subscriber. A subscriber whose buffer is full is dropped
+// rather than allowed to hold up the others or grow without bound.
+func (h *eventHub) broadcast(event dto.AlbumEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscriber := range h.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			delete(h.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
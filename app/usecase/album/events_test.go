@@ -0,0 +1,299 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/redis"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+)
+
+// fakeEventStore keeps the event log in memory and delivers published messages to subscribers
+// synchronously. Entry IDs are "<n>-0", numbered from first.
+type fakeEventStore struct {
+	mu         sync.Mutex
+	first      int
+	entries    []redis.StreamEntry
+	handlers   []func(data []byte)
+	subscribed chan struct{}
+}
+
+func newFakeEventStore() *fakeEventStore {
+	return &fakeEventStore{first: 1, subscribed: make(chan struct{}, 1)}
+}
+
+func (f *fakeEventStore) AppendToStream(_ string, maxLen int64, data []byte) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := strconv.Itoa(f.first+len(f.entries)) + "-0"
+	f.entries = append(f.entries, redis.StreamEntry{ID: id, Data: data})
+	if int64(len(f.entries)) > maxLen {
+		f.entries = f.entries[1:]
+		f.first++
+	}
+	return id, nil
+}
+
+func (f *fakeEventStore) ReadStreamAfter(_ string, after string, count int64) ([]redis.StreamEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	afterN, _ := strconv.Atoi(strings.TrimSuffix(after, "-0"))
+	var result []redis.StreamEntry
+	for i, entry := range f.entries {
+		if f.first+i > afterN && int64(len(result)) < count {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeEventStore) PublishToChannel(_ string, data []byte) error {
+	f.mu.Lock()
+	handlers := append([]func([]byte){}, f.handlers...)
+	f.mu.Unlock()
+	for _, handle := range handlers {
+		handle(data)
+	}
+	return nil
+}
+
+func (f *fakeEventStore) SubscribeToChannel(ctx context.Context, _ string, handle func(data []byte)) error {
+	f.mu.Lock()
+	f.handlers = append(f.handlers, handle)
+	f.mu.Unlock()
+	f.subscribed <- struct{}{}
+	<-ctx.Done()
+	return nil
+}
+
+// logged returns the events in the log, with their IDs
+func (f *fakeEventStore) logged(t *testing.T) []dto.AlbumEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events := make([]dto.AlbumEvent, len(f.entries))
+	for i, entry := range f.entries {
+		require.NoError(t, json.Unmarshal(entry.Data, &events[i]))
+		events[i].ID = entry.ID
+	}
+	return events
+}
+
+// startEventService returns a service publishing to store and relaying its events until the test ends
+func startEventService(t *testing.T, repo *mocks.RepositoryInterface, store *fakeEventStore, opts ...albumservice.Option) *albumservice.Service {
+	opts = append([]albumservice.Option{albumservice.WithEventStore(store)}, opts...)
+	service := albumservice.NewService(repo, nil, nil, 0*time.Second, nil, opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = service.RunAlbumEvents(ctx) }()
+	<-store.subscribed
+	return service
+}
+
+// receive reads n events, failing the test if they take too long
+func receive(t *testing.T, events <-chan dto.AlbumEvent, n int) []dto.AlbumEvent {
+	var received []dto.AlbumEvent
+	for len(received) < n {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "stream ended after %d events", len(received))
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d events, want %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func eventTypes(events []dto.AlbumEvent) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.Type + " " + event.AlbumID
+	}
+	return types
+}
+
+func TestService_PublishesAlbumEvents(t *testing.T) {
+	repo := mocks.NewRepositoryInterface(t)
+	repo.On("CreateAlbum", mock.Anything, mock.Anything).Return("1", nil)
+	repo.On("UpdateAlbum", mock.Anything, mock.Anything).Return(int64(2), nil)
+	repo.On("DeleteAlbum", mock.Anything, "1", int64(2), false).Return(nil)
+	repo.On("RestoreAlbum", mock.Anything, "1").Return(int64(3), nil)
+	// Read back after the update, then after the restore
+	repo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: "1", Title: "Giant Steps", Version: 2}, nil).Once()
+	repo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: "1", Title: "Blue Train", Version: 3}, nil)
+
+	store := newFakeEventStore()
+	service := startEventService(t, repo, store)
+	events, err := service.SubscribeAlbumEvents(context.Background(), "")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = service.CreateAlbum(ctx, entity.Album{ID: "1", Title: "Blue Train"})
+	require.NoError(t, err)
+	_, err = service.UpdateAlbum(ctx, entity.Album{ID: "1", Title: "Giant Steps", Version: 1})
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteAlbum(ctx, "1", 2))
+	_, err = service.RestoreAlbum(ctx, "1")
+	require.NoError(t, err)
+
+	expected := []string{"album.created 1", "album.updated 1", "album.deleted 1", "album.restored 1"}
+	received := receive(t, events, 4)
+	assert.Equal(t, expected, eventTypes(received))
+	assert.Equal(t, expected, eventTypes(store.logged(t)))
+
+	assert.Equal(t, "1-0", received[0].ID)
+	assert.Equal(t, "Blue Train", received[0].Album.Title)
+	assert.Equal(t, int64(2), received[1].Album.Version)
+	assert.Equal(t, "Giant Steps", received[1].Album.Title)
+	assert.Nil(t, received[2].Album)
+	assert.Equal(t, "4-0", received[3].ID)
+}
+
+func TestService_SubscribeAlbumEvents(t *testing.T) {
+	// publish appends n events to the log and publishes them to the subscribers
+	publish := func(t *testing.T, store *fakeEventStore, from, n int) {
+		for i := from; i < from+n; i++ {
+			data, _ := json.Marshal(dto.BuildAlbumEventDTO(dto.AlbumUpdated, strconv.Itoa(i), nil))
+			id, _ := store.AppendToStream("", 1000, data)
+			var event dto.AlbumEvent
+			_ = json.Unmarshal(data, &event)
+			event.ID = id
+			data, _ = json.Marshal(event)
+			_ = store.PublishToChannel("", data)
+		}
+	}
+
+	t.Run("Resumes after the last event received", func(t *testing.T) {
+		store := newFakeEventStore()
+		service := startEventService(t, mocks.NewRepositoryInterface(t), store)
+		publish(t, store, 1, 3)
+
+		events, err := service.SubscribeAlbumEvents(context.Background(), "1-0")
+		require.NoError(t, err)
+		publish(t, store, 4, 1)
+
+		assert.Equal(t, []string{"album.updated 2", "album.updated 3", "album.updated 4"}, eventTypes(receive(t, events, 3)))
+	})
+
+	t.Run("Tells when missed events are gone from the log", func(t *testing.T) {
+		store := newFakeEventStore()
+		service := startEventService(t, mocks.NewRepositoryInterface(t), store, albumservice.WithEventLogSize(2))
+		for i := 1; i <= 4; i++ {
+			_, err := store.AppendToStream("", 2, []byte(`{"type":"album.updated","album_id":"`+strconv.Itoa(i)+`"}`))
+			require.NoError(t, err)
+		}
+
+		events, err := service.SubscribeAlbumEvents(context.Background(), "1-0")
+		require.NoError(t, err)
+
+		received := receive(t, events, 3)
+		assert.Equal(t, []string{"events.lost ", "album.updated 3", "album.updated 4"}, eventTypes(received))
+		assert.Empty(t, received[0].ID)
+	})
+
+	t.Run("Drops subscribers falling behind", func(t *testing.T) {
+		store := newFakeEventStore()
+		service := startEventService(t, mocks.NewRepositoryInterface(t), store, albumservice.WithEventBuffer(2))
+		events, err := service.SubscribeAlbumEvents(context.Background(), "")
+		require.NoError(t, err)
+
+		// One event waits to be sent while two fill the buffer; the next one overflows it
+		publish(t, store, 1, 5)
+
+		var received []dto.AlbumEvent
+		for event := range events {
+			received = append(received, event)
+		}
+		assert.NotEmpty(t, received)
+		assert.Less(t, len(received), 5)
+
+		// The dropped subscriber resumes from the log
+		resumed, err := service.SubscribeAlbumEvents(context.Background(), received[len(received)-1].ID)
+		require.NoError(t, err)
+		assert.Len(t, receive(t, resumed, 5-len(received)), 5-len(received))
+	})
+
+	t.Run("Ends with the context", func(t *testing.T) {
+		store := newFakeEventStore()
+		service := startEventService(t, mocks.NewRepositoryInterface(t), store)
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := service.SubscribeAlbumEvents(ctx, "")
+		require.NoError(t, err)
+
+		cancel()
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("stream still open")
+		}
+	})
+
+	t.Run("Invalid last event ID", func(t *testing.T) {
+		service := startEventService(t, mocks.NewRepositoryInterface(t), newFakeEventStore())
+		_, err := service.SubscribeAlbumEvents(context.Background(), "latest")
+		assert.True(t, customerr.IsInvalidInput(err))
+	})
+
+	t.Run("Events disabled", func(t *testing.T) {
+		service := albumservice.NewService(mocks.NewRepositoryInterface(t), nil, nil, 0*time.Second, nil)
+		_, err := service.SubscribeAlbumEvents(context.Background(), "")
+		assert.ErrorIs(t, err, customerr.ErrEventsDisabled)
+	})
+}
+
+// fakeDispatcher records the album events handed to it
+type fakeDispatcher struct {
+	mu     sync.Mutex
+	events []dto.AlbumEvent
+}
+
+func (f *fakeDispatcher) DispatchAlbumEvent(_ context.Context, event dto.AlbumEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestService_DispatchesAlbumEvents(t *testing.T) {
+	t.Run("With the ID given by the event log", func(t *testing.T) {
+		repo := mocks.NewRepositoryInterface(t)
+		repo.On("DeleteAlbum", mock.Anything, "1", int64(0), false).Return(nil)
+		dispatcher := &fakeDispatcher{}
+
+		service := startEventService(t, repo, newFakeEventStore(), albumservice.WithEventDispatcher(dispatcher))
+		require.NoError(t, service.DeleteAlbum(context.Background(), "1", 0))
+
+		require.Len(t, dispatcher.events, 1)
+		assert.Equal(t, "album.deleted 1", eventTypes(dispatcher.events)[0])
+		assert.Equal(t, "1-0", dispatcher.events[0].ID)
+	})
+
+	t.Run("Without an event log", func(t *testing.T) {
+		repo := mocks.NewRepositoryInterface(t)
+		repo.On("CreateAlbum", mock.Anything, mock.Anything).Return("1", nil)
+		dispatcher := &fakeDispatcher{}
+
+		service := albumservice.NewService(repo, nil, nil, 0*time.Second, nil, albumservice.WithEventDispatcher(dispatcher))
+		_, err := service.CreateAlbum(context.Background(), entity.Album{ID: "1", Title: "Blue Train"})
+		require.NoError(t, err)
+
+		require.Len(t, dispatcher.events, 1)
+		assert.Equal(t, "album.created 1", eventTypes(dispatcher.events)[0])
+		assert.Empty(t, dispatcher.events[0].ID)
+	})
+}
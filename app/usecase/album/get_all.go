@@ -2,20 +2,46 @@ package services
 
 import (
 	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
 	"context"
+	"fmt"
 )
 
-func (s *Service) GetAllAlbums(ctx context.Context) ([]dto.Album, error) {
-	albums, err := s.albumRepo.GetAlbums(ctx)
-	if err != nil {
-		return []dto.Album{}, err
+const (
+	// DefaultAlbumPageLimit is the page size used when the caller does not ask for one
+	DefaultAlbumPageLimit = 20
+	// MaxAlbumPageLimit is the largest page size a caller may ask for
+	MaxAlbumPageLimit = 100
+)
+
+// GetAllAlbums returns one page of albums according to the listing options
+func (s *Service) GetAllAlbums(ctx context.Context, opts entity.AlbumListOptions) (dto.AlbumList, error) {
+	if opts.Limit == 0 {
+		opts.Limit = DefaultAlbumPageLimit
+	}
+	if opts.SortBy == "" {
+		opts.SortBy = entity.AlbumSortByID
+	}
+	invalid := errors.NewValidationError()
+	if opts.Limit < 0 || opts.Limit > MaxAlbumPageLimit {
+		invalid.Add("limit", "range", fmt.Sprintf("must be between 1 and %d", MaxAlbumPageLimit))
+	}
+	if !opts.SortBy.IsValid() {
+		invalid.Add("sort", "enum", "must be one of: id, title, created_at, optionally prefixed with '-'")
+	}
+	// A cursor is only meaningful for the ordering it was issued for
+	if opts.Cursor != nil && (opts.Cursor.SortBy != opts.SortBy || opts.Cursor.Descending != opts.Descending) {
+		invalid.Add("cursor", "mismatch", "was issued for a different sort order")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return dto.AlbumList{}, err
 	}
 
-	albumDTOs := make([]dto.Album, len(albums))
-	for i, albumEntity := range albums {
-		dto := dto.BuildAlbumDTO(albumEntity)
-		albumDTOs[i] = dto
+	page, err := s.albumRepo.GetAlbums(ctx, opts)
+	if err != nil {
+		return dto.AlbumList{}, err
 	}
 
-	return albumDTOs, nil
+	return dto.BuildAlbumListDTO(page), nil
 }
@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_GetAllAlbums(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          entity.AlbumListOptions
+		expectedOpts  *entity.AlbumListOptions
+		page          entity.AlbumPage
+		expected      dto.AlbumList
+		expectedError error
+	}{
+		{
+			name:         "Defaults applied",
+			opts:         entity.AlbumListOptions{},
+			expectedOpts: &entity.AlbumListOptions{Limit: albumservice.DefaultAlbumPageLimit, SortBy: entity.AlbumSortByID},
+			page: entity.AlbumPage{
+				Albums: []entity.Album{{ID: entity.AlbumID("1"), Title: "Album1"}},
+				Next:   &entity.AlbumCursor{SortBy: entity.AlbumSortByID, SortValue: "1", ID: "1"},
+			},
+			expected: dto.AlbumList{
+				Albums:     []dto.Album{{ID: "1", Title: "Album1"}},
+				NextCursor: dto.EncodeAlbumCursor(&entity.AlbumCursor{SortBy: entity.AlbumSortByID, SortValue: "1", ID: "1"}),
+			},
+		},
+		{
+			name:          "Limit above maximum",
+			opts:          entity.AlbumListOptions{Limit: albumservice.MaxAlbumPageLimit + 1},
+			expectedError: customerr.ErrInvalidInput,
+		},
+		{
+			name:          "Unknown sort field",
+			opts:          entity.AlbumListOptions{SortBy: "rating"},
+			expectedError: customerr.ErrInvalidInput,
+		},
+		{
+			name: "Cursor issued for another ordering",
+			opts: entity.AlbumListOptions{
+				SortBy: entity.AlbumSortByTitle,
+				Cursor: &entity.AlbumCursor{SortBy: entity.AlbumSortByID, SortValue: "1", ID: "1"},
+			},
+			expectedError: customerr.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepositoryInterface(t)
+			if tt.expectedOpts != nil {
+				mockRepo.On("GetAlbums", mock.Anything, *tt.expectedOpts).Return(tt.page, nil).Once()
+			}
+
+			service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+			result, err := service.GetAllAlbums(context.Background(), tt.opts)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
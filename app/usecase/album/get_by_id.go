@@ -9,16 +9,35 @@ import (
 	"fmt"
 )
 
-// GetAlbumByID retrieves a album by ID using the repository
-func (s *Service) GetAlbumByID(ctx context.Context, id string) (dto.Album, error) {
+// GetAlbumByID retrieves a album by ID using the repository, embedding the related resources selected in opts
+func (s *Service) GetAlbumByID(ctx context.Context, id string, opts entity.AlbumGetOptions) (dto.Album, error) {
+	album, err := s.getAlbum(ctx, id)
+	if err != nil {
+		return dto.Album{}, err
+	}
+
+	albumDTO := dto.BuildAlbumDTO(album)
+	if opts.IncludeTracks {
+		// Tracks are not cached with the album, so track changes never leave the album cache stale
+		tracks, err := s.trackRepo.GetTracksByAlbumID(ctx, id)
+		if err != nil {
+			return dto.Album{}, fmt.Errorf("service error getting tracks: %w", err)
+		}
+		albumDTO.Tracks = dto.BuildTrackDTOs(tracks)
+	}
+
+	return albumDTO, nil
+}
 
+// getAlbum loads an album from the cache, falling back to the repository
+func (s *Service) getAlbum(ctx context.Context, id string) (entity.Album, error) {
 	var album entity.Album
 	// Try to get from cache
 	if s.cache != nil {
 		cachedData, err := s.cache.GetFromCache(id)
 		if err == nil {
 			if jsonErr := json.Unmarshal(cachedData, &album); jsonErr == nil {
-				return dto.BuildAlbumDTO(album), nil
+				return album, nil
 			}
 		}
 	}
@@ -26,20 +45,74 @@ func (s *Service) GetAlbumByID(ctx context.Context, id string) (dto.Album, error
 	album, err := s.albumRepo.GetAlbumByID(ctx, id)
 	if err != nil {
 		if errors.IsAlbumNotFound(err) {
-			return dto.Album{}, errors.ErrAlbumNotFound
+			return entity.Album{}, errors.ErrAlbumNotFound
 		}
 
-		return dto.Album{}, fmt.Errorf("service error getting album: %v", err)
+		return entity.Album{}, fmt.Errorf("service error getting album: %w", err)
 	}
 
 	// Store in cache for next time
-	if s.cache != nil {
-		if err := s.cache.SetToCache(id, album, s.cacheT); err != nil {
-			// Log cache error but don't fail the request if cache write fails
-			fmt.Printf("Failed to cache album %s: %v\n", id, err)
+	s.cacheAlbum(album)
+
+	return album, nil
+}
+
+// cacheAlbum stores the album, and apart from it its version, in the cache
+func (s *Service) cacheAlbum(album entity.Album) {
+	if s.cache == nil {
+		return
+	}
+	id := album.ID.String()
+	if err := s.cache.SetToCache(id, album, s.cacheT); err != nil {
+		// Log cache error but don't fail the request if cache write fails
+		fmt.Printf("Failed to cache album %s: %v\n", id, err)
+		return
+	}
+	if err := s.cache.SetToCache(albumVersionKey(id), dto.BuildAlbumVersionDTO(album), s.cacheT); err != nil {
+		fmt.Printf("Failed to cache version of album %s: %v\n", id, err)
+	}
+}
+
+// GetAlbumsByIDs retrieves several albums at once: the cache is read with a single lookup and the
+// albums missing from it are loaded from the repository with a single query.
+// IDs that do not match an album are left out of the returned map.
+func (s *Service) GetAlbumsByIDs(ctx context.Context, ids []string) (map[string]dto.Album, error) {
+	albums := make(map[string]dto.Album, len(ids))
+	missing := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			missing = append(missing, id)
+		}
+	}
+
+	if s.cache != nil && len(missing) > 0 {
+		cachedData, err := s.cache.GetManyFromCache(missing...)
+		if err == nil {
+			stillMissing := missing[:0]
+			for i, id := range missing {
+				var album entity.Album
+				if cachedData[i] != nil && json.Unmarshal(cachedData[i], &album) == nil {
+					albums[id] = dto.BuildAlbumDTO(album)
+					continue
+				}
+				stillMissing = append(stillMissing, id)
+			}
+			missing = stillMissing
 		}
 	}
+	if len(missing) == 0 {
+		return albums, nil
+	}
 
-	dto := dto.BuildAlbumDTO(album)
-	return dto, nil
+	loaded, err := s.albumRepo.GetAlbumsByIDs(ctx, missing)
+	if err != nil {
+		return nil, fmt.Errorf("service error getting albums: %w", err)
+	}
+	for _, album := range loaded {
+		albums[album.ID.String()] = dto.BuildAlbumDTO(album)
+		s.cacheAlbum(album)
+	}
+	return albums, nil
 }
@@ -0,0 +1,56 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_GetAlbumsByIDs(t *testing.T) {
+	t.Run("Duplicates are loaded once in a single query", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumsByIDs", mock.Anything, []string{"1", "2", "3"}).Return([]entity.Album{
+			{ID: entity.AlbumID("1"), Title: "Album1"},
+			{ID: entity.AlbumID("3"), Title: "Album3"},
+		}, nil).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		albums, err := service.GetAlbumsByIDs(context.Background(), []string{"1", "2", "1", "3"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]dto.Album{
+			"1": {ID: "1", Title: "Album1"},
+			"3": {ID: "3", Title: "Album3"},
+		}, albums)
+	})
+
+	t.Run("No IDs", func(t *testing.T) {
+		service := albumservice.NewService(mocks.NewRepositoryInterface(t), nil, nil, 0*time.Second, nil)
+
+		albums, err := service.GetAlbumsByIDs(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, albums)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumsByIDs", mock.Anything, []string{"1"}).Return(nil, errors.New("db error"))
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		_, err := service.GetAlbumsByIDs(context.Background(), []string{"1"})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+)
+
+// DefaultImportBatchSize is the number of albums written per multi-row insert unless configured otherwise
+const DefaultImportBatchSize = 500
+
+// ImportAlbums validates the rows read from an import file and stores them in batches.
+// Rows whose ID is already taken, in the store or earlier in the file, are skipped; invalid
+// rows fail without stopping the import. A read error ends the import, but batches written
+// before it are kept.
+func (s *Service) ImportAlbums(ctx context.Context, rows entity.AlbumImportReader, opts entity.AlbumImportOptions) (dto.AlbumImportReport, error) {
+	report := dto.AlbumImportReport{DryRun: opts.DryRun, Rows: []dto.AlbumImportRowInfo{}}
+	seen := make(map[entity.AlbumID]bool)
+	batch := make([]entity.AlbumImportRow, 0, s.importBatchSize)
+
+	for {
+		row, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return dto.AlbumImportReport{}, fmt.Errorf("service error reading import: %w", err)
+		}
+
+		if row.Err == nil {
+			row.Err = validateAlbum(row.Album)
+		}
+		if row.Err != nil {
+			report.Add(failedImportRow(row, row.Err))
+			continue
+		}
+
+		if row.Album.ID == "" {
+			id, err := s.newID()
+			if err != nil {
+				return dto.AlbumImportReport{}, fmt.Errorf("service error creating album: %w", err)
+			}
+			row.Album.ID = entity.AlbumID(id)
+		}
+		if seen[row.Album.ID] {
+			report.Add(dto.AlbumImportRowInfo{
+				Line:   row.Line,
+				ID:     row.Album.ID.String(),
+				Status: dto.AlbumImportSkipped,
+				Reason: "duplicate id in import",
+			})
+			continue
+		}
+		seen[row.Album.ID] = true
+
+		batch = append(batch, row)
+		if len(batch) == s.importBatchSize {
+			if err := s.importBatch(ctx, batch, opts, &report); err != nil {
+				return dto.AlbumImportReport{}, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := s.importBatch(ctx, batch, opts, &report); err != nil {
+		return dto.AlbumImportReport{}, err
+	}
+
+	// Failed rows are reported as they are read and stored rows once their batch is written
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Line < report.Rows[j].Line
+	})
+	return report, nil
+}
+
+// importBatch writes one batch of valid rows and records their outcome in the report.
+// A failing batch marks all of its rows as failed; only a cancelled context aborts the import.
+func (s *Service) importBatch(ctx context.Context, batch []entity.AlbumImportRow, opts entity.AlbumImportOptions, report *dto.AlbumImportReport) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	albums := make([]entity.Album, len(batch))
+	for i, row := range batch {
+		albums[i] = row.Album
+	}
+
+	skippedIDs, err := s.albumRepo.CreateAlbums(ctx, albums, opts.DryRun)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("service error importing albums: %w", ctx.Err())
+		}
+		if !errors.IsAlbumExists(err) {
+			log.Printf("Failed to import batch of %d albums: %v", len(batch), err)
+			err = errors.ErrInternalServer
+		}
+		for _, row := range batch {
+			report.Add(failedImportRow(row, err))
+		}
+		return nil
+	}
+
+	skipped := make(map[string]bool, len(skippedIDs))
+	for _, id := range skippedIDs {
+		skipped[id] = true
+	}
+	for _, row := range batch {
+		info := dto.AlbumImportRowInfo{Line: row.Line, ID: row.Album.ID.String(), Status: dto.AlbumImportCreated}
+		if skipped[info.ID] {
+			info.Status = dto.AlbumImportSkipped
+			info.Reason = errors.ErrAlbumExists.Error()
+		} else if !opts.DryRun {
+			created := dto.BuildAlbumDTO(row.Album)
+			s.publishAlbumEvent(ctx, dto.AlbumCreated, info.ID, &created)
+		}
+		report.Add(info)
+	}
+	return nil
+}
+
+// failedImportRow describes a row that could not be imported
+func failedImportRow(row entity.AlbumImportRow, err error) dto.AlbumImportRowInfo {
+	info := dto.AlbumImportRowInfo{
+		Line:    row.Line,
+		ID:      row.Album.ID.String(),
+		Status:  dto.AlbumImportFailed,
+		Reason:  err.Error(),
+		Details: errors.ValidationDetails(err),
+	}
+	if info.Details != nil {
+		info.Reason = errors.ErrInvalidInput.Error()
+	}
+	return info
+}
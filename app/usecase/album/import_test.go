@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// sliceImportReader serves import rows from a slice
+type sliceImportReader struct {
+	rows []entity.AlbumImportRow
+	err  error
+}
+
+func (r *sliceImportReader) Next() (entity.AlbumImportRow, error) {
+	if len(r.rows) == 0 {
+		if r.err != nil {
+			return entity.AlbumImportRow{}, r.err
+		}
+		return entity.AlbumImportRow{}, io.EOF
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
+}
+
+func TestService_ImportAlbums(t *testing.T) {
+	invalidRow := customerr.NewValidationError(customerr.FieldError{Field: "title", Code: "required", Message: "is required"})
+
+	t.Run("Reports created, skipped and failed rows", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("CreateAlbums", mock.Anything, []entity.Album{
+			{ID: "a1", Title: "One"},
+			{ID: "generated-id", Title: "Two"},
+		}, false).Return([]string{"a1"}, nil).Once()
+		mockRepo.On("CreateAlbums", mock.Anything, []entity.Album{
+			{ID: "a5", Title: "Five"},
+		}, false).Return(nil, nil).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil,
+			albumservice.WithIDGenerator(func() (string, error) { return "generated-id", nil }),
+			albumservice.WithImportBatchSize(2),
+		)
+
+		report, err := service.ImportAlbums(context.Background(), &sliceImportReader{rows: []entity.AlbumImportRow{
+			{Line: 1, Album: entity.Album{ID: "a1", Title: "One"}},
+			{Line: 2, Album: entity.Album{Title: "Two"}},
+			{Line: 3, Err: invalidRow},
+			{Line: 4, Album: entity.Album{ID: "a1", Title: "One again"}},
+			{Line: 5, Album: entity.Album{ID: "a5", Title: "Five"}},
+			{Line: 6, Album: entity.Album{ID: "a6", Title: " "}},
+		}}, entity.AlbumImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.AlbumImportReport{
+			Created: 2,
+			Skipped: 2,
+			Failed:  2,
+			Rows: []dto.AlbumImportRowInfo{
+				{Line: 1, ID: "a1", Status: dto.AlbumImportSkipped, Reason: "album already exists"},
+				{Line: 2, ID: "generated-id", Status: dto.AlbumImportCreated},
+				{Line: 3, Status: dto.AlbumImportFailed, Reason: "invalid input", Details: invalidRow.Fields},
+				{Line: 4, ID: "a1", Status: dto.AlbumImportSkipped, Reason: "duplicate id in import"},
+				{Line: 5, ID: "a5", Status: dto.AlbumImportCreated},
+				{Line: 6, ID: "a6", Status: dto.AlbumImportFailed, Reason: "invalid input", Details: []customerr.FieldError{
+					{Field: "title", Code: "required", Message: "must not be blank"},
+				}},
+			},
+		}, report)
+	})
+
+	t.Run("Dry run is passed to the repository", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("CreateAlbums", mock.Anything, []entity.Album{{ID: "a1", Title: "One"}}, true).Return(nil, nil).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		report, err := service.ImportAlbums(context.Background(), &sliceImportReader{rows: []entity.AlbumImportRow{
+			{Line: 1, Album: entity.Album{ID: "a1", Title: "One"}},
+		}}, entity.AlbumImportOptions{DryRun: true})
+
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 1, report.Created)
+	})
+
+	t.Run("Failed batch marks its rows as failed", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("CreateAlbums", mock.Anything, mock.Anything, false).Return(nil, errors.New("database error")).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		report, err := service.ImportAlbums(context.Background(), &sliceImportReader{rows: []entity.AlbumImportRow{
+			{Line: 1, Album: entity.Album{ID: "a1", Title: "One"}},
+			{Line: 2, Album: entity.Album{ID: "a2", Title: "Two"}},
+		}}, entity.AlbumImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Failed)
+		assert.Equal(t, "internal server error", report.Rows[0].Reason)
+	})
+
+	t.Run("Read error stops the import", func(t *testing.T) {
+		service := albumservice.NewService(mocks.NewRepositoryInterface(t), nil, nil, 0*time.Second, nil)
+
+		_, err := service.ImportAlbums(context.Background(), &sliceImportReader{err: errors.New("connection reset")}, entity.AlbumImportOptions{})
+
+		assert.EqualError(t, err, "service error reading import: connection reset")
+	})
+}
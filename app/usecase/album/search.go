@@ -0,0 +1,49 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxAlbumSearchQueryLength bounds the search query, in characters
+	MaxAlbumSearchQueryLength = 255
+	// MaxAlbumSearchOffset bounds how deep into the results a search may page
+	MaxAlbumSearchOffset = 10000
+)
+
+// SearchAlbums returns one page of albums matching the query, best matches first
+func (s *Service) SearchAlbums(ctx context.Context, opts entity.AlbumSearchOptions) (dto.AlbumSearchResults, error) {
+	opts.Query = strings.TrimSpace(opts.Query)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultAlbumPageLimit
+	}
+
+	invalid := errors.NewValidationError()
+	if opts.Query == "" {
+		invalid.Add("q", "required", "is required")
+	} else if utf8.RuneCountInString(opts.Query) > MaxAlbumSearchQueryLength {
+		invalid.Add("q", "length", fmt.Sprintf("must be at most %d characters long", MaxAlbumSearchQueryLength))
+	}
+	if opts.Limit < 0 || opts.Limit > MaxAlbumPageLimit {
+		invalid.Add("limit", "range", fmt.Sprintf("must be between 1 and %d", MaxAlbumPageLimit))
+	}
+	if opts.Offset < 0 || opts.Offset > MaxAlbumSearchOffset {
+		invalid.Add("offset", "range", fmt.Sprintf("must be between 0 and %d", MaxAlbumSearchOffset))
+	}
+	if err := invalid.OrNil(); err != nil {
+		return dto.AlbumSearchResults{}, err
+	}
+
+	result, err := s.albumRepo.SearchAlbums(ctx, opts)
+	if err != nil {
+		return dto.AlbumSearchResults{}, fmt.Errorf("service error searching albums: %w", err)
+	}
+
+	return dto.BuildAlbumSearchResultsDTO(result, opts), nil
+}
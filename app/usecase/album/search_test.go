@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_SearchAlbums(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            entity.AlbumSearchOptions
+		expectedOpts    *entity.AlbumSearchOptions // Options expected at the repository, nil when it is not called
+		mockResult      entity.AlbumSearchResult
+		mockError       error
+		expected        dto.AlbumSearchResults
+		expectedError   error
+		expectedDetails []customerr.FieldError
+	}{
+		{
+			name:         "Defaults the limit and trims the query",
+			opts:         entity.AlbumSearchOptions{Query: "  blue "},
+			expectedOpts: &entity.AlbumSearchOptions{Query: "blue", Limit: albumservice.DefaultAlbumPageLimit},
+			mockResult: entity.AlbumSearchResult{Hits: []entity.AlbumSearchHit{
+				{Album: entity.Album{ID: "1", Title: "Blue"}, Score: 1},
+			}},
+			expected: dto.AlbumSearchResults{Results: []dto.AlbumSearchHit{
+				{Album: dto.Album{ID: "1", Title: "Blue"}, Score: 1, Highlights: map[string]string{"title": "<em>Blue</em>"}},
+			}},
+		},
+		{
+			name:            "Missing query",
+			opts:            entity.AlbumSearchOptions{Query: " "},
+			expectedError:   customerr.ErrInvalidInput,
+			expectedDetails: []customerr.FieldError{{Field: "q", Code: "required", Message: "is required"}},
+		},
+		{
+			name:          "Out of range paging",
+			opts:          entity.AlbumSearchOptions{Query: strings.Repeat("a", 256), Limit: 101, Offset: -1},
+			expectedError: customerr.ErrInvalidInput,
+			expectedDetails: []customerr.FieldError{
+				{Field: "q", Code: "length", Message: "must be at most 255 characters long"},
+				{Field: "limit", Code: "range", Message: "must be between 1 and 100"},
+				{Field: "offset", Code: "range", Message: "must be between 0 and 10000"},
+			},
+		},
+		{
+			name:          "Repository error",
+			opts:          entity.AlbumSearchOptions{Query: "blue", Limit: 5},
+			expectedOpts:  &entity.AlbumSearchOptions{Query: "blue", Limit: 5},
+			mockError:     errors.New("database error"),
+			expectedError: errors.New("service error searching albums: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepositoryInterface(t)
+			if tt.expectedOpts != nil {
+				mockRepo.On("SearchAlbums", mock.Anything, *tt.expectedOpts).Return(tt.mockResult, tt.mockError).Once()
+			}
+
+			service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+			result, err := service.SearchAlbums(context.Background(), tt.opts)
+
+			assert.Equal(t, tt.expected, result)
+			switch {
+			case tt.expectedDetails != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Equal(t, tt.expectedDetails, customerr.ValidationDetails(err))
+			case tt.expectedError != nil:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
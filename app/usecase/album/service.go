@@ -4,28 +4,99 @@ import (
 	"time"
 
 	httpClientJsonPostInterface "boilerplate/app/infrastructure/httpclient/interface"
+	"boilerplate/app/infrastructure/idgen"
 	"boilerplate/app/infrastructure/redis"
 	albumsRepositories "boilerplate/app/infrastructure/repositories/interface"
 )
 
 type Service struct {
 	albumRepo albumsRepositories.RepositoryInterface
+	trackRepo albumsRepositories.TrackRepositoryInterface
 	cache     *redis.RedisCache
 	cacheT    time.Duration // Duration for cache expiration
 
 	jsonPostService httpClientJsonPostInterface.HttpClientJsonPostInterface
+
+	// Whether deleting an album also deletes its tracks instead of being refused
+	cascadeTrackDeletes bool
+
+	// Generates IDs for albums created without one
+	newID idgen.Generator
+
+	// Number of albums written per repository call by ImportAlbums
+	importBatchSize int
+
+	// How long deleted albums stay in the trash before PurgeAlbums removes them
+	trashRetention time.Duration
+
+	// Log and channel of album change events, nil when changes are not published
+	eventStore   EventStore
+	eventLogSize int64
+	eventBuffer  int
+	events       *eventHub
+
+	// Passes album changes on to webhooks, nil when they are not dispatched
+	eventDispatcher EventDispatcher
+}
+
+// Option customises optional Service behaviour
+type Option func(*Service)
+
+// WithCascadeTrackDeletes makes DeleteAlbum remove an album's tracks instead of refusing the delete
+func WithCascadeTrackDeletes(cascade bool) Option {
+	return func(s *Service) {
+		s.cascadeTrackDeletes = cascade
+	}
+}
+
+// WithIDGenerator sets how IDs are generated for albums created without one
+func WithIDGenerator(generator idgen.Generator) Option {
+	return func(s *Service) {
+		s.newID = generator
+	}
+}
+
+// WithImportBatchSize sets how many albums ImportAlbums writes per multi-row insert
+func WithImportBatchSize(size int) Option {
+	return func(s *Service) {
+		if size > 0 {
+			s.importBatchSize = size
+		}
+	}
+}
+
+// WithTrashRetention sets how long deleted albums stay restorable before PurgeAlbums removes them
+func WithTrashRetention(retention time.Duration) Option {
+	return func(s *Service) {
+		if retention > 0 {
+			s.trashRetention = retention
+		}
+	}
 }
 
 func NewService(
 	albumRepo albumsRepositories.RepositoryInterface,
+	trackRepo albumsRepositories.TrackRepositoryInterface,
 	redisCache *redis.RedisCache,
 	cacheExpiration time.Duration,
 	jsonPostService httpClientJsonPostInterface.HttpClientJsonPostInterface,
+	opts ...Option,
 ) *Service {
-	return &Service{
+	s := &Service{
 		albumRepo:       albumRepo,
+		trackRepo:       trackRepo,
 		cache:           redisCache,
 		cacheT:          cacheExpiration,
 		jsonPostService: jsonPostService,
+		newID:           idgen.NewUUIDv7,
+		importBatchSize: DefaultImportBatchSize,
+		trashRetention:  DefaultTrashRetention,
+		eventLogSize:    DefaultEventLogSize,
+		eventBuffer:     DefaultEventBuffer,
+		events:          newEventHub(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultTrashRetention is how long deleted albums stay restorable before PurgeAlbums removes them
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// RestoreAlbum takes an album out of the trash and returns it as stored
+func (s *Service) RestoreAlbum(ctx context.Context, id string) (dto.Album, error) {
+	if _, err := s.albumRepo.RestoreAlbum(ctx, id); err != nil {
+		if errors.IsAlbumNotFound(err) {
+			return dto.Album{}, errors.ErrAlbumNotFound
+		}
+		return dto.Album{}, fmt.Errorf("service error restoring album: %w", err)
+	}
+
+	// Nothing should be cached for an album in the trash, but evict anyway so the reload below is fresh
+	s.evictAlbum(id)
+	s.auditAfter(ctx, id)
+	album, err := s.getAlbum(ctx, id)
+	if err != nil {
+		return dto.Album{}, err
+	}
+
+	restored := dto.BuildAlbumDTO(album)
+	s.publishAlbumEvent(ctx, dto.AlbumRestored, id, &restored)
+	return restored, nil
+}
+
+// PurgeAlbums permanently removes the albums that have been in the trash for longer than the retention
+func (s *Service) PurgeAlbums(ctx context.Context) (dto.AlbumPurgeReport, error) {
+	deletedBefore := time.Now().Add(-s.trashRetention)
+
+	ids, err := s.albumRepo.PurgeAlbums(ctx, deletedBefore)
+	if err != nil {
+		return dto.AlbumPurgeReport{}, fmt.Errorf("service error purging albums: %w", err)
+	}
+
+	for _, id := range ids {
+		s.evictAlbum(id)
+	}
+	return dto.BuildAlbumPurgeReportDTO(deletedBefore, ids), nil
+}
@@ -0,0 +1,82 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_RestoreAlbum(t *testing.T) {
+	tests := []struct {
+		name          string
+		restoreError  error
+		expected      dto.Album
+		expectedError error
+	}{
+		{
+			name:     "Restores and reloads the album",
+			expected: dto.Album{ID: "1", Title: "Back", Version: 3},
+		},
+		{
+			name:          "Album not in the trash",
+			restoreError:  customerr.ErrAlbumNotFound,
+			expectedError: customerr.ErrAlbumNotFound,
+		},
+		{
+			name:          "Repository error",
+			restoreError:  errors.New("database error"),
+			expectedError: errors.New("service error restoring album: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepositoryInterface(t)
+			mockRepo.On("RestoreAlbum", mock.Anything, "1").Return(int64(3), tt.restoreError).Once()
+			if tt.restoreError == nil {
+				mockRepo.On("GetAlbumByID", mock.Anything, "1").
+					Return(entity.Album{ID: "1", Title: "Back", Version: 3}, nil).
+					Once()
+			}
+
+			service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+			album, err := service.RestoreAlbum(context.Background(), "1")
+
+			assert.Equal(t, tt.expected, album)
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_PurgeAlbums(t *testing.T) {
+	retention := 48 * time.Hour
+	mockRepo := mocks.NewRepositoryInterface(t)
+
+	var cutoff time.Time
+	mockRepo.On("PurgeAlbums", mock.Anything, mock.MatchedBy(func(deletedBefore time.Time) bool {
+		cutoff = deletedBefore
+		return time.Since(deletedBefore) >= retention && time.Since(deletedBefore) < retention+time.Minute
+	})).Return([]string{"1", "2"}, nil).Once()
+
+	service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil, albumservice.WithTrashRetention(retention))
+
+	report, err := service.PurgeAlbums(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.AlbumPurgeReport{DeletedBefore: cutoff, Purged: 2, IDs: []string{"1", "2"}}, report)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// UpdateAlbum replaces an existing album and returns the stored result. A non-zero
+// album.Version is the version the caller last read; the update fails with
+// ErrVersionMismatch if the album has changed since.
+func (s *Service) UpdateAlbum(ctx context.Context, album entity.Album) (dto.Album, error) {
+	s.auditBefore(ctx, album.ID.String())
+	version, err := s.saveAlbum(ctx, album)
+	if err != nil {
+		return dto.Album{}, err
+	}
+	album.Version = version
+	s.auditAfter(ctx, album.ID.String())
+
+	updated := dto.BuildAlbumDTO(s.storedAlbum(ctx, album))
+	s.publishAlbumEvent(ctx, dto.AlbumUpdated, updated.ID, &updated)
+	return updated, nil
+}
+
+// storedAlbum reads back an album just written, so that what is returned and published carries
+// what the repository keeps, such as the timestamps, rather than what the caller sent. The write
+// already succeeded, so should the read fail the album written is returned instead.
+func (s *Service) storedAlbum(ctx context.Context, written entity.Album) entity.Album {
+	stored, err := s.albumRepo.GetAlbumByID(ctx, written.ID.String())
+	if err != nil {
+		fmt.Printf("Failed to read back album %s: %v\n", written.ID, err)
+		return written
+	}
+	return stored
+}
+
+// PatchAlbum applies a partial update to an existing album and returns the result.
+// A non-zero version is the version the caller last read, as for UpdateAlbum.
+func (s *Service) PatchAlbum(ctx context.Context, id string, patch entity.AlbumPatch, version int64) (dto.Album, error) {
+	album, err := s.albumRepo.GetAlbumByID(ctx, id)
+	if err != nil {
+		if errors.IsAlbumNotFound(err) {
+			return dto.Album{}, errors.ErrAlbumNotFound
+		}
+		return dto.Album{}, fmt.Errorf("service error getting album: %w", err)
+	}
+	if version != 0 && album.Version != version {
+		return dto.Album{}, errors.ErrVersionMismatch
+	}
+
+	// The patched album keeps the version it was read at, so a write landing in between is detected
+	return s.UpdateAlbum(ctx, patch.Apply(album))
+}
+
+// saveAlbum writes the album through the repository, evicts its cached copy and returns the new version
+func (s *Service) saveAlbum(ctx context.Context, album entity.Album) (int64, error) {
+	if err := validateAlbum(album); err != nil {
+		return 0, err
+	}
+
+	version, err := s.albumRepo.UpdateAlbum(ctx, album)
+	if err != nil {
+		if errors.IsAlbumNotFound(err) {
+			return 0, errors.ErrAlbumNotFound
+		}
+		if errors.IsVersionMismatch(err) {
+			return 0, errors.ErrVersionMismatch
+		}
+		return 0, fmt.Errorf("service error updating album: %w", err)
+	}
+
+	s.evictAlbum(album.ID.String())
+	return version, nil
+}
+
+// evictAlbum removes the album cached by GetAlbumByID and its cached version
+func (s *Service) evictAlbum(id string) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.DeleteFromCache(id, albumVersionKey(id)); err != nil {
+		// Log cache error but don't fail the request if cache eviction fails
+		fmt.Printf("Failed to evict album %s from cache: %v\n", id, err)
+	}
+}
@@ -0,0 +1,226 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_UpdateAlbum(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	tests := []struct {
+		name          string
+		album         entity.Album
+		mockError     error
+		stored        *entity.Album
+		readError     error
+		expected      dto.Album
+		expectedError error
+	}{
+		{
+			name:      "Successful update",
+			album:     entity.Album{ID: entity.AlbumID("album-123"), Title: "Updated", TrackCount: 12, Version: 3},
+			mockError: nil,
+			stored:    &entity.Album{ID: entity.AlbumID("album-123"), Title: "Updated", TrackCount: 12, Version: 4, CreatedAt: createdAt, UpdatedAt: updatedAt},
+			expected:  dto.Album{ID: "album-123", Title: "Updated", TrackCount: 12, Version: 4, CreatedAt: &createdAt, UpdatedAt: &updatedAt},
+		},
+		{
+			name:      "Stored album cannot be read back",
+			album:     entity.Album{ID: entity.AlbumID("album-123"), Title: "Updated", Version: 3},
+			mockError: nil,
+			readError: errors.New("database error"),
+			expected:  dto.Album{ID: "album-123", Title: "Updated", Version: 4},
+		},
+		{
+			name:          "Album changed since it was read",
+			album:         entity.Album{ID: entity.AlbumID("album-123"), Title: "Updated", Version: 2},
+			mockError:     customerr.ErrVersionMismatch,
+			expected:      dto.Album{},
+			expectedError: customerr.ErrVersionMismatch,
+		},
+		{
+			name:          "Album not found",
+			album:         entity.Album{ID: entity.AlbumID("album-404"), Title: "Updated"},
+			mockError:     customerr.ErrAlbumNotFound,
+			expected:      dto.Album{},
+			expectedError: customerr.ErrAlbumNotFound,
+		},
+		{
+			name:          "Repository error",
+			album:         entity.Album{ID: entity.AlbumID("album-456"), Title: "Updated"},
+			mockError:     errors.New("database error"),
+			expected:      dto.Album{},
+			expectedError: errors.New("service error updating album: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepositoryInterface(t)
+			var newVersion int64
+			if tt.mockError == nil {
+				newVersion = tt.album.Version + 1
+			}
+			mockRepo.On("UpdateAlbum", mock.Anything, tt.album).Return(newVersion, tt.mockError).Once()
+			if tt.stored != nil || tt.readError != nil {
+				stored := entity.Album{}
+				if tt.stored != nil {
+					stored = *tt.stored
+				}
+				mockRepo.On("GetAlbumByID", mock.Anything, tt.album.ID.String()).Return(stored, tt.readError).Once()
+			}
+
+			service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+			result, err := service.UpdateAlbum(context.Background(), tt.album)
+
+			assert.Equal(t, tt.expected, result)
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_PatchAlbum(t *testing.T) {
+	title := "Patched"
+	stored := entity.Album{ID: entity.AlbumID("album-123"), Title: "Original", Version: 3}
+
+	t.Run("Applies patch to stored album", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "album-123").Return(stored, nil).Once()
+		mockRepo.On("UpdateAlbum", mock.Anything, entity.Album{ID: stored.ID, Title: title, Version: 3}).Return(int64(4), nil).Once()
+		mockRepo.On("GetAlbumByID", mock.Anything, "album-123").Return(entity.Album{ID: stored.ID, Title: title, Version: 4}, nil).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		result, err := service.PatchAlbum(context.Background(), "album-123", entity.AlbumPatch{Title: &title}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.Album{ID: "album-123", Title: title, Version: 4}, result)
+	})
+
+	t.Run("Unconditional patch still guards against concurrent writes", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "album-123").Return(stored, nil).Once()
+		mockRepo.On("UpdateAlbum", mock.Anything, entity.Album{ID: stored.ID, Title: title, Version: 3}).
+			Return(int64(0), customerr.ErrVersionMismatch).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		_, err := service.PatchAlbum(context.Background(), "album-123", entity.AlbumPatch{Title: &title}, 0)
+
+		assert.ErrorIs(t, err, customerr.ErrVersionMismatch)
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "album-123").Return(stored, nil).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		_, err := service.PatchAlbum(context.Background(), "album-123", entity.AlbumPatch{Title: &title}, 2)
+
+		assert.ErrorIs(t, err, customerr.ErrVersionMismatch)
+	})
+
+	t.Run("Album not found", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "album-404").Return(entity.Album{}, customerr.ErrAlbumNotFound).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		_, err := service.PatchAlbum(context.Background(), "album-404", entity.AlbumPatch{Title: &title}, 0)
+
+		assert.ErrorIs(t, err, customerr.ErrAlbumNotFound)
+	})
+
+	t.Run("Rejects a patch leaving the album invalid", func(t *testing.T) {
+		blank := ""
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "album-123").Return(stored, nil).Once()
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		_, err := service.PatchAlbum(context.Background(), "album-123", entity.AlbumPatch{Title: &blank}, 0)
+
+		assert.ErrorIs(t, err, customerr.ErrInvalidInput)
+		assert.Equal(t, "title", customerr.ValidationDetails(err)[0].Field)
+	})
+}
+
+func TestService_DeleteAlbum(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		version       int64
+		cascade       bool
+		mockError     error
+		expectedError error
+	}{
+		{
+			name: "Successful delete",
+			id:   "album-123",
+		},
+		{
+			name:    "Cascading delete",
+			id:      "album-123",
+			cascade: true,
+		},
+		{
+			name:          "Album changed since it was read",
+			id:            "album-123",
+			version:       2,
+			mockError:     customerr.ErrVersionMismatch,
+			expectedError: customerr.ErrVersionMismatch,
+		},
+		{
+			name:          "Album still has tracks",
+			id:            "album-789",
+			mockError:     customerr.ErrAlbumHasTracks,
+			expectedError: customerr.ErrAlbumHasTracks,
+		},
+		{
+			name:          "Album not found",
+			id:            "album-404",
+			mockError:     customerr.ErrAlbumNotFound,
+			expectedError: customerr.ErrAlbumNotFound,
+		},
+		{
+			name:          "Repository error",
+			id:            "album-456",
+			mockError:     errors.New("database error"),
+			expectedError: errors.New("service error deleting album: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepositoryInterface(t)
+			mockRepo.On("DeleteAlbum", mock.Anything, tt.id, tt.version, tt.cascade).Return(tt.mockError).Once()
+
+			service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil, albumservice.WithCascadeTrackDeletes(tt.cascade))
+
+			err := service.DeleteAlbum(context.Background(), tt.id, tt.version)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxAlbumTrackCount is the largest track count an album may declare
+const MaxAlbumTrackCount = 500
+
+// validateAlbum applies the domain rules every stored album must satisfy,
+// whatever transport it arrived through
+func validateAlbum(album entity.Album) error {
+	invalid := errors.NewValidationError()
+	if strings.TrimSpace(album.Title) == "" {
+		invalid.Add("title", "required", "must not be blank")
+	}
+	if album.Genre != "" && !entity.IsValidGenre(album.Genre) {
+		invalid.Add("genre", "enum", "must be one of: "+strings.Join(entity.AlbumGenres, ", "))
+	}
+	if album.TrackCount < 0 || album.TrackCount > MaxAlbumTrackCount {
+		invalid.Add("track_count", "range", fmt.Sprintf("must be between 0 and %d", MaxAlbumTrackCount))
+	}
+	if album.ReleaseDate != nil && album.ReleaseDate.After(time.Now()) {
+		invalid.Add("release_date", "future", "must not be in the future")
+	}
+	return invalid.OrNil()
+}
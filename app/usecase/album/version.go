@@ -0,0 +1,33 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"context"
+	"encoding/json"
+)
+
+// albumVersionKey is the cache key of an album's version, stored apart from the album itself
+func albumVersionKey(id string) string {
+	return "album_version:" + id
+}
+
+// GetAlbumVersion returns the version and last update time of an album. They are read from
+// their own cache entry when present, so conditional requests are answered without loading
+// the album; otherwise the album is loaded and both are cached.
+func (s *Service) GetAlbumVersion(ctx context.Context, id string) (dto.AlbumVersion, error) {
+	if s.cache != nil {
+		cachedData, err := s.cache.GetFromCache(albumVersionKey(id))
+		if err == nil {
+			var version dto.AlbumVersion
+			if jsonErr := json.Unmarshal(cachedData, &version); jsonErr == nil {
+				return version, nil
+			}
+		}
+	}
+
+	album, err := s.getAlbum(ctx, id)
+	if err != nil {
+		return dto.AlbumVersion{}, err
+	}
+	return dto.BuildAlbumVersionDTO(album), nil
+}
@@ -0,0 +1,42 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	albumservice "boilerplate/app/usecase/album"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_GetAlbumVersion(t *testing.T) {
+	t.Run("Taken from the album without a cache", func(t *testing.T) {
+		updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: entity.AlbumID("1"), Title: "Album1", Version: 3, UpdatedAt: updatedAt}, nil)
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		version, err := service.GetAlbumVersion(context.Background(), "1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.AlbumVersion{Version: 3, UpdatedAt: &updatedAt}, version)
+	})
+
+	t.Run("Album not found", func(t *testing.T) {
+		mockRepo := mocks.NewRepositoryInterface(t)
+		mockRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{}, errors.ErrAlbumNotFound)
+
+		service := albumservice.NewService(mockRepo, nil, nil, 0*time.Second, nil)
+
+		_, err := service.GetAlbumVersion(context.Background(), "1")
+
+		assert.ErrorIs(t, err, errors.ErrAlbumNotFound)
+	})
+}
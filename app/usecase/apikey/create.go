@@ -0,0 +1,48 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+	"fmt"
+)
+
+// CreateAPIKey issues an API key with the name, scopes and expiry of apiKey. The result is the only
+// one carrying the key, of which only a hash is stored.
+func (s *Service) CreateAPIKey(ctx context.Context, apiKey entity.APIKey) (dto.APIKey, error) {
+	if err := validateAPIKey(apiKey, s.now()); err != nil {
+		return dto.APIKey{}, err
+	}
+
+	apiKey.Scopes = uniqueScopes(apiKey.Scopes)
+	key, err := s.issue(&apiKey)
+	if err != nil {
+		return dto.APIKey{}, fmt.Errorf("service error creating api key: %w", err)
+	}
+
+	if err := s.apiKeyRepo.CreateAPIKey(ctx, apiKey); err != nil {
+		return dto.APIKey{}, fmt.Errorf("service error creating api key: %w", err)
+	}
+	// The prefix may have been looked up, and cached as unknown, before it was issued
+	s.forget(apiKey.Prefix)
+
+	created := dto.BuildAPIKeyDTO(apiKey)
+	created.Key = key
+	return created, nil
+}
+
+// issue gives apiKey a new ID and a new key, returned, which apiKey gets the prefix and hash of
+func (s *Service) issue(apiKey *entity.APIKey) (string, error) {
+	id, err := s.newID()
+	if err != nil {
+		return "", err
+	}
+	prefix, key, err := generateKey()
+	if err != nil {
+		return "", err
+	}
+	apiKey.ID = id
+	apiKey.Prefix = prefix
+	apiKey.Hash = hashKey(key)
+	return key, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// GetAPIKeys lists every API key, revoked and expired ones included
+func (s *Service) GetAPIKeys(ctx context.Context) ([]dto.APIKey, error) {
+	apiKeys, err := s.apiKeyRepo.GetAPIKeys(ctx)
+	if err != nil {
+		return []dto.APIKey{}, fmt.Errorf("service error getting api keys: %w", err)
+	}
+	return dto.BuildAPIKeyDTOs(apiKeys), nil
+}
+
+// GetAPIKeyByID retrieves a single API key
+func (s *Service) GetAPIKeyByID(ctx context.Context, id string) (dto.APIKey, error) {
+	apiKey, err := s.getAPIKey(ctx, id)
+	if err != nil {
+		return dto.APIKey{}, err
+	}
+	return dto.BuildAPIKeyDTO(apiKey), nil
+}
+
+// getAPIKey loads an API key from the repository
+func (s *Service) getAPIKey(ctx context.Context, id string) (entity.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		if errors.IsAPIKeyNotFound(err) {
+			return entity.APIKey{}, errors.ErrAPIKeyNotFound
+		}
+		return entity.APIKey{}, fmt.Errorf("service error getting api key: %w", err)
+	}
+	return apiKey, nil
+}
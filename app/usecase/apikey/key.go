@@ -0,0 +1,58 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// API keys are written "ak_<prefix>_<secret>": the prefix identifies the key and is shown in
+// listings, the secret is only known to the holder of the key
+const (
+	keyScheme = "ak_"
+	// prefixSize and secretSize are numbers of random bytes, hex encoded in the key
+	prefixSize = 6
+	secretSize = 32
+)
+
+// generateKey returns a new key and its prefix
+func generateKey() (prefix, key string, err error) {
+	random := make([]byte, prefixSize+secretSize)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", fmt.Errorf("error generating api key: %w", err)
+	}
+	prefix = hex.EncodeToString(random[:prefixSize])
+	return prefix, keyScheme + prefix + "_" + hex.EncodeToString(random[prefixSize:]), nil
+}
+
+// parseKey returns the prefix of a key, and whether the key is well formed
+func parseKey(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, keyScheme)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != 2*prefixSize || len(secret) != 2*secretSize {
+		return "", false
+	}
+	return prefix, true
+}
+
+// hashKey returns the hash a key is stored as. Keys are random, so a fast hash without salt
+// resists guessing as well as a slow one would.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheKey is the cache entry of the key with the given prefix
+func cacheKey(prefix string) string {
+	return "api_key:" + prefix
+}
+
+// lastUsedKey is the cache entry marking a key whose last use was written recently
+func lastUsedKey(id string) string {
+	return "api_key_used:" + id
+}
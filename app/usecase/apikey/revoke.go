@@ -0,0 +1,31 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// RevokeAPIKey revokes an API key, which is rejected from then on but still listed. Revoking a key
+// twice keeps its first revocation time.
+func (s *Service) RevokeAPIKey(ctx context.Context, id string) (dto.APIKey, error) {
+	apiKey, err := s.getAPIKey(ctx, id)
+	if err != nil {
+		return dto.APIKey{}, err
+	}
+
+	if !apiKey.Revoked() {
+		now := s.now()
+		if err := s.apiKeyRepo.RevokeAPIKey(ctx, id, now); err != nil {
+			if errors.IsAPIKeyNotFound(err) {
+				return dto.APIKey{}, errors.ErrAPIKeyNotFound
+			}
+			return dto.APIKey{}, fmt.Errorf("service error revoking api key: %w", err)
+		}
+		apiKey.RevokedAt = &now
+	}
+	s.forget(apiKey.Prefix)
+
+	return dto.BuildAPIKeyDTO(apiKey), nil
+}
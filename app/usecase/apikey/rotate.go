@@ -0,0 +1,52 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+	"time"
+)
+
+// RotateAPIKey replaces an API key with a new one of the same name, scopes and expiry. The replaced
+// key stays valid for gracePeriod, so that its holders can switch over, or is revoked at once when
+// gracePeriod is 0. Revoked and expired keys cannot be rotated.
+func (s *Service) RotateAPIKey(ctx context.Context, id string, gracePeriod time.Duration) (dto.APIKey, error) {
+	replaced, err := s.getAPIKey(ctx, id)
+	if err != nil {
+		return dto.APIKey{}, err
+	}
+	now := s.now()
+	if replaced.Revoked() || replaced.Expired(now) {
+		return dto.APIKey{}, errors.ErrAPIKeyInactive
+	}
+
+	replacement := entity.APIKey{Name: replaced.Name, Scopes: replaced.Scopes, ExpiresAt: replaced.ExpiresAt}
+	key, err := s.issue(&replacement)
+	if err != nil {
+		return dto.APIKey{}, fmt.Errorf("service error rotating api key: %w", err)
+	}
+
+	if gracePeriod > 0 {
+		until := now.Add(gracePeriod)
+		if !replaced.Expired(until) {
+			replaced.ExpiresAt = &until
+		}
+	} else {
+		replaced.RevokedAt = &now
+	}
+
+	if err := s.apiKeyRepo.RotateAPIKey(ctx, replaced, replacement); err != nil {
+		if errors.IsAPIKeyInactive(err) {
+			return dto.APIKey{}, errors.ErrAPIKeyInactive
+		}
+		return dto.APIKey{}, fmt.Errorf("service error rotating api key: %w", err)
+	}
+	s.forget(replaced.Prefix)
+	s.forget(replacement.Prefix)
+
+	rotated := dto.BuildAPIKeyDTO(replacement)
+	rotated.Key = key
+	return rotated, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"time"
+
+	"boilerplate/app/infrastructure/idgen"
+	apiKeyRepositories "boilerplate/app/infrastructure/repositories/interface"
+)
+
+// Defaults of API key verification unless configured otherwise
+const (
+	// DefaultCacheTTL is how long a verified key is served from the cache before it is read again
+	DefaultCacheTTL = time.Minute
+	// DefaultLastUsedInterval is how often at most the last use of a key is written
+	DefaultLastUsedInterval = time.Minute
+)
+
+// KeyCache holds the verified keys shared by every API instance. It is satisfied by *redis.RedisCache.
+type KeyCache interface {
+	GetFromCache(key string) ([]byte, error)
+	SetToCache(key string, value interface{}, expiration time.Duration) error
+	SetToCacheIfAbsent(key string, value interface{}, expiration time.Duration) (bool, error)
+	DeleteFromCache(keys ...string) error
+}
+
+type Service struct {
+	apiKeyRepo apiKeyRepositories.APIKeyRepositoryInterface
+
+	// Cache of verified keys and unknown prefixes, nil to read every key from the repository
+	cache    KeyCache
+	cacheTTL time.Duration
+
+	// Generates the IDs of API keys
+	newID idgen.Generator
+	now   func() time.Time
+
+	lastUsedInterval time.Duration
+}
+
+// Option customises optional Service behaviour
+type Option func(*Service)
+
+// WithIDGenerator sets how the IDs of API keys are generated
+func WithIDGenerator(generator idgen.Generator) Option {
+	return func(s *Service) {
+		s.newID = generator
+	}
+}
+
+// WithCache serves verified keys, and the prefixes no key has, from cache for up to ttl. Revoking or
+// rotating a key removes it from the cache.
+func WithCache(cache KeyCache, ttl time.Duration) Option {
+	return func(s *Service) {
+		s.cache = cache
+		if ttl > 0 {
+			s.cacheTTL = ttl
+		}
+	}
+}
+
+// WithLastUsedInterval sets how often at most the last use of a key is written
+func WithLastUsedInterval(interval time.Duration) Option {
+	return func(s *Service) {
+		if interval > 0 {
+			s.lastUsedInterval = interval
+		}
+	}
+}
+
+// WithClock sets the clock expiry and revocation times are read from
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(apiKeyRepo apiKeyRepositories.APIKeyRepositoryInterface, opts ...Option) *Service {
+	s := &Service{
+		apiKeyRepo:       apiKeyRepo,
+		cacheTTL:         DefaultCacheTTL,
+		newID:            idgen.NewUUIDv7,
+		now:              time.Now,
+		lastUsedInterval: DefaultLastUsedInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
@@ -0,0 +1,299 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	apikeyservice "boilerplate/app/usecase/apikey"
+)
+
+var testNow = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// fakeCache is a KeyCache held in memory, or failing every call when err is set
+type fakeCache struct {
+	entries map[string][]byte
+	err     error
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{entries: map[string][]byte{}}
+}
+
+func (c *fakeCache) GetFromCache(key string) ([]byte, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	value, ok := c.entries[key]
+	if !ok {
+		return nil, errors.New("redis: nil")
+	}
+	return value, nil
+}
+
+func (c *fakeCache) SetToCache(key string, value interface{}, _ time.Duration) error {
+	if c.err != nil {
+		return c.err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.entries[key] = data
+	return nil
+}
+
+func (c *fakeCache) SetToCacheIfAbsent(key string, value interface{}, expiration time.Duration) (bool, error) {
+	if c.err != nil {
+		return false, c.err
+	}
+	if _, ok := c.entries[key]; ok {
+		return false, nil
+	}
+	return true, c.SetToCache(key, value, expiration)
+}
+
+func (c *fakeCache) DeleteFromCache(keys ...string) error {
+	if c.err != nil {
+		return c.err
+	}
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+const testKey = "ak_0123456789ab_0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// storedKey returns the stored form of testKey
+func storedKey() entity.APIKey {
+	sum := sha256.Sum256([]byte(testKey))
+	return entity.APIKey{
+		ID:     "k1",
+		Name:   "billing",
+		Prefix: "0123456789ab",
+		Hash:   hex.EncodeToString(sum[:]),
+		Scopes: []string{"albums:read"},
+	}
+}
+
+func newService(repo *mocks.APIKeyRepositoryInterface, cache apikeyservice.KeyCache) *apikeyservice.Service {
+	opts := []apikeyservice.Option{
+		apikeyservice.WithIDGenerator(func() (string, error) { return "k2", nil }),
+		apikeyservice.WithClock(func() time.Time { return testNow }),
+	}
+	if cache != nil {
+		opts = append(opts, apikeyservice.WithCache(cache, time.Minute))
+	}
+	return apikeyservice.NewService(repo, opts...)
+}
+
+func TestService_CreateAPIKey(t *testing.T) {
+	t.Run("Stores the hash of the issued key", func(t *testing.T) {
+		var stored entity.APIKey
+		repo := mocks.NewAPIKeyRepositoryInterface(t)
+		repo.On("CreateAPIKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(entity.APIKey)
+		}).Return(nil).Once()
+
+		created, err := newService(repo, nil).CreateAPIKey(context.Background(), entity.APIKey{
+			Name:   "billing",
+			Scopes: []string{"albums:read", "albums:read"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "k2", created.ID)
+		assert.True(t, strings.HasPrefix(created.Key, "ak_"+created.Prefix+"_"))
+		assert.Equal(t, []string{"albums:read"}, created.Scopes)
+		sum := sha256.Sum256([]byte(created.Key))
+		assert.Equal(t, hex.EncodeToString(sum[:]), stored.Hash)
+		assert.NotContains(t, stored.Hash, created.Key)
+	})
+
+	t.Run("Invalid scopes and expiry", func(t *testing.T) {
+		past := testNow.Add(-time.Hour)
+		_, err := newService(mocks.NewAPIKeyRepositoryInterface(t), nil).CreateAPIKey(context.Background(), entity.APIKey{
+			Name:      "billing",
+			Scopes:    []string{"albums:delete"},
+			ExpiresAt: &past,
+		})
+
+		var validationErr *customerr.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "scopes", validationErr.Fields[0].Field)
+		assert.Equal(t, "expires_at", validationErr.Fields[1].Field)
+	})
+}
+
+func TestService_VerifyAPIKey(t *testing.T) {
+	expired := testNow.Add(-time.Second)
+	revoked := testNow.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		key         string
+		stored      func(*entity.APIKey)
+		lookupErr   error
+		expectedErr error
+	}{
+		{name: "Valid key"},
+		{name: "Malformed key", key: "ak_0123", expectedErr: customerr.ErrAPIKeyInvalid},
+		{name: "Unknown prefix", lookupErr: customerr.ErrAPIKeyNotFound, expectedErr: customerr.ErrAPIKeyInvalid},
+		{
+			name:        "Wrong secret",
+			key:         "ak_0123456789ab_fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210",
+			expectedErr: customerr.ErrAPIKeyInvalid,
+		},
+		{name: "Expired", stored: func(k *entity.APIKey) { k.ExpiresAt = &expired }, expectedErr: customerr.ErrAPIKeyExpired},
+		{name: "Revoked", stored: func(k *entity.APIKey) { k.RevokedAt = &revoked }, expectedErr: customerr.ErrAPIKeyRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := tt.key
+			if key == "" {
+				key = testKey
+			}
+			stored := storedKey()
+			if tt.stored != nil {
+				tt.stored(&stored)
+			}
+
+			repo := mocks.NewAPIKeyRepositoryInterface(t)
+			if key != "ak_0123" {
+				repo.On("GetAPIKeyByPrefix", mock.Anything, "0123456789ab").Return(stored, tt.lookupErr).Once()
+			}
+			if tt.expectedErr == nil {
+				repo.On("TouchAPIKey", mock.Anything, "k1", testNow).Return(nil).Once()
+			}
+
+			apiKey, err := newService(repo, nil).VerifyAPIKey(context.Background(), key)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "k1", apiKey.ID)
+		})
+	}
+}
+
+func TestService_VerifyAPIKey_Cache(t *testing.T) {
+	t.Run("Reads the key and writes its use once per interval", func(t *testing.T) {
+		repo := mocks.NewAPIKeyRepositoryInterface(t)
+		repo.On("GetAPIKeyByPrefix", mock.Anything, "0123456789ab").Return(storedKey(), nil).Once()
+		repo.On("TouchAPIKey", mock.Anything, "k1", testNow).Return(nil).Once()
+
+		service := newService(repo, newFakeCache())
+		for i := 0; i < 3; i++ {
+			_, err := service.VerifyAPIKey(context.Background(), testKey)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Revoking removes the key from the cache", func(t *testing.T) {
+		repo := mocks.NewAPIKeyRepositoryInterface(t)
+		repo.On("GetAPIKeyByPrefix", mock.Anything, "0123456789ab").Return(storedKey(), nil).Once()
+		repo.On("TouchAPIKey", mock.Anything, "k1", testNow).Return(nil).Once()
+		repo.On("GetAPIKeyByID", mock.Anything, "k1").Return(storedKey(), nil).Once()
+		repo.On("RevokeAPIKey", mock.Anything, "k1", testNow).Return(nil).Once()
+
+		service := newService(repo, newFakeCache())
+		_, err := service.VerifyAPIKey(context.Background(), testKey)
+		require.NoError(t, err)
+
+		revokedKey, err := service.RevokeAPIKey(context.Background(), "k1")
+		require.NoError(t, err)
+		assert.Equal(t, &testNow, revokedKey.RevokedAt)
+
+		revoked := storedKey()
+		revoked.RevokedAt = &testNow
+		repo.On("GetAPIKeyByPrefix", mock.Anything, "0123456789ab").Return(revoked, nil).Once()
+		_, err = service.VerifyAPIKey(context.Background(), testKey)
+		assert.ErrorIs(t, err, customerr.ErrAPIKeyRevoked)
+	})
+
+	t.Run("Caches unknown prefixes", func(t *testing.T) {
+		repo := mocks.NewAPIKeyRepositoryInterface(t)
+		repo.On("GetAPIKeyByPrefix", mock.Anything, "0123456789ab").Return(entity.APIKey{}, customerr.ErrAPIKeyNotFound).Once()
+
+		service := newService(repo, newFakeCache())
+		for i := 0; i < 3; i++ {
+			_, err := service.VerifyAPIKey(context.Background(), testKey)
+			assert.ErrorIs(t, err, customerr.ErrAPIKeyInvalid)
+		}
+	})
+
+	t.Run("Falls back to the repository when the cache is down", func(t *testing.T) {
+		repo := mocks.NewAPIKeyRepositoryInterface(t)
+		repo.On("GetAPIKeyByPrefix", mock.Anything, "0123456789ab").Return(storedKey(), nil).Once()
+		repo.On("TouchAPIKey", mock.Anything, "k1", testNow).Return(nil).Once()
+
+		_, err := newService(repo, &fakeCache{err: errors.New("connection refused")}).VerifyAPIKey(context.Background(), testKey)
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_RotateAPIKey(t *testing.T) {
+	tests := []struct {
+		name               string
+		gracePeriod        time.Duration
+		expectedExpiresAt  *time.Time
+		expectedRevokedAt  *time.Time
+		existingRevokedAt  *time.Time
+		expectedErr        error
+		expectedRotateCall bool
+	}{
+		{name: "Revokes the replaced key at once", expectedRevokedAt: &testNow, expectedRotateCall: true},
+		{name: "Keeps the replaced key for the grace period", gracePeriod: time.Hour, expectedExpiresAt: timePtr(testNow.Add(time.Hour)), expectedRotateCall: true},
+		{name: "Revoked keys cannot be rotated", existingRevokedAt: &testNow, expectedErr: customerr.ErrAPIKeyInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := storedKey()
+			existing.RevokedAt = tt.existingRevokedAt
+
+			repo := mocks.NewAPIKeyRepositoryInterface(t)
+			repo.On("GetAPIKeyByID", mock.Anything, "k1").Return(existing, nil).Once()
+			if tt.expectedRotateCall {
+				repo.On("RotateAPIKey", mock.Anything,
+					mock.MatchedBy(func(replaced entity.APIKey) bool {
+						return replaced.ID == "k1" &&
+							assert.ObjectsAreEqual(tt.expectedExpiresAt, replaced.ExpiresAt) &&
+							assert.ObjectsAreEqual(tt.expectedRevokedAt, replaced.RevokedAt)
+					}),
+					mock.MatchedBy(func(replacement entity.APIKey) bool {
+						return replacement.ID == "k2" && replacement.Name == "billing" && replacement.Prefix != "0123456789ab"
+					}),
+				).Return(nil).Once()
+			}
+
+			rotated, err := newService(repo, nil).RotateAPIKey(context.Background(), "k1", tt.gracePeriod)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "k2", rotated.ID)
+			assert.Equal(t, []string{"albums:read"}, rotated.Scopes)
+			assert.True(t, strings.HasPrefix(rotated.Key, "ak_"+rotated.Prefix+"_"))
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
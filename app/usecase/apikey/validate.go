@@ -0,0 +1,42 @@
+package services
+
+import (
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"strings"
+	"time"
+)
+
+// validateAPIKey applies the domain rules every issued API key must satisfy
+func validateAPIKey(apiKey entity.APIKey, now time.Time) error {
+	invalid := errors.NewValidationError()
+	if strings.TrimSpace(apiKey.Name) == "" {
+		invalid.Add("name", "required", "is required")
+	}
+	if len(apiKey.Scopes) == 0 {
+		invalid.Add("scopes", "required", "must grant at least one scope")
+	}
+	for _, scope := range apiKey.Scopes {
+		if !entity.IsValidScope(scope) {
+			invalid.Add("scopes", "enum", "must be one of: "+strings.Join(entity.Scopes, ", "))
+			break
+		}
+	}
+	if apiKey.Expired(now) {
+		invalid.Add("expires_at", "range", "must be in the future")
+	}
+	return invalid.OrNil()
+}
+
+// uniqueScopes drops repeated scopes, keeping the first occurrence of each
+func uniqueScopes(scopes []string) []string {
+	var unique []string
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
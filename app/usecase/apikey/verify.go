@@ -0,0 +1,103 @@
+package services
+
+import (
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+)
+
+// VerifyAPIKey checks a key sent by a caller and returns the stored key. The error is
+// ErrAPIKeyInvalid, ErrAPIKeyRevoked or ErrAPIKeyExpired when the key is rejected.
+func (s *Service) VerifyAPIKey(ctx context.Context, key string) (entity.APIKey, error) {
+	prefix, ok := parseKey(key)
+	if !ok {
+		return entity.APIKey{}, errors.ErrAPIKeyInvalid
+	}
+
+	apiKey, err := s.lookup(ctx, prefix)
+	if err != nil {
+		if errors.IsAPIKeyNotFound(err) {
+			return entity.APIKey{}, errors.ErrAPIKeyInvalid
+		}
+		return entity.APIKey{}, fmt.Errorf("service error verifying api key: %w", err)
+	}
+
+	// The state of the key is only told to callers holding it
+	if subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashKey(key))) != 1 {
+		return entity.APIKey{}, errors.ErrAPIKeyInvalid
+	}
+	if apiKey.Revoked() {
+		return entity.APIKey{}, errors.ErrAPIKeyRevoked
+	}
+	if apiKey.Expired(s.now()) {
+		return entity.APIKey{}, errors.ErrAPIKeyExpired
+	}
+
+	s.touch(ctx, apiKey)
+	return apiKey, nil
+}
+
+// lookup loads the key with the given prefix from the cache, falling back to the repository.
+// Prefixes without a key are cached too, as a key without ID, so that callers guessing keys are
+// answered from the cache rather than the repository.
+func (s *Service) lookup(ctx context.Context, prefix string) (entity.APIKey, error) {
+	var apiKey entity.APIKey
+	if s.cache != nil {
+		cachedData, err := s.cache.GetFromCache(cacheKey(prefix))
+		if err == nil {
+			if jsonErr := json.Unmarshal(cachedData, &apiKey); jsonErr == nil {
+				if apiKey.ID == "" {
+					return entity.APIKey{}, errors.ErrAPIKeyNotFound
+				}
+				return apiKey, nil
+			}
+		}
+	}
+
+	apiKey, err := s.apiKeyRepo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil && !errors.IsAPIKeyNotFound(err) {
+		return entity.APIKey{}, err
+	}
+
+	// Store in cache for next time, unknown prefixes included
+	if s.cache != nil {
+		if cacheErr := s.cache.SetToCache(cacheKey(prefix), apiKey, s.cacheTTL); cacheErr != nil {
+			// Log cache error but don't fail the request if cache write fails
+			fmt.Printf("Failed to cache api key %s: %v\n", prefix, cacheErr)
+		}
+	}
+	return apiKey, err
+}
+
+// touch writes the last use of a key, unless it was written less than lastUsedInterval ago
+func (s *Service) touch(ctx context.Context, apiKey entity.APIKey) {
+	now := s.now()
+	recent := apiKey.LastUsedAt != nil && now.Sub(*apiKey.LastUsedAt) < s.lastUsedInterval
+	if s.cache != nil {
+		// The marker is shared by every instance, so that the use is written once per interval by all
+		// of them, whereas the cached key keeps the last use it was read with
+		if first, err := s.cache.SetToCacheIfAbsent(lastUsedKey(apiKey.ID), now, s.lastUsedInterval); err == nil {
+			recent = !first
+		}
+	}
+	if recent {
+		return
+	}
+
+	if err := s.apiKeyRepo.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+		fmt.Printf("Failed to record use of api key %s: %v\n", apiKey.ID, err)
+	}
+}
+
+// forget removes a key from the cache, so that every instance reads its revocation or new expiry
+func (s *Service) forget(prefix string) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.DeleteFromCache(cacheKey(prefix)); err != nil {
+		fmt.Printf("Failed to remove api key %s from cache: %v\n", prefix, err)
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// Bounds of the audit log page returned by GetAuditRecords
+const (
+	DefaultAuditPageLimit = 50
+	MaxAuditPageLimit     = 200
+)
+
+// GetAuditRecords lists one page of the audit records matching filter, newest first.
+// A limit of 0 picks DefaultAuditPageLimit.
+func (s *Service) GetAuditRecords(ctx context.Context, filter entity.AuditFilter) (dto.AuditList, error) {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultAuditPageLimit
+	}
+
+	invalid := errors.NewValidationError()
+	if filter.Limit < 1 || filter.Limit > MaxAuditPageLimit {
+		invalid.Add("limit", "range", fmt.Sprintf("must be between 1 and %d", MaxAuditPageLimit))
+	}
+	switch filter.Outcome {
+	case "", entity.AuditOutcomeSuccess, entity.AuditOutcomeFailure, entity.AuditOutcomeDenied:
+	default:
+		invalid.Add("outcome", "enum", "must be one of: "+entity.AuditOutcomeSuccess+", "+entity.AuditOutcomeFailure+", "+entity.AuditOutcomeDenied)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		invalid.Add("to", "range", "must be after from")
+	}
+	if err := invalid.OrNil(); err != nil {
+		return dto.AuditList{Records: []dto.AuditRecord{}}, err
+	}
+
+	page, err := s.auditRepo.GetAuditRecords(ctx, filter)
+	if err != nil {
+		return dto.AuditList{Records: []dto.AuditRecord{}}, fmt.Errorf("service error getting audit records: %w", err)
+	}
+	return dto.BuildAuditListDTO(page), nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"boilerplate/app/domain/entity"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// RecordAudit queues a record for RunAuditWriter and returns without waiting for it to be written.
+// While the queue is full the record is dropped rather than holding the caller up: it is counted
+// by DroppedRecords and logged in full, so that it can still be recovered from the logs.
+func (s *Service) RecordAudit(record entity.AuditRecord) {
+	if record.OccurredAt.IsZero() {
+		record.OccurredAt = s.now()
+	}
+
+	select {
+	case s.queue <- record:
+	default:
+		dropped := s.dropped.Add(1)
+		s.logLost(record, fmt.Errorf("audit queue is full, %d records dropped so far", dropped))
+	}
+}
+
+// DroppedRecords returns how many records RecordAudit dropped because the queue was full
+func (s *Service) DroppedRecords() int64 {
+	return s.dropped.Load()
+}
+
+// RunAuditWriter writes the queued records until ctx is done, then writes those still queued and
+// returns ctx.Err()
+func (s *Service) RunAuditWriter(ctx context.Context) error {
+	for {
+		select {
+		case record := <-s.queue:
+			s.write(record)
+		case <-ctx.Done():
+			for {
+				select {
+				case record := <-s.queue:
+					s.write(record)
+				default:
+					return ctx.Err()
+				}
+			}
+		}
+	}
+}
+
+// write stores a record. The records that could not be stored are logged in full, so that they
+// can still be recovered from the logs.
+func (s *Service) write(record entity.AuditRecord) {
+	if record.ID == "" {
+		id, err := s.newID()
+		if err != nil {
+			s.logLost(record, err)
+			return
+		}
+		record.ID = id
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.writeTimeout)
+	defer cancel()
+	if err := s.auditRepo.RecordAudit(ctx, record); err != nil {
+		s.logLost(record, err)
+	}
+}
+
+func (s *Service) logLost(record entity.AuditRecord, err error) {
+	data, _ := json.Marshal(record)
+	log.Printf("Error writing audit record: %v; record: %s", err, data)
+}
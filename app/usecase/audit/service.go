@@ -0,0 +1,82 @@
+package services
+
+import (
+	"sync/atomic"
+	"time"
+
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/infrastructure/idgen"
+	auditRepositories "boilerplate/app/infrastructure/repositories/interface"
+)
+
+// Defaults of the audit log unless configured otherwise
+const (
+	// DefaultQueueSize is how many records may wait for the writer before new ones are dropped
+	DefaultQueueSize = 1000
+	// DefaultWriteTimeout bounds the write of every record
+	DefaultWriteTimeout = 5 * time.Second
+)
+
+type Service struct {
+	auditRepo auditRepositories.AuditRepositoryInterface
+
+	// Records waiting for RunAuditWriter
+	queue chan entity.AuditRecord
+	// Records dropped while the queue was full
+	dropped atomic.Int64
+
+	// Generates the IDs of audit records
+	newID idgen.Generator
+	now   func() time.Time
+
+	writeTimeout time.Duration
+}
+
+// Option customises optional Service behaviour
+type Option func(*Service)
+
+// WithIDGenerator sets how the IDs of audit records are generated
+func WithIDGenerator(generator idgen.Generator) Option {
+	return func(s *Service) {
+		s.newID = generator
+	}
+}
+
+// WithQueueSize sets how many records may wait for the writer before new ones are dropped
+func WithQueueSize(size int) Option {
+	return func(s *Service) {
+		if size > 0 {
+			s.queue = make(chan entity.AuditRecord, size)
+		}
+	}
+}
+
+// WithWriteTimeout bounds the write of every record
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(s *Service) {
+		if timeout > 0 {
+			s.writeTimeout = timeout
+		}
+	}
+}
+
+// WithClock sets the clock records are timed with when they come without a time
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(auditRepo auditRepositories.AuditRepositoryInterface, opts ...Option) *Service {
+	s := &Service{
+		auditRepo:    auditRepo,
+		queue:        make(chan entity.AuditRecord, DefaultQueueSize),
+		newID:        idgen.NewUUIDv7,
+		now:          time.Now,
+		writeTimeout: DefaultWriteTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
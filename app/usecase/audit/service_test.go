@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	auditservice "boilerplate/app/usecase/audit"
+)
+
+var testNow = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newService(repo *mocks.AuditRepositoryInterface, opts ...auditservice.Option) *auditservice.Service {
+	opts = append([]auditservice.Option{
+		auditservice.WithIDGenerator(func() (string, error) { return "a1", nil }),
+		auditservice.WithClock(func() time.Time { return testNow }),
+	}, opts...)
+	return auditservice.NewService(repo, opts...)
+}
+
+func TestService_RecordAudit(t *testing.T) {
+	record := entity.AuditRecord{
+		Actor:      "user:user-1",
+		RequestID:  "req-1",
+		Method:     "DELETE",
+		Route:      "/api/v2/albums/:id",
+		TargetID:   "1",
+		Outcome:    entity.AuditOutcomeSuccess,
+		StatusCode: 204,
+	}
+	written := record
+	written.ID = "a1"
+	written.OccurredAt = testNow
+
+	t.Run("Queues the record for the writer", func(t *testing.T) {
+		repo := mocks.NewAuditRepositoryInterface(t)
+		service := newService(repo)
+
+		// Nothing is written until the writer runs
+		service.RecordAudit(record)
+
+		repo.On("RecordAudit", mock.Anything, written).Return(nil).Once()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, service.RunAuditWriter(ctx), context.Canceled)
+	})
+
+	t.Run("Drops the record while the queue is full", func(t *testing.T) {
+		repo := mocks.NewAuditRepositoryInterface(t)
+		service := newService(repo, auditservice.WithQueueSize(1))
+
+		service.RecordAudit(record)
+		service.RecordAudit(record)
+		assert.Equal(t, int64(1), service.DroppedRecords())
+
+		repo.On("RecordAudit", mock.Anything, written).Return(nil).Once()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = service.RunAuditWriter(ctx)
+	})
+
+	t.Run("Keeps writing after a failed write", func(t *testing.T) {
+		repo := mocks.NewAuditRepositoryInterface(t)
+		repo.On("RecordAudit", mock.Anything, written).Return(errors.New("connection refused")).Once()
+		repo.On("RecordAudit", mock.Anything, written).Return(nil).Once()
+		service := newService(repo)
+
+		service.RecordAudit(record)
+		service.RecordAudit(record)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = service.RunAuditWriter(ctx)
+	})
+}
+
+func TestService_GetAuditRecords(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        entity.AuditFilter
+		expectedLimit int
+		// invalidField is the field rejected, empty when the filter is valid
+		invalidField string
+	}{
+		{name: "Default limit", filter: entity.AuditFilter{}, expectedLimit: auditservice.DefaultAuditPageLimit},
+		{name: "Given limit", filter: entity.AuditFilter{Limit: 10, Outcome: entity.AuditOutcomeFailure}, expectedLimit: 10},
+		{name: "Limit too large", filter: entity.AuditFilter{Limit: auditservice.MaxAuditPageLimit + 1}, invalidField: "limit"},
+		{name: "Unknown outcome", filter: entity.AuditFilter{Outcome: "pending"}, invalidField: "outcome"},
+		{name: "Empty period", filter: entity.AuditFilter{From: testNow, To: testNow}, invalidField: "to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewAuditRepositoryInterface(t)
+			if tt.invalidField == "" {
+				filter := tt.filter
+				filter.Limit = tt.expectedLimit
+				repo.On("GetAuditRecords", mock.Anything, filter).Return(entity.AuditPage{
+					Records: []entity.AuditRecord{{ID: "a1", Actor: "user:user-1", OccurredAt: testNow}},
+					Next:    &entity.AuditCursor{OccurredAt: testNow, ID: "a1"},
+				}, nil).Once()
+			}
+
+			list, err := newService(repo).GetAuditRecords(context.Background(), tt.filter)
+			if tt.invalidField != "" {
+				var validationErr *customerr.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.invalidField, validationErr.Fields[0].Field)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, list.Records, 1)
+			assert.Equal(t, dto.AuditRecord{
+				ID: "a1", Actor: "user:user-1", Before: []byte("null"), After: []byte("null"), OccurredAt: testNow,
+			}, list.Records[0])
+
+			cursor, err := dto.DecodeAuditCursor(list.NextCursor)
+			require.NoError(t, err)
+			assert.Equal(t, &entity.AuditCursor{OccurredAt: testNow, ID: "a1"}, cursor)
+		})
+	}
+}
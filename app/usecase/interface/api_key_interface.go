@@ -0,0 +1,17 @@
+package service
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+	"time"
+)
+
+type APIKeyInterface interface {
+	GetAPIKeys(ctx context.Context) ([]dto.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id string) (dto.APIKey, error)
+	CreateAPIKey(ctx context.Context, apiKey entity.APIKey) (dto.APIKey, error)
+	RotateAPIKey(ctx context.Context, id string, gracePeriod time.Duration) (dto.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (dto.APIKey, error)
+	VerifyAPIKey(ctx context.Context, key string) (entity.APIKey, error)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+)
+
+type AuditInterface interface {
+	RecordAudit(record entity.AuditRecord)
+	GetAuditRecords(ctx context.Context, filter entity.AuditFilter) (dto.AuditList, error)
+}
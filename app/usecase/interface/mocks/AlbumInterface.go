@@ -43,9 +43,27 @@ func (_m *AlbumInterface) CreateAlbum(ctx context.Context, album entity.Album) (
 	return r0, r1
 }
 
-// GetAlbumByID provides a mock function with given fields: ctx, id
-func (_m *AlbumInterface) GetAlbumByID(ctx context.Context, id string) (dto.Album, error) {
-	ret := _m.Called(ctx, id)
+// DeleteAlbum provides a mock function with given fields: ctx, id, version
+func (_m *AlbumInterface) DeleteAlbum(ctx context.Context, id string, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlbum")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAlbumByID provides a mock function with given fields: ctx, id, opts
+func (_m *AlbumInterface) GetAlbumByID(ctx context.Context, id string, opts entity.AlbumGetOptions) (dto.Album, error) {
+	ret := _m.Called(ctx, id, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAlbumByID")
@@ -53,13 +71,41 @@ func (_m *AlbumInterface) GetAlbumByID(ctx context.Context, id string) (dto.Albu
 
 	var r0 dto.Album
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (dto.Album, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AlbumGetOptions) (dto.Album, error)); ok {
+		return rf(ctx, id, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AlbumGetOptions) dto.Album); ok {
+		r0 = rf(ctx, id, opts)
+	} else {
+		r0 = ret.Get(0).(dto.Album)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.AlbumGetOptions) error); ok {
+		r1 = rf(ctx, id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlbumVersion provides a mock function with given fields: ctx, id
+func (_m *AlbumInterface) GetAlbumVersion(ctx context.Context, id string) (dto.AlbumVersion, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumVersion")
+	}
+
+	var r0 dto.AlbumVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (dto.AlbumVersion, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) dto.Album); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) dto.AlbumVersion); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(dto.Album)
+		r0 = ret.Get(0).(dto.AlbumVersion)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -71,29 +117,57 @@ func (_m *AlbumInterface) GetAlbumByID(ctx context.Context, id string) (dto.Albu
 	return r0, r1
 }
 
-// GetAllAlbums provides a mock function with given fields: ctx
-func (_m *AlbumInterface) GetAllAlbums(ctx context.Context) ([]dto.Album, error) {
-	ret := _m.Called(ctx)
+// GetAlbumsByIDs provides a mock function with given fields: ctx, ids
+func (_m *AlbumInterface) GetAlbumsByIDs(ctx context.Context, ids []string) (map[string]dto.Album, error) {
+	ret := _m.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllAlbums")
+		panic("no return value specified for GetAlbumsByIDs")
 	}
 
-	var r0 []dto.Album
+	var r0 map[string]dto.Album
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]dto.Album, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]dto.Album, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []dto.Album); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]dto.Album); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dto.Album)
+			r0 = ret.Get(0).(map[string]dto.Album)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllAlbums provides a mock function with given fields: ctx, opts
+func (_m *AlbumInterface) GetAllAlbums(ctx context.Context, opts entity.AlbumListOptions) (dto.AlbumList, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAlbums")
+	}
+
+	var r0 dto.AlbumList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumListOptions) (dto.AlbumList, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumListOptions) dto.AlbumList); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		r0 = ret.Get(0).(dto.AlbumList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlbumListOptions) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -131,6 +205,204 @@ func (_m *AlbumInterface) GetFromThirdPartyAPI(ctx context.Context) ([]dto.Post,
 	return r0, r1
 }
 
+// ImportAlbums provides a mock function with given fields: ctx, rows, opts
+func (_m *AlbumInterface) ImportAlbums(ctx context.Context, rows entity.AlbumImportReader, opts entity.AlbumImportOptions) (dto.AlbumImportReport, error) {
+	ret := _m.Called(ctx, rows, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportAlbums")
+	}
+
+	var r0 dto.AlbumImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumImportReader, entity.AlbumImportOptions) (dto.AlbumImportReport, error)); ok {
+		return rf(ctx, rows, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumImportReader, entity.AlbumImportOptions) dto.AlbumImportReport); ok {
+		r0 = rf(ctx, rows, opts)
+	} else {
+		r0 = ret.Get(0).(dto.AlbumImportReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlbumImportReader, entity.AlbumImportOptions) error); ok {
+		r1 = rf(ctx, rows, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchAlbum provides a mock function with given fields: ctx, id, patch, version
+func (_m *AlbumInterface) PatchAlbum(ctx context.Context, id string, patch entity.AlbumPatch, version int64) (dto.Album, error) {
+	ret := _m.Called(ctx, id, patch, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchAlbum")
+	}
+
+	var r0 dto.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AlbumPatch, int64) (dto.Album, error)); ok {
+		return rf(ctx, id, patch, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AlbumPatch, int64) dto.Album); ok {
+		r0 = rf(ctx, id, patch, version)
+	} else {
+		r0 = ret.Get(0).(dto.Album)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.AlbumPatch, int64) error); ok {
+		r1 = rf(ctx, id, patch, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeAlbums provides a mock function with given fields: ctx
+func (_m *AlbumInterface) PurgeAlbums(ctx context.Context) (dto.AlbumPurgeReport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeAlbums")
+	}
+
+	var r0 dto.AlbumPurgeReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (dto.AlbumPurgeReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) dto.AlbumPurgeReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(dto.AlbumPurgeReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreAlbum provides a mock function with given fields: ctx, id
+func (_m *AlbumInterface) RestoreAlbum(ctx context.Context, id string) (dto.Album, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAlbum")
+	}
+
+	var r0 dto.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (dto.Album, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) dto.Album); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dto.Album)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchAlbums provides a mock function with given fields: ctx, opts
+func (_m *AlbumInterface) SearchAlbums(ctx context.Context, opts entity.AlbumSearchOptions) (dto.AlbumSearchResults, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAlbums")
+	}
+
+	var r0 dto.AlbumSearchResults
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumSearchOptions) (dto.AlbumSearchResults, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumSearchOptions) dto.AlbumSearchResults); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		r0 = ret.Get(0).(dto.AlbumSearchResults)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlbumSearchOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeAlbumEvents provides a mock function with given fields: ctx, lastEventID
+func (_m *AlbumInterface) SubscribeAlbumEvents(ctx context.Context, lastEventID string) (<-chan dto.AlbumEvent, error) {
+	ret := _m.Called(ctx, lastEventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeAlbumEvents")
+	}
+
+	var r0 <-chan dto.AlbumEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan dto.AlbumEvent, error)); ok {
+		return rf(ctx, lastEventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan dto.AlbumEvent); ok {
+		r0 = rf(ctx, lastEventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan dto.AlbumEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, lastEventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAlbum provides a mock function with given fields: ctx, album
+func (_m *AlbumInterface) UpdateAlbum(ctx context.Context, album entity.Album) (dto.Album, error) {
+	ret := _m.Called(ctx, album)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAlbum")
+	}
+
+	var r0 dto.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Album) (dto.Album, error)); ok {
+		return rf(ctx, album)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Album) dto.Album); ok {
+		r0 = rf(ctx, album)
+	} else {
+		r0 = ret.Get(0).(dto.Album)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Album) error); ok {
+		r1 = rf(ctx, album)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAlbumInterface creates a new instance of AlbumInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAlbumInterface(t interface {
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	dto "boilerplate/app/domain/dto"
+	entity "boilerplate/app/domain/entity"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
@@ -14,9 +15,9 @@ type GetAlbumInterface struct {
 	mock.Mock
 }
 
-// GetAlbumByID provides a mock function with given fields: ctx, id
-func (_m *GetAlbumInterface) GetAlbumByID(ctx context.Context, id string) (dto.Album, error) {
-	ret := _m.Called(ctx, id)
+// GetAlbumByID provides a mock function with given fields: ctx, id, opts
+func (_m *GetAlbumInterface) GetAlbumByID(ctx context.Context, id string, opts entity.AlbumGetOptions) (dto.Album, error) {
+	ret := _m.Called(ctx, id, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAlbumByID")
@@ -24,13 +25,41 @@ func (_m *GetAlbumInterface) GetAlbumByID(ctx context.Context, id string) (dto.A
 
 	var r0 dto.Album
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (dto.Album, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AlbumGetOptions) (dto.Album, error)); ok {
+		return rf(ctx, id, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AlbumGetOptions) dto.Album); ok {
+		r0 = rf(ctx, id, opts)
+	} else {
+		r0 = ret.Get(0).(dto.Album)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.AlbumGetOptions) error); ok {
+		r1 = rf(ctx, id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlbumVersion provides a mock function with given fields: ctx, id
+func (_m *GetAlbumInterface) GetAlbumVersion(ctx context.Context, id string) (dto.AlbumVersion, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumVersion")
+	}
+
+	var r0 dto.AlbumVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (dto.AlbumVersion, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) dto.Album); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) dto.AlbumVersion); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(dto.Album)
+		r0 = ret.Get(0).(dto.AlbumVersion)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -42,29 +71,57 @@ func (_m *GetAlbumInterface) GetAlbumByID(ctx context.Context, id string) (dto.A
 	return r0, r1
 }
 
-// GetAllAlbums provides a mock function with given fields: ctx
-func (_m *GetAlbumInterface) GetAllAlbums(ctx context.Context) ([]dto.Album, error) {
-	ret := _m.Called(ctx)
+// GetAlbumsByIDs provides a mock function with given fields: ctx, ids
+func (_m *GetAlbumInterface) GetAlbumsByIDs(ctx context.Context, ids []string) (map[string]dto.Album, error) {
+	ret := _m.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllAlbums")
+		panic("no return value specified for GetAlbumsByIDs")
 	}
 
-	var r0 []dto.Album
+	var r0 map[string]dto.Album
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]dto.Album, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]dto.Album, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []dto.Album); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]dto.Album); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dto.Album)
+			r0 = ret.Get(0).(map[string]dto.Album)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllAlbums provides a mock function with given fields: ctx, opts
+func (_m *GetAlbumInterface) GetAllAlbums(ctx context.Context, opts entity.AlbumListOptions) (dto.AlbumList, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAlbums")
+	}
+
+	var r0 dto.AlbumList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumListOptions) (dto.AlbumList, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlbumListOptions) dto.AlbumList); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		r0 = ret.Get(0).(dto.AlbumList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlbumListOptions) error); ok {
+		r1 = rf(ctx, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
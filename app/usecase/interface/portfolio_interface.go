@@ -7,20 +7,54 @@ import (
 )
 
 type GetAlbumInterface interface {
-	GetAllAlbums(ctx context.Context) ([]dto.Album, error)
-	GetAlbumByID(ctx context.Context, id string) (dto.Album, error)
+	GetAllAlbums(ctx context.Context, opts entity.AlbumListOptions) (dto.AlbumList, error)
+	GetAlbumByID(ctx context.Context, id string, opts entity.AlbumGetOptions) (dto.Album, error)
+	GetAlbumsByIDs(ctx context.Context, ids []string) (map[string]dto.Album, error)
+	GetAlbumVersion(ctx context.Context, id string) (dto.AlbumVersion, error)
+}
+
+type SearchAlbumInterface interface {
+	SearchAlbums(ctx context.Context, opts entity.AlbumSearchOptions) (dto.AlbumSearchResults, error)
 }
 
 type CreateAlbumInterface interface {
 	CreateAlbum(ctx context.Context, album entity.Album) (string, error)
 }
 
+type ImportAlbumInterface interface {
+	ImportAlbums(ctx context.Context, rows entity.AlbumImportReader, opts entity.AlbumImportOptions) (dto.AlbumImportReport, error)
+}
+
+type UpdateAlbumInterface interface {
+	UpdateAlbum(ctx context.Context, album entity.Album) (dto.Album, error)
+	PatchAlbum(ctx context.Context, id string, patch entity.AlbumPatch, version int64) (dto.Album, error)
+}
+
+type DeleteAlbumInterface interface {
+	DeleteAlbum(ctx context.Context, id string, version int64) error
+}
+
+type TrashAlbumInterface interface {
+	RestoreAlbum(ctx context.Context, id string) (dto.Album, error)
+	PurgeAlbums(ctx context.Context) (dto.AlbumPurgeReport, error)
+}
+
+type AlbumEventInterface interface {
+	SubscribeAlbumEvents(ctx context.Context, lastEventID string) (<-chan dto.AlbumEvent, error)
+}
+
 type GetJSONPostInterface interface {
 	GetFromThirdPartyAPI(ctx context.Context) ([]dto.Post, error)
 }
 
 type AlbumInterface interface {
 	GetAlbumInterface
+	SearchAlbumInterface
 	CreateAlbumInterface
+	ImportAlbumInterface
+	UpdateAlbumInterface
+	DeleteAlbumInterface
+	TrashAlbumInterface
+	AlbumEventInterface
 	GetJSONPostInterface
 }
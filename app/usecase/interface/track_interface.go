@@ -0,0 +1,15 @@
+package service
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+)
+
+type TrackInterface interface {
+	GetTracks(ctx context.Context, albumID string) ([]dto.Track, error)
+	GetTrackByID(ctx context.Context, albumID string, trackID string) (dto.Track, error)
+	CreateTrack(ctx context.Context, track entity.Track) (string, error)
+	UpdateTrack(ctx context.Context, track entity.Track) (dto.Track, error)
+	DeleteTrack(ctx context.Context, albumID string, trackID string) error
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+)
+
+type WebhookInterface interface {
+	GetWebhooks(ctx context.Context) ([]dto.Webhook, error)
+	GetWebhookByID(ctx context.Context, id string) (dto.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook entity.Webhook) (dto.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook entity.Webhook) (dto.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]dto.WebhookDelivery, error)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// auditBefore records the album in the audit change of the call, when it is audited, right before
+// one of its tracks is written
+func (s *Service) auditBefore(ctx context.Context, albumID string) {
+	if change := entity.AuditChangeFromContext(ctx); change != nil {
+		change.SetBefore(s.auditSnapshot(ctx, albumID))
+	}
+}
+
+// auditAfter records the album in the audit change of the call, when it is audited, right after
+// one of its tracks was written
+func (s *Service) auditAfter(ctx context.Context, albumID string) {
+	if change := entity.AuditChangeFromContext(ctx); change != nil {
+		change.SetAfter(s.auditSnapshot(ctx, albumID))
+	}
+}
+
+// auditSnapshot reads an album and its tracks from the repositories, or returns nil when the album
+// does not exist
+func (s *Service) auditSnapshot(ctx context.Context, albumID string) json.RawMessage {
+	album, err := s.albumRepo.GetAlbumByID(ctx, albumID)
+	if err != nil {
+		return nil
+	}
+	tracks, err := s.trackRepo.GetTracksByAlbumID(ctx, albumID)
+	if err != nil {
+		fmt.Printf("Failed to read the tracks of album %s for the audit log: %v\n", albumID, err)
+		return nil
+	}
+	return dto.BuildAlbumSnapshot(album, tracks)
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CreateTrack adds a track to an album, generating its ID when none is given
+func (s *Service) CreateTrack(ctx context.Context, track entity.Track) (string, error) {
+	if err := validateTrack(track); err != nil {
+		return "", err
+	}
+
+	if track.ID == "" {
+		track.ID = entity.TrackID(uuid.NewString())
+	}
+
+	s.auditBefore(ctx, track.AlbumID.String())
+	id, err := s.trackRepo.CreateTrack(ctx, track)
+	if err != nil {
+		if errors.IsAlbumNotFound(err) {
+			return "", errors.ErrAlbumNotFound
+		}
+		return "", fmt.Errorf("service error creating track: %w", err)
+	}
+	s.auditAfter(ctx, track.AlbumID.String())
+	return id, nil
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// DeleteTrack removes a track from an album
+func (s *Service) DeleteTrack(ctx context.Context, albumID string, trackID string) error {
+	s.auditBefore(ctx, albumID)
+	if err := s.trackRepo.DeleteTrack(ctx, albumID, trackID); err != nil {
+		if errors.IsTrackNotFound(err) {
+			return errors.ErrTrackNotFound
+		}
+		return fmt.Errorf("service error deleting track: %w", err)
+	}
+	s.auditAfter(ctx, albumID)
+	return nil
+}
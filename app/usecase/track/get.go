@@ -0,0 +1,41 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// GetTracks lists the tracks of an album, failing with ErrAlbumNotFound for an unknown album
+func (s *Service) GetTracks(ctx context.Context, albumID string) ([]dto.Track, error) {
+	tracks, err := s.trackRepo.GetTracksByAlbumID(ctx, albumID)
+	if err != nil {
+		return []dto.Track{}, fmt.Errorf("service error getting tracks: %w", err)
+	}
+
+	// An empty list is ambiguous: tell an album without tracks apart from a missing album
+	if len(tracks) == 0 {
+		if _, err := s.albumRepo.GetAlbumByID(ctx, albumID); err != nil {
+			if errors.IsAlbumNotFound(err) {
+				return []dto.Track{}, errors.ErrAlbumNotFound
+			}
+			return []dto.Track{}, fmt.Errorf("service error getting album: %w", err)
+		}
+	}
+
+	return dto.BuildTrackDTOs(tracks), nil
+}
+
+// GetTrackByID retrieves a single track of an album
+func (s *Service) GetTrackByID(ctx context.Context, albumID string, trackID string) (dto.Track, error) {
+	track, err := s.trackRepo.GetTrackByID(ctx, albumID, trackID)
+	if err != nil {
+		if errors.IsTrackNotFound(err) {
+			return dto.Track{}, errors.ErrTrackNotFound
+		}
+		return dto.Track{}, fmt.Errorf("service error getting track: %w", err)
+	}
+
+	return dto.BuildTrackDTO(track), nil
+}
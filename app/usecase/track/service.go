@@ -0,0 +1,20 @@
+package services
+
+import (
+	albumsRepositories "boilerplate/app/infrastructure/repositories/interface"
+)
+
+type Service struct {
+	trackRepo albumsRepositories.TrackRepositoryInterface
+	albumRepo albumsRepositories.RepositoryInterface
+}
+
+func NewService(
+	trackRepo albumsRepositories.TrackRepositoryInterface,
+	albumRepo albumsRepositories.RepositoryInterface,
+) *Service {
+	return &Service{
+		trackRepo: trackRepo,
+		albumRepo: albumRepo,
+	}
+}
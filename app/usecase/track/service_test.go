@@ -0,0 +1,109 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	trackservice "boilerplate/app/usecase/track"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_GetTracks(t *testing.T) {
+	tests := []struct {
+		name          string
+		tracks        []entity.Track
+		albumErr      error
+		checkAlbum    bool
+		expected      []dto.Track
+		expectedError error
+	}{
+		{
+			name:     "Album with tracks",
+			tracks:   []entity.Track{{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro"}},
+			expected: []dto.Track{{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro"}},
+		},
+		{
+			name:       "Album without tracks",
+			tracks:     []entity.Track{},
+			checkAlbum: true,
+			expected:   []dto.Track{},
+		},
+		{
+			name:          "Unknown album",
+			tracks:        []entity.Track{},
+			checkAlbum:    true,
+			albumErr:      customerr.ErrAlbumNotFound,
+			expected:      []dto.Track{},
+			expectedError: customerr.ErrAlbumNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trackRepo := mocks.NewTrackRepositoryInterface(t)
+			albumRepo := mocks.NewRepositoryInterface(t)
+			trackRepo.On("GetTracksByAlbumID", mock.Anything, "1").Return(tt.tracks, nil).Once()
+			if tt.checkAlbum {
+				albumRepo.On("GetAlbumByID", mock.Anything, "1").Return(entity.Album{ID: "1"}, tt.albumErr).Once()
+			}
+
+			service := trackservice.NewService(trackRepo, albumRepo)
+
+			result, err := service.GetTracks(context.Background(), "1")
+
+			assert.Equal(t, tt.expected, result)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_CreateTrack(t *testing.T) {
+	t.Run("Generates an ID when none is given", func(t *testing.T) {
+		trackRepo := mocks.NewTrackRepositoryInterface(t)
+		trackRepo.On("CreateTrack", mock.Anything, mock.MatchedBy(func(track entity.Track) bool {
+			return track.ID != "" && track.AlbumID == "1"
+		})).Return("generated", nil).Once()
+
+		service := trackservice.NewService(trackRepo, nil)
+
+		id, err := service.CreateTrack(context.Background(), entity.Track{AlbumID: "1", Position: 1, Title: "Intro"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "generated", id)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		trackRepo := mocks.NewTrackRepositoryInterface(t)
+		trackRepo.On("CreateTrack", mock.Anything, mock.Anything).Return("", errors.New("database error")).Once()
+
+		service := trackservice.NewService(trackRepo, nil)
+
+		_, err := service.CreateTrack(context.Background(), entity.Track{ID: "t1", AlbumID: "1", Position: 1, Title: "Intro"})
+
+		assert.EqualError(t, err, "service error creating track: database error")
+	})
+
+	t.Run("Rejects an invalid track", func(t *testing.T) {
+		service := trackservice.NewService(mocks.NewTrackRepositoryInterface(t), nil)
+
+		_, err := service.CreateTrack(context.Background(), entity.Track{AlbumID: "1", Position: 0, Title: " ", DurationSeconds: -1})
+
+		assert.True(t, customerr.IsInvalidInput(err))
+		assert.Equal(t, []customerr.FieldError{
+			{Field: "title", Code: "required", Message: "must not be blank"},
+			{Field: "position", Code: "range", Message: "must be at least 1"},
+			{Field: "duration_seconds", Code: "range", Message: "must not be negative"},
+		}, customerr.ValidationDetails(err))
+	})
+}
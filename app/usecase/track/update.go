@@ -0,0 +1,27 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// UpdateTrack replaces an existing track and returns the stored result
+func (s *Service) UpdateTrack(ctx context.Context, track entity.Track) (dto.Track, error) {
+	if err := validateTrack(track); err != nil {
+		return dto.Track{}, err
+	}
+
+	s.auditBefore(ctx, track.AlbumID.String())
+	if err := s.trackRepo.UpdateTrack(ctx, track); err != nil {
+		if errors.IsTrackNotFound(err) {
+			return dto.Track{}, errors.ErrTrackNotFound
+		}
+		return dto.Track{}, fmt.Errorf("service error updating track: %w", err)
+	}
+
+	s.auditAfter(ctx, track.AlbumID.String())
+	return dto.BuildTrackDTO(track), nil
+}
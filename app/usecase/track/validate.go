@@ -0,0 +1,22 @@
+package services
+
+import (
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"strings"
+)
+
+// validateTrack applies the domain rules every stored track must satisfy
+func validateTrack(track entity.Track) error {
+	invalid := errors.NewValidationError()
+	if strings.TrimSpace(track.Title) == "" {
+		invalid.Add("title", "required", "must not be blank")
+	}
+	if track.Position < 1 {
+		invalid.Add("position", "range", "must be at least 1")
+	}
+	if track.DurationSeconds < 0 {
+		invalid.Add("duration_seconds", "range", "must not be negative")
+	}
+	return invalid.OrNil()
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// secretSize is the number of random bytes of a generated webhook secret
+const secretSize = 32
+
+// CreateWebhook registers a webhook, generating its ID and, when none is given, its secret. The
+// result is the only one carrying the secret.
+func (s *Service) CreateWebhook(ctx context.Context, webhook entity.Webhook) (dto.Webhook, error) {
+	if err := validateWebhook(webhook); err != nil {
+		return dto.Webhook{}, err
+	}
+
+	id, err := s.newID()
+	if err != nil {
+		return dto.Webhook{}, fmt.Errorf("service error creating webhook: %w", err)
+	}
+	webhook.ID = id
+	webhook.Events = uniqueEvents(webhook.Events)
+	if webhook.Secret == "" {
+		secret := make([]byte, secretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return dto.Webhook{}, fmt.Errorf("service error generating webhook secret: %w", err)
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	if err := s.webhookRepo.CreateWebhook(ctx, webhook); err != nil {
+		return dto.Webhook{}, fmt.Errorf("service error creating webhook: %w", err)
+	}
+
+	created := dto.BuildWebhookDTO(webhook)
+	created.Secret = webhook.Secret
+	return created, nil
+}
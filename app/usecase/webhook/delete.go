@@ -0,0 +1,18 @@
+package services
+
+import (
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// DeleteWebhook removes a webhook and its delivery log; deliveries still queued for it are dropped
+func (s *Service) DeleteWebhook(ctx context.Context, id string) error {
+	if err := s.webhookRepo.DeleteWebhook(ctx, id); err != nil {
+		if errors.IsWebhookNotFound(err) {
+			return errors.ErrWebhookNotFound
+		}
+		return fmt.Errorf("service error deleting webhook: %w", err)
+	}
+	return nil
+}
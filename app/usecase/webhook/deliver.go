@@ -0,0 +1,199 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every delivery
+const (
+	// HeaderDeliveryID is the same for every attempt at delivering an event, so receivers can drop repeats
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderAttempt    = "X-Webhook-Attempt"
+	// HeaderSignature carries "t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<body>">", keyed with the webhook secret
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// deliveryMessage is the queue message asking the worker for one attempt at delivering an event to a
+// webhook. A message without a webhook asks the worker to fan the event out to every subscribed webhook.
+type deliveryMessage struct {
+	DeliveryID string         `json:"delivery_id"`
+	WebhookID  string         `json:"webhook_id"`
+	Attempt    int            `json:"attempt"`
+	Event      dto.AlbumEvent `json:"event"`
+}
+
+// Sign returns the signature header value of a payload sent at timestamp. Receivers recompute the
+// HMAC over "<t>.<body>" with their secret, compare it in constant time and reject old timestamps.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	t := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(payload)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// DispatchAlbumEvent queues a single message for the worker, which then queues the delivery of the
+// album event to every enabled webhook subscribed to its type, so that the change making the event
+// neither reads the webhooks nor waits for one message per webhook.
+func (s *Service) DispatchAlbumEvent(_ context.Context, event dto.AlbumEvent) error {
+	deliveryID, err := s.newID()
+	if err != nil {
+		return fmt.Errorf("service error generating delivery ID: %w", err)
+	}
+	if err := s.enqueue(deliveryMessage{DeliveryID: deliveryID, Event: event}, 0); err != nil {
+		return fmt.Errorf("service error queueing webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// fanOut queues the delivery of the event of a message from DispatchAlbumEvent to every enabled
+// webhook subscribed to its type. Delivery IDs derive from the message's, so a message received
+// again queues deliveries receivers recognise as repeats.
+func (s *Service) fanOut(ctx context.Context, msg deliveryMessage) error {
+	webhooks, err := s.webhookRepo.GetWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("service error getting webhooks: %w", err)
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if !webhook.Enabled || !webhook.Subscribes(msg.Event.Type) {
+			continue
+		}
+		message := deliveryMessage{DeliveryID: msg.DeliveryID + "-" + webhook.ID, WebhookID: webhook.ID, Attempt: 1, Event: msg.Event}
+		if err := s.enqueue(message, 0); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", webhook.ID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("service error queueing webhook deliveries: %w", stderrors.Join(errs...))
+	}
+	return nil
+}
+
+// ProcessMessage fans out an event queued by DispatchAlbumEvent, or makes one attempt at a delivery
+// and logs it. A failed attempt is queued again, with a delay doubling every time, until the
+// attempts run out; the delivery then counts as failed, and the webhook is disabled once enough deliveries in a row have
+// failed. Deliveries to webhooks that were deleted or disabled meanwhile are dropped. An error is
+// returned only when the message should be received again.
+func (s *Service) ProcessMessage(ctx context.Context, message string) error {
+	var msg deliveryMessage
+	if err := json.Unmarshal([]byte(message), &msg); err != nil {
+		log.Printf("Dropping malformed webhook delivery message: %v", err)
+		return nil
+	}
+	if msg.WebhookID == "" {
+		return s.fanOut(ctx, msg)
+	}
+
+	webhook, err := s.webhookRepo.GetWebhookByID(ctx, msg.WebhookID)
+	if err != nil {
+		if errors.IsWebhookNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("service error getting webhook: %w", err)
+	}
+	if !webhook.Enabled {
+		return nil
+	}
+
+	delivery := s.deliver(ctx, webhook, msg)
+	if err := s.webhookRepo.RecordWebhookDelivery(ctx, delivery); err != nil && !errors.IsWebhookNotFound(err) {
+		log.Printf("Failed to log delivery %s to webhook %s: %v", msg.DeliveryID, webhook.ID, err)
+	}
+
+	if delivery.Succeeded {
+		if webhook.FailureCount > 0 {
+			if err := s.webhookRepo.ResetWebhookFailures(ctx, webhook.ID); err != nil {
+				log.Printf("Failed to reset failures of webhook %s: %v", webhook.ID, err)
+			}
+		}
+		return nil
+	}
+
+	if msg.Attempt < s.maxAttempts {
+		delay := s.retryDelay << (msg.Attempt - 1)
+		msg.Attempt++
+		if err := s.enqueue(msg, delay); err != nil {
+			return fmt.Errorf("service error queueing webhook retry: %w", err)
+		}
+		return nil
+	}
+
+	disabled, err := s.webhookRepo.RecordWebhookFailure(ctx, webhook.ID, s.disableAfter)
+	if err != nil {
+		if errors.IsWebhookNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("service error recording webhook failure: %w", err)
+	}
+	if disabled {
+		log.Printf("Disabled webhook %s after %d failed deliveries in a row", webhook.ID, s.disableAfter)
+	}
+	return nil
+}
+
+// deliver posts the event of a delivery message to the webhook, signed with its secret
+func (s *Service) deliver(ctx context.Context, webhook entity.Webhook, msg deliveryMessage) entity.WebhookDelivery {
+	delivery := entity.WebhookDelivery{
+		WebhookID:  webhook.ID,
+		DeliveryID: msg.DeliveryID,
+		EventID:    msg.Event.ID,
+		EventType:  msg.Event.Type,
+		Attempt:    msg.Attempt,
+	}
+	var err error
+	if delivery.ID, err = s.newID(); err != nil {
+		delivery.ID = msg.DeliveryID + "-" + strconv.Itoa(msg.Attempt)
+	}
+
+	payload, err := json.Marshal(msg.Event)
+	if err != nil {
+		delivery.Error = fmt.Sprintf("error encoding event: %v", err)
+		return delivery
+	}
+	headers := map[string]string{
+		HeaderDeliveryID: msg.DeliveryID,
+		HeaderEvent:      msg.Event.Type,
+		HeaderAttempt:    strconv.Itoa(msg.Attempt),
+		HeaderSignature:  Sign(webhook.Secret, time.Now().Unix(), payload),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.deliveryTimeout)
+	defer cancel()
+	start := time.Now()
+	delivery.StatusCode, err = s.sender.PostWebhook(ctx, webhook.URL, headers, payload)
+	delivery.Duration = time.Since(start)
+
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case delivery.StatusCode < 200 || delivery.StatusCode > 299:
+		delivery.Error = fmt.Sprintf("unexpected status code: %d", delivery.StatusCode)
+	default:
+		delivery.Succeeded = true
+	}
+	return delivery
+}
+
+// enqueue sends a delivery message to the worker, to be received after delay
+func (s *Service) enqueue(msg deliveryMessage, delay time.Duration) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error encoding delivery message: %w", err)
+	}
+	return s.queue.SendMessageWithDelay(string(data), delay)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// Bounds of the delivery log page returned by GetWebhookDeliveries
+const (
+	DefaultDeliveryLogLimit = 20
+	MaxDeliveryLogLimit     = 100
+)
+
+// GetWebhooks lists every webhook
+func (s *Service) GetWebhooks(ctx context.Context) ([]dto.Webhook, error) {
+	webhooks, err := s.webhookRepo.GetWebhooks(ctx)
+	if err != nil {
+		return []dto.Webhook{}, fmt.Errorf("service error getting webhooks: %w", err)
+	}
+	return dto.BuildWebhookDTOs(webhooks), nil
+}
+
+// GetWebhookByID retrieves a single webhook
+func (s *Service) GetWebhookByID(ctx context.Context, id string) (dto.Webhook, error) {
+	webhook, err := s.webhookRepo.GetWebhookByID(ctx, id)
+	if err != nil {
+		if errors.IsWebhookNotFound(err) {
+			return dto.Webhook{}, errors.ErrWebhookNotFound
+		}
+		return dto.Webhook{}, fmt.Errorf("service error getting webhook: %w", err)
+	}
+	return dto.BuildWebhookDTO(webhook), nil
+}
+
+// GetWebhookDeliveries lists the latest attempts at delivering events to a webhook, newest first.
+// A limit of 0 picks DefaultDeliveryLogLimit.
+func (s *Service) GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]dto.WebhookDelivery, error) {
+	if limit == 0 {
+		limit = DefaultDeliveryLogLimit
+	}
+	if limit < 1 || limit > MaxDeliveryLogLimit {
+		return []dto.WebhookDelivery{}, errors.NewValidationError(errors.FieldError{
+			Field: "limit", Code: "range", Message: fmt.Sprintf("must be between 1 and %d", MaxDeliveryLogLimit),
+		})
+	}
+
+	if _, err := s.GetWebhookByID(ctx, webhookID); err != nil {
+		return []dto.WebhookDelivery{}, err
+	}
+	deliveries, err := s.webhookRepo.GetWebhookDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return []dto.WebhookDelivery{}, fmt.Errorf("service error getting webhook deliveries: %w", err)
+	}
+	return dto.BuildWebhookDeliveryDTOs(deliveries), nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"time"
+
+	httpClientInterface "boilerplate/app/infrastructure/httpclient/interface"
+	"boilerplate/app/infrastructure/idgen"
+	webhookRepositories "boilerplate/app/infrastructure/repositories/interface"
+)
+
+// Defaults of webhook deliveries unless configured otherwise
+const (
+	// DefaultMaxAttempts is how many times an event is posted to a webhook before the delivery fails
+	DefaultMaxAttempts = 6
+	// DefaultRetryDelay is the wait before the second attempt; it doubles with every attempt after that
+	DefaultRetryDelay = 30 * time.Second
+	// DefaultDisableAfter is how many deliveries in a row may fail before the webhook is disabled
+	DefaultDisableAfter = 5
+	// DefaultDeliveryTimeout bounds every attempt, the response included
+	DefaultDeliveryTimeout = 10 * time.Second
+)
+
+// DeliveryQueue carries webhook deliveries to the worker. It is satisfied by *queue.Queue.
+type DeliveryQueue interface {
+	SendMessageWithDelay(message string, delay time.Duration) error
+}
+
+type Service struct {
+	webhookRepo webhookRepositories.WebhookRepositoryInterface
+	queue       DeliveryQueue
+	sender      httpClientInterface.HttpClientWebhookInterface
+
+	// Generates the IDs of webhooks, deliveries and delivery attempts
+	newID idgen.Generator
+
+	maxAttempts     int
+	retryDelay      time.Duration
+	disableAfter    int
+	deliveryTimeout time.Duration
+}
+
+// Option customises optional Service behaviour
+type Option func(*Service)
+
+// WithIDGenerator sets how the IDs of webhooks and deliveries are generated
+func WithIDGenerator(generator idgen.Generator) Option {
+	return func(s *Service) {
+		s.newID = generator
+	}
+}
+
+// WithMaxAttempts sets how many times an event is posted to a webhook before the delivery fails
+func WithMaxAttempts(attempts int) Option {
+	return func(s *Service) {
+		if attempts > 0 {
+			s.maxAttempts = attempts
+		}
+	}
+}
+
+// WithRetryDelay sets the wait before the second attempt at a delivery, doubled for every attempt after that
+func WithRetryDelay(delay time.Duration) Option {
+	return func(s *Service) {
+		if delay > 0 {
+			s.retryDelay = delay
+		}
+	}
+}
+
+// WithDisableAfter sets how many deliveries in a row may fail before the webhook is disabled
+func WithDisableAfter(failures int) Option {
+	return func(s *Service) {
+		if failures > 0 {
+			s.disableAfter = failures
+		}
+	}
+}
+
+// WithDeliveryTimeout bounds every attempt at a delivery
+func WithDeliveryTimeout(timeout time.Duration) Option {
+	return func(s *Service) {
+		if timeout > 0 {
+			s.deliveryTimeout = timeout
+		}
+	}
+}
+
+func NewService(
+	webhookRepo webhookRepositories.WebhookRepositoryInterface,
+	queue DeliveryQueue,
+	sender httpClientInterface.HttpClientWebhookInterface,
+	opts ...Option,
+) *Service {
+	s := &Service{
+		webhookRepo:     webhookRepo,
+		queue:           queue,
+		sender:          sender,
+		newID:           idgen.NewUUIDv7,
+		maxAttempts:     DefaultMaxAttempts,
+		retryDelay:      DefaultRetryDelay,
+		disableAfter:    DefaultDisableAfter,
+		deliveryTimeout: DefaultDeliveryTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
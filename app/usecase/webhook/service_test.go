@@ -0,0 +1,293 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	customerr "boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/repositories/interface/mocks"
+	webhookservice "boilerplate/app/usecase/webhook"
+)
+
+// queuedMessage is a message sent to fakeQueue
+type queuedMessage struct {
+	body  string
+	delay time.Duration
+}
+
+type fakeQueue struct {
+	messages []queuedMessage
+	err      error
+}
+
+func (q *fakeQueue) SendMessageWithDelay(message string, delay time.Duration) error {
+	q.messages = append(q.messages, queuedMessage{body: message, delay: delay})
+	return q.err
+}
+
+// postedWebhook is a request made through fakeSender
+type postedWebhook struct {
+	url     string
+	headers map[string]string
+	payload []byte
+}
+
+type fakeSender struct {
+	posted []postedWebhook
+	status int
+	err    error
+}
+
+func (f *fakeSender) PostWebhook(_ context.Context, url string, headers map[string]string, payload []byte) (int, error) {
+	f.posted = append(f.posted, postedWebhook{url: url, headers: headers, payload: payload})
+	return f.status, f.err
+}
+
+// sequentialIDs returns a generator of the IDs "id-1", "id-2", ...
+func sequentialIDs() func() (string, error) {
+	n := 0
+	return func() (string, error) {
+		n++
+		return "id-" + strconv.Itoa(n), nil
+	}
+}
+
+func TestService_CreateWebhook(t *testing.T) {
+	t.Run("Generates the ID and the secret", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(webhook entity.Webhook) bool {
+			return webhook.ID == "id-1" && len(webhook.Secret) == 64 && len(webhook.Events) == 1
+		})).Return(nil).Once()
+
+		service := webhookservice.NewService(repo, nil, nil, webhookservice.WithIDGenerator(sequentialIDs()))
+
+		created, err := service.CreateWebhook(context.Background(), entity.Webhook{
+			URL:     "https://partner.example/hooks",
+			Events:  []string{"album.created", "album.created"},
+			Enabled: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "id-1", created.ID)
+		assert.Len(t, created.Secret, 64)
+		assert.Equal(t, []string{"album.created"}, created.Events)
+	})
+
+	invalidURLs := []struct {
+		name         string
+		url          string
+		expectedCode string
+	}{
+		{name: "Not http", url: "ftp://partner.example", expectedCode: "format"},
+		{name: "Loopback", url: "http://127.0.0.1:8080/hooks", expectedCode: "host"},
+		{name: "Localhost", url: "http://api.localhost/hooks", expectedCode: "host"},
+		{name: "Private", url: "https://10.0.0.5/hooks", expectedCode: "host"},
+		{name: "Metadata service", url: "http://169.254.169.254/latest/meta-data", expectedCode: "host"},
+		{name: "Metadata host name", url: "http://metadata.google.internal/computeMetadata/v1", expectedCode: "host"},
+		{name: "IPv6 loopback", url: "http://[::1]/hooks", expectedCode: "host"},
+		{name: "IPv4-mapped private", url: "http://[::ffff:192.168.1.1]/hooks", expectedCode: "host"},
+	}
+	for _, tt := range invalidURLs {
+		t.Run(tt.name, func(t *testing.T) {
+			service := webhookservice.NewService(mocks.NewWebhookRepositoryInterface(t), nil, nil)
+
+			_, err := service.CreateWebhook(context.Background(), entity.Webhook{URL: tt.url})
+
+			var validationErr *customerr.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "url", validationErr.Fields[0].Field)
+			assert.Equal(t, tt.expectedCode, validationErr.Fields[0].Code)
+		})
+	}
+}
+
+func TestService_UpdateWebhook(t *testing.T) {
+	disabledAt := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	existing := entity.Webhook{ID: "w1", URL: "https://partner.example/hooks", Secret: "0123456789abcdef", FailureCount: 5, DisabledAt: &disabledAt}
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		expectedCount int
+		expectedAt    *time.Time
+	}{
+		{name: "Enabling clears the failures", enabled: true, expectedCount: 0},
+		{name: "Staying disabled keeps them", enabled: false, expectedCount: 5, expectedAt: &disabledAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewWebhookRepositoryInterface(t)
+			repo.On("GetWebhookByID", mock.Anything, "w1").Return(existing, nil).Once()
+			repo.On("UpdateWebhook", mock.Anything, entity.Webhook{
+				ID: "w1", URL: "https://partner.example/v2/hooks", Secret: "0123456789abcdef",
+				Enabled: tt.enabled, FailureCount: tt.expectedCount, DisabledAt: tt.expectedAt,
+			}).Return(nil).Once()
+
+			service := webhookservice.NewService(repo, nil, nil)
+
+			updated, err := service.UpdateWebhook(context.Background(), entity.Webhook{ID: "w1", URL: "https://partner.example/v2/hooks", Enabled: tt.enabled})
+			assert.NoError(t, err)
+			assert.Empty(t, updated.Secret)
+			assert.Equal(t, tt.expectedCount, updated.FailureCount)
+		})
+	}
+}
+
+func TestService_GetWebhookDeliveries(t *testing.T) {
+	t.Run("Unknown webhook", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("GetWebhookByID", mock.Anything, "w9").Return(entity.Webhook{}, customerr.ErrWebhookNotFound).Once()
+
+		service := webhookservice.NewService(repo, nil, nil)
+
+		_, err := service.GetWebhookDeliveries(context.Background(), "w9", 0)
+		assert.ErrorIs(t, err, customerr.ErrWebhookNotFound)
+	})
+
+	t.Run("Limit out of range", func(t *testing.T) {
+		service := webhookservice.NewService(mocks.NewWebhookRepositoryInterface(t), nil, nil)
+
+		_, err := service.GetWebhookDeliveries(context.Background(), "w1", 500)
+		assert.True(t, customerr.IsInvalidInput(err))
+	})
+}
+
+func TestService_DispatchAlbumEvent(t *testing.T) {
+	// The webhooks are read by the worker, not while dispatching
+	repo := mocks.NewWebhookRepositoryInterface(t)
+	queue := &fakeQueue{}
+
+	service := webhookservice.NewService(repo, queue, nil, webhookservice.WithIDGenerator(sequentialIDs()))
+
+	err := service.DispatchAlbumEvent(context.Background(), dto.AlbumEvent{ID: "1-0", Type: "album.created", AlbumID: "1"})
+	require.NoError(t, err)
+
+	require.Len(t, queue.messages, 1)
+	assert.JSONEq(t, `{"delivery_id":"id-1","webhook_id":"","attempt":0,"event":{"id":"1-0","type":"album.created","album_id":"1","occurred_at":"0001-01-01T00:00:00Z"}}`, queue.messages[0].body)
+	assert.Zero(t, queue.messages[0].delay)
+
+	// The worker then queues one delivery per subscribed webhook
+	repo.On("GetWebhooks", mock.Anything).Return([]entity.Webhook{
+		{ID: "all", Enabled: true},
+		{ID: "deletes", Enabled: true, Events: []string{"album.deleted"}},
+		{ID: "creates", Enabled: true, Events: []string{"album.created"}},
+		{ID: "disabled", Enabled: false},
+	}, nil).Once()
+	require.NoError(t, service.ProcessMessage(context.Background(), queue.messages[0].body))
+
+	require.Len(t, queue.messages, 3)
+	assert.JSONEq(t, `{"delivery_id":"id-1-all","webhook_id":"all","attempt":1,"event":{"id":"1-0","type":"album.created","album_id":"1","occurred_at":"0001-01-01T00:00:00Z"}}`, queue.messages[1].body)
+	assert.Contains(t, queue.messages[2].body, `"webhook_id":"creates"`)
+	assert.Zero(t, queue.messages[1].delay)
+}
+
+func TestService_ProcessMessage(t *testing.T) {
+	webhook := entity.Webhook{ID: "w1", URL: "https://partner.example/hooks", Secret: "0123456789abcdef", Enabled: true, FailureCount: 2}
+	message := func(attempt int) string {
+		data, _ := json.Marshal(map[string]interface{}{
+			"delivery_id": "d1",
+			"webhook_id":  "w1",
+			"attempt":     attempt,
+			"event":       dto.AlbumEvent{ID: "1-0", Type: "album.deleted", AlbumID: "1"},
+		})
+		return string(data)
+	}
+
+	t.Run("Delivers a signed event", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("GetWebhookByID", mock.Anything, "w1").Return(webhook, nil).Once()
+		repo.On("RecordWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+			return delivery.Succeeded && delivery.StatusCode == 204 && delivery.DeliveryID == "d1" && delivery.EventID == "1-0" && delivery.Attempt == 1
+		})).Return(nil).Once()
+		repo.On("ResetWebhookFailures", mock.Anything, "w1").Return(nil).Once()
+		sender := &fakeSender{status: 204}
+
+		service := webhookservice.NewService(repo, &fakeQueue{}, sender)
+
+		require.NoError(t, service.ProcessMessage(context.Background(), message(1)))
+
+		require.Len(t, sender.posted, 1)
+		posted := sender.posted[0]
+		assert.Equal(t, webhook.URL, posted.url)
+		assert.Equal(t, "d1", posted.headers[webhookservice.HeaderDeliveryID])
+		assert.Equal(t, "album.deleted", posted.headers[webhookservice.HeaderEvent])
+
+		var timestamp int64
+		_, err := fmt.Sscanf(posted.headers[webhookservice.HeaderSignature], "t=%d,", &timestamp)
+		require.NoError(t, err)
+		assert.Equal(t, webhookservice.Sign(webhook.Secret, timestamp, posted.payload), posted.headers[webhookservice.HeaderSignature])
+		assert.JSONEq(t, `{"id":"1-0","type":"album.deleted","album_id":"1","occurred_at":"0001-01-01T00:00:00Z"}`, string(posted.payload))
+	})
+
+	t.Run("Retries a failed attempt later", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("GetWebhookByID", mock.Anything, "w1").Return(webhook, nil).Once()
+		repo.On("RecordWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+			return !delivery.Succeeded && delivery.Error == "unexpected status code: 503"
+		})).Return(nil).Once()
+		queue := &fakeQueue{}
+
+		service := webhookservice.NewService(repo, queue, &fakeSender{status: 503}, webhookservice.WithRetryDelay(10*time.Second))
+
+		require.NoError(t, service.ProcessMessage(context.Background(), message(3)))
+
+		require.Len(t, queue.messages, 1)
+		assert.Equal(t, 40*time.Second, queue.messages[0].delay)
+		assert.Contains(t, queue.messages[0].body, `"attempt":4`)
+	})
+
+	t.Run("Counts the failure once the attempts run out", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("GetWebhookByID", mock.Anything, "w1").Return(webhook, nil).Once()
+		repo.On("RecordWebhookDelivery", mock.Anything, mock.Anything).Return(nil).Once()
+		repo.On("RecordWebhookFailure", mock.Anything, "w1", 3).Return(true, nil).Once()
+		queue := &fakeQueue{}
+
+		service := webhookservice.NewService(repo, queue, &fakeSender{err: errors.New("connection refused")},
+			webhookservice.WithMaxAttempts(2), webhookservice.WithDisableAfter(3))
+
+		require.NoError(t, service.ProcessMessage(context.Background(), message(2)))
+		assert.Empty(t, queue.messages)
+	})
+
+	t.Run("Drops deliveries to deleted webhooks", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("GetWebhookByID", mock.Anything, "w1").Return(entity.Webhook{}, customerr.ErrWebhookNotFound).Once()
+		sender := &fakeSender{}
+
+		service := webhookservice.NewService(repo, &fakeQueue{}, sender)
+
+		require.NoError(t, service.ProcessMessage(context.Background(), message(1)))
+		assert.Empty(t, sender.posted)
+	})
+
+	t.Run("Receives the fan-out again when the webhooks cannot be read", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("GetWebhooks", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		service := webhookservice.NewService(repo, &fakeQueue{}, &fakeSender{})
+
+		assert.Error(t, service.ProcessMessage(context.Background(), `{"delivery_id":"d1","event":{"type":"album.created"}}`))
+	})
+
+	t.Run("Receives the message again when the retry cannot be queued", func(t *testing.T) {
+		repo := mocks.NewWebhookRepositoryInterface(t)
+		repo.On("GetWebhookByID", mock.Anything, "w1").Return(webhook, nil).Once()
+		repo.On("RecordWebhookDelivery", mock.Anything, mock.Anything).Return(nil).Once()
+
+		service := webhookservice.NewService(repo, &fakeQueue{err: errors.New("queue unavailable")}, &fakeSender{status: 500})
+
+		assert.Error(t, service.ProcessMessage(context.Background(), message(1)))
+	})
+}
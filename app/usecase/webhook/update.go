@@ -0,0 +1,46 @@
+package services
+
+import (
+	"boilerplate/app/domain/dto"
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"context"
+	"fmt"
+)
+
+// UpdateWebhook replaces the URL, events and enabled flag of a webhook, and its secret when one is
+// given. Enabling a webhook that was disabled clears its failures.
+func (s *Service) UpdateWebhook(ctx context.Context, webhook entity.Webhook) (dto.Webhook, error) {
+	if err := validateWebhook(webhook); err != nil {
+		return dto.Webhook{}, err
+	}
+
+	existing, err := s.webhookRepo.GetWebhookByID(ctx, webhook.ID)
+	if err != nil {
+		if errors.IsWebhookNotFound(err) {
+			return dto.Webhook{}, errors.ErrWebhookNotFound
+		}
+		return dto.Webhook{}, fmt.Errorf("service error getting webhook: %w", err)
+	}
+
+	webhook.Events = uniqueEvents(webhook.Events)
+	if webhook.Secret == "" {
+		webhook.Secret = existing.Secret
+	}
+	webhook.FailureCount = existing.FailureCount
+	webhook.DisabledAt = existing.DisabledAt
+	if webhook.Enabled && !existing.Enabled {
+		webhook.FailureCount = 0
+		webhook.DisabledAt = nil
+	}
+	webhook.CreatedAt = existing.CreatedAt
+
+	if err := s.webhookRepo.UpdateWebhook(ctx, webhook); err != nil {
+		if errors.IsWebhookNotFound(err) {
+			return dto.Webhook{}, errors.ErrWebhookNotFound
+		}
+		return dto.Webhook{}, fmt.Errorf("service error updating webhook: %w", err)
+	}
+
+	return dto.BuildWebhookDTO(webhook), nil
+}
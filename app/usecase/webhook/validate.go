@@ -0,0 +1,59 @@
+package services
+
+import (
+	"boilerplate/app/domain/entity"
+	"boilerplate/app/domain/errors"
+	"boilerplate/app/infrastructure/httpclient"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// nonPublicHosts are host names that always lead to the API's own network
+var nonPublicHosts = map[string]bool{
+	"localhost":                true,
+	"metadata.google.internal": true,
+}
+
+// validateWebhook applies the domain rules every stored webhook must satisfy
+func validateWebhook(webhook entity.Webhook) error {
+	invalid := errors.NewValidationError()
+	if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		invalid.Add("url", "format", "must be an absolute http or https URL")
+	} else if !isPublicHost(u.Hostname()) {
+		invalid.Add("url", "host", "must not point to a loopback, private or link-local address")
+	}
+	for _, eventType := range webhook.Events {
+		if !entity.IsValidWebhookEventType(eventType) {
+			invalid.Add("events", "enum", "must be one of: "+strings.Join(entity.WebhookEventTypes, ", "))
+			break
+		}
+	}
+	return invalid.OrNil()
+}
+
+// isPublicHost tells whether a webhook host may be on the internet. Host names are resolved only
+// when delivering, where the client refuses to connect to addresses that are not public.
+func isPublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if nonPublicHosts[host] || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return httpclient.IsPublicAddr(ip)
+	}
+	return true
+}
+
+// uniqueEvents drops repeated event types, keeping the first occurrence of each
+func uniqueEvents(events []string) []string {
+	var unique []string
+	seen := make(map[string]bool, len(events))
+	for _, eventType := range events {
+		if !seen[eventType] {
+			seen[eventType] = true
+			unique = append(unique, eventType)
+		}
+	}
+	return unique
+}
@@ -1,19 +1,38 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 
+	"boilerplate/app/infrastructure/auth"
 	"boilerplate/app/infrastructure/config"
 	"boilerplate/app/infrastructure/httpclient"
 	"boilerplate/app/infrastructure/httpclient/jsonpost"
+	"boilerplate/app/infrastructure/httpclient/webhook"
+	"boilerplate/app/infrastructure/idgen"
 	"boilerplate/app/infrastructure/redis"
 	mysqlRepo "boilerplate/app/infrastructure/repositories/mysql"
+	sqsclient "boilerplate/app/infrastructure/sqs/client"
+	"boilerplate/app/infrastructure/sqs/queue"
+	"boilerplate/app/presentation/graphql"
+	grpcalbum "boilerplate/app/presentation/grpc/album"
+	grpcserver "boilerplate/app/presentation/grpc/server"
 	restcontroller "boilerplate/app/presentation/rest/album"
+	"boilerplate/app/presentation/rest/openapi"
 	"boilerplate/app/presentation/rest/router"
 	albumservice "boilerplate/app/usecase/album"
+	apikeyservice "boilerplate/app/usecase/apikey"
+	auditservice "boilerplate/app/usecase/audit"
+	trackservice "boilerplate/app/usecase/track"
+	webhookservice "boilerplate/app/usecase/webhook"
 )
 
 func main() {
@@ -22,9 +41,14 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Load the SQS settings, webhook deliveries being queued for the worker
+	workerConfig, err := config.LoadWorkerConfig()
+	if err != nil {
+		log.Fatalf("Failed to load worker configuration: %v", err)
+	}
+
 	// Open MySQL connection
-	connectionString := fmt.Sprintf("%s:%s@tcp(%s:3306)/%s", config.AppCfg.MySQLUser, config.AppCfg.MySQLPassword, config.AppCfg.MySQLHost, config.AppCfg.MySQLDatabase)
-	db, err := mysqlRepo.OpenMySQLConnection(connectionString)
+	db, err := mysqlRepo.OpenMySQLConnection(config.AppCfg.MySQLConnectionString())
 	if err != nil {
 		log.Fatalf("Failed to open MySQL connection: %v", err)
 	}
@@ -36,10 +60,32 @@ func main() {
 	}
 
 	// Initialize Repository layer
-	albumRepo, err := mysqlRepo.NewAlbumRepository(db)
+	albumRepo, err := mysqlRepo.NewAlbumRepository(db, mysqlRepo.WithSearchMode(mysqlRepo.SearchMode(config.AppCfg.AlbumSearchMode)))
 	if err != nil {
 		log.Fatalf("Failed to initialize repository: %v", err)
 	}
+	trackRepo, err := mysqlRepo.NewTrackRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize track repository: %v", err)
+	}
+	webhookRepo, err := mysqlRepo.NewWebhookRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize webhook repository: %v", err)
+	}
+	apiKeyRepo, err := mysqlRepo.NewAPIKeyRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize API key repository: %v", err)
+	}
+	auditRepo, err := mysqlRepo.NewAuditRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize audit repository: %v", err)
+	}
+
+	// Initialize SQS client
+	sqsClient, err := sqsclient.NewSQSClient(context.TODO(), workerConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize SQS client: %v", err)
+	}
 
 	// Initialize HTTP client
 	httpClient := httpclient.NewClient()
@@ -47,19 +93,161 @@ func main() {
 	// Initialize third-party api service
 	jsonPostHTTPClient := jsonpost.NewHttpJsonPost(httpClient, &config.AppCfg)
 
+	// Initialize the generator for server-assigned album IDs
+	albumIDGenerator, err := idgen.New(config.AppCfg.AlbumIDStrategy)
+	if err != nil {
+		log.Fatalf("Failed to initialize album ID generator: %v", err)
+	}
+
 	// Initialize Usecase layer
-	albumService := albumservice.NewService(albumRepo, redisCache, config.AppCfg.CacheDuration, jsonPostHTTPClient)
+	webhookService := webhookservice.NewService(
+		webhookRepo,
+		queue.NewQueue(sqsClient, workerConfig.WebhookQueueURL),
+		webhook.NewHttpWebhook(httpclient.NewPublicClient()),
+	)
+	albumService := albumservice.NewService(
+		albumRepo,
+		trackRepo,
+		redisCache,
+		config.AppCfg.CacheDuration,
+		jsonPostHTTPClient,
+		albumservice.WithCascadeTrackDeletes(config.AppCfg.AlbumDeleteCascadeTracks),
+		albumservice.WithIDGenerator(albumIDGenerator),
+		albumservice.WithImportBatchSize(config.AppCfg.AlbumImportBatchSize),
+		albumservice.WithTrashRetention(config.AppCfg.AlbumTrashRetention),
+		albumservice.WithEventStore(redisCache),
+		albumservice.WithEventLogSize(int64(config.AppCfg.AlbumEventLogSize)),
+		albumservice.WithEventBuffer(config.AppCfg.AlbumEventBuffer),
+		albumservice.WithEventDispatcher(webhookService),
+	)
+	trackService := trackservice.NewService(trackRepo, albumRepo)
+	apiKeyService := apikeyservice.NewService(
+		apiKeyRepo,
+		apikeyservice.WithCache(redisCache, config.AppCfg.APIKeyCacheTTL),
+		apikeyservice.WithLastUsedInterval(config.AppCfg.APIKeyLastUsedInterval),
+	)
+	auditService := auditservice.NewService(
+		auditRepo,
+		auditservice.WithQueueSize(config.AppCfg.AuditQueueSize),
+		auditservice.WithWriteTimeout(config.AppCfg.AuditWriteTimeout),
+	)
 
 	// Initialize Controller layer
-	restController := restcontroller.NewController(albumService)
+	restController := restcontroller.NewController(
+		albumService,
+		trackService,
+		restcontroller.WithStreamHeartbeat(config.AppCfg.AlbumStreamHeartbeat),
+		restcontroller.WithWebhookService(webhookService),
+		restcontroller.WithAPIKeyService(apiKeyService),
+		restcontroller.WithAuditService(auditService),
+	)
+
+	// Relay the album changes published by every API instance to the streams connected to this one
+	go func() {
+		if err := albumService.RunAlbumEvents(context.Background()); err != nil {
+			log.Fatalf("Error relaying album events: %v", err)
+		}
+	}()
+
+	// Write the audit records of album changes to MySQL off the request path, until the servers
+	// have stopped and the records still queued are written
+	auditCtx, stopAuditWriter := context.WithCancel(context.Background())
+	auditWriterDone := make(chan struct{})
+	go func() {
+		defer close(auditWriterDone)
+		if err := auditService.RunAuditWriter(auditCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Fatalf("Error writing audit records: %v", err)
+		}
+	}()
+
+	// Load the keys bearer tokens are verified with, reloaded as they are rotated
+	tokenKeys, err := auth.NewKeySet(auth.KeySources{
+		HMACSecret: config.AppCfg.JWTHMACSecret,
+		JWKSFile:   config.AppCfg.JWTJWKSFile,
+		PEMFiles:   config.AppCfg.JWTPublicKeys,
+	}, config.AppCfg.JWTKeysReloadInterval)
+	if err != nil {
+		log.Fatalf("Failed to load token verification keys: %v", err)
+	}
+	tokenVerifier := auth.NewVerifier(
+		tokenKeys,
+		auth.WithIssuer(config.AppCfg.JWTIssuer),
+		auth.WithAudience(config.AppCfg.JWTAudience),
+		auth.WithLeeway(config.AppCfg.JWTLeeway),
+	)
+
+	// Load the OpenAPI document served at /openapi.json and used to validate requests
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI document: %v", err)
+	}
+
+	// Build the GraphQL schema served at /graphql
+	graphqlHandler, err := graphql.NewHandler(albumService, tokenVerifier, apiKeyService, auditService, graphql.Limits{
+		MaxDepth:      config.AppCfg.GraphQLMaxDepth,
+		MaxComplexity: config.AppCfg.GraphQLMaxComplexity,
+	})
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
+	}
 
 	// set up routers
 	r := gin.Default()
-	router.SetupRoutes(r, restController, &config.AppCfg)
+	// Client addresses, which the open routes are limited by, are only read from X-Forwarded-For
+	// when it is set by a trusted proxy
+	if err := r.SetTrustedProxies(config.AppCfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	router.SetupRoutes(r, restController, &config.AppCfg, redisCache, spec, graphqlHandler, tokenVerifier, apiKeyService, redisCache, auditService, grpcserver.Permissions()...)
+
+	// Start the gRPC server next to the HTTP server
+	grpcListener, err := net.Listen("tcp", ":"+config.AppCfg.GRPCPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port: %v", err)
+	}
+	grpcServer := grpcserver.NewServer(grpcalbum.NewServer(albumService), &config.AppCfg, tokenVerifier, apiKeyService, auditService)
+	go func() {
+		log.Printf("gRPC server starting on :%s", config.AppCfg.GRPCPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("Error starting gRPC server: %v", err)
+		}
+	}()
 
 	// Start the server
-	log.Println("Server starting on :8080")
-	if err := r.Run(":8080"); err != nil {
-		log.Fatalf("Error starting server: %v", err)
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	go func() {
+		log.Println("Server starting on :8080")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Error starting server: %v", err)
+		}
+	}()
+
+	// Wait for termination signal for graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	// Stop taking calls and let those in flight finish, so that their audit records are queued.
+	// Calls still running after SHUTDOWN_TIMEOUT, such as album streams, are cut.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.AppCfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down server: %v", err)
+		srv.Close()
 	}
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
+
+	// Write the audit records still queued
+	stopAuditWriter()
+	<-auditWriterDone
+	log.Printf("Server has stopped; %d audit records were dropped while the queue was full", auditService.DroppedRecords())
 }
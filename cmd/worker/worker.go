@@ -9,10 +9,14 @@ import (
 	"syscall"
 
 	infraConfig "boilerplate/app/infrastructure/config"
+	"boilerplate/app/infrastructure/httpclient"
+	"boilerplate/app/infrastructure/httpclient/webhook"
+	mysqlRepo "boilerplate/app/infrastructure/repositories/mysql"
 	"boilerplate/app/infrastructure/sqs"
 	sqsclient "boilerplate/app/infrastructure/sqs/client"
 	"boilerplate/app/infrastructure/sqs/queue"
 	services "boilerplate/app/usecase"
+	webhookservice "boilerplate/app/usecase/webhook"
 	"boilerplate/app/usecase/worker"
 )
 
@@ -32,6 +36,26 @@ func main() {
 	// Create the dummy service
 	dummyService := services.NewDummyService()
 
+	// Create the webhook service, delivering album events to webhooks
+	db, err := mysqlRepo.OpenMySQLConnection(workerConfig.MySQLConnectionString())
+	if err != nil {
+		log.Fatalf("Failed to open MySQL connection: %v", err)
+	}
+	webhookRepo, err := mysqlRepo.NewWebhookRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize webhook repository: %v", err)
+	}
+	webhookService := webhookservice.NewService(
+		webhookRepo,
+		queue.NewQueue(sqsClient, workerConfig.WebhookQueueURL),
+		webhook.NewHttpWebhook(httpclient.NewPublicClient()),
+		webhookservice.WithMaxAttempts(workerConfig.Webhook.MaxAttempts),
+		webhookservice.WithRetryDelay(workerConfig.Webhook.RetryDelay),
+		webhookservice.WithDisableAfter(workerConfig.Webhook.DisableAfter),
+		webhookservice.WithDeliveryTimeout(workerConfig.Webhook.DeliveryTimeout),
+	)
+	webhookProcessor := sqs.NewAlbumProcessor(sqsClient, workerConfig.WebhookQueueURL, workerConfig, webhookService)
+
 	// Setup the processor and worker
 	sqsProcessor := sqs.NewAlbumProcessor(sqsClient, workerConfig.QueueURL, workerConfig, dummyService)
 
@@ -62,14 +86,22 @@ func main() {
 		workerConfig.AlbumWorker.WaitTime,
 	)
 
+	webhookWorker := worker.NewWorker(
+		webhookProcessor,
+		workerConfig.WebhookWorker.GoroutinesNumber,
+		workerConfig.WebhookWorker.RetryInterval,
+		workerConfig.WebhookWorker.WaitTime,
+	)
+
 	var wg sync.WaitGroup
 	// Create a context for the worker
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Start the worker
-	wg.Add(1)
+	// Start the workers
+	wg.Add(2)
 	albumWorker.Start(ctx, wrappedHandler)
+	webhookWorker.Start(ctx, webhookProcessor.DefaultMessageHandler)
 
 	// Wait for termination signal for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -79,11 +111,13 @@ func main() {
 	// Signal the worker to stop
 	cancel()
 
-	// Wait for worker to finish
-	go func(albumWorker *worker.Worker) {
-		<-albumWorker.Done()
-		wg.Done()
-	}(albumWorker)
+	// Wait for the workers to finish
+	for _, w := range []*worker.Worker{albumWorker, webhookWorker} {
+		go func(w *worker.Worker) {
+			<-w.Done()
+			wg.Done()
+		}(w)
+	}
 
 	wg.Wait()
 	log.Println("Worker has stopped.")
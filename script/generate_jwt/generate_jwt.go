@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/joho/godotenv"
+)
+
+// Prints an HS256 token signed with JWT_HMAC_SECRET, for calling the protected routes locally
+// go run ./script/generate_jwt/generate_jwt.go -sub user-1 -ttl 1h -scope "albums:read albums:write"
+func main() {
+	subject := flag.String("sub", "local-user", "subject of the token")
+	ttl := flag.Duration("ttl", time.Hour, "how long the token is valid")
+	scope := flag.String("scope", "admin", "space-separated scopes granted by the token")
+	flag.Parse()
+
+	// Load .env file
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	secret := os.Getenv("JWT_HMAC_SECRET")
+	if secret == "" {
+		log.Fatal("JWT_HMAC_SECRET is not set")
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": *subject,
+		"iat": now.Unix(),
+		"exp": now.Add(*ttl).Unix(),
+	}
+	if *scope != "" {
+		claims["scope"] = *scope
+	}
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		claims["iss"] = issuer
+	}
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		claims["aud"] = audience
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		log.Fatalf("Error signing token: %v", err)
+	}
+	fmt.Println(token)
+}
@@ -1,8 +1,11 @@
 #!/bin/sh
 
-echo "LocalStack SQS is up, creating 'album' queue..."
+echo "LocalStack SQS is up, creating 'album' and 'webhook' queues..."
 
 # Create the 'album' queue
 awslocal sqs create-queue --queue-name album
 
-echo "Queue 'album' created."
\ No newline at end of file
+# Create the 'webhook' queue, carrying album event deliveries to webhooks
+awslocal sqs create-queue --queue-name webhook
+
+echo "Queues 'album' and 'webhook' created."
\ No newline at end of file
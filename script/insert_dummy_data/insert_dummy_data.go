@@ -14,6 +14,8 @@ import (
 
 var localhost string = "localhost"
 
+var genres = []string{"rock", "pop", "jazz", "classical", "hip-hop", "electronic"}
+
 // go run ./script/insert_dummy_data/insert_dummy_data.go
 func main() {
 	// Seed the random number generator
@@ -59,18 +61,22 @@ func main() {
 		// Generate dummy data
 		id := fmt.Sprintf("A%04d", i+1) // Example: P0001, P0002, etc.
 		title := fmt.Sprintf("Album Title %d", i+1)
+		artist := fmt.Sprintf("Artist %d", rand.Intn(5)+1)
+		genre := genres[rand.Intn(len(genres))]
+		releaseDate := time.Now().AddDate(0, 0, -rand.Intn(3650)).Format("2006-01-02")
+		trackCount := rand.Intn(15) + 5
 
 		// SQL statement to insert a record if it does not already exist
 		insertSQL := `
-		INSERT INTO album (id, title)
-		SELECT ?, ?
+		INSERT INTO album (id, title, artist, release_date, genre, track_count)
+		SELECT ?, ?, ?, ?, ?, ?
 		WHERE NOT EXISTS (
 				SELECT 1 FROM album WHERE id = ?
 		);
 		`
 
 		// Execute the SQL command
-		result, err := db.Exec(insertSQL, id, title, id)
+		result, err := db.Exec(insertSQL, id, title, artist, releaseDate, genre, trackCount, id)
 		if err != nil {
 			log.Printf("Could not check or insert record %d: %v", i+1, err)
 		} else {
@@ -52,7 +52,16 @@ func main() {
     CREATE TABLE IF NOT EXISTS album (
         id VARCHAR(255) PRIMARY KEY,
         title VARCHAR(255) ,
-        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        artist VARCHAR(255) NOT NULL DEFAULT '',
+        release_date DATE NULL,
+        genre VARCHAR(64) NOT NULL DEFAULT '',
+        track_count INT NOT NULL DEFAULT 0,
+        version BIGINT NOT NULL DEFAULT 1,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+        deleted_at TIMESTAMP NULL DEFAULT NULL,
+        INDEX idx_album_deleted_at (deleted_at),
+        FULLTEXT INDEX ft_album_title_artist (title, artist)
     )`
 
 	// Execute the SQL command
@@ -60,6 +69,198 @@ func main() {
 	if err != nil {
 		log.Printf("Could not create table: %v", err)
 	} else {
-		fmt.Println("Table example_table created successfully")
+		fmt.Println("Table album created successfully")
 	}
+
+	// Bring tables created by an earlier version of this script up to date
+	for _, column := range albumColumns {
+		if err := addColumnIfMissing(db, dbName, "album", column); err != nil {
+			log.Printf("Could not add column %s: %v", column.name, err)
+		}
+	}
+
+	// Trash listing and purging look albums up by deletion time
+	if err := addIndexIfMissing(db, dbName, "album", "idx_album_deleted_at", "INDEX idx_album_deleted_at (deleted_at)"); err != nil {
+		log.Printf("Could not add deleted_at index: %v", err)
+	}
+
+	// Album search ranks with this index; without it the application falls back to LIKE
+	if err := addIndexIfMissing(db, dbName, "album", "ft_album_title_artist", "FULLTEXT INDEX ft_album_title_artist (title, artist)"); err != nil {
+		log.Printf("Could not add full-text index: %v", err)
+	}
+
+	// Tracks reference their album; RESTRICT lets the application decide whether album deletes cascade
+	createTrackTableSQL := `
+    CREATE TABLE IF NOT EXISTS track (
+        id VARCHAR(255) PRIMARY KEY,
+        album_id VARCHAR(255) NOT NULL,
+        position INT NOT NULL DEFAULT 0,
+        title VARCHAR(255) NOT NULL,
+        duration_seconds INT NOT NULL DEFAULT 0,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+        INDEX idx_track_album_position (album_id, position),
+        CONSTRAINT fk_track_album FOREIGN KEY (album_id) REFERENCES album(id) ON DELETE RESTRICT
+    )`
+
+	_, err = db.Exec(createTrackTableSQL)
+	if err != nil {
+		log.Printf("Could not create track table: %v", err)
+	} else {
+		fmt.Println("Table track created successfully")
+	}
+
+	// Webhooks registered by partner systems; events holds the subscribed event types separated by commas
+	createWebhookTableSQL := `
+    CREATE TABLE IF NOT EXISTS webhook (
+        id VARCHAR(255) PRIMARY KEY,
+        url VARCHAR(2048) NOT NULL,
+        secret VARCHAR(255) NOT NULL,
+        events VARCHAR(255) NOT NULL DEFAULT '',
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        failure_count INT NOT NULL DEFAULT 0,
+        disabled_at TIMESTAMP NULL DEFAULT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    )`
+
+	_, err = db.Exec(createWebhookTableSQL)
+	if err != nil {
+		log.Printf("Could not create webhook table: %v", err)
+	} else {
+		fmt.Println("Table webhook created successfully")
+	}
+
+	// One row per attempt at delivering an event to a webhook; deleting a webhook deletes its log
+	createWebhookDeliveryTableSQL := `
+    CREATE TABLE IF NOT EXISTS webhook_delivery (
+        id VARCHAR(255) PRIMARY KEY,
+        webhook_id VARCHAR(255) NOT NULL,
+        delivery_id VARCHAR(255) NOT NULL,
+        event_id VARCHAR(64) NOT NULL DEFAULT '',
+        event_type VARCHAR(64) NOT NULL,
+        attempt INT NOT NULL,
+        status_code INT NOT NULL DEFAULT 0,
+        error TEXT NOT NULL,
+        duration_ms BIGINT NOT NULL DEFAULT 0,
+        succeeded BOOLEAN NOT NULL DEFAULT FALSE,
+        created_at TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP(3),
+        INDEX idx_webhook_delivery_webhook_created (webhook_id, created_at),
+        CONSTRAINT fk_webhook_delivery_webhook FOREIGN KEY (webhook_id) REFERENCES webhook(id) ON DELETE CASCADE
+    )`
+
+	_, err = db.Exec(createWebhookDeliveryTableSQL)
+	if err != nil {
+		log.Printf("Could not create webhook_delivery table: %v", err)
+	} else {
+		fmt.Println("Table webhook_delivery created successfully")
+	}
+
+	// API keys are stored as the SHA-256 hash of the key, looked up by the unique prefix it carries
+	createAPIKeyTableSQL := `
+    CREATE TABLE IF NOT EXISTS api_key (
+        id VARCHAR(255) PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        prefix CHAR(12) NOT NULL,
+        key_hash CHAR(64) NOT NULL,
+        scopes VARCHAR(255) NOT NULL DEFAULT '',
+        expires_at TIMESTAMP NULL DEFAULT NULL,
+        last_used_at TIMESTAMP NULL DEFAULT NULL,
+        revoked_at TIMESTAMP NULL DEFAULT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+        UNIQUE KEY uk_api_key_prefix (prefix)
+    )`
+
+	_, err = db.Exec(createAPIKeyTableSQL)
+	if err != nil {
+		log.Printf("Could not create api_key table: %v", err)
+	} else {
+		fmt.Println("Table api_key created successfully")
+	}
+
+	// One row per mutating album request, kept apart from the albums so that it outlives them
+	createAuditLogTableSQL := `
+    CREATE TABLE IF NOT EXISTS audit_log (
+        id VARCHAR(255) PRIMARY KEY,
+        actor VARCHAR(255) NOT NULL,
+        request_id VARCHAR(255) NOT NULL DEFAULT '',
+        method VARCHAR(16) NOT NULL,
+        route VARCHAR(255) NOT NULL,
+        target_id VARCHAR(255) NOT NULL DEFAULT '',
+        before_snapshot JSON NULL,
+        after_snapshot JSON NULL,
+        outcome VARCHAR(16) NOT NULL,
+        status_code INT NOT NULL,
+        occurred_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+        INDEX idx_audit_log_occurred (occurred_at, id),
+        INDEX idx_audit_log_actor (actor, occurred_at),
+        INDEX idx_audit_log_target (target_id, occurred_at)
+    )`
+
+	_, err = db.Exec(createAuditLogTableSQL)
+	if err != nil {
+		log.Printf("Could not create audit_log table: %v", err)
+	} else {
+		fmt.Println("Table audit_log created successfully")
+	}
+}
+
+// columnMigration describes a column added to a table after it was first created
+type columnMigration struct {
+	name       string
+	definition string
+}
+
+// albumColumns are the album columns that did not exist in the original table
+var albumColumns = []columnMigration{
+	{name: "artist", definition: "VARCHAR(255) NOT NULL DEFAULT '' AFTER title"},
+	{name: "release_date", definition: "DATE NULL AFTER artist"},
+	{name: "genre", definition: "VARCHAR(64) NOT NULL DEFAULT '' AFTER release_date"},
+	{name: "track_count", definition: "INT NOT NULL DEFAULT 0 AFTER genre"},
+	{name: "version", definition: "BIGINT NOT NULL DEFAULT 1 AFTER track_count"},
+	{name: "updated_at", definition: "TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP AFTER created_at"},
+	{name: "deleted_at", definition: "TIMESTAMP NULL DEFAULT NULL AFTER updated_at"},
+}
+
+// addColumnIfMissing adds the column unless the table already has it (MySQL has no ADD COLUMN IF NOT EXISTS)
+func addColumnIfMissing(db *sql.DB, schema, table string, column columnMigration) error {
+	var count int
+	err := db.QueryRow(
+		"SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?",
+		schema, table, column.name,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column.name, column.definition)); err != nil {
+		return err
+	}
+	fmt.Printf("Added column %s.%s\n", table, column.name)
+	return nil
+}
+
+// addIndexIfMissing adds the index unless the table already has one with that name
+func addIndexIfMissing(db *sql.DB, schema, table, name, definition string) error {
+	var count int
+	err := db.QueryRow(
+		"SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = ? AND table_name = ? AND index_name = ?",
+		schema, table, name,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD %s", table, definition)); err != nil {
+		return err
+	}
+	fmt.Printf("Added index %s.%s\n", table, name)
+	return nil
 }